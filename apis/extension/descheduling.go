@@ -37,6 +37,13 @@ const (
 	AnnotationEvictionCost = SchedulingDomainPrefix + "/eviction-cost"
 )
 
+const (
+	// AnnotationEvictReason indicates the reason why the PodMigrationJob is created to evict the pod.
+	AnnotationEvictReason = DomainPrefix + "evict-reason"
+	// AnnotationEvictTrigger indicates the initiator of the PodMigrationJob.
+	AnnotationEvictTrigger = DomainPrefix + "evict-trigger"
+)
+
 const (
 	// AnnotationSoftEviction indicates custom eviction. It can be used to set to an "true".
 	AnnotationSoftEviction = SchedulingDomainPrefix + "/soft-eviction"
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apiserver/pkg/quota/v1"
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 )
@@ -38,8 +39,26 @@ const (
 	AnnotationSharedWeight = QuotaKoordinatorPrefix + "/shared-weight"
 	AnnotationRuntime      = QuotaKoordinatorPrefix + "/runtime"
 	AnnotationRequest      = QuotaKoordinatorPrefix + "/request"
+	// AnnotationUsageAccounting records the QuotaUsageAccounting of the quota
+	AnnotationUsageAccounting = QuotaKoordinatorPrefix + "/usage-accounting"
 )
 
+// QuotaUsageAccounting is the time-integrated usage of a quota group, which is used for chargeback.
+// The values are in resource-hours, i.e. the base unit of the resource multiplied by hours,
+// e.g. core-hours for cpu, byte-hours for memory and device-hours for gpu.
+type QuotaUsageAccounting struct {
+	// StartTime is the time when the accounting started
+	StartTime metav1.Time `json:"startTime"`
+	// LastUpdateTime is the last time the usage was accumulated
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// Used is the accumulated used resource-hours
+	Used map[corev1.ResourceName]float64 `json:"used,omitempty"`
+	// Guaranteed is the part of Used within the min quota
+	Guaranteed map[corev1.ResourceName]float64 `json:"guaranteed,omitempty"`
+	// Borrowed is the part of Used beyond the min quota, which is borrowed from the other quota groups
+	Borrowed map[corev1.ResourceName]float64 `json:"borrowed,omitempty"`
+}
+
 func GetParentQuotaName(quota *v1alpha1.ElasticQuota) string {
 	parentName := quota.Labels[LabelQuotaParent]
 	if parentName == "" {
@@ -68,6 +87,20 @@ func GetSharedWeight(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
 	return quota.Spec.Max.DeepCopy() //default equals to max
 }
 
+// GetQuotaUsageAccounting parses the QuotaUsageAccounting persisted in the annotations of the quota,
+// it returns nil if the annotation is absent.
+func GetQuotaUsageAccounting(quota *v1alpha1.ElasticQuota) (*QuotaUsageAccounting, error) {
+	value, exist := quota.Annotations[AnnotationUsageAccounting]
+	if !exist || value == "" {
+		return nil, nil
+	}
+	accounting := &QuotaUsageAccounting{}
+	if err := json.Unmarshal([]byte(value), accounting); err != nil {
+		return nil, err
+	}
+	return accounting, nil
+}
+
 func IsForbiddenModify(quota *v1alpha1.ElasticQuota) (bool, error) {
 	if quota.Name == SystemQuotaName || quota.Name == RootQuotaName {
 		// can't modify SystemQuotaGroup
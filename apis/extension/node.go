@@ -64,6 +64,9 @@ const (
 
 type CPUTopology struct {
 	Detail []CPUInfo `json:"detail,omitempty"`
+	// SMTDisabled indicates that each physical core of the node has only one logical CPU online,
+	// e.g. the simultaneous multithreading (hyper-threading) is turned off.
+	SMTDisabled bool `json:"smtDisabled,omitempty"`
 }
 
 type CPUInfo struct {
@@ -71,8 +74,21 @@ type CPUInfo struct {
 	Core   int32 `json:"core"`
 	Socket int32 `json:"socket"`
 	Node   int32 `json:"node"`
+	// CoreType is the type of the physical core on the heterogeneous CPUs, e.g. big.LITTLE or Intel hybrid architecture.
+	// It is empty if all cores of the node are the same.
+	CoreType CPUCoreType `json:"coreType,omitempty"`
 }
 
+// CPUCoreType defines the type of the physical core on the heterogeneous CPUs
+type CPUCoreType string
+
+const (
+	// CPUCoreTypePerformance represents the performance cores, e.g. the big cores of big.LITTLE
+	CPUCoreTypePerformance CPUCoreType = "Performance"
+	// CPUCoreTypeEfficiency represents the efficiency cores, e.g. the LITTLE cores of big.LITTLE
+	CPUCoreTypeEfficiency CPUCoreType = "Efficiency"
+)
+
 type PodCPUAlloc struct {
 	Namespace        string    `json:"namespace,omitempty"`
 	Name             string    `json:"name,omitempty"`
@@ -18,6 +18,7 @@ package extension
 
 import (
 	"encoding/json"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,14 @@ const (
 
 	// AnnotationReservationAffinity represents the constraints of Pod selection Reservation
 	AnnotationReservationAffinity = SchedulingDomainPrefix + "/reservation-affinity"
+
+	// AnnotationReservationBackfillMaxRuntime allows the pods which are not the owners of the Reservation to
+	// temporarily use the reserved resources if they declare a max runtime by spec.activeDeadlineSeconds
+	// no longer than the value, e.g. "30m".
+	AnnotationReservationBackfillMaxRuntime = SchedulingDomainPrefix + "/reservation-backfill-max-runtime"
+
+	// AnnotationReservationBackfilled records the Reservations whose reserved resources are temporarily used by the pod.
+	AnnotationReservationBackfilled = SchedulingDomainPrefix + "/reservation-backfilled"
 )
 
 type ReservationAllocated struct {
@@ -95,6 +104,31 @@ func SetReservationAllocated(pod *corev1.Pod, r metav1.Object) {
 	pod.Annotations[AnnotationReservationAllocated] = string(data)
 }
 
+// GetReservationBackfilled returns the Reservations whose reserved resources are temporarily used by the pod.
+func GetReservationBackfilled(pod *corev1.Pod) ([]ReservationAllocated, error) {
+	data, ok := pod.Annotations[AnnotationReservationBackfilled]
+	if !ok {
+		return nil, nil
+	}
+	var reservations []ReservationAllocated
+	if err := json.Unmarshal([]byte(data), &reservations); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func SetReservationBackfilled(pod *corev1.Pod, reservations []metav1.Object) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	backfilled := make([]ReservationAllocated, 0, len(reservations))
+	for _, r := range reservations {
+		backfilled = append(backfilled, ReservationAllocated{Name: r.GetName(), UID: r.GetUID()})
+	}
+	data, _ := json.Marshal(backfilled) // assert no error
+	pod.Annotations[AnnotationReservationBackfilled] = string(data)
+}
+
 func IsReservationAllocateOnce(r *schedulingv1alpha1.Reservation) bool {
 	return pointer.BoolDeref(r.Spec.AllocateOnce, true)
 }
@@ -108,3 +142,13 @@ func GetReservationAffinity(annotations map[string]string) (*ReservationAffinity
 	}
 	return &affinity, nil
 }
+
+// GetReservationBackfillMaxRuntime returns the max runtime of the pods which can backfill the reserved resources.
+// It returns 0 if the Reservation does not allow backfilling.
+func GetReservationBackfillMaxRuntime(annotations map[string]string) (time.Duration, error) {
+	s := annotations[AnnotationReservationBackfillMaxRuntime]
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -18,6 +18,7 @@ package extension
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -44,3 +45,34 @@ func TestSetReservationAllocated(t *testing.T) {
 	}
 	assert.Equal(t, expectReservationAllocated, reservationAllocated)
 }
+
+func TestGetReservationBackfillMaxRuntime(t *testing.T) {
+	maxRuntime, err := GetReservationBackfillMaxRuntime(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), maxRuntime)
+
+	maxRuntime, err = GetReservationBackfillMaxRuntime(map[string]string{AnnotationReservationBackfillMaxRuntime: "30m"})
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, maxRuntime)
+
+	_, err = GetReservationBackfillMaxRuntime(map[string]string{AnnotationReservationBackfillMaxRuntime: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestSetReservationBackfilled(t *testing.T) {
+	pod := &corev1.Pod{}
+	backfilled, err := GetReservationBackfilled(pod)
+	assert.NoError(t, err)
+	assert.Nil(t, backfilled)
+
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "test-reservation",
+		},
+	}
+	SetReservationBackfilled(pod, []metav1.Object{reservation})
+	backfilled, err = GetReservationBackfilled(pod)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReservationAllocated{{Name: reservation.Name, UID: reservation.UID}}, backfilled)
+}
@@ -59,6 +59,18 @@ const (
 )
 
 var (
+	// DeviceResourceNames are the resources allocated from the devices on the node.
+	DeviceResourceNames = map[corev1.ResourceName]struct{}{
+		ResourceNvidiaGPU:      {},
+		ResourceHygonDCU:       {},
+		ResourceGPU:            {},
+		ResourceGPUCore:        {},
+		ResourceGPUMemory:      {},
+		ResourceGPUMemoryRatio: {},
+		ResourceFPGA:           {},
+		ResourceRDMA:           {},
+	}
+
 	ResourceNameMap = map[PriorityClass]map[corev1.ResourceName]corev1.ResourceName{
 		PriorityBatch: {
 			corev1.ResourceCPU:    BatchCPU,
@@ -77,6 +89,9 @@ type ResourceSpec struct {
 	PreferredCPUBindPolicy CPUBindPolicy `json:"preferredCPUBindPolicy,omitempty"`
 	// PreferredCPUExclusivePolicy represents best-effort CPU exclusive policy.
 	PreferredCPUExclusivePolicy CPUExclusivePolicy `json:"preferredCPUExclusivePolicy,omitempty"`
+	// PreferredCPUCoreType represents best-effort type of the physical cores to bind on the heterogeneous CPUs.
+	// koord-scheduler allocates CPUs from the other types of cores if the preferred cores are insufficient.
+	PreferredCPUCoreType CPUCoreType `json:"preferredCPUCoreType,omitempty"`
 }
 
 // ResourceStatus describes resource allocation result, such as how to bind CPU.
@@ -86,6 +101,15 @@ type ResourceStatus struct {
 	CPUSet string `json:"cpuset,omitempty"`
 	// CPUSharedPools represents the desired CPU Shared Pools used by LS Pods.
 	CPUSharedPools []CPUSharedPool `json:"cpuSharedPools,omitempty"`
+	// NUMANodeResources represents the resources allocated on each NUMA Node.
+	// When LSE/LSR Pod requested, koord-scheduler will update the field with the NUMA Nodes of the allocated CPUs,
+	// and koordlet binds the memory of the Pod to these NUMA Nodes.
+	NUMANodeResources []NUMANodeResource `json:"numaNodeResources,omitempty"`
+}
+
+type NUMANodeResource struct {
+	Node      int32               `json:"node"`
+	Resources corev1.ResourceList `json:"resources,omitempty"`
 }
 
 // CPUBindPolicy defines the CPU binding policy
@@ -194,6 +218,22 @@ func SetResourceStatus(obj metav1.Object, status *ResourceStatus) error {
 	return nil
 }
 
+// AllowUseCPUSet returns true if the pod is an LSE/LSR Prod pod, whose CPUs are bound by cpuset.
+func AllowUseCPUSet(pod *corev1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	qosClass := GetPodQoSClassRaw(pod)
+	priorityClass := GetPodPriorityClassWithDefault(pod)
+	return (qosClass == QoSLSE || qosClass == QoSLSR) && priorityClass == PriorityProd
+}
+
+// IsDeviceResource returns true if the resource is allocated from the devices on the node.
+func IsDeviceResource(resourceName corev1.ResourceName) bool {
+	_, ok := DeviceResourceNames[resourceName]
+	return ok
+}
+
 // TranslateResourceNameByPriorityClass translates defaultResourceName to extend resourceName by PriorityClass
 func TranslateResourceNameByPriorityClass(priorityClass PriorityClass, defaultResourceName corev1.ResourceName) corev1.ResourceName {
 	if priorityClass == PriorityProd || priorityClass == PriorityNone {
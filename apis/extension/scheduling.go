@@ -19,6 +19,7 @@ package extension
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +35,10 @@ const (
 
 	// AnnotationDeviceAllocated represents the device allocated by the pod
 	AnnotationDeviceAllocated = SchedulingDomainPrefix + "/device-allocated"
+
+	// AnnotationPodExpectedLifetime declares the expected lifetime of the pod, e.g. "2h".
+	// The load-aware scheduling scores nodes according to the hourly usage profile during the lifetime.
+	AnnotationPodExpectedLifetime = SchedulingDomainPrefix + "/expected-lifetime"
 )
 
 const (
@@ -72,6 +77,20 @@ const (
 	GangMatchPolicyOnlyWaiting       = "only-waiting"
 	GangMatchPolicyWaitingAndRunning = "waiting-and-running"
 	GangMatchPolicyOnceSatisfied     = "once-satisfied"
+
+	// AnnotationGangNetworkTopologyPolicy defines whether the pods of a GangGroup should be placed
+	// within one network topology domain (e.g. rack or spine), see CoschedulingArgs.NetworkTopologyLevels.
+	// Support GangNetworkTopologyPolicyRequired and GangNetworkTopologyPolicyPreferred, default is disabled.
+	AnnotationGangNetworkTopologyPolicy = AnnotationGangPrefix + "/network-topology-policy"
+	// GangNetworkTopologyPolicyRequired means the gang must be placed within one network topology domain.
+	GangNetworkTopologyPolicyRequired = "Required"
+	// GangNetworkTopologyPolicyPreferred means the gang is placed within one network topology domain if possible,
+	// otherwise the gang can be placed on any nodes.
+	GangNetworkTopologyPolicyPreferred = "Preferred"
+
+	// LabelGangReservation marks the Reservations created by the scheduler for the gang members
+	// which have waited too long, see CoschedulingArgs.GangReservationThreshold.
+	LabelGangReservation = AnnotationGangPrefix + "/reservation"
 )
 
 const (
@@ -188,3 +207,15 @@ var GetGangName = func(pod *corev1.Pod) string {
 var GetGangMatchPolicy = func(pod *corev1.Pod) string {
 	return pod.Annotations[AnnotationGangMatchPolicy]
 }
+
+// GetPodExpectedLifetime returns the expected lifetime of the pod declared by AnnotationPodExpectedLifetime
+// or spec.activeDeadlineSeconds. It returns 0 if the pod does not declare it.
+func GetPodExpectedLifetime(pod *corev1.Pod) (time.Duration, error) {
+	if s := pod.Annotations[AnnotationPodExpectedLifetime]; s != "" {
+		return time.ParseDuration(s)
+	}
+	if pod.Spec.ActiveDeadlineSeconds != nil {
+		return time.Duration(*pod.Spec.ActiveDeadlineSeconds) * time.Second, nil
+	}
+	return 0, nil
+}
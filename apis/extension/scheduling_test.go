@@ -18,11 +18,13 @@ package extension
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 )
@@ -138,3 +140,51 @@ func Test_SetDeviceAllocations(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodExpectedLifetime(t *testing.T) {
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "not declared",
+			pod:  &corev1.Pod{},
+			want: 0,
+		},
+		{
+			name: "declared by annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AnnotationPodExpectedLifetime: "2h"},
+				},
+				Spec: corev1.PodSpec{ActiveDeadlineSeconds: pointer.Int64(60)},
+			},
+			want: 2 * time.Hour,
+		},
+		{
+			name: "declared by activeDeadlineSeconds",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{ActiveDeadlineSeconds: pointer.Int64(1800)},
+			},
+			want: 30 * time.Minute,
+		},
+		{
+			name: "invalid annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AnnotationPodExpectedLifetime: "abc"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPodExpectedLifetime(tt.pod)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
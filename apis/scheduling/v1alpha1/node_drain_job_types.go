@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelNodeDrainJob is the label of PodMigrationJob indicates the NodeDrainJob it belongs to.
+	LabelNodeDrainJob = "scheduling.koordinator.sh/node-drain-job"
+)
+
+type NodeDrainJobSpec struct {
+	// Paused indicates whether the NodeDrainJob should to work or not.
+	// Default is false
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// NodeName represents the node to be drained.
+	// +required
+	NodeName string `json:"nodeName"`
+
+	// MaxConcurrentMigrations represents the maximum number of Pods that can be migrating at the same time.
+	// Default is 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentMigrations *int32 `json:"maxConcurrentMigrations,omitempty"`
+
+	// PodMigrationJobTTL controls the timeout duration of each PodMigrationJob.
+	// Default is the DefaultJobTTL of the MigrationController.
+	// +optional
+	PodMigrationJobTTL *metav1.Duration `json:"podMigrationJobTTL,omitempty"`
+
+	// Timeout controls the timeout duration of the NodeDrainJob.
+	// The NodeDrainJob fails if the node is not drained within the duration. Default is no timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type NodeDrainJobPhase string
+
+const (
+	// NodeDrainJobPending represents the initial status
+	NodeDrainJobPending NodeDrainJobPhase = "Pending"
+	// NodeDrainJobRunning represents the node is cordoned and the Pods are being migrated
+	NodeDrainJobRunning NodeDrainJobPhase = "Running"
+	// NodeDrainJobSucceeded represents all evictable Pods are migrated from the node
+	NodeDrainJobSucceeded NodeDrainJobPhase = "Succeeded"
+	// NodeDrainJobFailed represents some Pods failed to be migrated, some Pods are not managed by a controller
+	// or the NodeDrainJob is timeout
+	NodeDrainJobFailed NodeDrainJobPhase = "Failed"
+)
+
+// These are valid reasons of NodeDrainJob.
+const (
+	NodeDrainJobReasonMissingNode      = "MissingNode"
+	NodeDrainJobReasonTimeout          = "Timeout"
+	NodeDrainJobReasonFailedMigratePod = "FailedMigratePod"
+	NodeDrainJobReasonUnreplicatedPod  = "UnreplicatedPod"
+)
+
+type NodeDrainJobStatus struct {
+	// Phase represents the phase of a NodeDrainJob.
+	// e.g. Pending/Running/Succeeded/Failed
+	Phase NodeDrainJobPhase `json:"phase,omitempty"`
+	// Reason represents a brief CamelCase message indicating details about why the NodeDrainJob is in this state.
+	Reason string `json:"reason,omitempty"`
+	// Message represents a human-readable message indicating details about why the NodeDrainJob is in this state.
+	Message string `json:"message,omitempty"`
+	// Pods is the number of evictable Pods remaining on the node.
+	// +optional
+	Pods int32 `json:"pods,omitempty"`
+	// MigratedPods is the number of Pods migrated successfully, which is counted by the succeeded PodMigrationJobs.
+	// +optional
+	MigratedPods int32 `json:"migratedPods,omitempty"`
+	// MigratingPods is the number of Pods being migrated by the PodMigrationJobs.
+	// +optional
+	MigratingPods int32 `json:"migratingPods,omitempty"`
+	// FailedPods is the number of Pods whose PodMigrationJobs failed.
+	// +optional
+	FailedPods int32 `json:"failedPods,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster,shortName=ndj
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of NodeDrainJob"
+// +kubebuilder:printcolumn:name="Pods",type="integer",JSONPath=".status.pods"
+// +kubebuilder:printcolumn:name="Migrated",type="integer",JSONPath=".status.migratedPods"
+// +kubebuilder:printcolumn:name="Migrating",type="integer",JSONPath=".status.migratingPods"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NodeDrainJob is the Schema for the NodeDrainJob API.
+// A NodeDrainJob cordons the node and migrates all evictable Pods on the node by PodMigrationJobs
+// in ReservationFirst mode, so that the Pods are evicted only after the resources are reserved on other nodes.
+type NodeDrainJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeDrainJobSpec   `json:"spec,omitempty"`
+	Status NodeDrainJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeDrainJobList contains a list of NodeDrainJob
+type NodeDrainJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeDrainJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeDrainJob{}, &NodeDrainJobList{})
+}
@@ -32,6 +32,8 @@ type ReservationSpec struct {
 	// like a normal pod.
 	// If the `template.spec.nodeName` is specified, the scheduler will not choose another node but reserve resources on
 	// the specified node.
+	// The `template.spec.priority` is regarded as the priority of the reservation. An available reservation which is
+	// not allocated by any owner can be preempted by the pods with higher priority if the scheduler enables it.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:validation:Required
@@ -185,6 +187,7 @@ const (
 	ReasonReservationAvailable = "Available"
 	ReasonReservationSucceeded = "Succeeded"
 	ReasonReservationExpired   = "Expired"
+	ReasonReservationPreempted = "Preempted"
 )
 
 type ReservationCondition struct {
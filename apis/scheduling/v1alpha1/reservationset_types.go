@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReservationSetSpec struct {
+	// Replicas is the desired number of reservations which are not consumed by owners yet.
+	// The consumed, expired or failed reservations are replenished by new ones created from the template.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Required
+	Replicas int32 `json:"replicas"`
+	// Template is the object that describes the Reservation that will be created.
+	// The owners specified in the template are expected to match the future replicas of the workload,
+	// e.g. by the label selector of a Deployment.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Required
+	Template *ReservationTemplateSpec `json:"template"`
+}
+
+type ReservationSetStatus struct {
+	// The generation observed by the ReservationSet controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of created reservations which are not consumed, expired or failed.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// AvailableReplicas is the number of reservations which are scheduled and available for allocation.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// AllocatedReplicas is the number of reservations which are allocated by owners and not garbage collected.
+	// +optional
+	AllocatedReplicas int32 `json:"allocatedReplicas,omitempty"`
+	// Allocatable is the total resources reserved by the available reservations.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+	// Allocated is the total resources allocated by the owners of the available reservations.
+	// +optional
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas",description="The desired number of reservations"
+// +kubebuilder:printcolumn:name="Current",type="integer",JSONPath=".status.replicas",description="The number of reservations not consumed"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas",description="The number of available reservations"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ReservationSet is the Schema for the reservationset API.
+// A ReservationSet maintains a desired number of reservations created from the template, which is useful to
+// reserve resources for the future replicas of a workload before scaling out.
+type ReservationSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSetSpec   `json:"spec,omitempty"`
+	Status ReservationSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReservationSetList contains a list of ReservationSet
+type ReservationSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReservationSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReservationSet{}, &ReservationSetList{})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// LabelWorkloadMigrationJob is the label of PodMigrationJob indicates the WorkloadMigrationJob it belongs to.
+	LabelWorkloadMigrationJob = "scheduling.koordinator.sh/workload-migration-job"
+)
+
+type WorkloadMigrationJobSpec struct {
+	// Paused indicates whether the WorkloadMigrationJob should to work or not.
+	// Default is false
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// WorkloadRef represents the workload whose Pods will be migrated, e.g. Deployment or StatefulSet.
+	// +required
+	WorkloadRef *corev1.ObjectReference `json:"workloadRef"`
+
+	// TargetNodeSelector selects the nodes that the Pods will be migrated to.
+	// The Pods already running on the selected nodes are not migrated.
+	// +required
+	TargetNodeSelector map[string]string `json:"targetNodeSelector"`
+
+	// MaxUnavailable represents the maximum number of Pods of the workload that can be unavailable during migration.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Default is the MaxUnavailablePerWorkload of the MigrationController.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// PodMigrationJobTTL controls the timeout duration of each PodMigrationJob.
+	// Default is the DefaultJobTTL of the MigrationController.
+	// +optional
+	PodMigrationJobTTL *metav1.Duration `json:"podMigrationJobTTL,omitempty"`
+
+	// Timeout controls the timeout duration of the WorkloadMigrationJob.
+	// The WorkloadMigrationJob fails if the workload is not migrated within the duration. Default is no timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type WorkloadMigrationJobPhase string
+
+const (
+	// WorkloadMigrationJobPending represents the initial status
+	WorkloadMigrationJobPending WorkloadMigrationJobPhase = "Pending"
+	// WorkloadMigrationJobRunning represents the WorkloadMigrationJob is being processed
+	WorkloadMigrationJobRunning WorkloadMigrationJobPhase = "Running"
+	// WorkloadMigrationJobSucceeded represents all Pods of the workload are running on the target nodes
+	WorkloadMigrationJobSucceeded WorkloadMigrationJobPhase = "Succeeded"
+	// WorkloadMigrationJobFailed represents some Pods of the workload failed to be migrated
+	WorkloadMigrationJobFailed WorkloadMigrationJobPhase = "Failed"
+)
+
+// These are valid reasons of WorkloadMigrationJob.
+const (
+	WorkloadMigrationJobReasonMissingWorkload  = "MissingWorkload"
+	WorkloadMigrationJobReasonInvalidSpec      = "InvalidSpec"
+	WorkloadMigrationJobReasonFailedMigratePod = "FailedMigratePod"
+	WorkloadMigrationJobReasonTimeout          = "Timeout"
+)
+
+type WorkloadMigrationJobStatus struct {
+	// Phase represents the phase of a WorkloadMigrationJob.
+	// e.g. Pending/Running/Succeeded/Failed
+	Phase WorkloadMigrationJobPhase `json:"phase,omitempty"`
+	// Reason represents a brief CamelCase message indicating details about why the WorkloadMigrationJob is in this state.
+	Reason string `json:"reason,omitempty"`
+	// Message represents a human-readable message indicating details about why the WorkloadMigrationJob is in this state.
+	Message string `json:"message,omitempty"`
+	// Replicas is the desired number of Pods of the workload.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// MigratedReplicas is the number of ready Pods running on the target nodes.
+	// +optional
+	MigratedReplicas int32 `json:"migratedReplicas,omitempty"`
+	// MigratingReplicas is the number of Pods being migrated by the PodMigrationJobs.
+	// +optional
+	MigratingReplicas int32 `json:"migratingReplicas,omitempty"`
+	// FailedReplicas is the number of Pods whose PodMigrationJobs failed.
+	// +optional
+	FailedReplicas int32 `json:"failedReplicas,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster,shortName=wmj
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of WorkloadMigrationJob"
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.workloadRef.kind"
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.workloadRef.namespace"
+// +kubebuilder:printcolumn:name="Workload",type="string",JSONPath=".spec.workloadRef.name"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas"
+// +kubebuilder:printcolumn:name="Migrated",type="integer",JSONPath=".status.migratedReplicas"
+// +kubebuilder:printcolumn:name="Migrating",type="integer",JSONPath=".status.migratingReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkloadMigrationJob is the Schema for the WorkloadMigrationJob API.
+// A WorkloadMigrationJob migrates all Pods of a workload to the target nodes by rolling PodMigrationJobs
+// in ReservationFirst mode, within the limit of MaxUnavailable.
+type WorkloadMigrationJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkloadMigrationJobSpec   `json:"spec,omitempty"`
+	Status WorkloadMigrationJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadMigrationJobList contains a list of WorkloadMigrationJob
+type WorkloadMigrationJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadMigrationJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkloadMigrationJob{}, &WorkloadMigrationJobList{})
+}
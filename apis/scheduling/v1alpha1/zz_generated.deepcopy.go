@@ -25,6 +25,7 @@ import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -238,6 +239,110 @@ func (in *DeviceTopology) DeepCopy() *DeviceTopology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainJob) DeepCopyInto(out *NodeDrainJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainJob.
+func (in *NodeDrainJob) DeepCopy() *NodeDrainJob {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeDrainJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainJobList) DeepCopyInto(out *NodeDrainJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeDrainJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainJobList.
+func (in *NodeDrainJobList) DeepCopy() *NodeDrainJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeDrainJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainJobSpec) DeepCopyInto(out *NodeDrainJobSpec) {
+	*out = *in
+	if in.MaxConcurrentMigrations != nil {
+		in, out := &in.MaxConcurrentMigrations, &out.MaxConcurrentMigrations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodMigrationJobTTL != nil {
+		in, out := &in.PodMigrationJobTTL, &out.PodMigrationJobTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainJobSpec.
+func (in *NodeDrainJobSpec) DeepCopy() *NodeDrainJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainJobStatus) DeepCopyInto(out *NodeDrainJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainJobStatus.
+func (in *NodeDrainJobStatus) DeepCopy() *NodeDrainJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMigrateReservationOptions) DeepCopyInto(out *PodMigrateReservationOptions) {
 	*out = *in
@@ -580,6 +685,114 @@ func (in *ReservationOwner) DeepCopy() *ReservationOwner {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSet) DeepCopyInto(out *ReservationSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSet.
+func (in *ReservationSet) DeepCopy() *ReservationSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSetList) DeepCopyInto(out *ReservationSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReservationSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSetList.
+func (in *ReservationSetList) DeepCopy() *ReservationSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSetSpec) DeepCopyInto(out *ReservationSetSpec) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ReservationTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSetSpec.
+func (in *ReservationSetSpec) DeepCopy() *ReservationSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSetStatus) DeepCopyInto(out *ReservationSetStatus) {
+	*out = *in
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Allocated != nil {
+		in, out := &in.Allocated, &out.Allocated
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSetStatus.
+func (in *ReservationSetStatus) DeepCopy() *ReservationSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReservationSpec) DeepCopyInto(out *ReservationSpec) {
 	*out = *in
@@ -720,3 +933,119 @@ func (in *VirtualFunctionGroup) DeepCopy() *VirtualFunctionGroup {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMigrationJob) DeepCopyInto(out *WorkloadMigrationJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMigrationJob.
+func (in *WorkloadMigrationJob) DeepCopy() *WorkloadMigrationJob {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadMigrationJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadMigrationJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMigrationJobList) DeepCopyInto(out *WorkloadMigrationJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadMigrationJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMigrationJobList.
+func (in *WorkloadMigrationJobList) DeepCopy() *WorkloadMigrationJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadMigrationJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadMigrationJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMigrationJobSpec) DeepCopyInto(out *WorkloadMigrationJobSpec) {
+	*out = *in
+	if in.WorkloadRef != nil {
+		in, out := &in.WorkloadRef, &out.WorkloadRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.TargetNodeSelector != nil {
+		in, out := &in.TargetNodeSelector, &out.TargetNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PodMigrationJobTTL != nil {
+		in, out := &in.PodMigrationJobTTL, &out.PodMigrationJobTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMigrationJobSpec.
+func (in *WorkloadMigrationJobSpec) DeepCopy() *WorkloadMigrationJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadMigrationJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMigrationJobStatus) DeepCopyInto(out *WorkloadMigrationJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMigrationJobStatus.
+func (in *WorkloadMigrationJobStatus) DeepCopy() *WorkloadMigrationJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadMigrationJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,58 @@ type NodeMetricInfo struct {
 	// AggregatedSystemUsages will report only if there are enough samples
 	// Deleted pods will be excluded during aggregation
 	AggregatedSystemUsages []AggregatedUsage `json:"aggregatedSystemUsages,omitempty"`
+	// PeakPrediction is the predicted peak usage of node in percentiles (e.g. p95, p99),
+	// which is calculated by koordlet according to the historical metrics.
+	PeakPrediction map[AggregationType]ResourceMap `json:"peakPrediction,omitempty"`
+	// HourlyUsageProfile is the hour-of-day usage profile of node, which records the peak usage in each
+	// hour of the day smoothed over the past days.
+	HourlyUsageProfile []HourlyUsage `json:"hourlyUsageProfile,omitempty"`
+	// Contention is the contention indicators of node, which reflect the interference among the pods
+	// on the shared resources (e.g. CPU, LLC and memory bandwidth).
+	Contention *NodeContention `json:"contention,omitempty"`
+}
+
+// NodeContention is the contention indicators of node aggregated by koordlet. An indicator is absent if its
+// metric is not collected on the node.
+type NodeContention struct {
+	// PSI is the pressure stall information of the node in percentage, which is the maximum average
+	// "some avg10" pressure among the pods during the aggregation period.
+	PSI *PSIContention `json:"psi,omitempty"`
+	// CPIOutlierPercent is the percentage of containers whose latest CPI (cycles per instruction)
+	// exceeds their average CPI during the aggregation period significantly.
+	CPIOutlierPercent *int64 `json:"cpiOutlierPercent,omitempty"`
+	// MemoryBandwidth is the average memory bandwidth usage of node in bytes per second, which is
+	// collected by the resctrl memory bandwidth monitoring (MBM).
+	MemoryBandwidth *resource.Quantity `json:"memoryBandwidth,omitempty"`
+	// CPUThrottledPercent is the average cpu throttled ratio of pods in percentage.
+	CPUThrottledPercent *int64 `json:"cpuThrottledPercent,omitempty"`
+}
+
+// PodContention is the contention indicators of pod aggregated by koordlet. An indicator is absent if its
+// metric is not collected for the pod.
+type PodContention struct {
+	// PSI is the average "some avg10" pressure stall information of the pod in percentage during the
+	// aggregation period.
+	PSI *PSIContention `json:"psi,omitempty"`
+	// CPIOutlierPercent is the percentage of the pod's containers whose latest CPI (cycles per instruction)
+	// exceeds their average CPI during the aggregation period significantly.
+	CPIOutlierPercent *int64 `json:"cpiOutlierPercent,omitempty"`
+	// CPUThrottledPercent is the average cpu throttled ratio of the pod in percentage.
+	CPUThrottledPercent *int64 `json:"cpuThrottledPercent,omitempty"`
+}
+
+// PSIContention is the pressure of resources in percentage.
+type PSIContention struct {
+	CPU    *int64 `json:"cpu,omitempty"`
+	Memory *int64 `json:"memory,omitempty"`
+	IO     *int64 `json:"io,omitempty"`
+}
+
+type HourlyUsage struct {
+	// Hour is the hour of the day in UTC, from 0 to 23.
+	Hour int32 `json:"hour"`
+	// Usage is the smoothed peak usage of node in the hour.
+	Usage ResourceMap `json:"usage,omitempty"`
 }
 
 type AggregatedUsage struct {
@@ -55,6 +108,11 @@ type PodMetricInfo struct {
 	Name      string      `json:"name,omitempty"`
 	Namespace string      `json:"namespace,omitempty"`
 	PodUsage  ResourceMap `json:"podUsage,omitempty"`
+	// PeakPrediction is the predicted peak usage of pod in percentiles (e.g. p95, p99),
+	// which is calculated by koordlet according to the historical metrics.
+	PeakPrediction map[AggregationType]ResourceMap `json:"peakPrediction,omitempty"`
+	// Contention is the contention indicators of pod, which reflect the interference suffered by the pod.
+	Contention *PodContention `json:"contention,omitempty"`
 	// Third party extensions for PodMetric
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
 }
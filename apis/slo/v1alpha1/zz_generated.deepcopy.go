@@ -226,6 +226,22 @@ func (in *CPUQOSCfg) DeepCopy() *CPUQOSCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HourlyUsage) DeepCopyInto(out *HourlyUsage) {
+	*out = *in
+	in.Usage.DeepCopyInto(&out.Usage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HourlyUsage.
+func (in *HourlyUsage) DeepCopy() *HourlyUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(HourlyUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IOCfg) DeepCopyInto(out *IOCfg) {
 	*out = *in
@@ -357,6 +373,41 @@ func (in *MemoryQOSCfg) DeepCopy() *MemoryQOSCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeContention) DeepCopyInto(out *NodeContention) {
+	*out = *in
+	if in.PSI != nil {
+		in, out := &in.PSI, &out.PSI
+		*out = new(PSIContention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPIOutlierPercent != nil {
+		in, out := &in.CPIOutlierPercent, &out.CPIOutlierPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryBandwidth != nil {
+		in, out := &in.MemoryBandwidth, &out.MemoryBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPUThrottledPercent != nil {
+		in, out := &in.CPUThrottledPercent, &out.CPUThrottledPercent
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeContention.
+func (in *NodeContention) DeepCopy() *NodeContention {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeContention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeMetric) DeepCopyInto(out *NodeMetric) {
 	*out = *in
@@ -433,6 +484,25 @@ func (in *NodeMetricInfo) DeepCopyInto(out *NodeMetricInfo) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PeakPrediction != nil {
+		in, out := &in.PeakPrediction, &out.PeakPrediction
+		*out = make(map[AggregationType]ResourceMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.HourlyUsageProfile != nil {
+		in, out := &in.HourlyUsageProfile, &out.HourlyUsageProfile
+		*out = make([]HourlyUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Contention != nil {
+		in, out := &in.Contention, &out.Contention
+		*out = new(NodeContention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMetricInfo.
@@ -650,6 +720,36 @@ func (in *NodeSLOStatus) DeepCopy() *NodeSLOStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSIContention) DeepCopyInto(out *PSIContention) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IO != nil {
+		in, out := &in.IO, &out.IO
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSIContention.
+func (in *PSIContention) DeepCopy() *PSIContention {
+	if in == nil {
+		return nil
+	}
+	out := new(PSIContention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMemoryQOSConfig) DeepCopyInto(out *PodMemoryQOSConfig) {
 	*out = *in
@@ -666,10 +766,52 @@ func (in *PodMemoryQOSConfig) DeepCopy() *PodMemoryQOSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodContention) DeepCopyInto(out *PodContention) {
+	*out = *in
+	if in.PSI != nil {
+		in, out := &in.PSI, &out.PSI
+		*out = new(PSIContention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPIOutlierPercent != nil {
+		in, out := &in.CPIOutlierPercent, &out.CPIOutlierPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPUThrottledPercent != nil {
+		in, out := &in.CPUThrottledPercent, &out.CPUThrottledPercent
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodContention.
+func (in *PodContention) DeepCopy() *PodContention {
+	if in == nil {
+		return nil
+	}
+	out := new(PodContention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMetricInfo) DeepCopyInto(out *PodMetricInfo) {
 	*out = *in
 	in.PodUsage.DeepCopyInto(&out.PodUsage)
+	if in.PeakPrediction != nil {
+		in, out := &in.PeakPrediction, &out.PeakPrediction
+		*out = make(map[AggregationType]ResourceMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Contention != nil {
+		in, out := &in.Contention, &out.Contention
+		*out = new(PodContention)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
 		*out = (*in).DeepCopy()
@@ -60,6 +60,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
 )
 
@@ -154,13 +155,13 @@ func Run(ctx context.Context, cc *deschedulerappconfig.CompletedConfig, desched
 
 	// Start up the healthz server.
 	if cc.InsecureServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, desched.Reports, checks...))
 		if err := cc.InsecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start healthz server: %v", err)
 		}
 	}
 	if cc.InsecureMetricsServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, desched.Reports, checks...))
 		if err := cc.InsecureMetricsServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start metrics server: %v", err)
 		}
@@ -168,7 +169,7 @@ func Run(ctx context.Context, cc *deschedulerappconfig.CompletedConfig, desched
 
 	// Start up the healthz server.
 	if cc.SecureServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, desched.Reports, checks...))
 		// TODO: handle stoppedCh and listenerStoppedCh returned by c.SecureServing.Serve
 		if _, _, err := cc.SecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			// fail early for secure handlers, removing the old error loop from above
@@ -242,10 +243,13 @@ func installMetricHandler(pathRecorderMux *mux.PathRecorderMux) {
 
 // newHealthzAndMetricsHandler creates a healthz server from the config, and will also
 // embed the metrics handler.
-func newHealthzAndMetricsHandler(config *deschedulerconfig.DeschedulerConfiguration, checks ...healthz.HealthChecker) http.Handler {
+func newHealthzAndMetricsHandler(config *deschedulerconfig.DeschedulerConfiguration, reports *report.Store, checks ...healthz.HealthChecker) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux("koord-descheduler")
 	healthz.InstallHandler(pathRecorderMux, checks...)
 	installMetricHandler(pathRecorderMux)
+	if reports != nil {
+		reports.InstallHandler(pathRecorderMux)
+	}
 	if config.EnableProfiling {
 		routes.Profiling{}.Install(pathRecorderMux)
 		if config.EnableContentionProfiling {
@@ -314,6 +318,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		descheduler.WithDeschedulingInterval(cc.ComponentConfig.DeschedulingInterval.Duration),
 		descheduler.WithNodeSelector(cc.ComponentConfig.NodeSelector),
 		descheduler.WithEvictionLimiter(evictionLimiter),
+		descheduler.WithReportConfiguration(cc.ComponentConfig.Report),
 		descheduler.WithPodAssignedToNodeFn(podAssignedToNode(cc.Manager.GetClient())),
 		descheduler.WithBuildFrameworkCapturer(func(profile deschedulerconfig.DeschedulerProfile) {
 			completedProfiles = append(completedProfiles, profile)
@@ -54,6 +54,8 @@ import (
 	"k8s.io/kubernetes/pkg/scheduler"
 	kubeschedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	frameworkplugins "k8s.io/kubernetes/pkg/scheduler/framework/plugins"
 	"k8s.io/kubernetes/pkg/scheduler/framework/runtime"
 	"k8s.io/kubernetes/pkg/scheduler/metrics/resources"
 	"k8s.io/kubernetes/pkg/scheduler/profile"
@@ -104,6 +106,8 @@ for cost reduction and efficiency enhancement.
 		},
 	}
 
+	cmd.AddCommand(newSimulateCommand(registryOptions...))
+
 	nfs := opts.Flags
 	verflag.AddFlags(nfs.FlagSet("global"))
 	globalflag.AddGlobalFlags(nfs.FlagSet("global"), cmd.Name(), logs.SkipLoggingConfigurationFlags())
@@ -347,6 +351,25 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	// Get the completed config
 	cc := c.Complete()
 
+	sched, frameworkExtenderFactory, completedProfiles, err := newScheduler(ctx, &cc, nil, outOfTreeRegistryOptions...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := scheduleroptions.LogOrWriteConfig(opts.WriteConfigTo, &cc.ComponentConfig, completedProfiles); err != nil {
+		return nil, nil, nil, err
+	}
+	if cc.ServicesEngine != nil {
+		simulator, err := newSimulator(&cc, sched, outOfTreeRegistryOptions...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cc.ServicesEngine.SetSimulator(simulator)
+	}
+	return &cc, sched, frameworkExtenderFactory, nil
+}
+
+// newScheduler creates the scheduler with the koordinator framework extensions from the completed config.
+func newScheduler(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, extenderOptions []frameworkext.Option, outOfTreeRegistryOptions ...Option) (*scheduler.Scheduler, *frameworkext.FrameworkExtenderFactory, []kubeschedulerconfig.KubeSchedulerProfile, error) {
 	defaultprofile.AppendDefaultPlugins(cc.ComponentConfig.Profiles)
 
 	frameworkext.SetupCustomInformers(cc.InformerFactory)
@@ -354,11 +377,11 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 
 	// NOTE(joseph): K8s scheduling framework does not provide extension point for initialization.
 	// Currently, only by copying the initialization code and implementing custom initialization.
-	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(append([]frameworkext.Option{
 		frameworkext.WithServicesEngine(cc.ServicesEngine),
 		frameworkext.WithKoordinatorClientSet(cc.KoordinatorClient),
 		frameworkext.WithKoordinatorSharedInformerFactory(cc.KoordinatorSharedInformerFactory),
-	)
+	}, extenderOptions...)...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -370,7 +393,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		}
 	}
 
-	recorderFactory := getRecorderFactory(&cc)
+	recorderFactory := getRecorderFactory(cc)
 	completedProfiles := make([]kubeschedulerconfig.KubeSchedulerProfile, 0)
 	// Create the scheduler.
 	sched, err := scheduler.New(cc.Client,
@@ -396,9 +419,6 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if err := scheduleroptions.LogOrWriteConfig(opts.WriteConfigTo, &cc.ComponentConfig, completedProfiles); err != nil {
-		return nil, nil, nil, err
-	}
 
 	// extend framework to hook run plugin functions
 	for k, fwk := range sched.Profiles {
@@ -422,5 +442,59 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	)
 	frameworkExtenderFactory.RegisterErrorHandler(reservationErrorHandler)
 
-	return &cc, sched, frameworkExtenderFactory, nil
+	return sched, frameworkExtenderFactory, completedProfiles, nil
+}
+
+// newSimulator creates the frameworks dedicated to the simulation API with the same profiles and plugins as the scheduler.
+// The frameworks refer to a private snapshot, and their Scheduler never changes the scheduler cache and queue,
+// so the simulation never runs the extension points on the scheduler's own frameworks.
+// The plugins are created in the simulation mode, so they keep their own states for Reserve, but never write
+// to the cluster or replace the global states of the scheduler's plugins during initialization, and the
+// controllers and services of the plugins are never started.
+func newSimulator(cc *schedulerserverconfig.CompletedConfig, sched *scheduler.Scheduler, outOfTreeRegistryOptions ...Option) (*services.Simulator, error) {
+	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+		frameworkext.WithKoordinatorClientSet(cc.KoordinatorClient),
+		frameworkext.WithKoordinatorSharedInformerFactory(cc.KoordinatorSharedInformerFactory),
+		frameworkext.WithSimulation(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := frameworkplugins.NewInTreeRegistry()
+	outOfTreeRegistry := make(runtime.Registry)
+	for _, option := range outOfTreeRegistryOptions {
+		if err := option(frameworkExtenderFactory, outOfTreeRegistry); err != nil {
+			return nil, err
+		}
+	}
+	if err := registry.Merge(outOfTreeRegistry); err != nil {
+		return nil, err
+	}
+
+	snapshot := services.NewSimulationSnapshot()
+	profiles := make(map[string]framework.Framework, len(cc.ComponentConfig.Profiles))
+	for i := range cc.ComponentConfig.Profiles {
+		profile := cc.ComponentConfig.Profiles[i].DeepCopy()
+		fwk, err := runtime.NewFramework(registry, profile,
+			runtime.WithComponentConfigVersion(cc.ComponentConfig.TypeMeta.APIVersion),
+			runtime.WithClientSet(cc.Client),
+			runtime.WithKubeConfig(cc.KubeConfig),
+			runtime.WithInformerFactory(cc.InformerFactory),
+			runtime.WithSnapshotSharedLister(snapshot),
+			runtime.WithPodNominator(sched.SchedulingQueue),
+			runtime.WithEventRecorder(&events.FakeRecorder{}),
+			runtime.WithParallelism(int(cc.ComponentConfig.Parallelism)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing simulation profile %s: %w", profile.SchedulerName, err)
+		}
+		if extender := frameworkExtenderFactory.GetExtender(profile.SchedulerName); extender != nil {
+			extender.SetConfiguredPlugins(fwk.ListPlugins())
+			fwk = extender
+		}
+		profiles[profile.SchedulerName] = fwk
+	}
+	frameworkExtenderFactory.InitScheduler(frameworkext.NewSimulationScheduler())
+	return services.NewSimulator(snapshot, profiles), nil
 }
@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	prettytable "github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/events"
+	schedulerappconfig "k8s.io/kubernetes/cmd/kube-scheduler/app/config"
+	"k8s.io/kubernetes/pkg/scheduler"
+	kubeschedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
+	kubeschedulerscheme "k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+
+	schedulerserverconfig "github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/config"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/simulator"
+)
+
+type simulateOptions struct {
+	configFile  string
+	snapshot    []string
+	podTemplate string
+	maxCount    int
+	output      string
+}
+
+// newSimulateCommand creates the command to estimate how many replicas of a pod fit in a cluster snapshot.
+// It runs fully offline with the fake clientsets serving the snapshot.
+func newSimulateCommand(registryOptions ...Option) *cobra.Command {
+	opts := &simulateOptions{
+		maxCount: 1000,
+		output:   "text",
+	}
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Estimate how many replicas of a pod can be scheduled in a cluster snapshot",
+		Long: `Load a cluster snapshot (Nodes, Pods, NodeMetrics, Devices, ElasticQuotas, Reservations, etc.)
+from YAML or JSON files, and schedule the replicas of the template pod one by one through the
+configured koordinator plugins until the pod is unschedulable. The maximum count and the limiting
+reason are reported. No apiserver is required.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSimulate(cmd.OutOrStdout(), opts, registryOptions...)
+		},
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	fs := cmd.Flags()
+	fs.StringVar(&opts.configFile, "config", opts.configFile, "The path to the scheduler configuration file, the default configuration is used if empty.")
+	fs.StringSliceVar(&opts.snapshot, "snapshot", opts.snapshot, "The files or directories containing the objects of the cluster snapshot.")
+	fs.StringVar(&opts.podTemplate, "pod-template", opts.podTemplate, "The file containing the pod to be scheduled.")
+	fs.IntVar(&opts.maxCount, "max-count", opts.maxCount, "The maximum number of replicas to schedule.")
+	fs.StringVarP(&opts.output, "output", "o", opts.output, "The output format, one of text or json.")
+	return cmd
+}
+
+func runSimulate(out io.Writer, opts *simulateOptions, registryOptions ...Option) error {
+	if len(opts.snapshot) == 0 {
+		return fmt.Errorf("--snapshot is required")
+	}
+	if opts.podTemplate == "" {
+		return fmt.Errorf("--pod-template is required")
+	}
+	if opts.maxCount <= 0 {
+		return fmt.Errorf("--max-count must be positive")
+	}
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("unsupported output format %q", opts.output)
+	}
+
+	componentConfig, err := loadSchedulerConfig(opts.configFile)
+	if err != nil {
+		return err
+	}
+	snapshot, err := simulator.LoadSnapshot(opts.snapshot...)
+	if err != nil {
+		return err
+	}
+	template, err := simulator.LoadPodFromFile(opts.podTemplate)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clients := snapshot.NewFakeClientSets()
+	cc := newSimulationConfig(componentConfig, clients)
+	sched, _, _, err := newScheduler(ctx, &cc, []frameworkext.Option{frameworkext.WithHandleWrapper(clients.HandleWrapper())}, registryOptions...)
+	if err != nil {
+		return err
+	}
+
+	cc.InformerFactory.Start(ctx.Done())
+	cc.KoordinatorSharedInformerFactory.Start(ctx.Done())
+	cc.InformerFactory.WaitForCacheSync(ctx.Done())
+	cc.KoordinatorSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	report, err := simulator.NewCapacityEstimator(sched, clients.KubeClient).Estimate(ctx, template, opts.maxCount)
+	if err != nil {
+		return err
+	}
+	if opts.output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printCapacityReport(out, report, opts.maxCount)
+	return nil
+}
+
+func loadSchedulerConfig(file string) (*kubeschedulerconfig.KubeSchedulerConfiguration, error) {
+	if file == "" {
+		return latest.Default()
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	obj, gvk, err := kubeschedulerscheme.Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg, ok := obj.(*kubeschedulerconfig.KubeSchedulerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("couldn't decode as KubeSchedulerConfiguration, got %s", gvk)
+	}
+	return cfg, nil
+}
+
+// newSimulationConfig creates the config with the fake clientsets, the servings and the leader election are disabled.
+func newSimulationConfig(componentConfig *kubeschedulerconfig.KubeSchedulerConfiguration, clients *simulator.ClientSets) schedulerserverconfig.CompletedConfig {
+	c := &schedulerserverconfig.Config{
+		Config: &schedulerappconfig.Config{
+			ComponentConfig:  *componentConfig,
+			Client:           clients.KubeClient,
+			InformerFactory:  scheduler.NewInformerFactory(clients.KubeClient, 0),
+			EventBroadcaster: events.NewEventBroadcasterAdapter(clients.KubeClient),
+		},
+		ServicesEngine:                   services.NewEngine(gin.New()),
+		KoordinatorClient:                clients.KoordClient,
+		KoordinatorSharedInformerFactory: koordinatorinformers.NewSharedInformerFactoryWithOptions(clients.KoordClient, 0),
+	}
+	return c.Complete()
+}
+
+func printCapacityReport(out io.Writer, report *simulator.CapacityReport, maxCount int) {
+	fmt.Fprintf(out, "Maximum count: %d\n", report.Count)
+	if report.LimitingReason != "" {
+		fmt.Fprintf(out, "Limiting reason: %s\n", report.LimitingReason)
+		fmt.Fprintf(out, "Message: %s\n", report.Message)
+	} else {
+		fmt.Fprintf(out, "Limiting reason: reached --max-count %d\n", maxCount)
+	}
+
+	if len(report.NodeCounts) > 0 {
+		nodeNames := make([]string, 0, len(report.NodeCounts))
+		for nodeName := range report.NodeCounts {
+			nodeNames = append(nodeNames, nodeName)
+		}
+		sort.Strings(nodeNames)
+		tw := prettytable.NewWriter()
+		tw.SetOutputMirror(out)
+		tw.AppendHeader(prettytable.Row{"Node", "Count"})
+		for _, nodeName := range nodeNames {
+			tw.AppendRow(prettytable.Row{nodeName, report.NodeCounts[nodeName]})
+		}
+		tw.Render()
+	}
+
+	if len(report.Reasons) > 0 {
+		reasons := make([]string, 0, len(report.Reasons))
+		for reason := range report.Reasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if report.Reasons[reasons[i]] != report.Reasons[reasons[j]] {
+				return report.Reasons[reasons[i]] > report.Reasons[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		tw := prettytable.NewWriter()
+		tw.SetOutputMirror(out)
+		tw.AppendHeader(prettytable.Row{"Reason", "Nodes"})
+		for _, reason := range reasons {
+			tw.AppendRow(prettytable.Row{reason, report.Reasons[reason]})
+		}
+		tw.Render()
+	}
+}
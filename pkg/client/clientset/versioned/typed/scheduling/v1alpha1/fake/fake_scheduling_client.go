@@ -32,6 +32,10 @@ func (c *FakeSchedulingV1alpha1) Devices() v1alpha1.DeviceInterface {
 	return &FakeDevices{c}
 }
 
+func (c *FakeSchedulingV1alpha1) NodeDrainJobs() v1alpha1.NodeDrainJobInterface {
+	return &FakeNodeDrainJobs{c}
+}
+
 func (c *FakeSchedulingV1alpha1) PodMigrationJobs() v1alpha1.PodMigrationJobInterface {
 	return &FakePodMigrationJobs{c}
 }
@@ -40,6 +44,14 @@ func (c *FakeSchedulingV1alpha1) Reservations() v1alpha1.ReservationInterface {
 	return &FakeReservations{c}
 }
 
+func (c *FakeSchedulingV1alpha1) ReservationSets() v1alpha1.ReservationSetInterface {
+	return &FakeReservationSets{c}
+}
+
+func (c *FakeSchedulingV1alpha1) WorkloadMigrationJobs() v1alpha1.WorkloadMigrationJobInterface {
+	return &FakeWorkloadMigrationJobs{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSchedulingV1alpha1) RESTClient() rest.Interface {
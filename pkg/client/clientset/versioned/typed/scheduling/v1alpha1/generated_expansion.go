@@ -20,6 +20,12 @@ package v1alpha1
 
 type DeviceExpansion interface{}
 
+type NodeDrainJobExpansion interface{}
+
 type PodMigrationJobExpansion interface{}
 
 type ReservationExpansion interface{}
+
+type ReservationSetExpansion interface{}
+
+type WorkloadMigrationJobExpansion interface{}
@@ -26,10 +26,16 @@ import (
 type Interface interface {
 	// Devices returns a DeviceInformer.
 	Devices() DeviceInformer
+	// NodeDrainJobs returns a NodeDrainJobInformer.
+	NodeDrainJobs() NodeDrainJobInformer
 	// PodMigrationJobs returns a PodMigrationJobInformer.
 	PodMigrationJobs() PodMigrationJobInformer
 	// Reservations returns a ReservationInformer.
 	Reservations() ReservationInformer
+	// ReservationSets returns a ReservationSetInformer.
+	ReservationSets() ReservationSetInformer
+	// WorkloadMigrationJobs returns a WorkloadMigrationJobInformer.
+	WorkloadMigrationJobs() WorkloadMigrationJobInformer
 }
 
 type version struct {
@@ -48,6 +54,11 @@ func (v *version) Devices() DeviceInformer {
 	return &deviceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NodeDrainJobs returns a NodeDrainJobInformer.
+func (v *version) NodeDrainJobs() NodeDrainJobInformer {
+	return &nodeDrainJobInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PodMigrationJobs returns a PodMigrationJobInformer.
 func (v *version) PodMigrationJobs() PodMigrationJobInformer {
 	return &podMigrationJobInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -57,3 +68,13 @@ func (v *version) PodMigrationJobs() PodMigrationJobInformer {
 func (v *version) Reservations() ReservationInformer {
 	return &reservationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ReservationSets returns a ReservationSetInformer.
+func (v *version) ReservationSets() ReservationSetInformer {
+	return &reservationSetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkloadMigrationJobs returns a WorkloadMigrationJobInformer.
+func (v *version) WorkloadMigrationJobs() WorkloadMigrationJobInformer {
+	return &workloadMigrationJobInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
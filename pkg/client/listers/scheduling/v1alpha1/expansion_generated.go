@@ -22,6 +22,10 @@ package v1alpha1
 // DeviceLister.
 type DeviceListerExpansion interface{}
 
+// NodeDrainJobListerExpansion allows custom methods to be added to
+// NodeDrainJobLister.
+type NodeDrainJobListerExpansion interface{}
+
 // PodMigrationJobListerExpansion allows custom methods to be added to
 // PodMigrationJobLister.
 type PodMigrationJobListerExpansion interface{}
@@ -29,3 +33,11 @@ type PodMigrationJobListerExpansion interface{}
 // ReservationListerExpansion allows custom methods to be added to
 // ReservationLister.
 type ReservationListerExpansion interface{}
+
+// ReservationSetListerExpansion allows custom methods to be added to
+// ReservationSetLister.
+type ReservationSetListerExpansion interface{}
+
+// WorkloadMigrationJobListerExpansion allows custom methods to be added to
+// WorkloadMigrationJobLister.
+type WorkloadMigrationJobListerExpansion interface{}
@@ -48,6 +48,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DeschedulerConfiguration{},
 		&MigrationControllerArgs{},
 		&LowNodeLoadArgs{},
+		&InterferenceMigrationArgs{},
+		&CPUCompactionArgs{},
+		&TopologySpreadRebalanceArgs{},
 	)
 	return nil
 }
@@ -67,6 +67,21 @@ type DeschedulerConfiguration struct {
 
 	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
 	MaxNoOfPodsToEvictPerNamespace *uint
+
+	// Report configures the reports explaining the decisions of each descheduling cycle.
+	// The report is disabled if it is nil.
+	Report *ReportConfiguration
+}
+
+// ReportConfiguration configures the reports explaining the decisions of each descheduling cycle.
+type ReportConfiguration struct {
+	// MaxReports is the number of the latest reports kept in memory and served by the HTTP endpoint.
+	MaxReports int32
+	// ConfigMapNamespace is the namespace of the ConfigMap which the latest report is written to.
+	ConfigMapNamespace string
+	// ConfigMapName is the name of the ConfigMap which the latest report is written to.
+	// The report is not written to any ConfigMap if it is empty.
+	ConfigMapName string
 }
 
 // DeschedulerProfile is a descheduling profile.
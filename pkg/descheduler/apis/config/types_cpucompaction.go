@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CPUCompactionArgs holds arguments used to configure the CPUCompaction plugin, which migrates the
+// CPU-bound LSE/LSR pods to defragment the CPUs of the nodes.
+type CPUCompactionArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the CPUCompaction should to work or not.
+	// Default is false
+	Paused bool
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun bool
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	// for which the pods are migrated
+	EvictableNamespaces *Namespaces
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector
+
+	// MaxFragmentedCPUPercent is the threshold of the percentage of the free CPUs on the partially allocated
+	// physical cores, which cannot be allocated to the FullPCPUs pods. A node exceeding it is fragmented.
+	// The default is 30.
+	MaxFragmentedCPUPercent int32
+
+	// NUMANodeCompaction indicates whether a node is fragmented if its free CPUs are enough for a NUMA node
+	// but none of the NUMA nodes is free, so that the NUMA exclusive pods cannot fit.
+	// The default is true.
+	NUMANodeCompaction bool
+
+	// MaxMigratingPerNode limits the number of pods migrated from a node in one round,
+	// the default is 2.
+	MaxMigratingPerNode int32
+
+	// MaxTargetNodes limits the number of nodes that the migrated pod is expected to be placed on,
+	// the nodes leave the least free CPUs after placing the pod compactly are preferred.
+	// The default is 3.
+	MaxTargetNodes int32
+}
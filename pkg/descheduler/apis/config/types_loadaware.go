@@ -61,6 +61,8 @@ type LowNodeLoadArgs struct {
 	UseDeviationThresholds bool
 
 	// HighThresholds defines the target usage threshold of resources
+	// GPU resources like koordinator.sh/gpu-core are supported by the GPU usage reported in NodeMetric
+	// and the nodes whose GPUs are allocated but the gpu-core usage is under the LowThresholds are consolidated
 	HighThresholds ResourceThresholds
 
 	// LowThresholds defines the low usage threshold of resources
@@ -75,6 +77,10 @@ type LowNodeLoadArgs struct {
 	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the load.
 	AnomalyCondition *LoadAnomalyCondition
 
+	// LoadForecast if set, the nodes are classified by the forecasted usage instead of the current usage,
+	// so that the pods are migrated ahead of the predictable peaks.
+	LoadForecast *LoadForecast
+
 	// NodePools supports multiple different types of batch nodes to configure different strategies
 	NodePools []LowNodeLoadNodePool
 }
@@ -90,6 +96,8 @@ type LowNodeLoadNodePool struct {
 	UseDeviationThresholds bool
 
 	// HighThresholds defines the target usage threshold of resources
+	// GPU resources like koordinator.sh/gpu-core are supported by the GPU usage reported in NodeMetric
+	// and the nodes whose GPUs are allocated but the gpu-core usage is under the LowThresholds are consolidated
 	HighThresholds ResourceThresholds
 
 	// LowThresholds defines the low usage threshold of resources
@@ -120,3 +128,25 @@ type LoadAnomalyCondition struct {
 	// ConsecutiveNormalities indicates the number of consecutive normalities
 	ConsecutiveNormalities uint32
 }
+
+// LoadForecastPolicy is the policy to forecast the usage of nodes.
+type LoadForecastPolicy string
+
+const (
+	// LoadForecastPolicyTrend extrapolates the linear trend of the node usage history kept by the descheduler.
+	LoadForecastPolicyTrend LoadForecastPolicy = "Trend"
+	// LoadForecastPolicyHourlyProfile refers to the hourly usage profile reported in NodeMetric,
+	// which reflects the diurnal pattern of the node usage.
+	LoadForecastPolicyHourlyProfile LoadForecastPolicy = "HourlyProfile"
+)
+
+type LoadForecast struct {
+	// Policy indicates how to forecast the node usage, the default is Trend
+	Policy LoadForecastPolicy
+	// Horizon indicates how far ahead the node usage is forecasted, the default is 15 minutes
+	Horizon metav1.Duration
+	// HistoryWindow indicates the duration of the node usage history used by the Trend policy, the default is 30 minutes
+	HistoryWindow metav1.Duration
+	// MinSamples indicates the minimum number of samples in the history to extrapolate the trend, the default is 3
+	MinSamples int32
+}
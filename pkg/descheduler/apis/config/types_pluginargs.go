@@ -104,6 +104,30 @@ type MigrationControllerArgs struct {
 	EvictionPolicy string
 	// DefaultDeleteOptions defines options when deleting migrated pods and preempted pods through the method specified by EvictionPolicy
 	DefaultDeleteOptions *metav1.DeleteOptions
+	// ArbitrationArgs defines the arbitration stage that pending PodMigrationJobs pass through before being reconciled
+	ArbitrationArgs *ArbitrationArgs
+}
+
+// ArbitrationArgs holds arguments used to configure the arbitration of PodMigrationJobs.
+// Pending jobs are collected and periodically deduplicated, sorted and released to the
+// Reconciler within the limits of the global migration budgets.
+type ArbitrationArgs struct {
+	// Enabled defines whether the arbitration stage is enabled. Default is false.
+	Enabled bool
+	// Interval defines the period in which pending jobs are arbitrated. Default is 500ms.
+	Interval metav1.Duration
+	// MigrationBudgets limit the number of PodMigrationJobs released cluster-wide within sliding windows.
+	// A job is released only if all budgets allow it.
+	MigrationBudgets []MigrationBudget
+}
+
+// MigrationBudget limits the number of PodMigrationJobs released within a sliding window.
+type MigrationBudget struct {
+	// Window indicates the duration of the sliding window.
+	Window metav1.Duration
+	// MaxMigrations indicates the maximum number of jobs released within the window.
+	// It must be positive, a zero budget would hold every job until it times out.
+	MaxMigrations int32
 }
 
 type MigrationLimitObjectType string
@@ -42,6 +42,21 @@ const (
 	defaultMigrationJobEvictionPolicy = migrationevictor.NativeEvictorName
 	defaultMigrationEvictQPS          = 10
 	defaultMigrationEvictBurst        = 1
+	defaultArbitrationInterval        = 500 * time.Millisecond
+
+	defaultLoadForecastHorizon       = 15 * time.Minute
+	defaultLoadForecastHistoryWindow = 30 * time.Minute
+	defaultLoadForecastMinSamples    = 3
+
+	defaultInterferenceMaxEvictionsPerNode = 1
+
+	defaultMaxFragmentedCPUPercent          = 30
+	defaultCPUCompactionMaxMigratingPerNode = 2
+	defaultCPUCompactionMaxTargetNodes      = 3
+
+	defaultTopologySpreadMaxMigratingPerConstraint = 2
+
+	defaultMaxReports = 10
 )
 
 var (
@@ -56,6 +71,12 @@ var (
 		ConsecutiveAbnormalities: 5,
 		ConsecutiveNormalities:   3,
 	}
+
+	defaultInterferenceThresholds = InterferenceThresholds{
+		CPUThrottledPercent:   pointer.Int64(20),
+		CPUPressurePercent:    pointer.Int64(20),
+		MemoryPressurePercent: pointer.Int64(20),
+	}
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -206,6 +227,11 @@ func SetDefaults_DeschedulerConfiguration(obj *DeschedulerConfiguration) {
 			}
 		}
 	}
+
+	// the report is disabled unless configured
+	if obj.Report != nil && obj.Report.MaxReports == nil {
+		obj.Report.MaxReports = pointer.Int32(defaultMaxReports)
+	}
 }
 
 func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
@@ -236,6 +262,14 @@ func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
 	if len(obj.ObjectLimiters) == 0 {
 		obj.ObjectLimiters = defaultObjectLimiters
 	}
+	if obj.ArbitrationArgs != nil {
+		if obj.ArbitrationArgs.Enabled == nil {
+			obj.ArbitrationArgs.Enabled = pointer.Bool(false)
+		}
+		if obj.ArbitrationArgs.Interval == nil {
+			obj.ArbitrationArgs.Interval = &metav1.Duration{Duration: defaultArbitrationInterval}
+		}
+	}
 }
 
 func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
@@ -247,6 +281,20 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
 		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
 	}
+	if obj.LoadForecast != nil {
+		if obj.LoadForecast.Policy == "" {
+			obj.LoadForecast.Policy = LoadForecastPolicyTrend
+		}
+		if obj.LoadForecast.Horizon == nil {
+			obj.LoadForecast.Horizon = &metav1.Duration{Duration: defaultLoadForecastHorizon}
+		}
+		if obj.LoadForecast.HistoryWindow == nil {
+			obj.LoadForecast.HistoryWindow = &metav1.Duration{Duration: defaultLoadForecastHistoryWindow}
+		}
+		if obj.LoadForecast.MinSamples == nil {
+			obj.LoadForecast.MinSamples = pointer.Int32(defaultLoadForecastMinSamples)
+		}
+	}
 
 	defaultResourceWeights := map[corev1.ResourceName]int64{
 		corev1.ResourceCPU:    1,
@@ -268,3 +316,43 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 		}
 	}
 }
+
+func SetDefaults_InterferenceMigrationArgs(obj *InterferenceMigrationArgs) {
+	thresholds := obj.Thresholds
+	if thresholds.CPUThrottledPercent == nil && thresholds.CPUPressurePercent == nil && thresholds.MemoryPressurePercent == nil &&
+		thresholds.IOPressurePercent == nil && thresholds.CPIOutlierPercent == nil {
+		obj.Thresholds = *defaultInterferenceThresholds.DeepCopy()
+	}
+	if obj.EvictionTarget == "" {
+		obj.EvictionTarget = InterferenceEvictionTargetAntagonist
+	}
+	if obj.MaxEvictionsPerNode == nil {
+		obj.MaxEvictionsPerNode = pointer.Int32(defaultInterferenceMaxEvictionsPerNode)
+	}
+	if obj.AnomalyCondition == nil {
+		obj.AnomalyCondition = defaultLoadAnomalyCondition.DeepCopy()
+	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+	}
+}
+
+func SetDefaults_CPUCompactionArgs(obj *CPUCompactionArgs) {
+	if obj.MaxFragmentedCPUPercent == nil {
+		obj.MaxFragmentedCPUPercent = pointer.Int32(defaultMaxFragmentedCPUPercent)
+	}
+	if obj.NUMANodeCompaction == nil {
+		obj.NUMANodeCompaction = pointer.Bool(true)
+	}
+	if obj.MaxMigratingPerNode == nil {
+		obj.MaxMigratingPerNode = pointer.Int32(defaultCPUCompactionMaxMigratingPerNode)
+	}
+	if obj.MaxTargetNodes == nil {
+		obj.MaxTargetNodes = pointer.Int32(defaultCPUCompactionMaxTargetNodes)
+	}
+}
+
+func SetDefaults_TopologySpreadRebalanceArgs(obj *TopologySpreadRebalanceArgs) {
+	if obj.MaxMigratingPerConstraint == nil {
+		obj.MaxMigratingPerConstraint = pointer.Int32(defaultTopologySpreadMaxMigratingPerConstraint)
+	}
+}
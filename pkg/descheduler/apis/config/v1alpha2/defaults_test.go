@@ -26,6 +26,41 @@ import (
 	"k8s.io/utils/pointer"
 )
 
+func TestSetDefaults_DeschedulerConfigurationReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		report   *ReportConfiguration
+		expected *ReportConfiguration
+	}{
+		{
+			name: "report is disabled by default",
+		},
+		{
+			name:   "set default maxReports",
+			report: &ReportConfiguration{},
+			expected: &ReportConfiguration{
+				MaxReports: pointer.Int32(10),
+			},
+		},
+		{
+			name: "keep the maxReports set",
+			report: &ReportConfiguration{
+				MaxReports: pointer.Int32(3),
+			},
+			expected: &ReportConfiguration{
+				MaxReports: pointer.Int32(3),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &DeschedulerConfiguration{Report: tt.report}
+			SetDefaults_DeschedulerConfiguration(obj)
+			assert.Equal(t, tt.expected, obj.Report)
+		})
+	}
+}
+
 func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -68,6 +103,28 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "set loadForecast",
+			args: &LowNodeLoadArgs{
+				LoadForecast: &LoadForecast{
+					Horizon: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			expected: &LowNodeLoadArgs{
+				NodeFit:          pointer.Bool(true),
+				AnomalyCondition: defaultLoadAnomalyCondition,
+				LoadForecast: &LoadForecast{
+					Policy:        LoadForecastPolicyTrend,
+					Horizon:       &metav1.Duration{Duration: time.Hour},
+					HistoryWindow: &metav1.Duration{Duration: 30 * time.Minute},
+					MinSamples:    pointer.Int32(3),
+				},
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+				},
+			},
+		},
 		{
 			name: "set weights",
 			args: &LowNodeLoadArgs{
@@ -104,3 +161,123 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaults_InterferenceMigrationArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *InterferenceMigrationArgs
+		expected *InterferenceMigrationArgs
+	}{
+		{
+			name: "set default args",
+			args: &InterferenceMigrationArgs{},
+			expected: &InterferenceMigrationArgs{
+				Thresholds:          defaultInterferenceThresholds,
+				EvictionTarget:      InterferenceEvictionTargetAntagonist,
+				MaxEvictionsPerNode: pointer.Int32(1),
+				AnomalyCondition:    defaultLoadAnomalyCondition,
+			},
+		},
+		{
+			name: "keep the thresholds set",
+			args: &InterferenceMigrationArgs{
+				Thresholds: InterferenceThresholds{
+					CPIOutlierPercent: pointer.Int64(50),
+				},
+				EvictionTarget:      InterferenceEvictionTargetVictim,
+				MaxEvictionsPerNode: pointer.Int32(2),
+				AnomalyCondition: &LoadAnomalyCondition{
+					Timeout: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			expected: &InterferenceMigrationArgs{
+				Thresholds: InterferenceThresholds{
+					CPIOutlierPercent: pointer.Int64(50),
+				},
+				EvictionTarget:      InterferenceEvictionTargetVictim,
+				MaxEvictionsPerNode: pointer.Int32(2),
+				AnomalyCondition: &LoadAnomalyCondition{
+					Timeout:                  &metav1.Duration{Duration: 10 * time.Second},
+					ConsecutiveAbnormalities: defaultLoadAnomalyCondition.ConsecutiveAbnormalities,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_InterferenceMigrationArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_CPUCompactionArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *CPUCompactionArgs
+		expected *CPUCompactionArgs
+	}{
+		{
+			name: "set default args",
+			args: &CPUCompactionArgs{},
+			expected: &CPUCompactionArgs{
+				MaxFragmentedCPUPercent: pointer.Int32(30),
+				NUMANodeCompaction:      pointer.Bool(true),
+				MaxMigratingPerNode:     pointer.Int32(2),
+				MaxTargetNodes:          pointer.Int32(3),
+			},
+		},
+		{
+			name: "keep the args set",
+			args: &CPUCompactionArgs{
+				MaxFragmentedCPUPercent: pointer.Int32(10),
+				NUMANodeCompaction:      pointer.Bool(false),
+				MaxMigratingPerNode:     pointer.Int32(1),
+				MaxTargetNodes:          pointer.Int32(5),
+			},
+			expected: &CPUCompactionArgs{
+				MaxFragmentedCPUPercent: pointer.Int32(10),
+				NUMANodeCompaction:      pointer.Bool(false),
+				MaxMigratingPerNode:     pointer.Int32(1),
+				MaxTargetNodes:          pointer.Int32(5),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_CPUCompactionArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_TopologySpreadRebalanceArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *TopologySpreadRebalanceArgs
+		expected *TopologySpreadRebalanceArgs
+	}{
+		{
+			name: "set default args",
+			args: &TopologySpreadRebalanceArgs{},
+			expected: &TopologySpreadRebalanceArgs{
+				MaxMigratingPerConstraint: pointer.Int32(2),
+			},
+		},
+		{
+			name: "keep the args set",
+			args: &TopologySpreadRebalanceArgs{
+				MaxMigratingPerConstraint: pointer.Int32(5),
+			},
+			expected: &TopologySpreadRebalanceArgs{
+				MaxMigratingPerConstraint: pointer.Int32(5),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_TopologySpreadRebalanceArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
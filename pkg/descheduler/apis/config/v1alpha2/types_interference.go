@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type InterferenceMigrationArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the InterferenceMigration should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	// for which the pods are migrated
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// Thresholds defines the contention thresholds of the LS pods (LSE, LSR and LS),
+	// a pod is interfered if any of its contention indicators reported in NodeMetric exceeds the threshold.
+	Thresholds InterferenceThresholds `json:"thresholds,omitempty"`
+
+	// EvictionTarget indicates which pods are migrated when an LS pod suffers sustained interference,
+	// the default is Antagonist.
+	EvictionTarget InterferenceEvictionTarget `json:"evictionTarget,omitempty"`
+
+	// MaxEvictionsPerNode limits the number of pods migrated from a node in one round,
+	// the default is 1.
+	MaxEvictionsPerNode *int32 `json:"maxEvictionsPerNode,omitempty"`
+
+	// AnomalyCondition indicates the pod interference anomaly thresholds,
+	// the default is 5 consecutive times exceeding Thresholds,
+	// it is determined that the pod is interfered, and the Pods need to be migrated to relieve the interference.
+	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
+}
+
+// InterferenceThresholds defines the thresholds of the contention indicators in percentage.
+// An indicator is ignored if its threshold is not set, and the default thresholds are used if none is set.
+type InterferenceThresholds struct {
+	// CPUThrottledPercent is the threshold of the cpu throttled ratio.
+	CPUThrottledPercent *int64 `json:"cpuThrottledPercent,omitempty"`
+	// CPUPressurePercent is the threshold of the cpu "some avg10" PSI.
+	CPUPressurePercent *int64 `json:"cpuPressurePercent,omitempty"`
+	// MemoryPressurePercent is the threshold of the memory "some avg10" PSI.
+	MemoryPressurePercent *int64 `json:"memoryPressurePercent,omitempty"`
+	// IOPressurePercent is the threshold of the io "some avg10" PSI.
+	IOPressurePercent *int64 `json:"ioPressurePercent,omitempty"`
+	// CPIOutlierPercent is the threshold of the percentage of containers whose CPI is an outlier.
+	CPIOutlierPercent *int64 `json:"cpiOutlierPercent,omitempty"`
+}
+
+type InterferenceEvictionTarget string
+
+const (
+	// InterferenceEvictionTargetAntagonist migrates the BE pods with the highest usage on the node
+	// of the interfered LS pods, which are considered as the antagonists.
+	InterferenceEvictionTargetAntagonist InterferenceEvictionTarget = "Antagonist"
+	// InterferenceEvictionTargetVictim migrates the interfered LS pods to other nodes.
+	InterferenceEvictionTargetVictim InterferenceEvictionTarget = "Victim"
+)
@@ -60,6 +60,8 @@ type LowNodeLoadArgs struct {
 	UseDeviationThresholds *bool `json:"useDeviationThresholds,omitempty"`
 
 	// HighThresholds defines the target usage threshold of resources
+	// GPU resources like koordinator.sh/gpu-core are supported by the GPU usage reported in NodeMetric
+	// and the nodes whose GPUs are allocated but the gpu-core usage is under the LowThresholds are consolidated
 	HighThresholds ResourceThresholds `json:"highThresholds,omitempty"`
 
 	// LowThresholds defines the low usage threshold of resources
@@ -74,6 +76,10 @@ type LowNodeLoadArgs struct {
 	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the load.
 	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
 
+	// LoadForecast if set, the nodes are classified by the forecasted usage instead of the current usage,
+	// so that the pods are migrated ahead of the predictable peaks.
+	LoadForecast *LoadForecast `json:"loadForecast,omitempty"`
+
 	// NodePools supports multiple different types of batch nodes to configure different strategies
 	NodePools []LowNodeLoadNodePool `json:"nodePools,omitempty"`
 }
@@ -89,6 +95,8 @@ type LowNodeLoadNodePool struct {
 	UseDeviationThresholds bool `json:"useDeviationThresholds,omitempty"`
 
 	// HighThresholds defines the target usage threshold of resources
+	// GPU resources like koordinator.sh/gpu-core are supported by the GPU usage reported in NodeMetric
+	// and the nodes whose GPUs are allocated but the gpu-core usage is under the LowThresholds are consolidated
 	HighThresholds ResourceThresholds `json:"highThresholds,omitempty"`
 
 	// LowThresholds defines the low usage threshold of resources
@@ -119,3 +127,25 @@ type LoadAnomalyCondition struct {
 	// ConsecutiveNormalities indicates the number of consecutive normalities
 	ConsecutiveNormalities uint32 `json:"consecutiveNormalities,omitempty"`
 }
+
+// LoadForecastPolicy is the policy to forecast the usage of nodes.
+type LoadForecastPolicy string
+
+const (
+	// LoadForecastPolicyTrend extrapolates the linear trend of the node usage history kept by the descheduler.
+	LoadForecastPolicyTrend LoadForecastPolicy = "Trend"
+	// LoadForecastPolicyHourlyProfile refers to the hourly usage profile reported in NodeMetric,
+	// which reflects the diurnal pattern of the node usage.
+	LoadForecastPolicyHourlyProfile LoadForecastPolicy = "HourlyProfile"
+)
+
+type LoadForecast struct {
+	// Policy indicates how to forecast the node usage, the default is Trend
+	Policy LoadForecastPolicy `json:"policy,omitempty"`
+	// Horizon indicates how far ahead the node usage is forecasted, the default is 15 minutes
+	Horizon *metav1.Duration `json:"horizon,omitempty"`
+	// HistoryWindow indicates the duration of the node usage history used by the Trend policy, the default is 30 minutes
+	HistoryWindow *metav1.Duration `json:"historyWindow,omitempty"`
+	// MinSamples indicates the minimum number of samples in the history to extrapolate the trend, the default is 3
+	MinSamples *int32 `json:"minSamples,omitempty"`
+}
@@ -106,6 +106,30 @@ type MigrationControllerArgs struct {
 	EvictionPolicy string `json:"evictionPolicy,omitempty"`
 	// DefaultDeleteOptions defines options when deleting migrated pods and preempted pods through the method specified by EvictionPolicy
 	DefaultDeleteOptions *metav1.DeleteOptions `json:"defaultDeleteOptions,omitempty"`
+	// ArbitrationArgs defines the arbitration stage that pending PodMigrationJobs pass through before being reconciled
+	ArbitrationArgs *ArbitrationArgs `json:"arbitrationArgs,omitempty"`
+}
+
+// ArbitrationArgs holds arguments used to configure the arbitration of PodMigrationJobs.
+// Pending jobs are collected and periodically deduplicated, sorted and released to the
+// Reconciler within the limits of the global migration budgets.
+type ArbitrationArgs struct {
+	// Enabled defines whether the arbitration stage is enabled. Default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Interval defines the period in which pending jobs are arbitrated. Default is 500ms.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// MigrationBudgets limit the number of PodMigrationJobs released cluster-wide within sliding windows.
+	// A job is released only if all budgets allow it.
+	MigrationBudgets []MigrationBudget `json:"migrationBudgets,omitempty"`
+}
+
+// MigrationBudget limits the number of PodMigrationJobs released within a sliding window.
+type MigrationBudget struct {
+	// Window indicates the duration of the sliding window.
+	Window metav1.Duration `json:"window,omitempty"`
+	// MaxMigrations indicates the maximum number of jobs released within the window.
+	// It must be positive, a zero budget would hold every job until it times out.
+	MaxMigrations int32 `json:"maxMigrations,omitempty"`
 }
 
 type MigrationLimitObjectType string
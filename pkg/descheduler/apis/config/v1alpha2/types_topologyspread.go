@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TopologySpreadRebalanceArgs holds arguments used to configure the TopologySpreadRebalance plugin, which
+// migrates the pods violating the topology spread constraints with the koordinator semantics.
+type TopologySpreadRebalanceArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the TopologySpreadRebalance should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	// for which the pods are migrated
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// IncludeSoftConstraints indicates whether the constraints with ScheduleAnyway are also rebalanced.
+	// Default is false
+	IncludeSoftConstraints *bool `json:"includeSoftConstraints,omitempty"`
+
+	// MaxMigratingPerConstraint limits the number of pods migrated for a topology spread constraint in one round,
+	// the default is 2.
+	MaxMigratingPerConstraint *int32 `json:"maxMigratingPerConstraint,omitempty"`
+}
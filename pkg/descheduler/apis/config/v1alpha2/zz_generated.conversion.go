@@ -40,6 +40,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ArbitrationArgs)(nil), (*config.ArbitrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(a.(*ArbitrationArgs), b.(*config.ArbitrationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ArbitrationArgs)(nil), (*ArbitrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(a.(*config.ArbitrationArgs), b.(*ArbitrationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CPUCompactionArgs)(nil), (*config.CPUCompactionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CPUCompactionArgs_To_config_CPUCompactionArgs(a.(*CPUCompactionArgs), b.(*config.CPUCompactionArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CPUCompactionArgs)(nil), (*CPUCompactionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CPUCompactionArgs_To_v1alpha2_CPUCompactionArgs(a.(*config.CPUCompactionArgs), b.(*CPUCompactionArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*config.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_config_DeschedulerProfile(a.(*DeschedulerProfile), b.(*config.DeschedulerProfile), scope)
 	}); err != nil {
@@ -50,6 +70,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceMigrationArgs)(nil), (*config.InterferenceMigrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceMigrationArgs_To_config_InterferenceMigrationArgs(a.(*InterferenceMigrationArgs), b.(*config.InterferenceMigrationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceMigrationArgs)(nil), (*InterferenceMigrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceMigrationArgs_To_v1alpha2_InterferenceMigrationArgs(a.(*config.InterferenceMigrationArgs), b.(*InterferenceMigrationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceThresholds)(nil), (*config.InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(a.(*InterferenceThresholds), b.(*config.InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceThresholds)(nil), (*InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(a.(*config.InterferenceThresholds), b.(*InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadAnomalyCondition)(nil), (*config.LoadAnomalyCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(a.(*LoadAnomalyCondition), b.(*config.LoadAnomalyCondition), scope)
 	}); err != nil {
@@ -65,6 +105,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadForecast)(nil), (*config.LoadForecast)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LoadForecast_To_config_LoadForecast(a.(*LoadForecast), b.(*config.LoadForecast), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LoadForecast)(nil), (*LoadForecast)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LoadForecast_To_v1alpha2_LoadForecast(a.(*config.LoadForecast), b.(*LoadForecast), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LowNodeLoadNodePool)(nil), (*config.LowNodeLoadNodePool)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LowNodeLoadNodePool_To_config_LowNodeLoadNodePool(a.(*LowNodeLoadNodePool), b.(*config.LowNodeLoadNodePool), scope)
 	}); err != nil {
@@ -85,6 +135,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MigrationBudget)(nil), (*config.MigrationBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MigrationBudget_To_config_MigrationBudget(a.(*MigrationBudget), b.(*config.MigrationBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MigrationBudget)(nil), (*MigrationBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MigrationBudget_To_v1alpha2_MigrationBudget(a.(*config.MigrationBudget), b.(*MigrationBudget), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MigrationControllerArgs)(nil), (*config.MigrationControllerArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(a.(*MigrationControllerArgs), b.(*config.MigrationControllerArgs), scope)
 	}); err != nil {
@@ -165,6 +225,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ReportConfiguration)(nil), (*config.ReportConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration(a.(*ReportConfiguration), b.(*config.ReportConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ReportConfiguration)(nil), (*ReportConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration(a.(*config.ReportConfiguration), b.(*ReportConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TopologySpreadRebalanceArgs)(nil), (*config.TopologySpreadRebalanceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TopologySpreadRebalanceArgs_To_config_TopologySpreadRebalanceArgs(a.(*TopologySpreadRebalanceArgs), b.(*config.TopologySpreadRebalanceArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TopologySpreadRebalanceArgs)(nil), (*TopologySpreadRebalanceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TopologySpreadRebalanceArgs_To_v1alpha2_TopologySpreadRebalanceArgs(a.(*config.TopologySpreadRebalanceArgs), b.(*TopologySpreadRebalanceArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*config.DeschedulerConfiguration)(nil), (*DeschedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfiguration(a.(*config.DeschedulerConfiguration), b.(*DeschedulerConfiguration), scope)
 	}); err != nil {
@@ -183,6 +263,96 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationArgs, out *config.ArbitrationArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Enabled, &out.Enabled, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Interval, &out.Interval, s); err != nil {
+		return err
+	}
+	out.MigrationBudgets = *(*[]config.MigrationBudget)(unsafe.Pointer(&in.MigrationBudgets))
+	return nil
+}
+
+// Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationArgs, out *config.ArbitrationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in, out, s)
+}
+
+func autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.ArbitrationArgs, out *ArbitrationArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Enabled, &out.Enabled, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.Interval, &out.Interval, s); err != nil {
+		return err
+	}
+	out.MigrationBudgets = *(*[]MigrationBudget)(unsafe.Pointer(&in.MigrationBudgets))
+	return nil
+}
+
+// Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs is an autogenerated conversion function.
+func Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.ArbitrationArgs, out *ArbitrationArgs, s conversion.Scope) error {
+	return autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_CPUCompactionArgs_To_config_CPUCompactionArgs(in *CPUCompactionArgs, out *config.CPUCompactionArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxFragmentedCPUPercent, &out.MaxFragmentedCPUPercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NUMANodeCompaction, &out.NUMANodeCompaction, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxMigratingPerNode, &out.MaxMigratingPerNode, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxTargetNodes, &out.MaxTargetNodes, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CPUCompactionArgs_To_config_CPUCompactionArgs is an autogenerated conversion function.
+func Convert_v1alpha2_CPUCompactionArgs_To_config_CPUCompactionArgs(in *CPUCompactionArgs, out *config.CPUCompactionArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CPUCompactionArgs_To_config_CPUCompactionArgs(in, out, s)
+}
+
+func autoConvert_config_CPUCompactionArgs_To_v1alpha2_CPUCompactionArgs(in *config.CPUCompactionArgs, out *CPUCompactionArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxFragmentedCPUPercent, &out.MaxFragmentedCPUPercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NUMANodeCompaction, &out.NUMANodeCompaction, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxMigratingPerNode, &out.MaxMigratingPerNode, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxTargetNodes, &out.MaxTargetNodes, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_CPUCompactionArgs_To_v1alpha2_CPUCompactionArgs is an autogenerated conversion function.
+func Convert_config_CPUCompactionArgs_To_v1alpha2_CPUCompactionArgs(in *config.CPUCompactionArgs, out *CPUCompactionArgs, s conversion.Scope) error {
+	return autoConvert_config_CPUCompactionArgs_To_v1alpha2_CPUCompactionArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfiguration(in *DeschedulerConfiguration, out *config.DeschedulerConfiguration, s conversion.Scope) error {
 	if err := v1alpha1.Convert_v1alpha1_LeaderElectionConfiguration_To_config_LeaderElectionConfiguration(&in.LeaderElection, &out.LeaderElection, s); err != nil {
 		return err
@@ -215,6 +385,15 @@ func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfigur
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(config.ReportConfiguration)
+		if err := Convert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Report = nil
+	}
 	return nil
 }
 
@@ -250,6 +429,15 @@ func autoConvert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfigur
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(ReportConfiguration)
+		if err := Convert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Report = nil
+	}
 	return nil
 }
 
@@ -297,6 +485,100 @@ func Convert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *config
 	return autoConvert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_InterferenceMigrationArgs_To_config_InterferenceMigrationArgs(in *InterferenceMigrationArgs, out *config.InterferenceMigrationArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(&in.Thresholds, &out.Thresholds, s); err != nil {
+		return err
+	}
+	out.EvictionTarget = config.InterferenceEvictionTarget(in.EvictionTarget)
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(config.LoadAnomalyCondition)
+		if err := Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceMigrationArgs_To_config_InterferenceMigrationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceMigrationArgs_To_config_InterferenceMigrationArgs(in *InterferenceMigrationArgs, out *config.InterferenceMigrationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceMigrationArgs_To_config_InterferenceMigrationArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	out.CPUThrottledPercent = (*int64)(unsafe.Pointer(in.CPUThrottledPercent))
+	out.CPUPressurePercent = (*int64)(unsafe.Pointer(in.CPUPressurePercent))
+	out.MemoryPressurePercent = (*int64)(unsafe.Pointer(in.MemoryPressurePercent))
+	out.IOPressurePercent = (*int64)(unsafe.Pointer(in.IOPressurePercent))
+	out.CPIOutlierPercent = (*int64)(unsafe.Pointer(in.CPIOutlierPercent))
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in, out, s)
+}
+
+func autoConvert_config_InterferenceMigrationArgs_To_v1alpha2_InterferenceMigrationArgs(in *config.InterferenceMigrationArgs, out *InterferenceMigrationArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(&in.Thresholds, &out.Thresholds, s); err != nil {
+		return err
+	}
+	out.EvictionTarget = InterferenceEvictionTarget(in.EvictionTarget)
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		if err := Convert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	return nil
+}
+
+// Convert_config_InterferenceMigrationArgs_To_v1alpha2_InterferenceMigrationArgs is an autogenerated conversion function.
+func Convert_config_InterferenceMigrationArgs_To_v1alpha2_InterferenceMigrationArgs(in *config.InterferenceMigrationArgs, out *InterferenceMigrationArgs, s conversion.Scope) error {
+	return autoConvert_config_InterferenceMigrationArgs_To_v1alpha2_InterferenceMigrationArgs(in, out, s)
+}
+
+func autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	out.CPUThrottledPercent = (*int64)(unsafe.Pointer(in.CPUThrottledPercent))
+	out.CPUPressurePercent = (*int64)(unsafe.Pointer(in.CPUPressurePercent))
+	out.MemoryPressurePercent = (*int64)(unsafe.Pointer(in.MemoryPressurePercent))
+	out.IOPressurePercent = (*int64)(unsafe.Pointer(in.IOPressurePercent))
+	out.CPIOutlierPercent = (*int64)(unsafe.Pointer(in.CPIOutlierPercent))
+	return nil
+}
+
+// Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds is an autogenerated conversion function.
+func Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(in *LoadAnomalyCondition, out *config.LoadAnomalyCondition, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Timeout, &out.Timeout, s); err != nil {
 		return err
@@ -325,6 +607,44 @@ func Convert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(in *co
 	return autoConvert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(in, out, s)
 }
 
+func autoConvert_v1alpha2_LoadForecast_To_config_LoadForecast(in *LoadForecast, out *config.LoadForecast, s conversion.Scope) error {
+	out.Policy = config.LoadForecastPolicy(in.Policy)
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Horizon, &out.Horizon, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.HistoryWindow, &out.HistoryWindow, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MinSamples, &out.MinSamples, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_LoadForecast_To_config_LoadForecast is an autogenerated conversion function.
+func Convert_v1alpha2_LoadForecast_To_config_LoadForecast(in *LoadForecast, out *config.LoadForecast, s conversion.Scope) error {
+	return autoConvert_v1alpha2_LoadForecast_To_config_LoadForecast(in, out, s)
+}
+
+func autoConvert_config_LoadForecast_To_v1alpha2_LoadForecast(in *config.LoadForecast, out *LoadForecast, s conversion.Scope) error {
+	out.Policy = LoadForecastPolicy(in.Policy)
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.Horizon, &out.Horizon, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.HistoryWindow, &out.HistoryWindow, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MinSamples, &out.MinSamples, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_LoadForecast_To_v1alpha2_LoadForecast is an autogenerated conversion function.
+func Convert_config_LoadForecast_To_v1alpha2_LoadForecast(in *config.LoadForecast, out *LoadForecast, s conversion.Scope) error {
+	return autoConvert_config_LoadForecast_To_v1alpha2_LoadForecast(in, out, s)
+}
+
 func autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeLoadArgs, out *config.LowNodeLoadArgs, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
 		return err
@@ -356,6 +676,15 @@ func autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeL
 	} else {
 		out.AnomalyCondition = nil
 	}
+	if in.LoadForecast != nil {
+		in, out := &in.LoadForecast, &out.LoadForecast
+		*out = new(config.LoadForecast)
+		if err := Convert_v1alpha2_LoadForecast_To_config_LoadForecast(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LoadForecast = nil
+	}
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]config.LowNodeLoadNodePool, len(*in))
@@ -401,6 +730,15 @@ func autoConvert_config_LowNodeLoadArgs_To_v1alpha2_LowNodeLoadArgs(in *config.L
 	} else {
 		out.AnomalyCondition = nil
 	}
+	if in.LoadForecast != nil {
+		in, out := &in.LoadForecast, &out.LoadForecast
+		*out = new(LoadForecast)
+		if err := Convert_config_LoadForecast_To_v1alpha2_LoadForecast(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LoadForecast = nil
+	}
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]LowNodeLoadNodePool, len(*in))
@@ -490,6 +828,28 @@ func Convert_config_LowNodeLoadPodSelector_To_v1alpha2_LowNodeLoadPodSelector(in
 	return autoConvert_config_LowNodeLoadPodSelector_To_v1alpha2_LowNodeLoadPodSelector(in, out, s)
 }
 
+func autoConvert_v1alpha2_MigrationBudget_To_config_MigrationBudget(in *MigrationBudget, out *config.MigrationBudget, s conversion.Scope) error {
+	out.Window = in.Window
+	out.MaxMigrations = in.MaxMigrations
+	return nil
+}
+
+// Convert_v1alpha2_MigrationBudget_To_config_MigrationBudget is an autogenerated conversion function.
+func Convert_v1alpha2_MigrationBudget_To_config_MigrationBudget(in *MigrationBudget, out *config.MigrationBudget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MigrationBudget_To_config_MigrationBudget(in, out, s)
+}
+
+func autoConvert_config_MigrationBudget_To_v1alpha2_MigrationBudget(in *config.MigrationBudget, out *MigrationBudget, s conversion.Scope) error {
+	out.Window = in.Window
+	out.MaxMigrations = in.MaxMigrations
+	return nil
+}
+
+// Convert_config_MigrationBudget_To_v1alpha2_MigrationBudget is an autogenerated conversion function.
+func Convert_config_MigrationBudget_To_v1alpha2_MigrationBudget(in *config.MigrationBudget, out *MigrationBudget, s conversion.Scope) error {
+	return autoConvert_config_MigrationBudget_To_v1alpha2_MigrationBudget(in, out, s)
+}
+
 func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(in *MigrationControllerArgs, out *config.MigrationControllerArgs, s conversion.Scope) error {
 	out.DryRun = in.DryRun
 	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles, s); err != nil {
@@ -520,6 +880,15 @@ func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerA
 	}
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
+	if in.ArbitrationArgs != nil {
+		in, out := &in.ArbitrationArgs, &out.ArbitrationArgs
+		*out = new(config.ArbitrationArgs)
+		if err := Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ArbitrationArgs = nil
+	}
 	return nil
 }
 
@@ -558,6 +927,15 @@ func autoConvert_config_MigrationControllerArgs_To_v1alpha2_MigrationControllerA
 	}
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
+	if in.ArbitrationArgs != nil {
+		in, out := &in.ArbitrationArgs, &out.ArbitrationArgs
+		*out = new(ArbitrationArgs)
+		if err := Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ArbitrationArgs = nil
+	}
 	return nil
 }
 
@@ -741,3 +1119,77 @@ func autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *conf
 func Convert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *config.PriorityThreshold, out *PriorityThreshold, s conversion.Scope) error {
 	return autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in, out, s)
 }
+
+func autoConvert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration(in *ReportConfiguration, out *config.ReportConfiguration, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxReports, &out.MaxReports, s); err != nil {
+		return err
+	}
+	out.ConfigMapNamespace = in.ConfigMapNamespace
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration is an autogenerated conversion function.
+func Convert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration(in *ReportConfiguration, out *config.ReportConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ReportConfiguration_To_config_ReportConfiguration(in, out, s)
+}
+
+func autoConvert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration(in *config.ReportConfiguration, out *ReportConfiguration, s conversion.Scope) error {
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxReports, &out.MaxReports, s); err != nil {
+		return err
+	}
+	out.ConfigMapNamespace = in.ConfigMapNamespace
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration is an autogenerated conversion function.
+func Convert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration(in *config.ReportConfiguration, out *ReportConfiguration, s conversion.Scope) error {
+	return autoConvert_config_ReportConfiguration_To_v1alpha2_ReportConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha2_TopologySpreadRebalanceArgs_To_config_TopologySpreadRebalanceArgs(in *TopologySpreadRebalanceArgs, out *config.TopologySpreadRebalanceArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.IncludeSoftConstraints, &out.IncludeSoftConstraints, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxMigratingPerConstraint, &out.MaxMigratingPerConstraint, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_TopologySpreadRebalanceArgs_To_config_TopologySpreadRebalanceArgs is an autogenerated conversion function.
+func Convert_v1alpha2_TopologySpreadRebalanceArgs_To_config_TopologySpreadRebalanceArgs(in *TopologySpreadRebalanceArgs, out *config.TopologySpreadRebalanceArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TopologySpreadRebalanceArgs_To_config_TopologySpreadRebalanceArgs(in, out, s)
+}
+
+func autoConvert_config_TopologySpreadRebalanceArgs_To_v1alpha2_TopologySpreadRebalanceArgs(in *config.TopologySpreadRebalanceArgs, out *TopologySpreadRebalanceArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.IncludeSoftConstraints, &out.IncludeSoftConstraints, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxMigratingPerConstraint, &out.MaxMigratingPerConstraint, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_TopologySpreadRebalanceArgs_To_v1alpha2_TopologySpreadRebalanceArgs is an autogenerated conversion function.
+func Convert_config_TopologySpreadRebalanceArgs_To_v1alpha2_TopologySpreadRebalanceArgs(in *config.TopologySpreadRebalanceArgs, out *TopologySpreadRebalanceArgs, s conversion.Scope) error {
+	return autoConvert_config_TopologySpreadRebalanceArgs_To_v1alpha2_TopologySpreadRebalanceArgs(in, out, s)
+}
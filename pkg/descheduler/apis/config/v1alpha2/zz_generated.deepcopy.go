@@ -29,6 +29,102 @@ import (
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArbitrationArgs) DeepCopyInto(out *ArbitrationArgs) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MigrationBudgets != nil {
+		in, out := &in.MigrationBudgets, &out.MigrationBudgets
+		*out = make([]MigrationBudget, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArbitrationArgs.
+func (in *ArbitrationArgs) DeepCopy() *ArbitrationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ArbitrationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUCompactionArgs) DeepCopyInto(out *CPUCompactionArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxFragmentedCPUPercent != nil {
+		in, out := &in.MaxFragmentedCPUPercent, &out.MaxFragmentedCPUPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NUMANodeCompaction != nil {
+		in, out := &in.NUMANodeCompaction, &out.NUMANodeCompaction
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxMigratingPerNode != nil {
+		in, out := &in.MaxMigratingPerNode, &out.MaxMigratingPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTargetNodes != nil {
+		in, out := &in.MaxTargetNodes, &out.MaxTargetNodes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUCompactionArgs.
+func (in *CPUCompactionArgs) DeepCopy() *CPUCompactionArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUCompactionArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CPUCompactionArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration) {
 	*out = *in
@@ -69,6 +165,11 @@ func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration)
 		*out = new(uint)
 		**out = **in
 	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(ReportConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -118,6 +219,103 @@ func (in *DeschedulerProfile) DeepCopy() *DeschedulerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceMigrationArgs) DeepCopyInto(out *InterferenceMigrationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Thresholds.DeepCopyInto(&out.Thresholds)
+	if in.MaxEvictionsPerNode != nil {
+		in, out := &in.MaxEvictionsPerNode, &out.MaxEvictionsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceMigrationArgs.
+func (in *InterferenceMigrationArgs) DeepCopy() *InterferenceMigrationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceMigrationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterferenceMigrationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceThresholds) DeepCopyInto(out *InterferenceThresholds) {
+	*out = *in
+	if in.CPUThrottledPercent != nil {
+		in, out := &in.CPUThrottledPercent, &out.CPUThrottledPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPUPressurePercent != nil {
+		in, out := &in.CPUPressurePercent, &out.CPUPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryPressurePercent != nil {
+		in, out := &in.MemoryPressurePercent, &out.MemoryPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IOPressurePercent != nil {
+		in, out := &in.IOPressurePercent, &out.IOPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPIOutlierPercent != nil {
+		in, out := &in.CPIOutlierPercent, &out.CPIOutlierPercent
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceThresholds.
+func (in *InterferenceThresholds) DeepCopy() *InterferenceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAnomalyCondition) DeepCopyInto(out *LoadAnomalyCondition) {
 	*out = *in
@@ -139,6 +337,37 @@ func (in *LoadAnomalyCondition) DeepCopy() *LoadAnomalyCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadForecast) DeepCopyInto(out *LoadForecast) {
+	*out = *in
+	if in.Horizon != nil {
+		in, out := &in.Horizon, &out.Horizon
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HistoryWindow != nil {
+		in, out := &in.HistoryWindow, &out.HistoryWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadForecast.
+func (in *LoadForecast) DeepCopy() *LoadForecast {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadForecast)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 	*out = *in
@@ -211,6 +440,11 @@ func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 		*out = new(LoadAnomalyCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadForecast != nil {
+		in, out := &in.LoadForecast, &out.LoadForecast
+		*out = new(LoadForecast)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]LowNodeLoadNodePool, len(*in))
@@ -307,6 +541,23 @@ func (in *LowNodeLoadPodSelector) DeepCopy() *LowNodeLoadPodSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationBudget) DeepCopyInto(out *MigrationBudget) {
+	*out = *in
+	out.Window = in.Window
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationBudget.
+func (in *MigrationBudget) DeepCopy() *MigrationBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 	*out = *in
@@ -383,6 +634,11 @@ func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 		*out = new(v1.DeleteOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArbitrationArgs != nil {
+		in, out := &in.ArbitrationArgs, &out.ArbitrationArgs
+		*out = new(ArbitrationArgs)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -574,6 +830,27 @@ func (in *PriorityThreshold) DeepCopy() *PriorityThreshold {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportConfiguration) DeepCopyInto(out *ReportConfiguration) {
+	*out = *in
+	if in.MaxReports != nil {
+		in, out := &in.MaxReports, &out.MaxReports
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportConfiguration.
+func (in *ReportConfiguration) DeepCopy() *ReportConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ResourceThresholds) DeepCopyInto(out *ResourceThresholds) {
 	{
@@ -595,3 +872,58 @@ func (in ResourceThresholds) DeepCopy() ResourceThresholds {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadRebalanceArgs) DeepCopyInto(out *TopologySpreadRebalanceArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IncludeSoftConstraints != nil {
+		in, out := &in.IncludeSoftConstraints, &out.IncludeSoftConstraints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxMigratingPerConstraint != nil {
+		in, out := &in.MaxMigratingPerConstraint, &out.MaxMigratingPerConstraint
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadRebalanceArgs.
+func (in *TopologySpreadRebalanceArgs) DeepCopy() *TopologySpreadRebalanceArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadRebalanceArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TopologySpreadRebalanceArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -29,16 +29,29 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&CPUCompactionArgs{}, func(obj interface{}) { SetObjectDefaults_CPUCompactionArgs(obj.(*CPUCompactionArgs)) })
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&InterferenceMigrationArgs{}, func(obj interface{}) { SetObjectDefaults_InterferenceMigrationArgs(obj.(*InterferenceMigrationArgs)) })
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
+	scheme.AddTypeDefaultingFunc(&TopologySpreadRebalanceArgs{}, func(obj interface{}) {
+		SetObjectDefaults_TopologySpreadRebalanceArgs(obj.(*TopologySpreadRebalanceArgs))
+	})
 	return nil
 }
 
+func SetObjectDefaults_CPUCompactionArgs(in *CPUCompactionArgs) {
+	SetDefaults_CPUCompactionArgs(in)
+}
+
 func SetObjectDefaults_DeschedulerConfiguration(in *DeschedulerConfiguration) {
 	SetDefaults_DeschedulerConfiguration(in)
 }
 
+func SetObjectDefaults_InterferenceMigrationArgs(in *InterferenceMigrationArgs) {
+	SetDefaults_InterferenceMigrationArgs(in)
+}
+
 func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 	SetDefaults_LowNodeLoadArgs(in)
 }
@@ -46,3 +59,7 @@ func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 func SetObjectDefaults_MigrationControllerArgs(in *MigrationControllerArgs) {
 	SetDefaults_MigrationControllerArgs(in)
 }
+
+func SetObjectDefaults_TopologySpreadRebalanceArgs(in *TopologySpreadRebalanceArgs) {
+	SetDefaults_TopologySpreadRebalanceArgs(in)
+}
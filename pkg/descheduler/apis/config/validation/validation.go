@@ -63,6 +63,16 @@ func ValidateDeschedulerConfiguration(cc *config.DeschedulerConfiguration) utile
 		}
 	}
 
+	if cc.Report != nil {
+		reportPath := field.NewPath("report")
+		if cc.Report.MaxReports < 0 {
+			errs = append(errs, field.Invalid(reportPath.Child("maxReports"), cc.Report.MaxReports, "must be greater than or equal to 0"))
+		}
+		if cc.Report.ConfigMapName != "" && cc.Report.ConfigMapNamespace == "" {
+			errs = append(errs, field.Required(reportPath.Child("configMapNamespace"), "must be specified when configMapName is set"))
+		}
+	}
+
 	return utilerrors.Flatten(utilerrors.NewAggregate(errs))
 }
 
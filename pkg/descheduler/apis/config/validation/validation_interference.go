@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateInterferenceMigrationArgs(path *field.Path, args *deschedulerconfig.InterferenceMigrationArgs) error {
+	var allErrs field.ErrorList
+
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeSelector"), args.NodeSelector, err.Error()))
+		}
+	}
+
+	thresholdsPath := path.Child("thresholds")
+	thresholds := []struct {
+		name      string
+		threshold *int64
+	}{
+		{"cpuThrottledPercent", args.Thresholds.CPUThrottledPercent},
+		{"cpuPressurePercent", args.Thresholds.CPUPressurePercent},
+		{"memoryPressurePercent", args.Thresholds.MemoryPressurePercent},
+		{"ioPressurePercent", args.Thresholds.IOPressurePercent},
+		{"cpiOutlierPercent", args.Thresholds.CPIOutlierPercent},
+	}
+	hasThreshold := false
+	for _, v := range thresholds {
+		if v.threshold == nil {
+			continue
+		}
+		hasThreshold = true
+		if *v.threshold < 0 || *v.threshold > 100 {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child(v.name), *v.threshold, "percentage must be in the range [0, 100]"))
+		}
+	}
+	if !hasThreshold {
+		allErrs = append(allErrs, field.Required(thresholdsPath, "at least one threshold must be set"))
+	}
+
+	if args.EvictionTarget != deschedulerconfig.InterferenceEvictionTargetAntagonist && args.EvictionTarget != deschedulerconfig.InterferenceEvictionTargetVictim {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictionTarget"), args.EvictionTarget, fmt.Sprintf("evictionTarget must be %s or %s", deschedulerconfig.InterferenceEvictionTargetAntagonist, deschedulerconfig.InterferenceEvictionTargetVictim)))
+	}
+
+	if args.MaxEvictionsPerNode <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxEvictionsPerNode"), args.MaxEvictionsPerNode, "maxEvictionsPerNode must be greater than 0"))
+	}
+
+	if args.AnomalyCondition != nil && args.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
+		fieldPath := path.Child("anomalyCondition").Child("consecutiveAbnormalities")
+		allErrs = append(allErrs, field.Invalid(fieldPath, args.AnomalyCondition.ConsecutiveAbnormalities, "consecutiveAbnormalities must be greater than 0"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -42,6 +44,24 @@ func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.Lo
 		}
 	}
 
+	if forecast := args.LoadForecast; forecast != nil {
+		forecastPath := path.Child("loadForecast")
+		if forecast.Policy != deschedulerconfig.LoadForecastPolicyTrend && forecast.Policy != deschedulerconfig.LoadForecastPolicyHourlyProfile {
+			allErrs = append(allErrs, field.Invalid(forecastPath.Child("policy"), forecast.Policy, fmt.Sprintf("policy must be %s or %s", deschedulerconfig.LoadForecastPolicyTrend, deschedulerconfig.LoadForecastPolicyHourlyProfile)))
+		}
+		if forecast.Horizon.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(forecastPath.Child("horizon"), forecast.Horizon, "horizon must be greater than 0"))
+		}
+		if forecast.Policy == deschedulerconfig.LoadForecastPolicyTrend {
+			if forecast.HistoryWindow.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(forecastPath.Child("historyWindow"), forecast.HistoryWindow, "historyWindow must be greater than 0"))
+			}
+			if forecast.MinSamples < 2 {
+				allErrs = append(allErrs, field.Invalid(forecastPath.Child("minSamples"), forecast.MinSamples, "minSamples must be at least 2 to extrapolate the trend"))
+			}
+		}
+	}
+
 	for i, nodePool := range args.NodePools {
 		nodePoolPath := path.Child("nodePools").Index(i)
 		if nodePool.NodeSelector != nil {
@@ -79,6 +79,22 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobTTL"), args.DefaultJobTTL, "defaultJobTTL should be positive or zero"))
 	}
 
+	if args.ArbitrationArgs != nil {
+		arbitrationPath := path.Child("arbitrationArgs")
+		if args.ArbitrationArgs.Enabled && args.ArbitrationArgs.Interval.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(arbitrationPath.Child("interval"), args.ArbitrationArgs.Interval, "interval should be positive"))
+		}
+		for i, budget := range args.ArbitrationArgs.MigrationBudgets {
+			budgetPath := arbitrationPath.Child("migrationBudgets").Index(i)
+			if budget.Window.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(budgetPath.Child("window"), budget.Window, "window should be positive"))
+			}
+			if budget.MaxMigrations <= 0 {
+				allErrs = append(allErrs, field.Invalid(budgetPath.Child("maxMigrations"), budget.MaxMigrations, "maxMigrations should be positive"))
+			}
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -92,6 +92,49 @@ func TestValidateMigrationControllerArgs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid arbitrationArgs",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					Enabled: pointer.Bool(true),
+					MigrationBudgets: []v1alpha2.MigrationBudget{
+						{Window: metav1.Duration{Duration: time.Minute}, MaxMigrations: 10},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid arbitration interval",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					Enabled:  pointer.Bool(true),
+					Interval: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid migration budget",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					MigrationBudgets: []v1alpha2.MigrationBudget{
+						{Window: metav1.Duration{}, MaxMigrations: -1},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero maxMigrations",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					MigrationBudgets: []v1alpha2.MigrationBudget{
+						{Window: metav1.Duration{Duration: time.Minute}, MaxMigrations: 0},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,6 +78,24 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid report maxReports",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Report: &v1alpha2.ReportConfiguration{
+					MaxReports: pointer.Int32(-1),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "report configMap without namespace",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Report: &v1alpha2.ReportConfiguration{
+					ConfigMapName: "descheduler-report",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "duplicate plugin config",
 			args: &v1alpha2.DeschedulerConfiguration{
@@ -0,0 +1,402 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+)
+
+const (
+	PodMigrationJobReasonDuplicated = "Duplicated"
+)
+
+// arbitrator holds pending PodMigrationJobs back from the Reconciler. It periodically
+// deduplicates the jobs targeting the same Pod, sorts the remaining jobs and releases
+// them to the Reconciler within the limits of the global migration budgets.
+type arbitrator struct {
+	client        client.Client
+	eventRecorder events.EventRecorder
+	interval      time.Duration
+	budgets       []deschedulerconfig.MigrationBudget
+	clock         clock.Clock
+	releaseCh     chan event.GenericEvent
+
+	lock           sync.Mutex
+	waitingJobs    map[types.UID]*sev1alpha1.PodMigrationJob
+	releasedJobs   map[types.UID]time.Time
+	releaseHistory []time.Time
+}
+
+func newArbitrator(args *deschedulerconfig.ArbitrationArgs, c client.Client, eventRecorder events.EventRecorder) *arbitrator {
+	return &arbitrator{
+		client:        c,
+		eventRecorder: eventRecorder,
+		interval:      args.Interval.Duration,
+		budgets:       args.MigrationBudgets,
+		clock:         clock.RealClock{},
+		releaseCh:     make(chan event.GenericEvent, 1024),
+		waitingJobs:   map[types.UID]*sev1alpha1.PodMigrationJob{},
+		releasedJobs:  map[types.UID]time.Time{},
+	}
+}
+
+func (a *arbitrator) Start(ctx context.Context) error {
+	for {
+		a.doArbitrate(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-a.clock.After(a.interval):
+		}
+	}
+}
+
+// isReleased returns true if the job passed the arbitration and can be processed by the Reconciler.
+func (a *arbitrator) isReleased(job *sev1alpha1.PodMigrationJob) bool {
+	if !isPendingJob(job) {
+		return true
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	_, ok := a.releasedJobs[job.UID]
+	return ok
+}
+
+// hold returns true if the job must wait for the arbitration before being enqueued.
+func (a *arbitrator) hold(job *sev1alpha1.PodMigrationJob) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if !isPendingJob(job) {
+		delete(a.waitingJobs, job.UID)
+		// the job aborted by the Reconciler before running never migrates the Pod
+		if job.Status.Phase == sev1alpha1.PodMigrationJobFailed || job.Status.Phase == sev1alpha1.PodMigrationJobAborted {
+			a.returnBudget(job)
+		}
+		delete(a.releasedJobs, job.UID)
+		return false
+	}
+	if _, ok := a.releasedJobs[job.UID]; ok {
+		return false
+	}
+	a.waitingJobs[job.UID] = job
+	return true
+}
+
+func (a *arbitrator) forget(job *sev1alpha1.PodMigrationJob) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.waitingJobs, job.UID)
+	if isPendingJob(job) {
+		a.returnBudget(job)
+	}
+	delete(a.releasedJobs, job.UID)
+}
+
+// returnBudget gives the migration budget consumed by the released job back if it is still pending,
+// the caller must hold the lock.
+func (a *arbitrator) returnBudget(job *sev1alpha1.PodMigrationJob) {
+	releaseTime, ok := a.releasedJobs[job.UID]
+	if !ok {
+		return
+	}
+	for i := len(a.releaseHistory) - 1; i >= 0; i-- {
+		if a.releaseHistory[i].Equal(releaseTime) {
+			a.releaseHistory = append(a.releaseHistory[:i], a.releaseHistory[i+1:]...)
+			klog.V(4).Infof("MigrationJob %s returns the migration budget", job.Name)
+			return
+		}
+	}
+}
+
+func (a *arbitrator) doArbitrate(ctx context.Context) {
+	a.lock.Lock()
+	jobs := make([]*sev1alpha1.PodMigrationJob, 0, len(a.waitingJobs))
+	for _, job := range a.waitingJobs {
+		jobs = append(jobs, job)
+	}
+	a.lock.Unlock()
+	if len(jobs) == 0 {
+		return
+	}
+
+	candidates, duplicates := a.deduplicate(jobs)
+	for _, job := range duplicates {
+		a.abortDuplicatedJob(ctx, job)
+	}
+
+	candidates = a.expireTimeoutJobs(candidates)
+	a.sortJobs(ctx, candidates)
+	available := a.availableBudget()
+	for _, job := range candidates {
+		if available == 0 {
+			klog.V(4).Infof("MigrationJob %s is waiting for the migration budget", job.Name)
+			continue
+		}
+		if a.release(job) && available > 0 {
+			available--
+		}
+	}
+}
+
+// deduplicate keeps the earliest job for each Pod. The jobs targeting a Pod that is already
+// being migrated by a released or running job are considered duplicated.
+func (a *arbitrator) deduplicate(jobs []*sev1alpha1.PodMigrationJob) ([]*sev1alpha1.PodMigrationJob, []*sev1alpha1.PodMigrationJob) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+
+	migratingPods := a.getMigratingPods()
+	var candidates, duplicates []*sev1alpha1.PodMigrationJob
+	for _, job := range jobs {
+		podRef := job.Spec.PodRef
+		if podRef == nil || podRef.Name == "" {
+			// let the Reconciler abort the job with invalid PodRef
+			candidates = append(candidates, job)
+			continue
+		}
+		podKey := types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}
+		if _, ok := migratingPods[podKey]; ok {
+			duplicates = append(duplicates, job)
+			continue
+		}
+		migratingPods[podKey] = struct{}{}
+		candidates = append(candidates, job)
+	}
+	return candidates, duplicates
+}
+
+func (a *arbitrator) getMigratingPods() map[types.NamespacedName]struct{} {
+	migratingPods := map[types.NamespacedName]struct{}{}
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	if err := a.client.List(context.TODO(), jobList, utilclient.DisableDeepCopy); err != nil {
+		klog.Errorf("failed to get PodMigrationJobList, err: %v", err)
+		return migratingPods
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if job.Spec.PodRef == nil {
+			continue
+		}
+		_, released := a.releasedJobs[job.UID]
+		if job.Status.Phase == sev1alpha1.PodMigrationJobRunning || (isPendingJob(job) && released) {
+			migratingPods[types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name}] = struct{}{}
+		}
+	}
+	return migratingPods
+}
+
+// sortJobs sorts the jobs so that Pods with lower priority and lower eviction cost are migrated first.
+func (a *arbitrator) sortJobs(ctx context.Context, jobs []*sev1alpha1.PodMigrationJob) {
+	type sortKey struct {
+		priority int32
+		cost     int32
+	}
+	keys := make(map[types.UID]sortKey, len(jobs))
+	for _, job := range jobs {
+		if job.Spec.PodRef == nil {
+			continue
+		}
+		podKey := types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name}
+		pod := &corev1.Pod{}
+		if err := a.client.Get(ctx, podKey, pod); err != nil {
+			continue
+		}
+		cost, _ := extension.GetEvictionCost(pod.Annotations)
+		keys[job.UID] = sortKey{priority: corev1helpers.PodPriority(pod), cost: cost}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		iKey, jKey := keys[jobs[i].UID], keys[jobs[j].UID]
+		if iKey.priority != jKey.priority {
+			return iKey.priority < jKey.priority
+		}
+		if iKey.cost != jKey.cost {
+			return iKey.cost < jKey.cost
+		}
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+}
+
+// availableBudget returns the number of jobs that can be released now, -1 means unlimited.
+func (a *arbitrator) availableBudget() int {
+	if len(a.budgets) == 0 {
+		return -1
+	}
+	now := a.clock.Now()
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var maxWindow time.Duration
+	for _, budget := range a.budgets {
+		if budget.Window.Duration > maxWindow {
+			maxWindow = budget.Window.Duration
+		}
+	}
+	expired := 0
+	for expired < len(a.releaseHistory) && now.Sub(a.releaseHistory[expired]) >= maxWindow {
+		expired++
+	}
+	a.releaseHistory = a.releaseHistory[expired:]
+
+	available := -1
+	for _, budget := range a.budgets {
+		released := 0
+		for _, t := range a.releaseHistory {
+			if now.Sub(t) < budget.Window.Duration {
+				released++
+			}
+		}
+		remaining := int(budget.MaxMigrations) - released
+		if remaining < 0 {
+			remaining = 0
+		}
+		if available < 0 || remaining < available {
+			available = remaining
+		}
+	}
+	return available
+}
+
+// release hands the job over to the Reconciler and returns true if the job consumes the migration budget.
+func (a *arbitrator) release(job *sev1alpha1.PodMigrationJob) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if _, ok := a.waitingJobs[job.UID]; !ok {
+		return false
+	}
+	if !a.notify(job) {
+		klog.V(4).Infof("MigrationJob %s is requeued since the release channel is full", job.Name)
+		return false
+	}
+	delete(a.waitingJobs, job.UID)
+	now := a.clock.Now()
+	a.releasedJobs[job.UID] = now
+	if len(a.budgets) > 0 {
+		a.releaseHistory = append(a.releaseHistory, now)
+	}
+	klog.V(4).Infof("MigrationJob %s is released by arbitration", job.Name)
+	return true
+}
+
+// notify enqueues the job to the Reconciler without blocking, and returns false if the release channel is full.
+// The job not notified stays waiting and is tried again in the next round of the arbitration.
+func (a *arbitrator) notify(job *sev1alpha1.PodMigrationJob) bool {
+	select {
+	case a.releaseCh <- event.GenericEvent{Object: job}:
+		return true
+	default:
+		return false
+	}
+}
+
+// expireTimeoutJobs hands the jobs whose TTL has expired over to the Reconciler without
+// consuming the migration budget, so that the Reconciler can abort them as timeout.
+func (a *arbitrator) expireTimeoutJobs(jobs []*sev1alpha1.PodMigrationJob) []*sev1alpha1.PodMigrationJob {
+	now := a.clock.Now()
+	candidates := jobs[:0]
+	for _, job := range jobs {
+		if job.Spec.TTL == nil || job.Spec.TTL.Duration == 0 || now.Sub(job.CreationTimestamp.Time) < job.Spec.TTL.Duration {
+			candidates = append(candidates, job)
+			continue
+		}
+		a.lock.Lock()
+		if _, ok := a.waitingJobs[job.UID]; ok {
+			if a.notify(job) {
+				delete(a.waitingJobs, job.UID)
+				klog.V(4).Infof("MigrationJob %s timed out while waiting for arbitration", job.Name)
+			} else {
+				klog.V(4).Infof("MigrationJob %s timed out but is requeued since the release channel is full", job.Name)
+			}
+		}
+		a.lock.Unlock()
+	}
+	return candidates
+}
+
+func (a *arbitrator) abortDuplicatedJob(ctx context.Context, job *sev1alpha1.PodMigrationJob) {
+	a.forget(job)
+
+	job = job.DeepCopy()
+	job.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	job.Status.Reason = PodMigrationJobReasonDuplicated
+	job.Status.Message = fmt.Sprintf("Abort job caused by another PodMigrationJob migrating Pod %q", types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name})
+	err := a.client.Status().Update(ctx, job)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("Failed to abort duplicated MigrationJob %s, err: %v", job.Name, err)
+		}
+		return
+	}
+	a.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, PodMigrationJobReasonDuplicated, "Migrating", job.Status.Message)
+}
+
+func isPendingJob(job *sev1alpha1.PodMigrationJob) bool {
+	return job.Status.Phase == "" || job.Status.Phase == sev1alpha1.PodMigrationJobPending
+}
+
+var _ handler.EventHandler = &arbitrationEventHandler{}
+
+// arbitrationEventHandler enqueues the PodMigrationJobs which are not held by the arbitrator.
+type arbitrationEventHandler struct {
+	handler.EnqueueRequestForObject
+	arbitrator *arbitrator
+}
+
+func (h *arbitrationEventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if job, ok := evt.Object.(*sev1alpha1.PodMigrationJob); ok && h.arbitrator.hold(job) {
+		return
+	}
+	h.EnqueueRequestForObject.Create(evt, q)
+}
+
+func (h *arbitrationEventHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if job, ok := evt.ObjectNew.(*sev1alpha1.PodMigrationJob); ok && h.arbitrator.hold(job) {
+		return
+	}
+	h.EnqueueRequestForObject.Update(evt, q)
+}
+
+func (h *arbitrationEventHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if job, ok := evt.Object.(*sev1alpha1.PodMigrationJob); ok {
+		h.arbitrator.forget(job)
+	}
+	h.EnqueueRequestForObject.Delete(evt, q)
+}
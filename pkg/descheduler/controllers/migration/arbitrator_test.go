@@ -0,0 +1,308 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func newTestArbitrator(budgets []deschedulerconfig.MigrationBudget) (*Reconciler, *arbitrator, *clock.FakeClock) {
+	reconciler := newTestReconciler()
+	a := newArbitrator(&deschedulerconfig.ArbitrationArgs{
+		Enabled:          true,
+		Interval:         metav1.Duration{Duration: 500 * time.Millisecond},
+		MigrationBudgets: budgets,
+	}, reconciler.Client, reconciler.eventRecorder)
+	fakeClock := clock.NewFakeClock(time.Now())
+	a.clock = fakeClock
+	reconciler.arbitrator = a
+	return reconciler, a, fakeClock
+}
+
+func newTestArbitrationJob(name, podName string, created time.Time) *sev1alpha1.PodMigrationJob {
+	return &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      podName,
+			},
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobPending,
+		},
+	}
+}
+
+func drainReleasedJobs(a *arbitrator) []string {
+	var names []string
+	for {
+		select {
+		case evt := <-a.releaseCh:
+			names = append(names, evt.Object.GetName())
+		default:
+			return names
+		}
+	}
+}
+
+func TestArbitratorDeduplicate(t *testing.T) {
+	reconciler, a, _ := newTestArbitrator(nil)
+	now := time.Now()
+
+	jobs := []*sev1alpha1.PodMigrationJob{
+		newTestArbitrationJob("job-1", "pod-a", now.Add(-2*time.Minute)),
+		newTestArbitrationJob("job-2", "pod-a", now.Add(-time.Minute)),
+		newTestArbitrationJob("job-3", "pod-b", now),
+	}
+	runningJob := newTestArbitrationJob("job-running", "pod-b", now.Add(-time.Hour))
+	runningJob.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), runningJob))
+	for _, job := range jobs {
+		assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+		assert.True(t, a.hold(job))
+	}
+
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-1"}, drainReleasedJobs(a))
+	assert.True(t, a.isReleased(jobs[0]))
+	assert.Empty(t, a.waitingJobs)
+
+	for _, name := range []string{"job-2", "job-3"} {
+		job := &sev1alpha1.PodMigrationJob{}
+		assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: name}, job))
+		assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+		assert.Equal(t, PodMigrationJobReasonDuplicated, job.Status.Reason)
+	}
+
+	job := newTestArbitrationJob("job-4", "pod-a", now)
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+	assert.True(t, a.hold(job))
+	a.doArbitrate(context.TODO())
+	assert.Empty(t, drainReleasedJobs(a))
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "job-4"}, job))
+	assert.Equal(t, PodMigrationJobReasonDuplicated, job.Status.Reason)
+}
+
+func TestArbitratorSortAndBudget(t *testing.T) {
+	reconciler, a, fakeClock := newTestArbitrator([]deschedulerconfig.MigrationBudget{
+		{Window: metav1.Duration{Duration: time.Minute}, MaxMigrations: 2},
+		{Window: metav1.Duration{Duration: time.Hour}, MaxMigrations: 3},
+	})
+	now := time.Now()
+
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-high-priority"},
+			Spec:       corev1.PodSpec{Priority: pointer.Int32(1000)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "pod-high-cost",
+				Annotations: map[string]string{extension.AnnotationEvictionCost: "100"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-low-cost"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-latest"},
+		},
+	}
+	for _, pod := range pods {
+		assert.NoError(t, reconciler.Client.Create(context.TODO(), pod))
+	}
+	jobs := []*sev1alpha1.PodMigrationJob{
+		newTestArbitrationJob("job-high-priority", "pod-high-priority", now.Add(-3*time.Minute)),
+		newTestArbitrationJob("job-high-cost", "pod-high-cost", now.Add(-2*time.Minute)),
+		newTestArbitrationJob("job-low-cost", "pod-low-cost", now.Add(-time.Minute)),
+		newTestArbitrationJob("job-latest", "pod-latest", now),
+	}
+	for _, job := range jobs {
+		assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+		assert.True(t, a.hold(job))
+	}
+
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-low-cost", "job-latest"}, drainReleasedJobs(a))
+
+	a.doArbitrate(context.TODO())
+	assert.Empty(t, drainReleasedJobs(a))
+
+	fakeClock.Step(time.Minute)
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-high-cost"}, drainReleasedJobs(a))
+	assert.Len(t, a.waitingJobs, 1)
+
+	fakeClock.Step(time.Hour)
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-high-priority"}, drainReleasedJobs(a))
+	assert.Empty(t, a.waitingJobs)
+}
+
+func TestArbitrationEventHandler(t *testing.T) {
+	reconciler, a, _ := newTestArbitrator(nil)
+	h := &arbitrationEventHandler{arbitrator: a}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	job := newTestArbitrationJob("job-1", "pod-a", time.Now())
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+	h.Create(event.CreateEvent{Object: job}, q)
+	assert.Equal(t, 0, q.Len())
+	assert.False(t, a.isReleased(job))
+
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-1"}, drainReleasedJobs(a))
+	assert.True(t, a.isReleased(job))
+
+	h.Update(event.UpdateEvent{ObjectOld: job, ObjectNew: job}, q)
+	assert.Equal(t, 1, q.Len())
+
+	runningJob := job.DeepCopy()
+	runningJob.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	h.Update(event.UpdateEvent{ObjectOld: job, ObjectNew: runningJob}, q)
+	assert.NotContains(t, a.releasedJobs, job.UID)
+	assert.True(t, a.isReleased(runningJob))
+
+	job2 := newTestArbitrationJob("job-2", "pod-b", time.Now())
+	h.Create(event.CreateEvent{Object: job2}, q)
+	assert.Contains(t, a.waitingJobs, job2.UID)
+	h.Delete(event.DeleteEvent{Object: job2}, q)
+	assert.NotContains(t, a.waitingJobs, job2.UID)
+}
+
+func TestArbitratorExpireTimeoutJobs(t *testing.T) {
+	reconciler, a, fakeClock := newTestArbitrator([]deschedulerconfig.MigrationBudget{
+		{Window: metav1.Duration{Duration: time.Hour}, MaxMigrations: 1},
+	})
+	now := fakeClock.Now()
+
+	job := newTestArbitrationJob("job-held", "pod-held", now)
+	job.Spec.TTL = &metav1.Duration{Duration: 30 * time.Minute}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+	assert.True(t, a.hold(job))
+	a.releaseHistory = append(a.releaseHistory, now)
+
+	a.doArbitrate(context.TODO())
+	assert.Empty(t, drainReleasedJobs(a))
+	assert.False(t, a.isReleased(job))
+
+	fakeClock.Step(30 * time.Minute)
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-held"}, drainReleasedJobs(a))
+	assert.Empty(t, a.waitingJobs)
+	assert.Len(t, a.releaseHistory, 1)
+
+	reconciler.clock = fakeClock
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonTimeout, job.Status.Reason)
+}
+
+func TestArbitratorReleaseChannelFull(t *testing.T) {
+	reconciler, a, _ := newTestArbitrator([]deschedulerconfig.MigrationBudget{
+		{Window: metav1.Duration{Duration: time.Hour}, MaxMigrations: 10},
+	})
+	a.releaseCh = make(chan event.GenericEvent, 1)
+	now := time.Now()
+
+	jobs := []*sev1alpha1.PodMigrationJob{
+		newTestArbitrationJob("job-1", "pod-a", now.Add(-time.Minute)),
+		newTestArbitrationJob("job-2", "pod-b", now),
+	}
+	for _, job := range jobs {
+		assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+		assert.True(t, a.hold(job))
+	}
+
+	// the second job must not block the arbitration and waits for the next round without consuming the budget
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-1"}, drainReleasedJobs(a))
+	assert.Contains(t, a.waitingJobs, jobs[1].UID)
+	assert.False(t, a.isReleased(jobs[1]))
+	assert.Len(t, a.releaseHistory, 1)
+
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-2"}, drainReleasedJobs(a))
+	assert.Empty(t, a.waitingJobs)
+	assert.Len(t, a.releaseHistory, 2)
+}
+
+func TestArbitratorReturnBudgetOfAbortedJob(t *testing.T) {
+	reconciler, a, _ := newTestArbitrator([]deschedulerconfig.MigrationBudget{
+		{Window: metav1.Duration{Duration: time.Hour}, MaxMigrations: 1},
+	})
+	h := &arbitrationEventHandler{arbitrator: a}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	now := time.Now()
+
+	job1 := newTestArbitrationJob("job-1", "pod-a", now.Add(-time.Minute))
+	job2 := newTestArbitrationJob("job-2", "pod-b", now)
+	for _, job := range []*sev1alpha1.PodMigrationJob{job1, job2} {
+		assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+		h.Create(event.CreateEvent{Object: job}, q)
+	}
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-1"}, drainReleasedJobs(a))
+
+	// the Reconciler aborts the released job before migrating the Pod
+	abortedJob := job1.DeepCopy()
+	abortedJob.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	h.Update(event.UpdateEvent{ObjectOld: job1, ObjectNew: abortedJob}, q)
+	assert.Empty(t, a.releaseHistory)
+
+	a.doArbitrate(context.TODO())
+	assert.Equal(t, []string{"job-2"}, drainReleasedJobs(a))
+
+	// the job failed after running has consumed the budget
+	runningJob := job2.DeepCopy()
+	runningJob.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	h.Update(event.UpdateEvent{ObjectOld: job2, ObjectNew: runningJob}, q)
+	failedJob := runningJob.DeepCopy()
+	failedJob.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	h.Update(event.UpdateEvent{ObjectOld: runningJob, ObjectNew: failedJob}, q)
+	assert.Len(t, a.releaseHistory, 1)
+}
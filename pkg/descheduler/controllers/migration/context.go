@@ -34,6 +34,9 @@ type JobContext struct {
 	Annotations map[string]string
 	Timeout     *time.Duration
 	Mode        sev1alpha1.PodMigrationJobMode
+	// ReservationOptions is used as the ReservationOptions of the job,
+	// e.g. to reserve resources on the specified nodes in ReservationFirst mode.
+	ReservationOptions *sev1alpha1.PodMigrateReservationOptions
 }
 
 func WithContext(ctx context.Context, jobCtx *JobContext) context.Context {
@@ -73,5 +76,8 @@ func (c *JobContext) ApplyTo(job *sev1alpha1.PodMigrationJob) error {
 	if c.Mode != "" {
 		job.Spec.Mode = c.Mode
 	}
+	if c.ReservationOptions != nil {
+		job.Spec.ReservationOptions = c.ReservationOptions.DeepCopy()
+	}
 	return nil
 }
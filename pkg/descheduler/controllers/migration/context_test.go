@@ -62,3 +62,39 @@ func TestJobContext(t *testing.T) {
 	}
 	assert.Equal(t, expectJob, job)
 }
+
+func TestJobContextWithReservationOptions(t *testing.T) {
+	timeout := 1 * time.Minute
+	expectJobCtx := &JobContext{
+		Mode:    sev1alpha1.PodMigrationJobModeReservationFirst,
+		Timeout: &timeout,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			Template: &sev1alpha1.ReservationTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-reservation",
+				},
+			},
+		},
+	}
+
+	ctx := WithContext(context.TODO(), expectJobCtx)
+	jobCtx := FromContext(ctx)
+	assert.Equal(t, expectJobCtx, jobCtx)
+	job := &sev1alpha1.PodMigrationJob{}
+	err := jobCtx.ApplyTo(job)
+	assert.NoError(t, err)
+	expectJob := &sev1alpha1.PodMigrationJob{
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+			TTL:  &metav1.Duration{Duration: timeout},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				Template: &sev1alpha1.ReservationTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-reservation",
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectJob, job)
+}
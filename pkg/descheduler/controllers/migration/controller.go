@@ -81,6 +81,7 @@ type Reconciler struct {
 	retryablePodFilter     framework.FilterFunc
 	defaultFilterPlugin    framework.FilterPlugin
 	assumedCache           *assumedCache
+	arbitrator             *arbitrator
 	clock                  clock.Clock
 
 	lock           sync.Mutex
@@ -108,7 +109,14 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		return nil, err
 	}
 
-	if err = c.Watch(&source.Kind{Type: &sev1alpha1.PodMigrationJob{}}, &handler.EnqueueRequestForObject{}, &predicate.Funcs{
+	var jobEventHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
+	if r.arbitrator != nil {
+		jobEventHandler = &arbitrationEventHandler{arbitrator: r.arbitrator}
+		if err = c.Watch(&source.Channel{Source: r.arbitrator.releaseCh}, &handler.EnqueueRequestForObject{}); err != nil {
+			return nil, err
+		}
+	}
+	if err = c.Watch(&source.Kind{Type: &sev1alpha1.PodMigrationJob{}}, jobEventHandler, &predicate.Funcs{
 		DeleteFunc: func(event event.DeleteEvent) bool {
 			job := event.Object.(*sev1alpha1.PodMigrationJob)
 			r.assumedCache.delete(job)
@@ -123,6 +131,12 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err = c.Watch(&source.Kind{Type: r.reservationInterpreter.GetReservationType()}, &handler.Funcs{}); err != nil {
 		return nil, err
 	}
+	if err = newWorkloadMigrationController(r); err != nil {
+		return nil, err
+	}
+	if err = newNodeDrainController(r); err != nil {
+		return nil, err
+	}
 	return r, nil
 }
 
@@ -157,6 +171,12 @@ func newReconciler(args *deschedulerconfig.MigrationControllerArgs, handle frame
 	if err := manager.Add(r); err != nil {
 		return nil, err
 	}
+	if args.ArbitrationArgs != nil && args.ArbitrationArgs.Enabled {
+		r.arbitrator = newArbitrator(args.ArbitrationArgs, r.Client, r.eventRecorder)
+		if err := manager.Add(r.arbitrator); err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
 
@@ -280,6 +300,11 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 		return reconcile.Result{}, nil
 	}
 
+	if r.arbitrator != nil && !r.arbitrator.isReleased(job) {
+		klog.V(4).Infof("MigrationJob %s is waiting for arbitration", job.Name)
+		return reconcile.Result{}, nil
+	}
+
 	if job.Status.Phase == "" || job.Status.Phase == sev1alpha1.PodMigrationJobPending {
 		if result, err := r.preparePendingJob(ctx, job); err != nil || !result.IsZero() {
 			return result, err
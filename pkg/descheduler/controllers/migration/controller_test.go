@@ -26,6 +26,7 @@ import (
 	appsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,6 +50,7 @@ import (
 	evictionsutil "github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 )
 
 type fakeEvictionInterpreter struct {
@@ -95,6 +97,7 @@ func (f fakeReservationInterpreter) DeleteReservation(ctx context.Context, reser
 type fakeControllerFinder struct {
 	pods     []*corev1.Pod
 	replicas int32
+	workload *controllerfinder.ScaleAndSelector
 	err      error
 }
 
@@ -110,6 +113,10 @@ func (f *fakeControllerFinder) GetExpectedScaleForPod(pod *corev1.Pod) (int32, e
 	return f.replicas, f.err
 }
 
+func (f *fakeControllerFinder) GetScaleAndSelectorForRef(apiVersion, kind, ns, name string, uid types.UID) (*controllerfinder.ScaleAndSelector, error) {
+	return f.workload, f.err
+}
+
 func newTestReconciler() *Reconciler {
 	scheme := runtime.NewScheme()
 	_ = sev1alpha1.AddToScheme(scheme)
@@ -1432,6 +1439,60 @@ func TestEvict(t *testing.T) {
 	assert.Equal(t, expectPodRef, jobList.Items[0].Spec.PodRef)
 }
 
+func TestEvictWithPodDisruptionBudget(t *testing.T) {
+	reconciler := newTestReconciler()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-pod",
+			Labels:    map[string]string{"app": "test"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Controller: pointer.Bool(true),
+					Kind:       "Deployment",
+					Name:       "test",
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-pdb",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: 0,
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), pdb))
+
+	recorder := report.NewRecorder(1, false)
+	ctx := report.WithRecorder(context.TODO(), recorder)
+	assert.False(t, reconciler.Evict(ctx, pod, framework.EvictOptions{}))
+	var jobList sev1alpha1.PodMigrationJobList
+	assert.NoError(t, reconciler.Client.List(context.TODO(), &jobList))
+	assert.Empty(t, jobList.Items)
+	cycleReport := recorder.Complete()
+	assert.Len(t, cycleReport.Pods, 1)
+	assert.Equal(t, report.PodSkipped, cycleReport.Pods[0].Result)
+	assert.Equal(t, report.SkipReasonPDB, cycleReport.Pods[0].SkipReason)
+
+	pdb.Status.DisruptionsAllowed = 1
+	assert.NoError(t, reconciler.Client.Status().Update(context.TODO(), pdb))
+	assert.True(t, reconciler.Evict(context.TODO(), pod, framework.EvictOptions{}))
+	assert.NoError(t, reconciler.Client.List(context.TODO(), &jobList))
+	assert.Len(t, jobList.Items, 1)
+}
+
 func TestAbortJobIfReserveOnSameNode(t *testing.T) {
 	reconciler := newTestReconciler()
 	pod := &corev1.Pod{
@@ -71,6 +71,7 @@ type PodControllerFinder func(ref ControllerReference, namespace string) (*Scale
 type Interface interface {
 	GetPodsForRef(ownerReference *metav1.OwnerReference, ns string, labelSelector *metav1.LabelSelector, active bool) ([]*corev1.Pod, int32, error)
 	GetExpectedScaleForPod(pods *corev1.Pod) (int32, error)
+	GetScaleAndSelectorForRef(apiVersion, kind, ns, name string, uid types.UID) (*ScaleAndSelector, error)
 	ListPodsByWorkloads(workloadUIDs []types.UID, ns string, labelSelector *metav1.LabelSelector, active bool) ([]*corev1.Pod, error)
 }
 
@@ -18,9 +18,12 @@ package migration
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -28,12 +31,15 @@ import (
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 )
 
 // Evict evicts a pod
 func (r *Reconciler) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
 	framework.FillEvictOptionsFromContext(ctx, &evictOptions)
 
+	// the pods which would be evicted are recorded by the evictor proxy or the plugins in their own dry run,
+	// so the dry run of MigrationController only logs them to keep each pod recorded once.
 	if r.args.DryRun {
 		klog.Infof("%s tries to evict Pod %q via dryRun mode since %s", evictOptions.PluginName, klog.KObj(pod), evictOptions.Reason)
 		return true
@@ -41,11 +47,44 @@ func (r *Reconciler) Evict(ctx context.Context, pod *corev1.Pod, evictOptions fr
 
 	if !r.Filter(pod) {
 		klog.Errorf("Pod %q cannot be evicted since failed to filter", klog.KObj(pod))
+		report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonFilter, "rejected by MigrationController filters")
+		return false
+	}
+
+	// report the pods protected by PodDisruptionBudgets in the same way as the Eviction API rejecting them
+	if pdb := r.getViolatedPodDisruptionBudget(ctx, pod); pdb != nil {
+		klog.V(4).Infof("Pod %q cannot be evicted since PodDisruptionBudget %q allows no disruptions", klog.KObj(pod), klog.KObj(pdb))
+		report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonPDB, fmt.Sprintf("PodDisruptionBudget %s allows no disruptions", pdb.Name))
 		return false
 	}
 
 	err := CreatePodMigrationJob(ctx, pod, evictOptions, r.Client, r.args)
-	return err == nil
+	if err != nil {
+		report.RecordPod(ctx, pod, report.PodEvictFailed, "", err.Error())
+		return false
+	}
+	report.RecordPod(ctx, pod, report.PodEvicted, "", evictOptions.Reason)
+	return true
+}
+
+// getViolatedPodDisruptionBudget returns the PodDisruptionBudget which selects the pod and allows no disruptions.
+func (r *Reconciler) getViolatedPodDisruptionBudget(ctx context.Context, pod *corev1.Pod) *policyv1.PodDisruptionBudget {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := r.Client.List(ctx, pdbList, client.InNamespace(pod.Namespace)); err != nil {
+		klog.Errorf("Failed to list PodDisruptionBudgets for Pod %q, err: %v", klog.KObj(pod), err)
+		return nil
+	}
+	for i := range pdbList.Items {
+		pdb := &pdbList.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if pdb.Status.DisruptionsAllowed <= 0 {
+			return pdb
+		}
+	}
+	return nil
 }
 
 func CreatePodMigrationJob(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions, client client.Client, args *deschedulerconfig.MigrationControllerArgs) error {
@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
@@ -35,8 +36,8 @@ import (
 const (
 	LabelEvictPolicy = "koordinator.sh/evict-policy"
 
-	AnnotationEvictReason  = "koordinator.sh/evict-reason"
-	AnnotationEvictTrigger = "koordinator.sh/evict-trigger"
+	AnnotationEvictReason  = extension.AnnotationEvictReason
+	AnnotationEvictTrigger = extension.AnnotationEvictTrigger
 )
 
 var (
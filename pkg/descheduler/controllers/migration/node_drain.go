@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	deschedulerutil "github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+const (
+	NodeDrainControllerName = names.NodeDrainController
+
+	defaultMaxConcurrentDrainMigrations = 1
+)
+
+// nodeDrainReconciler cordons the node and migrates the Pods on the node by creating PodMigrationJobs
+// in ReservationFirst mode. A Pod is evicted only after its replacement reserved the resources on another
+// node, and the next Pods are migrated only after the previous PodMigrationJobs completed, which means
+// the replacements are ready.
+// Like kubectl drain without --force, the job fails without migrating any Pod if there are Pods not managed
+// by a controller on the node, because nobody would recreate them to consume the reservations.
+type nodeDrainReconciler struct {
+	client.Client
+	args          *deschedulerconfig.MigrationControllerArgs
+	eventRecorder events.EventRecorder
+	clock         clock.Clock
+}
+
+func newNodeDrainController(r *Reconciler) error {
+	nr := &nodeDrainReconciler{
+		Client:        r.Client,
+		args:          r.args,
+		eventRecorder: r.eventRecorder,
+		clock:         r.clock,
+	}
+	c, err := controller.New(NodeDrainControllerName, options.Manager, controller.Options{Reconciler: nr, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+	if err = c.Watch(&source.Kind{Type: &sev1alpha1.NodeDrainJob{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &sev1alpha1.PodMigrationJob{}}, enqueueRequestForLabel(sev1alpha1.LabelNodeDrainJob))
+}
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=nodedrainjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=nodedrainjobs/status,verbs=get;update;patch
+
+func (n *nodeDrainReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	job := &sev1alpha1.NodeDrainJob{}
+	err := n.Client.Get(ctx, request.NamespacedName, job)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		klog.Errorf("Failed to Get NodeDrainJob from %v, err: %v", request, err)
+		return reconcile.Result{}, err
+	}
+
+	if job.Spec.Paused ||
+		job.Status.Phase == sev1alpha1.NodeDrainJobSucceeded ||
+		job.Status.Phase == sev1alpha1.NodeDrainJobFailed {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := n.doDrain(ctx, job)
+	if err != nil {
+		klog.Errorf("Failed to reconcile NodeDrainJob %v, err: %v", request.NamespacedName, err)
+	}
+	return result, err
+}
+
+func (n *nodeDrainReconciler) doDrain(ctx context.Context, job *sev1alpha1.NodeDrainJob) (reconcile.Result, error) {
+	if job.Spec.Timeout != nil && job.Spec.Timeout.Duration > 0 && n.clock.Since(job.CreationTimestamp.Time) > job.Spec.Timeout.Duration {
+		msg := fmt.Sprintf("Abort job caused by the node %q is not drained within %v", job.Spec.NodeName, job.Spec.Timeout.Duration)
+		return reconcile.Result{}, n.finishJob(ctx, job, nil, sev1alpha1.NodeDrainJobFailed, sev1alpha1.NodeDrainJobReasonTimeout, msg)
+	}
+
+	node := &corev1.Node{}
+	err := n.Client.Get(ctx, types.NamespacedName{Name: job.Spec.NodeName}, node)
+	if errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Abort job caused by missing node %q", job.Spec.NodeName)
+		return reconcile.Result{}, n.finishJob(ctx, job, nil, sev1alpha1.NodeDrainJobFailed, sev1alpha1.NodeDrainJobReasonMissingNode, msg)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := n.cordonNode(ctx, job, node); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	pods, err := n.listDrainablePods(ctx, node.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if unreplicated := unreplicatedPods(pods); len(unreplicated) > 0 {
+		msg := fmt.Sprintf("Abort job caused by Pods not managed by a controller on node %q: %v", node.Name, unreplicated)
+		return reconcile.Result{}, n.finishJob(ctx, job, nil, sev1alpha1.NodeDrainJobFailed, sev1alpha1.NodeDrainJobReasonUnreplicatedPod, msg)
+	}
+	migrationJobs, err := listOwnedPodMigrationJobs(ctx, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status := job.Status.DeepCopy()
+	status.Pods = int32(len(pods))
+	// the Pods migrated successfully are no longer on the node, so they are counted by the succeeded PodMigrationJobs
+	status.MigratedPods = int32(migrationJobs.succeeded)
+	// the Pods evicted by the PodMigrationJobs waiting for their replacements to be ready are no longer
+	// on the node, so the migrating Pods are counted by the active PodMigrationJobs
+	status.MigratingPods = int32(len(migrationJobs.active))
+	status.FailedPods = 0
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if _, ok := migrationJobs.active[pod.UID]; ok {
+			continue
+		}
+		if _, ok := migrationJobs.failed[pod.UID]; ok {
+			status.FailedPods++
+		} else {
+			candidates = append(candidates, pod)
+		}
+	}
+
+	maxConcurrent := int32(defaultMaxConcurrentDrainMigrations)
+	if job.Spec.MaxConcurrentMigrations != nil && *job.Spec.MaxConcurrentMigrations > 0 {
+		maxConcurrent = *job.Spec.MaxConcurrentMigrations
+	}
+	for _, pod := range candidates {
+		if status.MigratingPods >= maxConcurrent {
+			break
+		}
+		if err := n.createPodMigrationJob(ctx, job, pod); err != nil {
+			return reconcile.Result{}, err
+		}
+		status.MigratingPods++
+	}
+	klog.V(4).Infof("NodeDrainJob %s, node: %s, pods: %d, migrated: %d, migrating: %d, failed: %d",
+		job.Name, node.Name, status.Pods, status.MigratedPods, status.MigratingPods, status.FailedPods)
+
+	if status.MigratingPods == 0 {
+		if status.FailedPods > 0 {
+			msg := fmt.Sprintf("%d Pods failed to be migrated from node %q", status.FailedPods, node.Name)
+			return reconcile.Result{}, n.finishJob(ctx, job, status, sev1alpha1.NodeDrainJobFailed, sev1alpha1.NodeDrainJobReasonFailedMigratePod, msg)
+		}
+		msg := fmt.Sprintf("Node %q is drained", node.Name)
+		return reconcile.Result{}, n.finishJob(ctx, job, status, sev1alpha1.NodeDrainJobSucceeded, "", msg)
+	}
+
+	status.Phase = sev1alpha1.NodeDrainJobRunning
+	status.Reason = ""
+	status.Message = ""
+	if err := n.updateStatus(ctx, job, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+func (n *nodeDrainReconciler) cordonNode(ctx context.Context, job *sev1alpha1.NodeDrainJob, node *corev1.Node) error {
+	if node.Spec.Unschedulable {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	if err := n.Client.Patch(ctx, node, patch); err != nil {
+		return err
+	}
+	n.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, "Cordoned", "Draining", "Cordon node %q", node.Name)
+	return nil
+}
+
+// listDrainablePods returns the Pods on the node except the DaemonSet Pods, mirror Pods and static Pods,
+// which are not migrated by draining.
+func (n *nodeDrainReconciler) listDrainablePods(ctx context.Context, nodeName string) ([]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	listOpts := &client.ListOptions{FieldSelector: fields.OneTermEqualSelector(fieldindex.IndexPodByNodeName, nodeName)}
+	if err := n.Client.List(ctx, podList, listOpts); err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName != nodeName || !kubecontroller.IsPodActive(pod) ||
+			deschedulerutil.IsMirrorPod(pod) || deschedulerutil.IsStaticPod(pod) || deschedulerutil.IsDaemonsetPod(pod.OwnerReferences) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+// unreplicatedPods returns the keys of the Pods without a controller, which are not recreated after eviction.
+func unreplicatedPods(pods []*corev1.Pod) []string {
+	var keys []string
+	for _, pod := range pods {
+		if metav1.GetControllerOf(pod) == nil {
+			keys = append(keys, klog.KObj(pod).String())
+		}
+	}
+	return keys
+}
+
+func (n *nodeDrainReconciler) createPodMigrationJob(ctx context.Context, job *sev1alpha1.NodeDrainJob, pod *corev1.Pod) error {
+	jobCtx := &JobContext{
+		Labels: map[string]string{
+			sev1alpha1.LabelNodeDrainJob: job.Name,
+		},
+		Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+	}
+	if job.Spec.PodMigrationJobTTL != nil {
+		timeout := job.Spec.PodMigrationJobTTL.Duration
+		jobCtx.Timeout = &timeout
+	}
+	evictOptions := framework.EvictOptions{
+		PluginName: NodeDrainControllerName,
+		Reason:     fmt.Sprintf("drain node %q", job.Spec.NodeName),
+	}
+	if err := CreatePodMigrationJob(WithContext(ctx, jobCtx), pod, evictOptions, n.Client, n.args); err != nil {
+		return err
+	}
+	n.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, "MigratingPod", "Draining", "Create PodMigrationJob for Pod %q", klog.KObj(pod))
+	return nil
+}
+
+func (n *nodeDrainReconciler) finishJob(ctx context.Context, job *sev1alpha1.NodeDrainJob, status *sev1alpha1.NodeDrainJobStatus,
+	phase sev1alpha1.NodeDrainJobPhase, reason, message string) error {
+	if status == nil {
+		status = job.Status.DeepCopy()
+	}
+	status.Phase = phase
+	status.Reason = reason
+	status.Message = message
+	if err := n.updateStatus(ctx, job, status); err != nil {
+		return err
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == sev1alpha1.NodeDrainJobFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	n.eventRecorder.Eventf(job, nil, eventType, string(phase), "Draining", message)
+	return nil
+}
+
+func (n *nodeDrainReconciler) updateStatus(ctx context.Context, job *sev1alpha1.NodeDrainJob, status *sev1alpha1.NodeDrainJobStatus) error {
+	if *status == job.Status {
+		return nil
+	}
+	job.Status = *status
+	return n.Client.Status().Update(ctx, job)
+}
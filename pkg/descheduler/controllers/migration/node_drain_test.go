@@ -0,0 +1,263 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func newTestNodeDrainReconciler() (*nodeDrainReconciler, *clock.FakeClock) {
+	r := newTestReconciler()
+	fakeClock := clock.NewFakeClock(time.Now())
+	return &nodeDrainReconciler{
+		Client:        r.Client,
+		args:          r.args,
+		eventRecorder: r.eventRecorder,
+		clock:         fakeClock,
+	}, fakeClock
+}
+
+func TestNodeDrain(t *testing.T) {
+	n, _ := newTestNodeDrainReconciler()
+	ctx := context.TODO()
+
+	assert.NoError(t, n.Client.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, n.Client.Create(ctx, newTestWorkloadPod(fmt.Sprintf("pod-%d", i), "test-node")))
+	}
+	daemonSetPod := newTestWorkloadPod("daemonset-pod", "test-node")
+	daemonSetPod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds", UID: "ds", Controller: pointer.Bool(true)}}
+	assert.NoError(t, n.Client.Create(ctx, daemonSetPod))
+	assert.NoError(t, n.Client.Create(ctx, newTestWorkloadPod("other-pod", "other-node")))
+
+	job := &sev1alpha1.NodeDrainJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: metav1.Now()},
+		Spec: sev1alpha1.NodeDrainJobSpec{
+			NodeName:                "test-node",
+			MaxConcurrentMigrations: pointer.Int32(2),
+			PodMigrationJobTTL:      &metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+	assert.NoError(t, n.Client.Create(ctx, job))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+
+	result, err := n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRequeueAfter, result.RequeueAfter)
+
+	node := &corev1.Node{}
+	assert.NoError(t, n.Client.Get(ctx, types.NamespacedName{Name: "test-node"}, node))
+	assert.True(t, node.Spec.Unschedulable)
+
+	migrationJobs := listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name)
+	assert.Len(t, migrationJobs, 2)
+	for _, migrationJob := range migrationJobs {
+		assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, migrationJob.Spec.Mode)
+		assert.Equal(t, 10*time.Minute, migrationJob.Spec.TTL.Duration)
+	}
+	assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, sev1alpha1.NodeDrainJobRunning, job.Status.Phase)
+	assert.Equal(t, int32(3), job.Status.Pods)
+	assert.Equal(t, int32(2), job.Status.MigratingPods)
+
+	// the next Pod is migrated only after the previous migration completed
+	_, err = n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name), 2)
+
+	for i := range migrationJobs {
+		migrationJob := &migrationJobs[i]
+		migrationJob.Status.Phase = sev1alpha1.PodMigrationJobSucceeded
+		assert.NoError(t, n.Client.Status().Update(ctx, migrationJob))
+		assert.NoError(t, n.Client.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: migrationJob.Spec.PodRef.Name}}))
+	}
+	_, err = n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	migrationJobs = listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name)
+	assert.Len(t, migrationJobs, 3)
+	assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, int32(1), job.Status.Pods)
+	assert.Equal(t, int32(2), job.Status.MigratedPods)
+	assert.Equal(t, int32(1), job.Status.MigratingPods)
+
+	for i := range migrationJobs {
+		migrationJob := &migrationJobs[i]
+		if migrationJob.Status.Phase == sev1alpha1.PodMigrationJobSucceeded {
+			continue
+		}
+		migrationJob.Status.Phase = sev1alpha1.PodMigrationJobSucceeded
+		assert.NoError(t, n.Client.Status().Update(ctx, migrationJob))
+		assert.NoError(t, n.Client.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: migrationJob.Spec.PodRef.Name}}))
+	}
+	result, err = n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+	assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, sev1alpha1.NodeDrainJobSucceeded, job.Status.Phase)
+	assert.Equal(t, int32(3), job.Status.MigratedPods)
+}
+
+func TestNodeDrainWaitForBoundPodReady(t *testing.T) {
+	n, _ := newTestNodeDrainReconciler()
+	ctx := context.TODO()
+
+	assert.NoError(t, n.Client.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}))
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, n.Client.Create(ctx, newTestWorkloadPod(fmt.Sprintf("pod-%d", i), "test-node")))
+	}
+	job := &sev1alpha1.NodeDrainJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: metav1.Now()},
+		Spec: sev1alpha1.NodeDrainJobSpec{
+			NodeName: "test-node",
+		},
+	}
+	assert.NoError(t, n.Client.Create(ctx, job))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+
+	_, err := n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	migrationJobs := listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name)
+	assert.Len(t, migrationJobs, 1)
+
+	// the Pod is evicted but the PodMigrationJob is still waiting for the replacement bound to the reservation to be ready
+	migrationJob := &migrationJobs[0]
+	migrationJob.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	migrationJob.Status.Conditions = []sev1alpha1.PodMigrationJobCondition{
+		{
+			Type:   sev1alpha1.PodMigrationJobConditionBoundPodReady,
+			Status: sev1alpha1.PodMigrationJobConditionStatusFalse,
+			Reason: sev1alpha1.PodMigrationJobReasonWaitForBoundPodReady,
+		},
+	}
+	assert.NoError(t, n.Client.Status().Update(ctx, migrationJob))
+	assert.NoError(t, n.Client.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: migrationJob.Spec.PodRef.Name}}))
+
+	_, err = n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name), 1)
+	assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, int32(1), job.Status.Pods)
+	assert.Equal(t, int32(0), job.Status.MigratedPods)
+	assert.Equal(t, int32(1), job.Status.MigratingPods)
+
+	// the next Pod is migrated after the replacement is ready and the PodMigrationJob succeeded
+	migrationJob.Status.Phase = sev1alpha1.PodMigrationJobSucceeded
+	migrationJob.Status.Conditions[0].Status = sev1alpha1.PodMigrationJobConditionStatusTrue
+	migrationJob.Status.Conditions[0].Reason = ""
+	assert.NoError(t, n.Client.Status().Update(ctx, migrationJob))
+
+	_, err = n.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name), 2)
+	assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, int32(1), job.Status.MigratedPods)
+	assert.Equal(t, int32(1), job.Status.MigratingPods)
+}
+
+func TestNodeDrainFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeName   string
+		failedJob  bool
+		timeout    bool
+		barePod    bool
+		wantReason string
+	}{
+		{
+			name:       "missing node",
+			nodeName:   "missing-node",
+			wantReason: sev1alpha1.NodeDrainJobReasonMissingNode,
+		},
+		{
+			name:       "timeout",
+			nodeName:   "test-node",
+			timeout:    true,
+			wantReason: sev1alpha1.NodeDrainJobReasonTimeout,
+		},
+		{
+			name:       "failed to migrate pod",
+			nodeName:   "test-node",
+			failedJob:  true,
+			wantReason: sev1alpha1.NodeDrainJobReasonFailedMigratePod,
+		},
+		{
+			name:       "pod not managed by a controller",
+			nodeName:   "test-node",
+			barePod:    true,
+			wantReason: sev1alpha1.NodeDrainJobReasonUnreplicatedPod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, fakeClock := newTestNodeDrainReconciler()
+			ctx := context.TODO()
+			assert.NoError(t, n.Client.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}))
+			assert.NoError(t, n.Client.Create(ctx, newTestWorkloadPod("pod-0", "test-node")))
+			if tt.barePod {
+				barePod := newTestWorkloadPod("bare-pod", "test-node")
+				barePod.OwnerReferences = nil
+				assert.NoError(t, n.Client.Create(ctx, barePod))
+			}
+			if tt.failedJob {
+				assert.NoError(t, n.Client.Create(ctx, &sev1alpha1.PodMigrationJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "failed-job",
+						Labels: map[string]string{sev1alpha1.LabelNodeDrainJob: "test"},
+					},
+					Spec: sev1alpha1.PodMigrationJobSpec{
+						PodRef: &corev1.ObjectReference{Namespace: "default", Name: "pod-0", UID: "pod-0"},
+					},
+					Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobFailed},
+				}))
+			}
+			job := &sev1alpha1.NodeDrainJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: metav1.Time{Time: fakeClock.Now()}},
+				Spec: sev1alpha1.NodeDrainJobSpec{
+					NodeName: tt.nodeName,
+					Timeout:  &metav1.Duration{Duration: time.Hour},
+				},
+			}
+			assert.NoError(t, n.Client.Create(ctx, job))
+			if tt.timeout {
+				fakeClock.Step(2 * time.Hour)
+			}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+			result, err := n.Reconcile(ctx, request)
+			assert.NoError(t, err)
+			assert.True(t, result.IsZero())
+			assert.NoError(t, n.Client.Get(ctx, request.NamespacedName, job))
+			assert.Equal(t, sev1alpha1.NodeDrainJobFailed, job.Status.Phase)
+			assert.Equal(t, tt.wantReason, job.Status.Reason)
+			if tt.barePod {
+				assert.Empty(t, listTestPodMigrationJobsByLabel(t, n.Client, sev1alpha1.LabelNodeDrainJob, job.Name))
+			}
+		})
+	}
+}
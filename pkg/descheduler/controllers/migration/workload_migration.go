@@ -0,0 +1,388 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+const (
+	WorkloadMigrationControllerName = names.WorkloadMigrationController
+)
+
+// workloadMigrationReconciler migrates all Pods of a workload to the target nodes by creating
+// PodMigrationJobs in ReservationFirst mode. At most MaxUnavailable Pods of the workload are
+// unavailable or migrating at the same time, and the next Pods are migrated only after the
+// migrated Pods become ready. The unready Pods are not migrated, so the job keeps waiting for them
+// until they become ready or the job times out.
+type workloadMigrationReconciler struct {
+	client.Client
+	args             *deschedulerconfig.MigrationControllerArgs
+	eventRecorder    events.EventRecorder
+	controllerFinder controllerfinder.Interface
+	clock            clock.Clock
+}
+
+func newWorkloadMigrationController(r *Reconciler) error {
+	wr := &workloadMigrationReconciler{
+		Client:           r.Client,
+		args:             r.args,
+		eventRecorder:    r.eventRecorder,
+		controllerFinder: r.controllerFinder,
+		clock:            r.clock,
+	}
+	c, err := controller.New(WorkloadMigrationControllerName, options.Manager, controller.Options{Reconciler: wr, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+	if err = c.Watch(&source.Kind{Type: &sev1alpha1.WorkloadMigrationJob{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &sev1alpha1.PodMigrationJob{}}, enqueueRequestForLabel(sev1alpha1.LabelWorkloadMigrationJob))
+}
+
+// enqueueRequestForLabel enqueues the cluster-scoped object named by the label value of the PodMigrationJob.
+func enqueueRequestForLabel(labelKey string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		name := obj.GetLabels()[labelKey]
+		if name == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	})
+}
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=workloadmigrationjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=workloadmigrationjobs/status,verbs=get;update;patch
+
+func (w *workloadMigrationReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	job := &sev1alpha1.WorkloadMigrationJob{}
+	err := w.Client.Get(ctx, request.NamespacedName, job)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		klog.Errorf("Failed to Get WorkloadMigrationJob from %v, err: %v", request, err)
+		return reconcile.Result{}, err
+	}
+
+	if job.Spec.Paused ||
+		job.Status.Phase == sev1alpha1.WorkloadMigrationJobSucceeded ||
+		job.Status.Phase == sev1alpha1.WorkloadMigrationJobFailed {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := w.doMigrateWorkload(ctx, job)
+	if err != nil {
+		klog.Errorf("Failed to reconcile WorkloadMigrationJob %v, err: %v", request.NamespacedName, err)
+	}
+	return result, err
+}
+
+// workloadMigrationProgress records the classified Pods of the workload in a round of reconciliation.
+type workloadMigrationProgress struct {
+	replicas    int
+	migrated    int
+	migrating   int
+	failed      int
+	unavailable int
+	candidates  []*corev1.Pod
+}
+
+func (w *workloadMigrationReconciler) doMigrateWorkload(ctx context.Context, job *sev1alpha1.WorkloadMigrationJob) (reconcile.Result, error) {
+	workloadRef := job.Spec.WorkloadRef
+	if workloadRef == nil || workloadRef.Name == "" || len(job.Spec.TargetNodeSelector) == 0 {
+		return reconcile.Result{}, w.finishJob(ctx, job, nil, sev1alpha1.WorkloadMigrationJobFailed, sev1alpha1.WorkloadMigrationJobReasonInvalidSpec, "workloadRef and targetNodeSelector are required")
+	}
+
+	workload, err := w.controllerFinder.GetScaleAndSelectorForRef(workloadRef.APIVersion, workloadRef.Kind, workloadRef.Namespace, workloadRef.Name, workloadRef.UID)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if workload == nil || !workload.Metadata.DeletionTimestamp.IsZero() {
+		msg := fmt.Sprintf("Abort job caused by missing workload %s %s/%s", workloadRef.Kind, workloadRef.Namespace, workloadRef.Name)
+		return reconcile.Result{}, w.finishJob(ctx, job, nil, sev1alpha1.WorkloadMigrationJobFailed, sev1alpha1.WorkloadMigrationJobReasonMissingWorkload, msg)
+	}
+
+	progress, err := w.getProgress(ctx, job, workload)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if job.Spec.Timeout != nil && job.Spec.Timeout.Duration > 0 && w.clock.Since(job.CreationTimestamp.Time) > job.Spec.Timeout.Duration {
+		msg := fmt.Sprintf("Abort job caused by the workload is not migrated within %v", job.Spec.Timeout.Duration)
+		return reconcile.Result{}, w.finishJob(ctx, job, progress, sev1alpha1.WorkloadMigrationJobFailed, sev1alpha1.WorkloadMigrationJobReasonTimeout, msg)
+	}
+
+	maxUnavailable := job.Spec.MaxUnavailable
+	if maxUnavailable == nil {
+		maxUnavailable = w.args.MaxUnavailablePerWorkload
+	}
+	allowedUnavailable, err := util.GetMaxUnavailable(progress.replicas, maxUnavailable)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, pod := range progress.candidates {
+		if progress.unavailable >= allowedUnavailable {
+			break
+		}
+		if err := w.createPodMigrationJob(ctx, job, pod); err != nil {
+			return reconcile.Result{}, err
+		}
+		progress.unavailable++
+		progress.migrating++
+	}
+	klog.V(4).Infof("WorkloadMigrationJob %s, replicas: %d, migrated: %d, migrating: %d, failed: %d, unavailable: %d, maxUnavailable: %d",
+		job.Name, progress.replicas, progress.migrated, progress.migrating, progress.failed, progress.unavailable, allowedUnavailable)
+
+	if progress.migrating == 0 && len(progress.candidates) == 0 {
+		// the Pods failed to be migrated are not retried, so the job fails even if some Pods are still unavailable
+		if progress.failed > 0 {
+			msg := fmt.Sprintf("%d Pods failed to be migrated", progress.failed)
+			return reconcile.Result{}, w.finishJob(ctx, job, progress, sev1alpha1.WorkloadMigrationJobFailed, sev1alpha1.WorkloadMigrationJobReasonFailedMigratePod, msg)
+		}
+		if progress.failed == 0 && progress.migrated >= progress.replicas {
+			return reconcile.Result{}, w.finishJob(ctx, job, progress, sev1alpha1.WorkloadMigrationJobSucceeded, "", "All Pods are migrated to the target nodes")
+		}
+	}
+
+	status := job.Status.DeepCopy()
+	status.Phase = sev1alpha1.WorkloadMigrationJobRunning
+	status.Reason = ""
+	status.Message = ""
+	w.fillProgress(status, progress)
+	if err := w.updateStatus(ctx, job, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+func (w *workloadMigrationReconciler) getProgress(ctx context.Context, job *sev1alpha1.WorkloadMigrationJob, workload *controllerfinder.ScaleAndSelector) (*workloadMigrationProgress, error) {
+	pods, err := w.listWorkloadPods(ctx, job.Spec.WorkloadRef.Namespace, workload.Selector)
+	if err != nil {
+		return nil, err
+	}
+	migrationJobs, err := listOwnedPodMigrationJobs(ctx, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	targetSelector := labels.SelectorFromSet(job.Spec.TargetNodeSelector)
+	progress := &workloadMigrationProgress{replicas: int(workload.Scale)}
+	if len(pods) < progress.replicas {
+		// the missing replicas are being recreated
+		progress.unavailable = progress.replicas - len(pods)
+	}
+	nodeMatched := map[string]bool{}
+	for _, pod := range pods {
+		if _, ok := migrationJobs.active[pod.UID]; ok {
+			progress.migrating++
+			progress.unavailable++
+			continue
+		}
+		ready := k8spodutil.IsPodReady(pod)
+		if !ready {
+			progress.unavailable++
+		}
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		matched, ok := nodeMatched[pod.Spec.NodeName]
+		if !ok {
+			node := &corev1.Node{}
+			if err := w.Client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			matched = targetSelector.Matches(labels.Set(node.Labels))
+			nodeMatched[pod.Spec.NodeName] = matched
+		}
+		if matched {
+			if ready {
+				progress.migrated++
+			}
+			continue
+		}
+		if _, ok := migrationJobs.failed[pod.UID]; ok {
+			progress.failed++
+			continue
+		}
+		if ready {
+			progress.candidates = append(progress.candidates, pod)
+		}
+	}
+	sort.Slice(progress.candidates, func(i, j int) bool {
+		return progress.candidates[i].Name < progress.candidates[j].Name
+	})
+	return progress, nil
+}
+
+func (w *workloadMigrationReconciler) listWorkloadPods(ctx context.Context, namespace string, selector *metav1.LabelSelector) ([]*corev1.Pod, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := w.Client.List(ctx, podList, &client.ListOptions{Namespace: namespace, LabelSelector: labelSelector}); err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if kubecontroller.IsPodActive(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// ownedPodMigrationJobs are the PodMigrationJobs created by a WorkloadMigrationJob or NodeDrainJob.
+type ownedPodMigrationJobs struct {
+	// active and failed jobs are indexed by Pod UID
+	active    map[types.UID]*sev1alpha1.PodMigrationJob
+	failed    map[types.UID]*sev1alpha1.PodMigrationJob
+	succeeded int
+}
+
+func listOwnedPodMigrationJobs(ctx context.Context, c client.Client, labelKey, owner string) (*ownedPodMigrationJobs, error) {
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	opts := &client.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{labelKey: owner})}
+	if err := c.List(ctx, jobList, opts); err != nil {
+		return nil, err
+	}
+	jobs := &ownedPodMigrationJobs{
+		active: map[types.UID]*sev1alpha1.PodMigrationJob{},
+		failed: map[types.UID]*sev1alpha1.PodMigrationJob{},
+	}
+	for i := range jobList.Items {
+		migrationJob := &jobList.Items[i]
+		if migrationJob.Spec.PodRef == nil {
+			continue
+		}
+		switch migrationJob.Status.Phase {
+		case "", sev1alpha1.PodMigrationJobPending, sev1alpha1.PodMigrationJobRunning:
+			jobs.active[migrationJob.Spec.PodRef.UID] = migrationJob
+		case sev1alpha1.PodMigrationJobFailed, sev1alpha1.PodMigrationJobAborted:
+			jobs.failed[migrationJob.Spec.PodRef.UID] = migrationJob
+		case sev1alpha1.PodMigrationJobSucceeded:
+			jobs.succeeded++
+		}
+	}
+	return jobs, nil
+}
+
+func (w *workloadMigrationReconciler) createPodMigrationJob(ctx context.Context, job *sev1alpha1.WorkloadMigrationJob, pod *corev1.Pod) error {
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: *pod.ObjectMeta.DeepCopy(),
+		Spec:       *pod.Spec.DeepCopy(),
+	}
+	if template.Spec.NodeSelector == nil {
+		template.Spec.NodeSelector = map[string]string{}
+	}
+	for k, v := range job.Spec.TargetNodeSelector {
+		template.Spec.NodeSelector[k] = v
+	}
+	jobCtx := &JobContext{
+		Labels: map[string]string{
+			sev1alpha1.LabelWorkloadMigrationJob: job.Name,
+		},
+		Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			Template: &sev1alpha1.ReservationTemplateSpec{
+				Spec: sev1alpha1.ReservationSpec{
+					Template: template,
+				},
+			},
+		},
+	}
+	if job.Spec.PodMigrationJobTTL != nil {
+		timeout := job.Spec.PodMigrationJobTTL.Duration
+		jobCtx.Timeout = &timeout
+	}
+	evictOptions := framework.EvictOptions{
+		PluginName: WorkloadMigrationControllerName,
+		Reason:     fmt.Sprintf("migrate workload to the nodes selected by %v", labels.Set(job.Spec.TargetNodeSelector)),
+	}
+	if err := CreatePodMigrationJob(WithContext(ctx, jobCtx), pod, evictOptions, w.Client, w.args); err != nil {
+		return err
+	}
+	w.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, "MigratingPod", "Migrating", "Create PodMigrationJob for Pod %q", klog.KObj(pod))
+	return nil
+}
+
+func (w *workloadMigrationReconciler) fillProgress(status *sev1alpha1.WorkloadMigrationJobStatus, progress *workloadMigrationProgress) {
+	status.Replicas = int32(progress.replicas)
+	status.MigratedReplicas = int32(progress.migrated)
+	status.MigratingReplicas = int32(progress.migrating)
+	status.FailedReplicas = int32(progress.failed)
+}
+
+func (w *workloadMigrationReconciler) finishJob(ctx context.Context, job *sev1alpha1.WorkloadMigrationJob, progress *workloadMigrationProgress,
+	phase sev1alpha1.WorkloadMigrationJobPhase, reason, message string) error {
+	status := job.Status.DeepCopy()
+	status.Phase = phase
+	status.Reason = reason
+	status.Message = message
+	if progress != nil {
+		w.fillProgress(status, progress)
+	}
+	if err := w.updateStatus(ctx, job, status); err != nil {
+		return err
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == sev1alpha1.WorkloadMigrationJobFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	w.eventRecorder.Eventf(job, nil, eventType, string(phase), "Migrating", message)
+	return nil
+}
+
+func (w *workloadMigrationReconciler) updateStatus(ctx context.Context, job *sev1alpha1.WorkloadMigrationJob, status *sev1alpha1.WorkloadMigrationJobStatus) error {
+	if *status == job.Status {
+		return nil
+	}
+	job.Status = *status
+	return w.Client.Status().Update(ctx, job)
+}
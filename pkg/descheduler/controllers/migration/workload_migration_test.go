@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+)
+
+func newTestWorkloadMigrationReconciler(workload *controllerfinder.ScaleAndSelector) *workloadMigrationReconciler {
+	r := newTestReconciler()
+	return &workloadMigrationReconciler{
+		Client:           r.Client,
+		args:             r.args,
+		eventRecorder:    r.eventRecorder,
+		controllerFinder: &fakeControllerFinder{workload: workload},
+		clock:            r.clock,
+	}
+}
+
+func newTestWorkloadPod(name, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    map[string]string{"app": "test"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test", UID: "test", Controller: pointer.Bool(true)},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func listTestPodMigrationJobsByLabel(t *testing.T, c client.Client, labelKey, owner string) []sev1alpha1.PodMigrationJob {
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	opts := &client.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{labelKey: owner})}
+	assert.NoError(t, c.List(context.TODO(), jobList, opts))
+	return jobList.Items
+}
+
+func TestWorkloadMigrationRolling(t *testing.T) {
+	w := newTestWorkloadMigrationReconciler(&controllerfinder.ScaleAndSelector{
+		Scale:    3,
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+	})
+	ctx := context.TODO()
+
+	for _, node := range []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "old-node", Labels: map[string]string{"pool": "old"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "new-node", Labels: map[string]string{"pool": "new"}}},
+	} {
+		assert.NoError(t, w.Client.Create(ctx, node))
+	}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, w.Client.Create(ctx, newTestWorkloadPod(fmt.Sprintf("pod-%d", i), "old-node")))
+	}
+	maxUnavailable := intstr.FromInt(1)
+	job := &sev1alpha1.WorkloadMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: sev1alpha1.WorkloadMigrationJobSpec{
+			WorkloadRef: &corev1.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  "default",
+				Name:       "test",
+			},
+			TargetNodeSelector: map[string]string{"pool": "new"},
+			MaxUnavailable:     &maxUnavailable,
+		},
+	}
+	assert.NoError(t, w.Client.Create(ctx, job))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+
+	result, err := w.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRequeueAfter, result.RequeueAfter)
+	migrationJobs := listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name)
+	assert.Len(t, migrationJobs, 1)
+	migrationJob := &migrationJobs[0]
+	assert.Equal(t, types.UID("pod-0"), migrationJob.Spec.PodRef.UID)
+	assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, migrationJob.Spec.Mode)
+	assert.Equal(t, "new", migrationJob.Spec.ReservationOptions.Template.Spec.Template.Spec.NodeSelector["pool"])
+
+	assert.NoError(t, w.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, sev1alpha1.WorkloadMigrationJobRunning, job.Status.Phase)
+	assert.Equal(t, int32(3), job.Status.Replicas)
+	assert.Equal(t, int32(1), job.Status.MigratingReplicas)
+
+	// the migrating Pod occupies the MaxUnavailable
+	_, err = w.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name), 1)
+
+	// the Pod is migrated to the target node
+	migrationJob.Status.Phase = sev1alpha1.PodMigrationJobSucceeded
+	assert.NoError(t, w.Client.Status().Update(ctx, migrationJob))
+	assert.NoError(t, w.Client.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-0"}}))
+	newPod := newTestWorkloadPod("pod-new-0", "new-node")
+	newPod.Status.Conditions = nil
+	assert.NoError(t, w.Client.Create(ctx, newPod))
+
+	// wait for the migrated Pod ready
+	_, err = w.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name), 1)
+
+	newPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	assert.NoError(t, w.Client.Status().Update(ctx, newPod))
+	_, err = w.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name), 2)
+	assert.NoError(t, w.Client.Get(ctx, request.NamespacedName, job))
+	assert.Equal(t, int32(1), job.Status.MigratedReplicas)
+	assert.Equal(t, int32(1), job.Status.MigratingReplicas)
+}
+
+func TestWorkloadMigrationFinished(t *testing.T) {
+	tests := []struct {
+		name       string
+		workload   *controllerfinder.ScaleAndSelector
+		failedJob  bool
+		unreadyPod bool
+		timeout    *metav1.Duration
+		wantPhase  sev1alpha1.WorkloadMigrationJobPhase
+		wantReason string
+	}{
+		{
+			name:       "missing workload",
+			wantPhase:  sev1alpha1.WorkloadMigrationJobFailed,
+			wantReason: sev1alpha1.WorkloadMigrationJobReasonMissingWorkload,
+		},
+		{
+			name: "all pods migrated",
+			workload: &controllerfinder.ScaleAndSelector{
+				Scale:    1,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			wantPhase: sev1alpha1.WorkloadMigrationJobSucceeded,
+		},
+		{
+			name: "failed to migrate pod",
+			workload: &controllerfinder.ScaleAndSelector{
+				Scale:    2,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			failedJob:  true,
+			wantPhase:  sev1alpha1.WorkloadMigrationJobFailed,
+			wantReason: sev1alpha1.WorkloadMigrationJobReasonFailedMigratePod,
+		},
+		{
+			name: "failed to migrate pod while some pods are unavailable",
+			workload: &controllerfinder.ScaleAndSelector{
+				Scale:    3,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			failedJob:  true,
+			wantPhase:  sev1alpha1.WorkloadMigrationJobFailed,
+			wantReason: sev1alpha1.WorkloadMigrationJobReasonFailedMigratePod,
+		},
+		{
+			name: "unready pod is not migrated within the timeout",
+			workload: &controllerfinder.ScaleAndSelector{
+				Scale:    2,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			unreadyPod: true,
+			timeout:    &metav1.Duration{Duration: time.Hour},
+			wantPhase:  sev1alpha1.WorkloadMigrationJobFailed,
+			wantReason: sev1alpha1.WorkloadMigrationJobReasonTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestWorkloadMigrationReconciler(tt.workload)
+			ctx := context.TODO()
+			assert.NoError(t, w.Client.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "new-node", Labels: map[string]string{"pool": "new"}}}))
+			assert.NoError(t, w.Client.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "old-node"}}))
+			assert.NoError(t, w.Client.Create(ctx, newTestWorkloadPod("pod-0", "new-node")))
+			if tt.failedJob {
+				assert.NoError(t, w.Client.Create(ctx, newTestWorkloadPod("pod-1", "old-node")))
+				assert.NoError(t, w.Client.Create(ctx, &sev1alpha1.PodMigrationJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "failed-job",
+						Labels: map[string]string{sev1alpha1.LabelWorkloadMigrationJob: "test"},
+					},
+					Spec: sev1alpha1.PodMigrationJobSpec{
+						PodRef: &corev1.ObjectReference{Namespace: "default", Name: "pod-1", UID: "pod-1"},
+					},
+					Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobFailed},
+				}))
+			}
+			if tt.unreadyPod {
+				pod := newTestWorkloadPod("pod-1", "old-node")
+				pod.Status.Conditions = nil
+				assert.NoError(t, w.Client.Create(ctx, pod))
+			}
+			job := &sev1alpha1.WorkloadMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					CreationTimestamp: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+				},
+				Spec: sev1alpha1.WorkloadMigrationJobSpec{
+					WorkloadRef:        &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "test"},
+					TargetNodeSelector: map[string]string{"pool": "new"},
+					Timeout:            tt.timeout,
+				},
+			}
+			assert.NoError(t, w.Client.Create(ctx, job))
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: job.Name}}
+			result, err := w.Reconcile(ctx, request)
+			assert.NoError(t, err)
+			assert.True(t, result.IsZero())
+			assert.NoError(t, w.Client.Get(ctx, request.NamespacedName, job))
+			assert.Equal(t, tt.wantPhase, job.Status.Phase)
+			assert.Equal(t, tt.wantReason, job.Status.Reason)
+			if tt.failedJob {
+				assert.Len(t, listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name), 1)
+			} else {
+				assert.Empty(t, listTestPodMigrationJobsByLabel(t, w.Client, sev1alpha1.LabelWorkloadMigrationJob, job.Name))
+			}
+		})
+	}
+}
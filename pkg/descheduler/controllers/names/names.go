@@ -17,5 +17,7 @@ limitations under the License.
 package names
 
 const (
-	MigrationController = "MigrationController"
+	MigrationController         = "MigrationController"
+	WorkloadMigrationController = "WorkloadMigrationController"
+	NodeDrainController         = "NodeDrainController"
 )
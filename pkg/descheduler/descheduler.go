@@ -41,6 +41,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/profile"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 )
 
 type Descheduler struct {
@@ -50,12 +51,17 @@ type Descheduler struct {
 	// Close this to shut down the scheduler.
 	StopEverything <-chan struct{}
 
+	// Reports keeps the reports of the latest descheduling cycles, it is nil if the report is disabled.
+	Reports *report.Store
+
 	clientSet    clientset.Interface
 	nodeInformer corev1informers.NodeInformer
 
+	dryRun               bool
 	deschedulingInterval time.Duration
 	nodeSelector         string
 	evictionLimiter      frameworkruntime.EvictionLimiter
+	reportWriter         *report.ConfigMapWriter
 }
 
 type deschedulerOptions struct {
@@ -70,6 +76,7 @@ type deschedulerOptions struct {
 	deschedulingInterval   time.Duration
 	nodeSelector           *metav1.LabelSelector
 	evictionLimiter        frameworkruntime.EvictionLimiter
+	reportConfiguration    *deschedulerconfig.ReportConfiguration
 }
 
 // Option configures a Scheduler
@@ -148,6 +155,13 @@ func WithEvictionLimiter(limiter frameworkruntime.EvictionLimiter) Option {
 	}
 }
 
+// WithReportConfiguration enables the reports explaining the decisions of each descheduling cycle.
+func WithReportConfiguration(cfg *deschedulerconfig.ReportConfiguration) Option {
+	return func(options *deschedulerOptions) {
+		options.reportConfiguration = cfg
+	}
+}
+
 var defaultDeschedulerOptions = deschedulerOptions{
 	applyDefaultProfile: true,
 }
@@ -231,10 +245,17 @@ func New(client clientset.Interface,
 		StopEverything:       stopEverything,
 		clientSet:            client,
 		nodeInformer:         nodeInformer,
+		dryRun:               options.dryRun,
 		deschedulingInterval: options.deschedulingInterval,
 		nodeSelector:         nodeSelector,
 		evictionLimiter:      options.evictionLimiter,
 	}
+	if cfg := options.reportConfiguration; cfg != nil {
+		descheduler.Reports = report.NewStore(int(cfg.MaxReports))
+		if cfg.ConfigMapName != "" {
+			descheduler.reportWriter = report.NewConfigMapWriter(client, cfg.ConfigMapNamespace, cfg.ConfigMapName)
+		}
+	}
 	return descheduler, nil
 }
 
@@ -268,6 +289,12 @@ func (d *Descheduler) deschedulerOnce(ctx context.Context) error {
 
 	d.evictionLimiter.Reset()
 
+	if d.Reports != nil {
+		recorder := d.Reports.NewRecorder(d.dryRun)
+		ctx = report.WithRecorder(ctx, recorder)
+		defer d.completeReport(ctx, recorder)
+	}
+
 	for _, p := range d.Profiles {
 		status := p.RunDeschedulePlugins(ctx, nodes)
 		if status != nil && status.Err != nil {
@@ -285,6 +312,16 @@ func (d *Descheduler) deschedulerOnce(ctx context.Context) error {
 	return nil
 }
 
+func (d *Descheduler) completeReport(ctx context.Context, recorder *report.Recorder) {
+	cycleReport := recorder.Complete()
+	d.Reports.Add(cycleReport)
+	if d.reportWriter != nil {
+		if err := d.reportWriter.Write(ctx, cycleReport); err != nil {
+			klog.ErrorS(err, "Failed to write descheduling report", "cycle", cycleReport.Cycle)
+		}
+	}
+}
+
 func podAssignedToNodeAdaptor(fn PodAssignedToNodeFn) framework.GetPodsAssignedToNodeFunc {
 	return func(nodeName string, filterFunc framework.FilterFunc) ([]*corev1.Pod, error) {
 		if fn == nil {
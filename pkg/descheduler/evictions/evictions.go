@@ -38,6 +38,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
@@ -124,23 +125,31 @@ func (pe *PodEvictor) Evict(ctx context.Context, pod *corev1.Pod, opts framework
 	if pe.NodeLimitExceeded(nodeName) {
 		metrics.PodsEvicted.With(map[string]string{"result": "maximum number of pods per node reached", "strategy": opts.PluginName, "namespace": pod.Namespace, "node": nodeName}).Inc()
 		klog.ErrorS(fmt.Errorf("maximum number of evicted pods per node reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", nodeName)
+		report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonLimiter, "maximum number of pods per node reached")
 		return false
 	}
 
 	if pe.NamespaceLimitExceeded(pod.Namespace) {
 		metrics.PodsEvicted.With(map[string]string{"result": "maximum number of pods per namespace reached", "strategy": opts.PluginName, "namespace": pod.Namespace, "node": nodeName}).Inc()
 		klog.ErrorS(fmt.Errorf("maximum number of evicted pods per namespace reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace)
+		report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonLimiter, "maximum number of pods per namespace reached")
 		return false
 	}
 
 	if pe.dryRun {
 		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.PluginName, "node", nodeName)
+		report.RecordPod(ctx, pod, report.PodWouldEvict, "", opts.Reason)
 	} else {
 		err := EvictPod(ctx, pe.client, pod, pe.policyGroupVersion, opts.DeleteOptions)
 		if err != nil {
 			// err is used only for logging purposes
 			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason)
 			metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": opts.PluginName, "namespace": pod.Namespace, "node": nodeName}).Inc()
+			if apierrors.IsTooManyRequests(err) {
+				report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonPDB, err.Error())
+			} else {
+				report.RecordPod(ctx, pod, report.PodEvictFailed, "", err.Error())
+			}
 			return false
 		}
 
@@ -158,6 +167,7 @@ func (pe *PodEvictor) Evict(ctx context.Context, pod *corev1.Pod, opts framework
 
 		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.PluginName, "node", nodeName)
 		pe.eventRecorder.Eventf(pod, nil, corev1.EventTypeNormal, "Descheduled", "Evicting", "pod evicted by %s", opts.Reason)
+		report.RecordPod(ctx, pod, report.PodEvicted, "", opts.Reason)
 	}
 	return true
 }
@@ -184,7 +194,7 @@ func EvictPod(ctx context.Context, client clientset.Interface, pod *corev1.Pod,
 		err = client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
 	}
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
 		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
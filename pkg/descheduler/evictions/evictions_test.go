@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,6 +35,7 @@ import (
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
 )
@@ -872,3 +874,33 @@ func TestPodEvictor(t *testing.T) {
 		assert.Equal(t, 1, podEvictor.TotalEvicted())
 	})
 }
+
+func TestPodEvictorReport(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+		}
+		return false, nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, record.NewEventRecorderAdapter(record.NewFakeRecorder(1024)), "", false, nil, nil)
+
+	recorder := report.NewRecorder(1, false)
+	ctx := framework.PluginNameWithContext(report.WithRecorder(context.TODO(), recorder), "test")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node-1",
+		},
+	}
+	assert.False(t, podEvictor.Evict(ctx, pod, framework.EvictOptions{}))
+
+	cycleReport := recorder.Complete()
+	assert.Len(t, cycleReport.Pods, 1)
+	assert.Equal(t, report.PodSkipped, cycleReport.Pods[0].Result)
+	assert.Equal(t, report.SkipReasonPDB, cycleReport.Pods[0].SkipReason)
+	assert.Equal(t, "test", cycleReport.Pods[0].Plugin)
+}
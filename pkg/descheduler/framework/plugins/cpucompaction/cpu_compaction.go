@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpucompaction
+
+import (
+	"context"
+	"fmt"
+
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	nrtlisters "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+const (
+	CPUCompactionName = "CPUCompaction"
+)
+
+var _ framework.BalancePlugin = &CPUCompaction{}
+
+// CPUCompaction migrates the CPU-bound LSE/LSR pods to defragment the CPUs of the nodes, so that the
+// FullPCPUs and NUMA exclusive pods can fit. The fragmentation is computed from the cpu-topology and
+// pod-cpu-allocs annotations of NodeResourceTopology, and the pods are migrated by ReservationFirst
+// PodMigrationJobs reserving the resources on the nodes where they can be placed compactly.
+type CPUCompaction struct {
+	handle       framework.Handle
+	podFilter    framework.FilterFunc
+	nodeSelector labels.Selector
+	nrtLister    nrtlisters.NodeResourceTopologyLister
+	args         *deschedulerconfig.CPUCompactionArgs
+}
+
+// NewCPUCompaction builds plugin from its arguments while passing a handle
+func NewCPUCompaction(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	compactionArgs, ok := args.(*deschedulerconfig.CPUCompactionArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type CPUCompactionArgs, got %T", args)
+	}
+	if err := validation.ValidateCPUCompactionArgs(nil, compactionArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if compactionArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(compactionArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(compactionArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nodeSelector := labels.Everything()
+	if compactionArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(compactionArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nrtClient, ok := handle.(nrtclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		nrtClient, err = nrtclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nrtInformerFactory := nrtinformers.NewSharedInformerFactoryWithOptions(nrtClient, 0)
+	nrtInformer := nrtInformerFactory.Topology().V1alpha1().NodeResourceTopologies()
+	nrtInformer.Informer()
+	nrtInformerFactory.Start(context.TODO().Done())
+	nrtInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &CPUCompaction{
+		handle:       handle,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		nrtLister:    nrtInformer.Lister(),
+		args:         compactionArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *CPUCompaction) Name() string {
+	return CPUCompactionName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *CPUCompaction) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("CPUCompaction is paused and will do nothing.")
+		return nil
+	}
+
+	var states []*nodeState
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		state, err := pl.getNodeState(node)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get CPU allocation of node", "node", klog.KObj(node))
+			continue
+		}
+		if state != nil {
+			states = append(states, state)
+		}
+	}
+
+	plans := planCompaction(states, pl.args)
+	if len(plans) == 0 {
+		klog.V(4).InfoS("None of the nodes need CPU compaction, nothing to do here")
+		return nil
+	}
+	for _, plan := range plans {
+		reason := fmt.Sprintf("compact CPUs of node %s, fragmented CPUs %d/%d -> %d/%d, NUMA gap %d -> %d",
+			plan.source, plan.before.fragmentedCPUs, plan.before.freeCPUs, plan.after.fragmentedCPUs, plan.after.freeCPUs,
+			plan.before.numaGap, plan.after.numaGap)
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(plan.pod), "node", plan.source, "targets", plan.targets, "reason", reason)
+			continue
+		}
+		evictCtx := migration.WithContext(ctx, newJobContext(plan))
+		if !pl.handle.Evictor().Evict(evictCtx, plan.pod, framework.EvictOptions{Reason: reason}) {
+			klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(plan.pod), "node", plan.source)
+			continue
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(plan.pod), "node", plan.source, "targets", plan.targets, "reason", reason)
+	}
+	return nil
+}
+
+// getNodeState returns the CPU allocation of the node, or nil if the node does not report its CPU topology.
+func (pl *CPUCompaction) getNodeState(node *corev1.Node) (*nodeState, error) {
+	nrt, err := pl.nrtLister.Get(node.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	topology, err := apiext.GetCPUTopology(nrt.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cpu topology, %w", err)
+	}
+	if len(topology.Detail) == 0 {
+		return nil, nil
+	}
+	kubeletPolicy, err := apiext.GetKubeletCPUManagerPolicy(nrt.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubelet cpu manager policy, %w", err)
+	}
+	reserved, err := cpuset.Parse(kubeletPolicy.ReservedCPUs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet reserved cpus, %w", err)
+	}
+	if reservedCPUs, _ := apiext.GetReservedCPUs(nrt.Annotations); reservedCPUs != "" {
+		cpus, err := cpuset.Parse(reservedCPUs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reserved cpus, %w", err)
+		}
+		reserved = reserved.Union(cpus)
+	}
+
+	podCPUAllocs, err := apiext.GetPodCPUAllocs(nrt.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod cpu allocs, %w", err)
+	}
+	allocated := cpuset.NewCPUSet()
+	for _, alloc := range podCPUAllocs {
+		cpus, err := cpuset.Parse(alloc.CPUSet)
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse cpuset of pod", "pod", klog.KRef(alloc.Namespace, alloc.Name), "node", klog.KObj(node), "err", err)
+			continue
+		}
+		allocated = allocated.Union(cpus)
+	}
+
+	state := &nodeState{
+		node:          node,
+		topology:      newCPUTopology(topology, reserved),
+		fullPCPUsOnly: apiext.GetNodeCPUBindPolicy(node.Labels, kubeletPolicy) == apiext.NodeCPUBindPolicyFullPCPUsOnly,
+	}
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods, %w", err)
+	}
+	for _, pod := range pods {
+		cpus, err := getPodCPUs(pod)
+		if err != nil {
+			klog.V(4).InfoS("Failed to get cpuset of pod", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		if cpus.IsEmpty() {
+			continue
+		}
+		allocated = allocated.Union(cpus)
+		qosClass := apiext.GetPodQoSClassWithDefault(pod)
+		if (qosClass != apiext.QoSLSE && qosClass != apiext.QoSLSR) || !pl.podFilter(pod) {
+			continue
+		}
+		resourceSpec, err := apiext.GetResourceSpec(pod.Annotations)
+		if err != nil {
+			continue
+		}
+		state.candidates = append(state.candidates, &podCPUs{
+			pod:       pod,
+			cpus:      cpus,
+			fullPCPUs: resourceSpec.PreferredCPUBindPolicy == apiext.CPUBindPolicyFullPCPUs || state.fullPCPUsOnly,
+		})
+	}
+	state.free = state.topology.cpus.Difference(allocated)
+	return state, nil
+}
+
+func getPodCPUs(pod *corev1.Pod) (cpuset.CPUSet, error) {
+	resourceStatus, err := apiext.GetResourceStatus(pod.Annotations)
+	if err != nil {
+		return cpuset.CPUSet{}, err
+	}
+	return cpuset.Parse(resourceStatus.CPUSet)
+}
+
+// newJobContext creates the context for the PodMigrationJob to reserve the resources on the target nodes first.
+func newJobContext(plan *migrationPlan) *migration.JobContext {
+	pod := plan.pod
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: *pod.ObjectMeta.DeepCopy(),
+		Spec:       *pod.Spec.DeepCopy(),
+	}
+	requireNodes(&template.Spec, plan.targets)
+	return &migration.JobContext{
+		Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			Template: &sev1alpha1.ReservationTemplateSpec{
+				Spec: sev1alpha1.ReservationSpec{
+					Template: template,
+				},
+			},
+		},
+	}
+}
+
+// requireNodes restricts the pod to be scheduled on the nodes by the required node affinity.
+func requireNodes(podSpec *corev1.PodSpec, nodeNames []string) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   nodeNames,
+	}
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchFields = append(terms[i].MatchFields, requirement)
+	}
+	if len(terms) == 0 {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = []corev1.NodeSelectorTerm{
+			{MatchFields: []corev1.NodeSelectorRequirement{requirement}},
+		}
+	}
+}
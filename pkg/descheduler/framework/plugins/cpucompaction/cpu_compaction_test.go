@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpucompaction
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtfake "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	nrtclientset.Interface
+	getPodsAssignedToNode framework.GetPodsAssignedToNodeFunc
+	evictor               *fakeEvictor
+}
+
+func (h *fakeFrameworkHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeFrameworkHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return h.getPodsAssignedToNode
+}
+
+// fakeEvictor records the evicted pods and the contexts of the PodMigrationJobs.
+type fakeEvictor struct {
+	evictedPods []string
+	jobContexts []*migration.JobContext
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool { return true }
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool { return true }
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.evictedPods = append(e.evictedPods, pod.Name)
+	e.jobContexts = append(e.jobContexts, migration.FromContext(ctx))
+	return true
+}
+
+func buildTestNRT(nodeName string, podCPUAllocs apiext.PodCPUAllocs) *nrtv1alpha1.NodeResourceTopology {
+	topologyData, _ := json.Marshal(buildCPUTopology(2, 2))
+	allocsData, _ := json.Marshal(podCPUAllocs)
+	return &nrtv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Annotations: map[string]string{
+				apiext.AnnotationNodeCPUTopology: string(topologyData),
+				apiext.AnnotationNodeCPUAllocs:   string(allocsData),
+			},
+		},
+	}
+}
+
+func buildTestCPUBoundPod(name, nodeName string, qos apiext.QoSClass, cpus string) *corev1.Pod {
+	return test.BuildTestPod(name, 1000, 0, nodeName, func(pod *corev1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.UID = types.UID(name)
+		pod.Labels = map[string]string{apiext.LabelPodQoS: string(qos)}
+		statusData, _ := json.Marshal(&apiext.ResourceStatus{CPUSet: cpus})
+		pod.Annotations = map[string]string{apiext.AnnotationResourceStatus: string(statusData)}
+	})
+}
+
+func TestCPUCompaction(t *testing.T) {
+	node1 := test.BuildTestNode("node-1", 8000, 16000, 20, nil)
+	node2 := test.BuildTestNode("node-2", 8000, 16000, 20, nil)
+	node3 := test.BuildTestNode("node-3", 8000, 16000, 20, nil)
+	pods := []*corev1.Pod{
+		buildTestCPUBoundPod("pod-a", node1.Name, apiext.QoSLSR, "0"),
+		buildTestCPUBoundPod("pod-b", node1.Name, apiext.QoSLSE, "2-3"),
+		buildTestCPUBoundPod("pod-c", node1.Name, apiext.QoSLSR, "4"),
+		buildTestCPUBoundPod("pod-ls", node1.Name, apiext.QoSLS, ""),
+	}
+	nrts := []runtime.Object{
+		buildTestNRT(node1.Name, nil),
+		buildTestNRT(node2.Name, apiext.PodCPUAllocs{
+			{Namespace: "default", Name: "kubelet-pod", UID: "kubelet-pod", CPUSet: "0-6", ManagedByKubelet: true},
+		}),
+		buildTestNRT(node3.Name, nil),
+	}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		nodes       []*corev1.Node
+		wantEvicted []string
+	}{
+		{
+			name:        "migrate the pod to the compacted placement",
+			nodes:       []*corev1.Node{node1, node2, node3},
+			wantEvicted: []string{"pod-c"},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			nodes:  []*corev1.Node{node1, node2, node3},
+		},
+		{
+			name:  "no node to place the pod compactly",
+			nodes: []*corev1.Node{node1, node3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tt.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			evictor := &fakeEvictor{}
+			pl, err := NewCPUCompaction(&deschedulerconfig.CPUCompactionArgs{
+				DryRun:                  tt.dryRun,
+				MaxFragmentedCPUPercent: 30,
+				NUMANodeCompaction:      true,
+				MaxMigratingPerNode:     2,
+				MaxTargetNodes:          3,
+			}, &fakeFrameworkHandle{
+				Interface:             nrtfake.NewSimpleClientset(nrts...),
+				getPodsAssignedToNode: getPodsAssignedToNode,
+				evictor:               evictor,
+			})
+			assert.NoError(t, err)
+
+			status := pl.(framework.BalancePlugin).Balance(ctx, tt.nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, evictor.evictedPods)
+			for _, jobCtx := range evictor.jobContexts {
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, jobCtx.Mode)
+				affinity := jobCtx.ReservationOptions.Template.Spec.Template.Spec.Affinity
+				expectedAffinity := &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{
+									MatchFields: []corev1.NodeSelectorRequirement{
+										{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{node2.Name}},
+									},
+								},
+							},
+						},
+					},
+				}
+				assert.Equal(t, expectedAffinity, affinity)
+			}
+		})
+	}
+}
+
+func TestRequireNodes(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	requireNodes(podSpec, []string{"node-1", "node-2"})
+	expected := []corev1.NodeSelectorTerm{
+		{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+			},
+			MatchFields: []corev1.NodeSelectorRequirement{
+				{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1", "node-2"}},
+			},
+		},
+	}
+	assert.Equal(t, expected, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
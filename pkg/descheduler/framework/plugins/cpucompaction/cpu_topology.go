@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpucompaction
+
+import (
+	"sort"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// cpuTopology describes the physical cores and the NUMA nodes of a node,
+// the reserved CPUs are excluded since they are never allocated to the pods.
+type cpuTopology struct {
+	cpus      cpuset.CPUSet
+	cores     []cpuset.CPUSet
+	numaNodes []cpuset.CPUSet
+	cpuToCore map[int]int
+	cpuToNUMA map[int]int
+	// coresWithReserved are the indexes of the cores having reserved CPUs, the cores can never be fully free.
+	coresWithReserved map[int]bool
+	// numaNodesWithReserved are the indexes of the NUMA nodes having reserved CPUs.
+	numaNodesWithReserved map[int]bool
+	cpusPerCore           int
+}
+
+func newCPUTopology(topology *apiext.CPUTopology, reserved cpuset.CPUSet) *cpuTopology {
+	type coreKey struct {
+		socket int32
+		core   int32
+	}
+	coreBuilders := map[coreKey]*cpuset.CPUSetBuilder{}
+	numaBuilders := map[int32]*cpuset.CPUSetBuilder{}
+	coresWithReserved := map[coreKey]bool{}
+	numaNodesWithReserved := map[int32]bool{}
+	cpusBuilder := cpuset.NewCPUSetBuilder()
+	for _, info := range topology.Detail {
+		key := coreKey{socket: info.Socket, core: info.Core}
+		if reserved.Contains(int(info.ID)) {
+			coresWithReserved[key] = true
+			numaNodesWithReserved[info.Node] = true
+			continue
+		}
+		if coreBuilders[key] == nil {
+			coreBuilders[key] = cpuset.NewCPUSetBuilder()
+		}
+		coreBuilders[key].Add(int(info.ID))
+		if numaBuilders[info.Node] == nil {
+			numaBuilders[info.Node] = cpuset.NewCPUSetBuilder()
+		}
+		numaBuilders[info.Node].Add(int(info.ID))
+		cpusBuilder.Add(int(info.ID))
+	}
+
+	t := &cpuTopology{
+		cpus:                  cpusBuilder.Result(),
+		cpuToCore:             map[int]int{},
+		cpuToNUMA:             map[int]int{},
+		coresWithReserved:     map[int]bool{},
+		numaNodesWithReserved: map[int]bool{},
+	}
+	coreKeys := make([]coreKey, 0, len(coreBuilders))
+	for key := range coreBuilders {
+		coreKeys = append(coreKeys, key)
+	}
+	sort.Slice(coreKeys, func(i, j int) bool {
+		if coreKeys[i].socket != coreKeys[j].socket {
+			return coreKeys[i].socket < coreKeys[j].socket
+		}
+		return coreKeys[i].core < coreKeys[j].core
+	})
+	for i, key := range coreKeys {
+		cpus := coreBuilders[key].Result()
+		t.cores = append(t.cores, cpus)
+		for _, cpu := range cpus.ToSliceNoSort() {
+			t.cpuToCore[cpu] = i
+		}
+		if coresWithReserved[key] {
+			t.coresWithReserved[i] = true
+		}
+		if cpus.Size() > t.cpusPerCore {
+			t.cpusPerCore = cpus.Size()
+		}
+	}
+	numaIDs := make([]int32, 0, len(numaBuilders))
+	for id := range numaBuilders {
+		numaIDs = append(numaIDs, id)
+	}
+	sort.Slice(numaIDs, func(i, j int) bool {
+		return numaIDs[i] < numaIDs[j]
+	})
+	for i, id := range numaIDs {
+		cpus := numaBuilders[id].Result()
+		t.numaNodes = append(t.numaNodes, cpus)
+		for _, cpu := range cpus.ToSliceNoSort() {
+			t.cpuToNUMA[cpu] = i
+		}
+		if numaNodesWithReserved[id] {
+			t.numaNodesWithReserved[i] = true
+		}
+	}
+	return t
+}
+
+// fragmentation describes how the free CPUs of a node are scattered.
+type fragmentation struct {
+	freeCPUs int
+	// fragmentedCPUs is the number of the free CPUs on the partially allocated physical cores,
+	// which cannot be allocated to the FullPCPUs pods.
+	fragmentedCPUs int
+	// numaGap is the minimum number of the CPUs to be released to make a NUMA node free,
+	// it is zero if any NUMA node is free or the free CPUs are not enough for a NUMA node.
+	numaGap int
+}
+
+func (t *cpuTopology) fragmentation(free cpuset.CPUSet, numaNodeCompaction bool) fragmentation {
+	f := fragmentation{freeCPUs: free.Size()}
+	for i, cpus := range t.cores {
+		if t.coresWithReserved[i] {
+			continue
+		}
+		freeInCore := cpus.Intersection(free).Size()
+		if freeInCore > 0 && freeInCore < cpus.Size() {
+			f.fragmentedCPUs += freeInCore
+		}
+	}
+	if !numaNodeCompaction {
+		return f
+	}
+	gap := -1
+	for i, cpus := range t.numaNodes {
+		if t.numaNodesWithReserved[i] || f.freeCPUs < cpus.Size() {
+			continue
+		}
+		allocated := cpus.Size() - cpus.Intersection(free).Size()
+		if allocated == 0 {
+			return f
+		}
+		if gap < 0 || allocated < gap {
+			gap = allocated
+		}
+	}
+	if gap > 0 {
+		f.numaGap = gap
+	}
+	return f
+}
+
+// cost is the number of the CPUs wasted by the fragmentation, the compaction tries to minimize it.
+func (f fragmentation) cost() int {
+	return f.fragmentedCPUs + f.numaGap
+}
+
+func (f fragmentation) isFragmented(maxFragmentedCPUPercent int32) bool {
+	return f.numaGap > 0 || f.fragmentedCPUs*100 > int(maxFragmentedCPUPercent)*f.freeCPUs
+}
+
+// allocate returns the free CPUs to place a pod requiring numCPUs compactly, and false if the pod cannot fit.
+// The CPUs are taken from a single NUMA node if possible, the NUMA node with the least free CPUs is preferred.
+// The FullPCPUs pods take only the fully free cores, other pods fill the partially allocated cores first.
+func (t *cpuTopology) allocate(free cpuset.CPUSet, numCPUs int, fullPCPUs bool) (cpuset.CPUSet, bool) {
+	if fullPCPUs && (t.cpusPerCore == 0 || numCPUs%t.cpusPerCore != 0) {
+		return cpuset.CPUSet{}, false
+	}
+	type numaCandidate struct {
+		index int
+		free  cpuset.CPUSet
+	}
+	var candidates []numaCandidate
+	for i, cpus := range t.numaNodes {
+		candidates = append(candidates, numaCandidate{index: i, free: cpus.Intersection(free)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].free.Size() < candidates[j].free.Size()
+	})
+	for _, c := range candidates {
+		if result, ok := t.takeCPUs(c.free, numCPUs, fullPCPUs); ok {
+			return result, true
+		}
+	}
+	return t.takeCPUs(free, numCPUs, fullPCPUs)
+}
+
+func (t *cpuTopology) takeCPUs(free cpuset.CPUSet, numCPUs int, fullPCPUs bool) (cpuset.CPUSet, bool) {
+	if free.Size() < numCPUs {
+		return cpuset.CPUSet{}, false
+	}
+	var partial, full []cpuset.CPUSet
+	for i, cpus := range t.cores {
+		freeInCore := cpus.Intersection(free)
+		if freeInCore.IsEmpty() {
+			continue
+		}
+		if freeInCore.Size() == cpus.Size() && !t.coresWithReserved[i] {
+			full = append(full, freeInCore)
+		} else if !fullPCPUs {
+			partial = append(partial, freeInCore)
+		}
+	}
+	sort.SliceStable(partial, func(i, j int) bool {
+		return partial[i].Size() < partial[j].Size()
+	})
+	builder := cpuset.NewCPUSetBuilder()
+	taken := 0
+	for _, cpus := range append(partial, full...) {
+		for _, cpu := range cpus.ToSlice() {
+			if taken == numCPUs {
+				break
+			}
+			builder.Add(cpu)
+			taken++
+		}
+	}
+	if taken < numCPUs {
+		return cpuset.CPUSet{}, false
+	}
+	return builder.Result(), true
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpucompaction
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// nodeState is the CPU allocation of a node.
+type nodeState struct {
+	node     *corev1.Node
+	topology *cpuTopology
+	free     cpuset.CPUSet
+	// fullPCPUsOnly indicates that the node only allocates the whole physical cores.
+	fullPCPUsOnly bool
+	// candidates are the LSE/LSR pods with bound CPUs which can be migrated.
+	candidates []*podCPUs
+}
+
+type podCPUs struct {
+	pod       *corev1.Pod
+	cpus      cpuset.CPUSet
+	fullPCPUs bool
+}
+
+type migrationPlan struct {
+	pod    *corev1.Pod
+	source string
+	// targets are the nodes the pod is expected to be placed on, in descending order of preference.
+	targets []string
+	before  fragmentation
+	after   fragmentation
+}
+
+// planCompaction selects a minimal set of pods to migrate from the fragmented nodes.
+// In each step, the pod whose removal reduces the fragmentation cost of the node most is selected,
+// as long as it can be placed compactly on the other nodes. The states are updated as the plans are made.
+func planCompaction(states []*nodeState, args *deschedulerconfig.CPUCompactionArgs) []*migrationPlan {
+	var sources []*nodeState
+	costs := map[*nodeState]int{}
+	isSource := map[*nodeState]bool{}
+	for _, state := range states {
+		f := state.topology.fragmentation(state.free, args.NUMANodeCompaction)
+		if f.isFragmented(args.MaxFragmentedCPUPercent) && len(state.candidates) > 0 {
+			sources = append(sources, state)
+			costs[state] = f.cost()
+			isSource[state] = true
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return costs[sources[i]] > costs[sources[j]]
+	})
+
+	var plans []*migrationPlan
+	for _, source := range sources {
+		excluded := map[*podCPUs]bool{}
+		for planned := 0; planned < int(args.MaxMigratingPerNode); {
+			current := source.topology.fragmentation(source.free, args.NUMANodeCompaction)
+			if !current.isFragmented(args.MaxFragmentedCPUPercent) {
+				break
+			}
+			var best *podCPUs
+			var bestAfter fragmentation
+			for _, candidate := range source.candidates {
+				if excluded[candidate] {
+					continue
+				}
+				after := source.topology.fragmentation(source.free.Union(candidate.cpus), args.NUMANodeCompaction)
+				if best == nil || after.cost() < bestAfter.cost() ||
+					(after.cost() == bestAfter.cost() && candidate.cpus.Size() < best.cpus.Size()) {
+					best, bestAfter = candidate, after
+				}
+			}
+			if best == nil || bestAfter.cost() >= current.cost() {
+				break
+			}
+			targets, target, allocated := selectTargets(states, isSource, best, args)
+			if len(targets) == 0 {
+				excluded[best] = true
+				continue
+			}
+			source.free = source.free.Union(best.cpus)
+			source.candidates = removeCandidate(source.candidates, best)
+			target.free = target.free.Difference(allocated)
+			plans = append(plans, &migrationPlan{
+				pod:     best.pod,
+				source:  source.node.Name,
+				targets: targets,
+				before:  current,
+				after:   bestAfter,
+			})
+			planned++
+		}
+	}
+	return plans
+}
+
+// selectTargets returns the nodes on which the pod can be placed without increasing their fragmentation,
+// the nodes with the least free CPUs after placing the pod are preferred. The best node and the CPUs
+// it would allocate are also returned.
+func selectTargets(states []*nodeState, isSource map[*nodeState]bool, pod *podCPUs, args *deschedulerconfig.CPUCompactionArgs) ([]string, *nodeState, cpuset.CPUSet) {
+	type target struct {
+		state     *nodeState
+		allocated cpuset.CPUSet
+		remaining int
+	}
+	var targets []target
+	for _, state := range states {
+		if isSource[state] {
+			continue
+		}
+		allocated, ok := state.topology.allocate(state.free, pod.cpus.Size(), pod.fullPCPUs || state.fullPCPUsOnly)
+		if !ok {
+			continue
+		}
+		remaining := state.free.Difference(allocated)
+		before := state.topology.fragmentation(state.free, args.NUMANodeCompaction)
+		after := state.topology.fragmentation(remaining, args.NUMANodeCompaction)
+		if after.cost() > before.cost() {
+			continue
+		}
+		targets = append(targets, target{state: state, allocated: allocated, remaining: remaining.Size()})
+	}
+	if len(targets) == 0 {
+		return nil, nil, cpuset.CPUSet{}
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		if targets[i].remaining != targets[j].remaining {
+			return targets[i].remaining < targets[j].remaining
+		}
+		return targets[i].state.node.Name < targets[j].state.node.Name
+	})
+	if len(targets) > int(args.MaxTargetNodes) {
+		targets = targets[:args.MaxTargetNodes]
+	}
+	nodeNames := make([]string, 0, len(targets))
+	for _, t := range targets {
+		nodeNames = append(nodeNames, t.state.node.Name)
+	}
+	return nodeNames, targets[0].state, targets[0].allocated
+}
+
+func removeCandidate(candidates []*podCPUs, pod *podCPUs) []*podCPUs {
+	r := make([]*podCPUs, 0, len(candidates))
+	for _, v := range candidates {
+		if v != pod {
+			r = append(r, v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpucompaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+// buildCPUTopology builds the topology with the CPUs 2c and 2c+1 on the core c,
+// and the cores are evenly distributed on the NUMA nodes.
+func buildCPUTopology(numaNodes, coresPerNUMANode int) *apiext.CPUTopology {
+	topology := &apiext.CPUTopology{}
+	for core := 0; core < numaNodes*coresPerNUMANode; core++ {
+		for thread := 0; thread < 2; thread++ {
+			topology.Detail = append(topology.Detail, apiext.CPUInfo{
+				ID:   int32(core*2 + thread),
+				Core: int32(core),
+				Node: int32(core / coresPerNUMANode),
+			})
+		}
+	}
+	return topology
+}
+
+func TestFragmentation(t *testing.T) {
+	topology := newCPUTopology(buildCPUTopology(2, 2), cpuset.NewCPUSet())
+	tests := []struct {
+		name               string
+		free               string
+		numaNodeCompaction bool
+		want               fragmentation
+	}{
+		{
+			name: "all free",
+			free: "0-7",
+			want: fragmentation{freeCPUs: 8},
+		},
+		{
+			name:               "free CPUs on partial cores",
+			free:               "1,5-7",
+			numaNodeCompaction: true,
+			want:               fragmentation{freeCPUs: 4, fragmentedCPUs: 2, numaGap: 1},
+		},
+		{
+			name: "ignore NUMA nodes",
+			free: "1,5-7",
+			want: fragmentation{freeCPUs: 4, fragmentedCPUs: 2},
+		},
+		{
+			name:               "free CPUs are not enough for a NUMA node",
+			free:               "1,5",
+			numaNodeCompaction: true,
+			want:               fragmentation{freeCPUs: 2, fragmentedCPUs: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := topology.fragmentation(cpuset.MustParse(tt.free), tt.numaNodeCompaction)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	reservedTopology := newCPUTopology(buildCPUTopology(2, 2), cpuset.NewCPUSet(0))
+	assert.Equal(t, fragmentation{freeCPUs: 1}, reservedTopology.fragmentation(cpuset.NewCPUSet(1), true))
+}
+
+func TestAllocate(t *testing.T) {
+	topology := newCPUTopology(buildCPUTopology(2, 2), cpuset.NewCPUSet())
+	tests := []struct {
+		name      string
+		free      string
+		numCPUs   int
+		fullPCPUs bool
+		want      string
+		wantOK    bool
+	}{
+		{
+			name:    "fill the partial cores first",
+			free:    "1,4-7",
+			numCPUs: 2,
+			want:    "4-5",
+			wantOK:  true,
+		},
+		{
+			name:    "prefer the NUMA node with least free CPUs",
+			free:    "1,3,4-7",
+			numCPUs: 2,
+			want:    "1,3",
+			wantOK:  true,
+		},
+		{
+			name:      "FullPCPUs takes the free cores",
+			free:      "1,3,4-7",
+			numCPUs:   2,
+			fullPCPUs: true,
+			want:      "4-5",
+			wantOK:    true,
+		},
+		{
+			name:      "FullPCPUs cannot fit",
+			free:      "1,3,5,7",
+			numCPUs:   2,
+			fullPCPUs: true,
+		},
+		{
+			name:    "across NUMA nodes",
+			free:    "1,3,5,7",
+			numCPUs: 3,
+			want:    "1,3,5",
+			wantOK:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := topology.allocate(cpuset.MustParse(tt.free), tt.numCPUs, tt.fullPCPUs)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestPlanCompaction(t *testing.T) {
+	args := &deschedulerconfig.CPUCompactionArgs{
+		MaxFragmentedCPUPercent: 30,
+		NUMANodeCompaction:      true,
+		MaxMigratingPerNode:     2,
+		MaxTargetNodes:          2,
+	}
+	newState := func(name, free string, candidates map[string]string) *nodeState {
+		state := &nodeState{
+			node:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			topology: newCPUTopology(buildCPUTopology(2, 2), cpuset.NewCPUSet()),
+			free:     cpuset.MustParse(free),
+		}
+		for podName, cpus := range candidates {
+			state.candidates = append(state.candidates, &podCPUs{
+				pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName}},
+				cpus: cpuset.MustParse(cpus),
+			})
+		}
+		return state
+	}
+
+	tests := []struct {
+		name        string
+		states      []*nodeState
+		wantPods    []string
+		wantTargets [][]string
+	}{
+		{
+			name: "node is not fragmented",
+			states: []*nodeState{
+				newState("node-1", "4-7", map[string]string{"pod-a": "0-3"}),
+				newState("node-2", "0-7", nil),
+			},
+		},
+		{
+			name: "migrate the pod releasing the most fragmented CPUs",
+			states: []*nodeState{
+				newState("node-1", "1,5-7", map[string]string{"pod-a": "0", "pod-b": "2-3", "pod-c": "4"}),
+				newState("node-2", "7", nil),
+				newState("node-3", "3,7", nil),
+				newState("node-4", "0-7", nil),
+			},
+			wantPods:    []string{"pod-c"},
+			wantTargets: [][]string{{"node-2", "node-3"}},
+		},
+		{
+			name: "no target to place the pod compactly",
+			states: []*nodeState{
+				newState("node-1", "1,5-7", map[string]string{"pod-a": "0", "pod-b": "2-3", "pod-c": "4"}),
+				newState("node-4", "0-7", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plans := planCompaction(tt.states, args)
+			var gotPods []string
+			var gotTargets [][]string
+			for _, plan := range plans {
+				gotPods = append(gotPods, plan.pod.Name)
+				gotTargets = append(gotTargets, plan.targets)
+			}
+			assert.Equal(t, tt.wantPods, gotPods)
+			assert.Equal(t, tt.wantTargets, gotTargets)
+		})
+	}
+}
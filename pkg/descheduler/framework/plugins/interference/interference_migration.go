@@ -0,0 +1,336 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/anomaly"
+)
+
+const (
+	InterferenceMigrationName = "InterferenceMigration"
+)
+
+var _ framework.BalancePlugin = &InterferenceMigration{}
+
+// InterferenceMigration migrates the pods to relieve the sustained interference suffered by the LS pods,
+// which is reflected by the contention indicators of the pods in NodeMetric.
+// Either the BE antagonists or the interfered LS pods are migrated according to the EvictionTarget.
+type InterferenceMigration struct {
+	handle              framework.Handle
+	podFilter           framework.FilterFunc
+	nodeSelector        labels.Selector
+	nodeMetricLister    koordslolisters.NodeMetricLister
+	args                *deschedulerconfig.InterferenceMigrationArgs
+	podAnomalyDetectors *gocache.Cache
+}
+
+// NewInterferenceMigration builds plugin from its arguments while passing a handle
+func NewInterferenceMigration(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	interferenceArgs, ok := args.(*deschedulerconfig.InterferenceMigrationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InterferenceMigrationArgs, got %T", args)
+	}
+	if err := validation.ValidateInterferenceMigrationArgs(nil, interferenceArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if interferenceArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nodeSelector := labels.Everything()
+	if interferenceArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(interferenceArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+	koordSharedInformerFactory.Start(context.TODO().Done())
+	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &InterferenceMigration{
+		handle:              handle,
+		podFilter:           podFilter,
+		nodeSelector:        nodeSelector,
+		nodeMetricLister:    nodeMetricInformer.Lister(),
+		args:                interferenceArgs,
+		podAnomalyDetectors: gocache.New(5*time.Minute, 5*time.Minute),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *InterferenceMigration) Name() string {
+	return InterferenceMigrationName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *InterferenceMigration) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("InterferenceMigration is paused and will do nothing.")
+		return nil
+	}
+
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if err := pl.processNode(ctx, node); err != nil {
+			klog.ErrorS(err, "Failed to process node for interference", "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// interferedPod is an LS pod whose contention indicators exceed the thresholds.
+type interferedPod struct {
+	pod     *corev1.Pod
+	reasons []string
+}
+
+func (pl *InterferenceMigration) processNode(ctx context.Context, node *corev1.Node) error {
+	nodeMetric, err := pl.nodeMetricLister.Get(node.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get NodeMetric, %w", err)
+	}
+	if len(nodeMetric.Status.PodsMetric) == 0 {
+		return nil
+	}
+	podMetrics := make(map[types.NamespacedName]*slov1alpha1.PodMetricInfo, len(nodeMetric.Status.PodsMetric))
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric
+	}
+
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to list pods, %w", err)
+	}
+
+	var victims []*interferedPod
+	for _, pod := range pods {
+		if !isLatencySensitive(pod) {
+			continue
+		}
+		reasons := exceededThresholds(podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}], &pl.args.Thresholds)
+		if len(reasons) == 0 {
+			pl.resetPodAsNormal(pod)
+			continue
+		}
+		if pl.markPodAsAbnormal(pod) {
+			victims = append(victims, &interferedPod{pod: pod, reasons: reasons})
+		}
+	}
+	if len(victims) == 0 {
+		klog.V(4).InfoS("None of the pods were detected as interfered, nothing to do here", "node", klog.KObj(node))
+		return nil
+	}
+	// the pods suffering more kinds of contention are more urgent
+	sort.SliceStable(victims, func(i, j int) bool {
+		return len(victims[i].reasons) > len(victims[j].reasons)
+	})
+
+	var candidates []*corev1.Pod
+	var evictionReason func(pod *corev1.Pod) string
+	switch pl.args.EvictionTarget {
+	case deschedulerconfig.InterferenceEvictionTargetVictim:
+		reasons := map[*corev1.Pod]string{}
+		for _, v := range victims {
+			candidates = append(candidates, v.pod)
+			reasons[v.pod] = strings.Join(v.reasons, ", ")
+		}
+		evictionReason = func(pod *corev1.Pod) string {
+			return fmt.Sprintf("pod suffers sustained interference, %s", reasons[pod])
+		}
+	default:
+		candidates = selectAntagonists(pods, podMetrics)
+		victim := victims[0]
+		evictionReason = func(pod *corev1.Pod) string {
+			return fmt.Sprintf("pod is the antagonist of the interfered pod %s, %s", klog.KObj(victim.pod), strings.Join(victim.reasons, ", "))
+		}
+	}
+
+	evicted := 0
+	for _, pod := range candidates {
+		if evicted >= int(pl.args.MaxEvictionsPerNode) {
+			break
+		}
+		if !pl.podFilter(pod) {
+			continue
+		}
+		reason := evictionReason(pod)
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+		} else {
+			if !pl.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{Reason: reason}) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+		}
+		evicted++
+	}
+	if evicted == 0 {
+		klog.V(4).InfoS("No pod can be migrated to relieve the interference", "node", klog.KObj(node), "evictionTarget", pl.args.EvictionTarget)
+		return nil
+	}
+	// wait for the interference to be sustained again before the next migration
+	for _, v := range victims {
+		pl.resetPodAsNormal(v.pod)
+	}
+	return nil
+}
+
+func isLatencySensitive(pod *corev1.Pod) bool {
+	switch apiext.GetPodQoSClassWithDefault(pod) {
+	case apiext.QoSLSE, apiext.QoSLSR, apiext.QoSLS:
+		return true
+	}
+	return false
+}
+
+// exceededThresholds returns the descriptions of the contention indicators of the pod exceeding the thresholds.
+func exceededThresholds(podMetric *slov1alpha1.PodMetricInfo, thresholds *deschedulerconfig.InterferenceThresholds) []string {
+	if podMetric == nil || podMetric.Contention == nil {
+		return nil
+	}
+	contention := podMetric.Contention
+	var reasons []string
+	check := func(name string, value, threshold *int64) {
+		if value != nil && threshold != nil && *value > *threshold {
+			reasons = append(reasons, fmt.Sprintf("%s(%d%%)>threshold(%d%%)", name, *value, *threshold))
+		}
+	}
+	check("cpu throttled", contention.CPUThrottledPercent, thresholds.CPUThrottledPercent)
+	if contention.PSI != nil {
+		check("cpu pressure", contention.PSI.CPU, thresholds.CPUPressurePercent)
+		check("memory pressure", contention.PSI.Memory, thresholds.MemoryPressurePercent)
+		check("io pressure", contention.PSI.IO, thresholds.IOPressurePercent)
+	}
+	check("cpi outlier", contention.CPIOutlierPercent, thresholds.CPIOutlierPercent)
+	return reasons
+}
+
+// selectAntagonists returns the BE pods on the node in descending order of the cpu usage,
+// the pods without metrics are considered as the least.
+func selectAntagonists(pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.PodMetricInfo) []*corev1.Pod {
+	type antagonist struct {
+		pod      *corev1.Pod
+		cpuUsage int64
+	}
+	var antagonists []antagonist
+	for _, pod := range pods {
+		if apiext.GetPodQoSClassWithDefault(pod) != apiext.QoSBE {
+			continue
+		}
+		var cpuUsage int64
+		if podMetric := podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; podMetric != nil {
+			cpuUsage = podMetric.PodUsage.ResourceList.Cpu().MilliValue()
+		}
+		antagonists = append(antagonists, antagonist{pod: pod, cpuUsage: cpuUsage})
+	}
+	sort.SliceStable(antagonists, func(i, j int) bool {
+		return antagonists[i].cpuUsage > antagonists[j].cpuUsage
+	})
+	r := make([]*corev1.Pod, 0, len(antagonists))
+	for _, v := range antagonists {
+		r = append(r, v.pod)
+	}
+	return r
+}
+
+func (pl *InterferenceMigration) resetPodAsNormal(pod *corev1.Pod) {
+	if obj, ok := pl.podAnomalyDetectors.Get(string(pod.UID)); ok {
+		anomalyDetector := obj.(anomaly.Detector)
+		anomalyDetector.Reset()
+	}
+}
+
+// markPodAsAbnormal marks the pod as interfered once, and returns true if the interference is sustained.
+func (pl *InterferenceMigration) markPodAsAbnormal(pod *corev1.Pod) bool {
+	anomalyCondition := pl.args.AnomalyCondition
+	if anomalyCondition == nil || anomalyCondition.ConsecutiveAbnormalities == 1 {
+		return true
+	}
+	key := string(pod.UID)
+	obj, ok := pl.podAnomalyDetectors.Get(key)
+	if !ok {
+		opts := anomaly.Options{
+			Timeout: anomalyCondition.Timeout.Duration,
+			NormalConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveNormalities > anomalyCondition.ConsecutiveNormalities
+			},
+			AnomalyConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveAbnormalities > anomalyCondition.ConsecutiveAbnormalities
+			},
+		}
+		obj = anomaly.NewBasicDetector(key, opts)
+	}
+	anomalyDetector := obj.(anomaly.Detector)
+	state, _ := anomalyDetector.Mark(false)
+	pl.podAnomalyDetectors.Set(key, anomalyDetector, gocache.DefaultExpiration)
+	return state == anomaly.StateAnomaly
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	koordinatorclientset.Interface
+}
+
+func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
+	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: policy.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+	fake.AddReactor("get", "resource", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+}
+
+func buildTestPodWithQoS(name string, cpu int64, nodeName string, qos apiext.QoSClass) *corev1.Pod {
+	return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *corev1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.UID = types.UID(name)
+		pod.Labels = map[string]string{apiext.LabelPodQoS: string(qos)}
+	})
+}
+
+func buildTestPodMetric(pod *corev1.Pod, cpuUsage int64, contention *slov1alpha1.PodContention) *slov1alpha1.PodMetricInfo {
+	podMetric := &slov1alpha1.PodMetricInfo{
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		Contention: contention,
+	}
+	podMetric.PodUsage.ResourceList = corev1.ResourceList{
+		corev1.ResourceCPU: *test.BuildTestPod("", cpuUsage, 0, "", nil).Spec.Containers[0].Resources.Requests.Cpu(),
+	}
+	return podMetric
+}
+
+func getEvictedPods(fakeClient *fake.Clientset) []string {
+	var evictedPods []string
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			createAction := action.(coretesting.CreateAction)
+			evictedPods = append(evictedPods, createAction.GetObject().(metav1.Object).GetName())
+		}
+	}
+	return evictedPods
+}
+
+func TestInterferenceMigration(t *testing.T) {
+	node := test.BuildTestNode("n1", 8000, 16000, 20, nil)
+	lsPod := buildTestPodWithQoS("ls-pod", 2000, node.Name, apiext.QoSLS)
+	lsPod2 := buildTestPodWithQoS("ls-pod-2", 2000, node.Name, apiext.QoSLS)
+	bePod1 := buildTestPodWithQoS("be-pod-1", 1000, node.Name, apiext.QoSBE)
+	bePod2 := buildTestPodWithQoS("be-pod-2", 1000, node.Name, apiext.QoSBE)
+	pods := []*corev1.Pod{lsPod, lsPod2, bePod1, bePod2}
+
+	interfered := &slov1alpha1.PodContention{
+		CPUThrottledPercent: pointer.Int64(30),
+		PSI: &slov1alpha1.PSIContention{
+			CPU: pointer.Int64(40),
+		},
+	}
+	notInterfered := &slov1alpha1.PodContention{
+		CPUThrottledPercent: pointer.Int64(10),
+		PSI: &slov1alpha1.PSIContention{
+			CPU: pointer.Int64(5),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		evictionTarget deschedulerconfig.InterferenceEvictionTarget
+		dryRun         bool
+		abnormalities  uint32
+		rounds         int
+		podMetrics     []*slov1alpha1.PodMetricInfo
+		wantEvicted    []string
+	}{
+		{
+			name:           "no pod interfered",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetAntagonist,
+			abnormalities:  1,
+			rounds:         1,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, notInterfered),
+				buildTestPodMetric(bePod1, 500, nil),
+				buildTestPodMetric(bePod2, 1000, nil),
+			},
+		},
+		{
+			name:           "migrate the BE antagonist with the highest usage",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetAntagonist,
+			abnormalities:  1,
+			rounds:         1,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, interfered),
+				buildTestPodMetric(bePod1, 500, nil),
+				buildTestPodMetric(bePod2, 1000, nil),
+			},
+			wantEvicted: []string{"be-pod-2"},
+		},
+		{
+			name:           "migrate the interfered LS pod",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetVictim,
+			abnormalities:  1,
+			rounds:         1,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, notInterfered),
+				buildTestPodMetric(lsPod2, 2000, interfered),
+				buildTestPodMetric(bePod1, 500, nil),
+			},
+			wantEvicted: []string{"ls-pod-2"},
+		},
+		{
+			name:           "dry run",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetAntagonist,
+			dryRun:         true,
+			abnormalities:  1,
+			rounds:         1,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, interfered),
+				buildTestPodMetric(bePod1, 500, nil),
+			},
+		},
+		{
+			name:           "interference is not sustained",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetAntagonist,
+			abnormalities:  3,
+			rounds:         3,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, interfered),
+				buildTestPodMetric(bePod1, 500, nil),
+			},
+		},
+		{
+			name:           "interference is sustained",
+			evictionTarget: deschedulerconfig.InterferenceEvictionTargetAntagonist,
+			abnormalities:  3,
+			rounds:         4,
+			podMetrics: []*slov1alpha1.PodMetricInfo{
+				buildTestPodMetric(lsPod, 2000, interfered),
+				buildTestPodMetric(bePod1, 500, nil),
+			},
+			wantEvicted: []string{"be-pod-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			koordClientSet := koordfake.NewSimpleClientset(&slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: node.Name},
+				Status: slov1alpha1.NodeMetricStatus{
+					PodsMetric: tt.podMetrics,
+				},
+			})
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(InterferenceMigrationName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewInterferenceMigration(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: InterferenceMigrationName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: InterferenceMigrationName,
+							Args: &deschedulerconfig.InterferenceMigrationArgs{
+								DryRun: tt.dryRun,
+								Thresholds: deschedulerconfig.InterferenceThresholds{
+									CPUThrottledPercent: pointer.Int64(20),
+									CPUPressurePercent:  pointer.Int64(20),
+								},
+								EvictionTarget:      tt.evictionTarget,
+								MaxEvictionsPerNode: 1,
+								AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+									ConsecutiveAbnormalities: tt.abnormalities,
+								},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil)),
+				frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			for i := 0; i < tt.rounds; i++ {
+				fh.RunBalancePlugins(ctx, []*corev1.Node{node})
+			}
+			assert.Equal(t, tt.wantEvicted, getEvictedPods(fakeClient))
+		})
+	}
+}
+
+func TestExceededThresholds(t *testing.T) {
+	thresholds := &deschedulerconfig.InterferenceThresholds{
+		CPUThrottledPercent: pointer.Int64(20),
+		IOPressurePercent:   pointer.Int64(10),
+	}
+	podMetric := &slov1alpha1.PodMetricInfo{
+		Contention: &slov1alpha1.PodContention{
+			CPUThrottledPercent: pointer.Int64(25),
+			PSI: &slov1alpha1.PSIContention{
+				CPU: pointer.Int64(90),
+				IO:  pointer.Int64(10),
+			},
+		},
+	}
+	assert.Equal(t, []string{"cpu throttled(25%)>threshold(20%)"}, exceededThresholds(podMetric, thresholds))
+	assert.Nil(t, exceededThresholds(&slov1alpha1.PodMetricInfo{}, thresholds))
+	assert.Nil(t, exceededThresholds(nil, thresholds))
+}
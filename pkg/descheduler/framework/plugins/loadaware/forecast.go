@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+// usageSample is the node usage reported by NodeMetric at the timestamp.
+type usageSample struct {
+	timestamp time.Time
+	usage     map[corev1.ResourceName]int64
+}
+
+// loadForecaster keeps the usage history of nodes and forecasts the node usage,
+// so that the nodes can be classified ahead of the predictable peaks.
+type loadForecaster struct {
+	args  *deschedulerconfig.LoadForecast
+	clock clock.Clock
+
+	lock    sync.Mutex
+	history map[string][]usageSample
+}
+
+func newLoadForecaster(args *deschedulerconfig.LoadForecast, clock clock.Clock) *loadForecaster {
+	return &loadForecaster{
+		args:    args,
+		clock:   clock,
+		history: map[string][]usageSample{},
+	}
+}
+
+// applyForecast records the current usage of nodes into the history and replaces the usage
+// with the forecasted usage if it is higher than the current usage.
+func (f *loadForecaster) applyForecast(nodeUsages map[string]*NodeUsage, resourceNames []corev1.ResourceName) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	now := f.clock.Now()
+	for _, nodeUsage := range nodeUsages {
+		f.record(nodeUsage, now)
+		forecast := f.forecast(nodeUsage, resourceNames, now)
+		for _, resourceName := range resourceNames {
+			forecasted, ok := forecast[resourceName]
+			if !ok {
+				continue
+			}
+			current := nodeUsage.usage[resourceName]
+			if current == nil || forecasted.Cmp(*current) > 0 {
+				nodeUsage.usage[resourceName] = forecasted
+				nodeUsage.forecasted = true
+			}
+		}
+		if nodeUsage.forecasted {
+			klog.V(4).InfoS("Node usage is forecasted to increase", "node", klog.KObj(nodeUsage.node), "policy", f.args.Policy, "horizon", f.args.Horizon.Duration)
+		}
+	}
+	f.prune(now)
+}
+
+// record appends the usage reported by NodeMetric into the history of the node.
+// The NodeMetric not updated since the last sample is ignored.
+func (f *loadForecaster) record(nodeUsage *NodeUsage, now time.Time) {
+	if f.args.Policy != deschedulerconfig.LoadForecastPolicyTrend {
+		return
+	}
+	updateTime := nodeUsage.nodeMetric.Status.UpdateTime
+	if updateTime == nil {
+		return
+	}
+	nodeName := nodeUsage.node.Name
+	samples := f.history[nodeName]
+	if len(samples) > 0 && !updateTime.Time.After(samples[len(samples)-1].timestamp) {
+		return
+	}
+	usage := make(map[corev1.ResourceName]int64, len(nodeUsage.usage))
+	for resourceName, quantity := range nodeUsage.usage {
+		usage[resourceName] = quantityValue(resourceName, quantity)
+	}
+	f.history[nodeName] = append(samples, usageSample{timestamp: updateTime.Time, usage: usage})
+}
+
+// prune drops the samples out of the history window.
+func (f *loadForecaster) prune(now time.Time) {
+	expiration := now.Add(-f.args.HistoryWindow.Duration)
+	for nodeName, samples := range f.history {
+		i := 0
+		for i < len(samples) && samples[i].timestamp.Before(expiration) {
+			i++
+		}
+		if i == len(samples) {
+			delete(f.history, nodeName)
+		} else if i > 0 {
+			f.history[nodeName] = append([]usageSample(nil), samples[i:]...)
+		}
+	}
+}
+
+func (f *loadForecaster) forecast(nodeUsage *NodeUsage, resourceNames []corev1.ResourceName, now time.Time) map[corev1.ResourceName]*resource.Quantity {
+	switch f.args.Policy {
+	case deschedulerconfig.LoadForecastPolicyTrend:
+		return forecastByTrend(f.history[nodeUsage.node.Name], resourceNames, now.Add(f.args.Horizon.Duration), int(f.args.MinSamples))
+	case deschedulerconfig.LoadForecastPolicyHourlyProfile:
+		return forecastByHourlyProfile(nodeUsage.nodeMetric, resourceNames, now, f.args.Horizon.Duration)
+	}
+	return nil
+}
+
+// forecastByTrend extrapolates the usage at the target time by the least squares linear fit of the samples.
+func forecastByTrend(samples []usageSample, resourceNames []corev1.ResourceName, target time.Time, minSamples int) map[corev1.ResourceName]*resource.Quantity {
+	if len(samples) < minSamples || len(samples) < 2 {
+		return nil
+	}
+	origin := samples[0].timestamp
+	forecast := map[corev1.ResourceName]*resource.Quantity{}
+	for _, resourceName := range resourceNames {
+		var n, sumX, sumY, sumXY, sumXX float64
+		for _, sample := range samples {
+			y, ok := sample.usage[resourceName]
+			if !ok {
+				continue
+			}
+			x := sample.timestamp.Sub(origin).Seconds()
+			n++
+			sumX += x
+			sumY += float64(y)
+			sumXY += x * float64(y)
+			sumXX += x * x
+		}
+		denominator := n*sumXX - sumX*sumX
+		if n < 2 || denominator == 0 {
+			continue
+		}
+		slope := (n*sumXY - sumX*sumY) / denominator
+		intercept := (sumY - slope*sumX) / n
+		value := intercept + slope*target.Sub(origin).Seconds()
+		forecast[resourceName] = newResourceQuantity(resourceName, int64(math.Max(0, math.Round(value))))
+	}
+	return forecast
+}
+
+// forecastByHourlyProfile returns the max usage of the hourly usage profile reported in NodeMetric
+// during the hours of [now, now+horizon].
+func forecastByHourlyProfile(nodeMetric *slov1alpha1.NodeMetric, resourceNames []corev1.ResourceName, now time.Time, horizon time.Duration) map[corev1.ResourceName]*resource.Quantity {
+	if nodeMetric.Status.NodeMetric == nil || len(nodeMetric.Status.NodeMetric.HourlyUsageProfile) == 0 {
+		return nil
+	}
+	currentHour := now.UTC().Hour()
+	hours := now.UTC().Add(horizon).Sub(now.UTC().Truncate(time.Hour)) / time.Hour
+	if hours > 23 {
+		hours = 23
+	}
+	forecast := map[corev1.ResourceName]*resource.Quantity{}
+	for _, hourlyUsage := range nodeMetric.Status.NodeMetric.HourlyUsageProfile {
+		if (int(hourlyUsage.Hour)-currentHour+24)%24 > int(hours) {
+			continue
+		}
+		usage := resourceUsageWithDevices(&hourlyUsage.Usage)
+		for _, resourceName := range resourceNames {
+			quantity, ok := usage[resourceName]
+			if !ok {
+				continue
+			}
+			if max, ok := forecast[resourceName]; !ok || quantity.Cmp(*max) > 0 {
+				q := quantity.DeepCopy()
+				forecast[resourceName] = &q
+			}
+		}
+	}
+	return forecast
+}
+
+func quantityValue(resourceName corev1.ResourceName, quantity *resource.Quantity) int64 {
+	if resourceName == corev1.ResourceCPU {
+		return quantity.MilliValue()
+	}
+	return quantity.Value()
+}
+
+func newResourceQuantity(resourceName corev1.ResourceName, value int64) *resource.Quantity {
+	switch resourceName {
+	case corev1.ResourceCPU:
+		return resource.NewMilliQuantity(value, resource.DecimalSI)
+	case corev1.ResourceMemory, corev1.ResourceEphemeralStorage, corev1.ResourceStorage:
+		return resource.NewQuantity(value, resource.BinarySI)
+	default:
+		return resource.NewQuantity(value, resource.DecimalSI)
+	}
+}
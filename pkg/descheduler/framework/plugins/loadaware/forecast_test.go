@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestForecastByTrend(t *testing.T) {
+	origin := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	newSamples := func(cpus ...int64) []usageSample {
+		var samples []usageSample
+		for i, cpu := range cpus {
+			samples = append(samples, usageSample{
+				timestamp: origin.Add(time.Duration(i) * time.Minute),
+				usage:     map[corev1.ResourceName]int64{corev1.ResourceCPU: cpu},
+			})
+		}
+		return samples
+	}
+	tests := []struct {
+		name       string
+		samples    []usageSample
+		target     time.Time
+		minSamples int
+		want       map[corev1.ResourceName]*resource.Quantity
+	}{
+		{
+			name:       "not enough samples",
+			samples:    newSamples(1000, 2000),
+			target:     origin.Add(10 * time.Minute),
+			minSamples: 3,
+		},
+		{
+			name:       "increasing trend",
+			samples:    newSamples(1000, 2000, 3000),
+			target:     origin.Add(10 * time.Minute),
+			minSamples: 3,
+			want: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewMilliQuantity(11000, resource.DecimalSI),
+			},
+		},
+		{
+			name:       "decreasing trend clamped at zero",
+			samples:    newSamples(3000, 2000, 1000),
+			target:     origin.Add(10 * time.Minute),
+			minSamples: 3,
+			want: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewMilliQuantity(0, resource.DecimalSI),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := forecastByTrend(tt.samples, []corev1.ResourceName{corev1.ResourceCPU}, tt.target, tt.minSamples)
+			assert.Equal(t, len(tt.want), len(got))
+			for resourceName, want := range tt.want {
+				assert.Equal(t, want.MilliValue(), got[resourceName].MilliValue(), resourceName)
+			}
+		})
+	}
+}
+
+func TestForecastByHourlyProfile(t *testing.T) {
+	nodeMetric := &slov1alpha1.NodeMetric{
+		Status: slov1alpha1.NodeMetricStatus{
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				HourlyUsageProfile: []slov1alpha1.HourlyUsage{
+					{Hour: 9, Usage: slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("80")}}},
+					{Hour: 10, Usage: slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}}},
+					{Hour: 11, Usage: slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("60")}}},
+					{Hour: 12, Usage: slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("90")}}},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		now     time.Time
+		horizon time.Duration
+		want    *resource.Quantity
+	}{
+		{
+			name:    "horizon within the current hour",
+			now:     time.Date(2026, 10, 1, 10, 10, 0, 0, time.UTC),
+			horizon: 15 * time.Minute,
+			want:    resource.NewQuantity(20, resource.DecimalSI),
+		},
+		{
+			name:    "horizon across the next hour",
+			now:     time.Date(2026, 10, 1, 10, 50, 0, 0, time.UTC),
+			horizon: 15 * time.Minute,
+			want:    resource.NewQuantity(60, resource.DecimalSI),
+		},
+		{
+			name:    "no profile of the hours",
+			now:     time.Date(2026, 10, 1, 20, 0, 0, 0, time.UTC),
+			horizon: 15 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := forecastByHourlyProfile(nodeMetric, []corev1.ResourceName{corev1.ResourceCPU}, tt.now, tt.horizon)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want.MilliValue(), got[corev1.ResourceCPU].MilliValue())
+		})
+	}
+}
+
+func TestLoadForecasterApplyForecast(t *testing.T) {
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	forecaster := newLoadForecaster(&deschedulerconfig.LoadForecast{
+		Policy:        deschedulerconfig.LoadForecastPolicyTrend,
+		Horizon:       metav1.Duration{Duration: 10 * time.Minute},
+		HistoryWindow: metav1.Duration{Duration: 30 * time.Minute},
+		MinSamples:    3,
+	}, fakeClock)
+	resourceNames := []corev1.ResourceName{corev1.ResourceCPU}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+
+	applyForecast := func(updateTime time.Time, cpu int64) *NodeUsage {
+		nodeUsage := &NodeUsage{
+			node: node,
+			nodeMetric: &slov1alpha1.NodeMetric{
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{Time: updateTime},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewMilliQuantity(cpu, resource.DecimalSI),
+			},
+		}
+		forecaster.applyForecast(map[string]*NodeUsage{node.Name: nodeUsage}, resourceNames)
+		return nodeUsage
+	}
+
+	nodeUsage := applyForecast(now, 1000)
+	assert.False(t, nodeUsage.forecasted)
+	fakeClock.Step(time.Minute)
+	nodeUsage = applyForecast(now.Add(time.Minute), 2000)
+	assert.False(t, nodeUsage.forecasted)
+
+	// the NodeMetric is not updated, the sample should not be recorded again
+	nodeUsage = applyForecast(now.Add(time.Minute), 2000)
+	assert.False(t, nodeUsage.forecasted)
+	assert.Len(t, forecaster.history[node.Name], 2)
+
+	fakeClock.Step(time.Minute)
+	nodeUsage = applyForecast(now.Add(2*time.Minute), 3000)
+	assert.True(t, nodeUsage.forecasted)
+	assert.Equal(t, int64(13000), nodeUsage.usage[corev1.ResourceCPU].MilliValue())
+
+	// the samples out of the history window are pruned
+	fakeClock.Step(time.Hour)
+	nodeUsage = applyForecast(now.Add(time.Hour), 1000)
+	assert.False(t, nodeUsage.forecasted)
+	assert.Len(t, forecaster.history[node.Name], 1)
+}
@@ -29,9 +29,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
@@ -57,6 +59,7 @@ type LowNodeLoad struct {
 	nodeMetricLister     koordslolisters.NodeMetricLister
 	args                 *deschedulerconfig.LowNodeLoadArgs
 	nodeAnomalyDetectors *gocache.Cache
+	forecaster           *loadForecaster
 }
 
 // NewLowNodeLoad builds plugin from its arguments while passing a handle
@@ -109,12 +112,18 @@ func NewLowNodeLoad(args runtime.Object, handle framework.Handle) (framework.Plu
 
 	nodeAnomalyDetectors := gocache.New(5*time.Minute, 5*time.Minute)
 
+	var forecaster *loadForecaster
+	if loadLoadUtilizationArgs.LoadForecast != nil {
+		forecaster = newLoadForecaster(loadLoadUtilizationArgs.LoadForecast, clock.RealClock{})
+	}
+
 	return &LowNodeLoad{
 		handle:               handle,
 		nodeMetricLister:     nodeMetricInformer.Lister(),
 		args:                 loadLoadUtilizationArgs,
 		podFilter:            podFilter,
 		nodeAnomalyDetectors: nodeAnomalyDetectors,
+		forecaster:           forecaster,
 	}, nil
 }
 
@@ -165,8 +174,12 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 	lowThresholds, highThresholds := newThresholds(nodePool.UseDeviationThresholds, nodePool.LowThresholds, nodePool.HighThresholds)
 	resourceNames := getResourceNames(lowThresholds)
 	nodeUsages := getNodeUsage(nodes, resourceNames, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc())
+	if pl.forecaster != nil {
+		pl.forecaster.applyForecast(nodeUsages, resourceNames)
+	}
 	nodeThresholds := getNodeThresholds(nodeUsages, lowThresholds, highThresholds, resourceNames, nodePool.UseDeviationThresholds)
 	lowNodes, sourceNodes := classifyNodes(nodeUsages, nodeThresholds, lowThresholdFilter, highThresholdFilter)
+	recordNodeClassifications(ctx, nodePool.Name, nodeUsages, lowNodes, sourceNodes)
 
 	logUtilizationCriteria(nodePool.Name, "Criteria for nodes under low thresholds and above high thresholds", lowThresholds, highThresholds, len(lowNodes), len(sourceNodes), len(nodes))
 
@@ -200,7 +213,8 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 	}
 
 	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) bool {
-		if _, overutilized := isNodeOverutilized(nodeInfo.NodeUsage.usage, nodeInfo.thresholds.highResourceThreshold); !overutilized {
+		if _, overutilized := isNodeOverutilized(nodeInfo.NodeUsage.usage, nodeInfo.thresholds.highResourceThreshold); !overutilized &&
+			!isGPUAllocatedButIdle(nodeInfo.NodeUsage, nodeInfo.thresholds) {
 			resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.nodeAnomalyDetectors)
 			return false
 		}
@@ -318,12 +332,16 @@ func lowThresholdFilter(usage *NodeUsage, threshold NodeThresholds) bool {
 		klog.V(4).InfoS("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(usage.node))
 		return false
 	}
+	if isGPUAllocatedButIdle(usage, threshold) {
+		klog.V(4).InfoS("Node has allocated but idle GPUs, thus not considered as underutilized", "node", klog.KObj(usage.node))
+		return false
+	}
 	return isNodeUnderutilized(usage.usage, threshold.lowResourceThreshold)
 }
 
 func highThresholdFilter(usage *NodeUsage, threshold NodeThresholds) bool {
 	_, overutilized := isNodeOverutilized(usage.usage, threshold.highResourceThreshold)
-	return overutilized
+	return overutilized || isGPUAllocatedButIdle(usage, threshold)
 }
 
 func filterNodes(nodeSelector *metav1.LabelSelector, nodes []*corev1.Node, processedNodes sets.String) ([]*corev1.Node, error) {
@@ -399,6 +417,12 @@ func overUtilizedEvictionReason(highThresholds deschedulerconfig.ResourceThresho
 				infos = append(infos, fmt.Sprintf("%s usage(%.2f%%)>threshold(%.2f%%)", resourceName, usagePercentages[resourceName], highThresholds[resourceName]))
 			}
 		}
+		if len(infos) == 0 && isGPUAllocatedButIdle(nodeInfo.NodeUsage, nodeInfo.thresholds) {
+			return fmt.Sprintf("node has allocated but idle GPUs, %s usage(%.2f%%)", apiext.ResourceGPUCore, usagePercentages[apiext.ResourceGPUCore])
+		}
+		if nodeInfo.forecasted {
+			return fmt.Sprintf("node is forecasted to be overutilized, %s", strings.Join(infos, ", "))
+		}
 		return fmt.Sprintf("node is overutilized, %s", strings.Join(infos, ", "))
 	}
 }
@@ -35,6 +35,8 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/events"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
@@ -128,6 +130,7 @@ func setupNodeMetrics(t *testing.T, koordClientSet koordinatorclientset.Interfac
 					q.Add(v)
 					nm.Status.NodeMetric.NodeUsage.ResourceList[k] = q
 				}
+				nm.Status.NodeMetric.NodeUsage.Devices = append(nm.Status.NodeMetric.NodeUsage.Devices, podUsage.Devices...)
 
 				nm.Status.PodsMetric = append(nm.Status.PodsMetric, &slov1alpha1.PodMetricInfo{
 					Namespace: v.Namespace,
@@ -229,6 +232,65 @@ func TestLowNodeLoad(t *testing.T) {
 			},
 			expectedPodsEvicted: 0,
 		},
+		{
+			name: "GPU overutilized node",
+			thresholds: ResourceThresholds{
+				apiext.ResourceGPUCore: 30,
+			},
+			targetThresholds: ResourceThresholds{
+				apiext.ResourceGPUCore: 50,
+			},
+			nodes: []*corev1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 9, withTestGPUs),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, withTestGPUs),
+				test.BuildTestNode(n3NodeName, 4000, 3000, 10, test.SetNodeUnschedulable),
+			},
+			pods: []*corev1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				// These won't be evicted since they don't use GPU.
+				test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p4", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p9", 400, 0, n2NodeName, test.SetRSOwnerRef),
+			},
+			podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+				{Namespace: "default", Name: "p1"}: buildTestGPUPodMetric(400, 0, 80),
+				{Namespace: "default", Name: "p2"}: buildTestGPUPodMetric(400, 1, 60),
+			},
+			expectedPodsEvicted: 1,
+		},
+		{
+			name: "GPU allocated but idle node",
+			thresholds: ResourceThresholds{
+				apiext.ResourceGPUCore: 30,
+			},
+			targetThresholds: ResourceThresholds{
+				apiext.ResourceGPUCore: 50,
+			},
+			nodes: []*corev1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 9, withTestGPUs),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, withTestGPUs),
+				test.BuildTestNode(n3NodeName, 4000, 3000, 10, test.SetNodeUnschedulable),
+			},
+			pods: []*corev1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1NodeName, func(pod *corev1.Pod) {
+					test.SetRSOwnerRef(pod)
+					test.SetPodExtendedResourceRequest(pod, apiext.ResourceGPUCore, 100)
+				}),
+				test.BuildTestPod("p2", 400, 0, n1NodeName, func(pod *corev1.Pod) {
+					test.SetRSOwnerRef(pod)
+					test.SetPodExtendedResourceRequest(pod, apiext.ResourceGPUCore, 50)
+				}),
+				// These won't be evicted since they don't allocate GPU.
+				test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p9", 400, 0, n2NodeName, test.SetRSOwnerRef),
+			},
+			podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+				{Namespace: "default", Name: "p1"}: buildTestGPUPodMetric(400, 0, 5),
+				{Namespace: "default", Name: "p2"}: buildTestGPUPodMetric(400, 1, 0),
+			},
+			expectedPodsEvicted: 2,
+		},
 		{
 			name: "without priorities",
 			thresholds: ResourceThresholds{
@@ -1060,6 +1122,7 @@ func TestOverUtilizedEvictionReason(t *testing.T) {
 		targetThresholds ResourceThresholds
 		node             *corev1.Node
 		usage            map[corev1.ResourceName]*resource.Quantity
+		forecasted       bool
 		want             string
 	}{
 		{
@@ -1108,13 +1171,38 @@ func TestOverUtilizedEvictionReason(t *testing.T) {
 			},
 			want: "node is overutilized, cpu usage(66.67%)>threshold(50.00%), memory usage(78.12%)>threshold(50.00%)",
 		},
+		{
+			name: "cpu forecasted to be overutilized",
+			targetThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    50,
+				corev1.ResourceMemory: 50,
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("96"),
+						corev1.ResourceMemory: resource.MustParse("512Gi"),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(64*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
+			},
+			forecasted: true,
+			want:       "node is forecasted to be overutilized, cpu usage(66.67%)>threshold(50.00%)",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			nodeUsage := &NodeUsage{
-				node:  tt.node,
-				usage: tt.usage,
+				node:       tt.node,
+				usage:      tt.usage,
+				forecasted: tt.forecasted,
 			}
 
 			resourceNames := getResourceNames(tt.targetThresholds)
@@ -1360,3 +1448,26 @@ func Test_filterRealAbnormalNodes(t *testing.T) {
 		})
 	}
 }
+
+func withTestGPUs(node *corev1.Node) {
+	node.Status.Allocatable[apiext.ResourceGPUCore] = *resource.NewQuantity(200, resource.DecimalSI)
+	node.Status.Allocatable[apiext.ResourceGPUMemoryRatio] = *resource.NewQuantity(200, resource.DecimalSI)
+}
+
+func buildTestGPUPodMetric(milliCPU int64, minor int32, gpuCoreUsage int64) *slov1alpha1.ResourceMap {
+	return &slov1alpha1.ResourceMap{
+		ResourceList: corev1.ResourceList{
+			corev1.ResourceCPU: *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+		},
+		Devices: []schedulingv1alpha1.DeviceInfo{
+			{
+				Type:  schedulingv1alpha1.GPU,
+				Minor: &minor,
+				Resources: corev1.ResourceList{
+					apiext.ResourceGPUCore:        *resource.NewQuantity(gpuCoreUsage, resource.DecimalSI),
+					apiext.ResourceGPUMemoryRatio: *resource.NewQuantity(gpuCoreUsage/2, resource.DecimalSI),
+				},
+			},
+		},
+	}
+}
@@ -26,13 +26,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 type Percentage = deschedulerconfig.Percentage
@@ -44,6 +48,10 @@ type NodeUsage struct {
 	usage      map[corev1.ResourceName]*resource.Quantity
 	allPods    []*corev1.Pod
 	podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap
+	// forecasted indicates the usage is replaced by the forecasted usage which is higher than the current usage.
+	forecasted bool
+	// allocatedGPUCore is the gpu-core requested by the pods on the node.
+	allocatedGPUCore *resource.Quantity
 }
 
 type NodeThresholds struct {
@@ -147,18 +155,12 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 			continue
 		}
 
+		nodeResourceUsage := resourceUsageWithDevices(&nodeMetric.Status.NodeMetric.NodeUsage)
 		usage := map[corev1.ResourceName]*resource.Quantity{}
 		for _, resourceName := range resourceNames {
-			usageQuantity, ok := nodeMetric.Status.NodeMetric.NodeUsage.ResourceList[resourceName]
+			usageQuantity, ok := nodeResourceUsage[resourceName]
 			if !ok {
-				switch resourceName {
-				case corev1.ResourceCPU:
-					usageQuantity = *resource.NewMilliQuantity(0, resource.DecimalSI)
-				case corev1.ResourceMemory, corev1.ResourceEphemeralStorage, corev1.ResourceStorage:
-					usageQuantity = *resource.NewQuantity(0, resource.BinarySI)
-				default:
-					usageQuantity = *resource.NewQuantity(0, resource.DecimalSI)
-				}
+				usageQuantity = *newResourceQuantity(resourceName, 0)
 			}
 			usage[resourceName] = &usageQuantity
 		}
@@ -166,21 +168,99 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 
 		podMetrics := make(map[types.NamespacedName]*slov1alpha1.ResourceMap)
 		for _, podMetric := range nodeMetric.Status.PodsMetric {
-			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric.PodUsage.DeepCopy()
+			podUsage := podMetric.PodUsage.DeepCopy()
+			podUsage.ResourceList = resourceUsageWithDevices(&podMetric.PodUsage)
+			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podUsage
+		}
+
+		allocatedGPUCore := resource.NewQuantity(0, resource.DecimalSI)
+		for _, pod := range pods {
+			allocatedGPUCore.Add(podGPUCoreRequest(pod))
 		}
 
 		nodeUsages[v.Name] = &NodeUsage{
-			node:       v,
-			nodeMetric: nodeMetric,
-			usage:      usage,
-			allPods:    pods,
-			podMetrics: podMetrics,
+			node:             v,
+			nodeMetric:       nodeMetric,
+			usage:            usage,
+			allPods:          pods,
+			podMetrics:       podMetrics,
+			allocatedGPUCore: allocatedGPUCore,
 		}
 	}
 
 	return nodeUsages
 }
 
+// gpuResourceNames are the GPU resources whose usage is reported by the GPU devices in NodeMetric.
+var gpuResourceNames = []corev1.ResourceName{
+	apiext.ResourceGPUCore,
+	apiext.ResourceGPUMemory,
+	apiext.ResourceGPUMemoryRatio,
+}
+
+// resourceUsageWithDevices returns the resource usage of the ResourceMap, the usage of the GPU resources is
+// summed up from the GPU devices if it is not reported in the ResourceList. Since the allocatable of the GPU
+// resources is the total of the GPU devices on the node, the thresholds apply to the usage of all the GPUs.
+func resourceUsageWithDevices(resourceMap *slov1alpha1.ResourceMap) corev1.ResourceList {
+	usage := resourceMap.ResourceList.DeepCopy()
+	if usage == nil {
+		usage = corev1.ResourceList{}
+	}
+	for _, resourceName := range gpuResourceNames {
+		if _, ok := resourceMap.ResourceList[resourceName]; ok {
+			continue
+		}
+		for _, device := range resourceMap.Devices {
+			if device.Type != schedulingv1alpha1.GPU {
+				continue
+			}
+			if quantity, ok := device.Resources[resourceName]; ok {
+				total := usage[resourceName]
+				total.Add(quantity)
+				usage[resourceName] = total
+			}
+		}
+	}
+	return usage
+}
+
+// podGPUCoreRequest returns the gpu-core requested by the pod, a nvidia.com/gpu is regarded as 100 gpu-core.
+func podGPUCoreRequest(pod *corev1.Pod) resource.Quantity {
+	requests := util.GetPodRequest(pod, apiext.ResourceGPUCore, apiext.ResourceNvidiaGPU)
+	if gpuCore, ok := requests[apiext.ResourceGPUCore]; ok {
+		return gpuCore
+	}
+	nvidiaGPU := requests[apiext.ResourceNvidiaGPU]
+	return *resource.NewQuantity(nvidiaGPU.Value()*100, resource.DecimalSI)
+}
+
+// isGPUAllocatedButIdle returns true if the GPUs of the node are allocated but the gpu-core usage is under
+// the low threshold, such node is regarded as a source node to consolidate the idle GPUs. It only works if
+// the low threshold of gpu-core is configured, i.e. the threshold is less than the allocatable.
+func isGPUAllocatedButIdle(usage *NodeUsage, threshold NodeThresholds) bool {
+	if usage.allocatedGPUCore == nil || usage.allocatedGPUCore.Sign() <= 0 {
+		return false
+	}
+	lowThreshold := threshold.lowResourceThreshold[apiext.ResourceGPUCore]
+	used := usage.usage[apiext.ResourceGPUCore]
+	if lowThreshold == nil || used == nil {
+		return false
+	}
+	allocatable := usage.node.Status.Allocatable[apiext.ResourceGPUCore]
+	if lowThreshold.Cmp(allocatable) >= 0 {
+		return false
+	}
+	return used.Cmp(*lowThreshold) <= 0
+}
+
+// consolidatesIdleGPU returns true if the node is a source node only because its GPUs are allocated but idle.
+func consolidatesIdleGPU(nodeInfo NodeInfo) bool {
+	if _, overutilized := isNodeOverutilized(nodeInfo.usage, nodeInfo.thresholds.highResourceThreshold); overutilized {
+		return false
+	}
+	return isGPUAllocatedButIdle(nodeInfo.NodeUsage, nodeInfo.thresholds)
+}
+
 // classifyNodes classifies the nodes into low-utilization or high-utilization nodes.
 // If a node lies between low and high thresholds, it is simply ignored.
 func classifyNodes(
@@ -207,6 +287,33 @@ func classifyNodes(
 	return lowNodes, highNodes
 }
 
+// recordNodeClassifications records the classifications of the nodes to the descheduling report.
+func recordNodeClassifications(ctx context.Context, nodePoolName string, nodeUsages map[string]*NodeUsage, lowNodes, highNodes []NodeInfo) {
+	if report.FromContext(ctx) == nil {
+		return
+	}
+	classifications := map[string]report.NodeClassification{}
+	for _, v := range lowNodes {
+		classifications[v.node.Name] = report.NodeUnderutilized
+	}
+	for _, v := range highNodes {
+		classifications[v.node.Name] = report.NodeOverutilized
+	}
+	nodeNames := make([]string, 0, len(nodeUsages))
+	for nodeName := range nodeUsages {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		nodeUsage := nodeUsages[nodeName]
+		classification, ok := classifications[nodeName]
+		if !ok {
+			classification = report.NodeAppropriatelyUtilized
+		}
+		report.RecordNode(ctx, nodePoolName, nodeUsage.node, classification, resourceUsagePercentages(nodeUsage))
+	}
+}
+
 func resourceUsagePercentages(nodeUsage *NodeUsage) map[corev1.ResourceName]float64 {
 	allocatable := nodeUsage.node.Status.Allocatable
 	resourceUsagePercentage := map[corev1.ResourceName]float64{}
@@ -265,15 +372,42 @@ func evictPodsFromSourceNodes(
 	}
 	klog.V(4).InfoS("Total capacity to be moved", keysAndValues...)
 
+	// filterWithReport is the only place recording the pods rejected by the filters, a pod is recorded
+	// at most once since evictPods only re-checks the pods which passed the filters of the source node.
+	// In dry run, evictPods is the only place recording the pods which would be evicted since the
+	// evictor is never called.
+	filterWithReport := func(pod *corev1.Pod) bool {
+		if !podFilter(pod) {
+			report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonFilter, "")
+			return false
+		}
+		return true
+	}
 	for _, srcNode := range sourceNodes {
+		gpuResources := overutilizedGPUResources(srcNode)
+		idleGPU := consolidatesIdleGPU(srcNode)
 		nonRemovablePods, removablePods := classifyPods(
 			srcNode.allPods,
-			podutil.WrapFilterFuncs(podFilter, func(pod *corev1.Pod) bool {
-				if !nodeFit {
-					return true
+			func(pod *corev1.Pod) bool {
+				if !filterWithReport(pod) {
+					return false
+				}
+				if idleGPU {
+					if gpuCore := podGPUCoreRequest(pod); gpuCore.Sign() <= 0 {
+						report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonFilter, "pod does not allocate the idle GPUs")
+						return false
+					}
 				}
-				return nodeutil.PodFitsAnyNode(nodeIndexer, pod, targetNodes)
-			}),
+				if !relievesGPUOverutilization(srcNode, gpuResources, pod) {
+					report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonFilter, "pod does not use the overutilized GPU resources")
+					return false
+				}
+				if nodeFit && !nodeutil.PodFitsAnyNode(nodeIndexer, pod, targetNodes) {
+					report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonNodeFit, "pod does not fit any underutilized node")
+					return false
+				}
+				return true
+			},
 		)
 		klog.V(4).InfoS("Evicting pods from node",
 			"nodePool", nodePoolName, "node", klog.KObj(srcNode.node), "usage", srcNode.usage,
@@ -284,13 +418,17 @@ func evictPodsFromSourceNodes(
 			continue
 		}
 
-		sorter.SortPodsByUsage(
-			removablePods,
-			srcNode.podMetrics,
-			map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable},
-			resourceWeights,
-		)
-		evictPods(ctx, nodePoolName, dryRun, removablePods, srcNode, totalAvailableUsages, podEvictor, podFilter, continueEviction, evictionReasonGenerator)
+		if idleGPU {
+			sortPodsByIdleGPU(removablePods, srcNode.podMetrics)
+		} else {
+			sorter.SortPodsByUsage(
+				removablePods,
+				srcNode.podMetrics,
+				map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable},
+				victimResourceWeights(resourceWeights, gpuResources),
+			)
+		}
+		evictPods(ctx, nodePoolName, dryRun, removablePods, srcNode, totalAvailableUsages, podEvictor, filterWithReport, continueEviction, evictionReasonGenerator)
 	}
 }
 
@@ -317,6 +455,7 @@ func evictPods(
 		}
 		if dryRun {
 			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+			report.RecordPod(ctx, pod, report.PodWouldEvict, "", evictionReasonGenerator(nodeInfo))
 		} else {
 			evictionOptions := framework.EvictOptions{
 				Reason: evictionReasonGenerator(nodeInfo),
@@ -345,6 +484,9 @@ func evictPods(
 				nodeUsage.Sub(quantity)
 			}
 		}
+		if nodeInfo.allocatedGPUCore != nil {
+			nodeInfo.allocatedGPUCore.Sub(podGPUCoreRequest(pod))
+		}
 
 		keysAndValues := []interface{}{
 			"node", nodeInfo.node.Name,
@@ -361,16 +503,96 @@ func evictPods(
 	}
 }
 
+// overutilizedGPUResources returns the GPU resources on which the node is overutilized. It returns nil if the node
+// is also overutilized on any other resource, since migrating any pod may relieve the node then.
+func overutilizedGPUResources(nodeInfo NodeInfo) []corev1.ResourceName {
+	overutilizedResources, _ := isNodeOverutilized(nodeInfo.usage, nodeInfo.thresholds.highResourceThreshold)
+	var gpuResources []corev1.ResourceName
+	for resourceName := range overutilizedResources {
+		if !isGPUResource(resourceName) {
+			return nil
+		}
+		gpuResources = append(gpuResources, resourceName)
+	}
+	return gpuResources
+}
+
+// relievesGPUOverutilization returns false if the node is only overutilized on the GPU resources but the pod
+// uses none of them, since migrating the pod cannot relieve the node.
+func relievesGPUOverutilization(nodeInfo NodeInfo, gpuResources []corev1.ResourceName, pod *corev1.Pod) bool {
+	if len(gpuResources) == 0 {
+		return true
+	}
+	podMetric := nodeInfo.podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+	if podMetric == nil {
+		return false
+	}
+	for _, resourceName := range gpuResources {
+		if quantity, ok := podMetric.ResourceList[resourceName]; ok && !quantity.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// victimResourceWeights returns the resource weights to sort the pods of the source node. If the node is only
+// overutilized on the GPU resources, the pods are sorted by the usage of these GPU resources, so that the pods
+// using the most of the hot GPUs are evicted first. The weights of the GPU resources default to 1.
+func victimResourceWeights(resourceWeights map[corev1.ResourceName]int64, gpuResources []corev1.ResourceName) map[corev1.ResourceName]int64 {
+	if len(gpuResources) == 0 {
+		return resourceWeights
+	}
+	weights := make(map[corev1.ResourceName]int64, len(gpuResources))
+	for _, resourceName := range gpuResources {
+		weights[resourceName] = 1
+		if weight := resourceWeights[resourceName]; weight > 0 {
+			weights[resourceName] = weight
+		}
+	}
+	return weights
+}
+
+// sortPodsByIdleGPU sorts the pods by the gpu-core allocated but unused, so that the pods wasting the most
+// of the GPUs are evicted first.
+func sortPodsByIdleGPU(pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap) {
+	idleGPUCore := func(pod *corev1.Pod) int64 {
+		idle := podGPUCoreRequest(pod)
+		if podMetric := podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; podMetric != nil {
+			idle.Sub(podMetric.ResourceList[apiext.ResourceGPUCore])
+		}
+		return idle.Value()
+	}
+	sorter.PodSorter(sorter.Reverse(func(p1, p2 *corev1.Pod) int {
+		p1Idle, p2Idle := idleGPUCore(p1), idleGPUCore(p2)
+		if p1Idle == p2Idle {
+			return 0
+		}
+		if p1Idle > p2Idle {
+			return 1
+		}
+		return -1
+	})).Sort(pods)
+}
+
+func isGPUResource(resourceName corev1.ResourceName) bool {
+	for _, v := range gpuResourceNames {
+		if v == resourceName {
+			return true
+		}
+	}
+	return false
+}
+
 // sortNodesByUsage sorts nodes based on usage.
 func sortNodesByUsage(nodes []NodeInfo, resourceToWeightMap map[corev1.ResourceName]int64, ascending bool) {
 	scorer := sorter.ResourceUsageScorer(resourceToWeightMap)
 	sort.Slice(nodes, func(i, j int) bool {
 		var iNodeUsage, jNodeUsage corev1.ResourceList
 		if nodeMetric := nodes[i].nodeMetric.Status.NodeMetric; nodeMetric != nil {
-			iNodeUsage = nodeMetric.NodeUsage.ResourceList
+			iNodeUsage = resourceUsageWithDevices(&nodeMetric.NodeUsage)
 		}
 		if nodeMetric := nodes[j].nodeMetric.Status.NodeMetric; nodeMetric != nil {
-			jNodeUsage = nodeMetric.NodeUsage.ResourceList
+			jNodeUsage = resourceUsageWithDevices(&nodeMetric.NodeUsage)
 		}
 
 		iScore := scorer(iNodeUsage, nodes[i].node.Status.Allocatable)
@@ -17,15 +17,23 @@ limitations under the License.
 package loadaware
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 )
 
 var (
@@ -188,3 +196,234 @@ func TestSortNodesByUsageAscendingOrder(t *testing.T) {
 
 	assert.Equal(t, expectedNodeList, nodeList)
 }
+
+func TestRecordNodeClassifications(t *testing.T) {
+	nodeUsages := map[string]*NodeUsage{
+		"node1": testNode1.NodeUsage,
+		"node2": testNode2.NodeUsage,
+		"node3": testNode3.NodeUsage,
+	}
+	recorder := report.NewRecorder(1, false)
+	ctx := framework.PluginNameWithContext(report.WithRecorder(context.TODO(), recorder), LowNodeLoadName)
+	recordNodeClassifications(ctx, "test-pool", nodeUsages, []NodeInfo{testNode2}, []NodeInfo{testNode1})
+
+	cycleReport := recorder.Complete()
+	assert.Len(t, cycleReport.Nodes, 3)
+	expected := []report.NodeClassification{report.NodeOverutilized, report.NodeUnderutilized, report.NodeAppropriatelyUtilized}
+	for i, record := range cycleReport.Nodes {
+		assert.Equal(t, LowNodeLoadName, record.Plugin)
+		assert.Equal(t, "test-pool", record.NodePool)
+		assert.Equal(t, fmt.Sprintf("node%d", i+1), record.Node)
+		assert.Equal(t, expected[i], record.Classification)
+		assert.NotEmpty(t, record.UsagePercentages)
+	}
+}
+
+func TestResourceUsageWithDevices(t *testing.T) {
+	podMetric := buildTestGPUPodMetric(1000, 0, 80)
+	gpu1 := buildTestGPUPodMetric(0, 1, 40)
+	podMetric.Devices = append(podMetric.Devices, gpu1.Devices...)
+
+	usage := resourceUsageWithDevices(podMetric)
+	expected := corev1.ResourceList{
+		corev1.ResourceCPU:            *resource.NewMilliQuantity(1000, resource.DecimalSI),
+		apiext.ResourceGPUCore:        *resource.NewQuantity(120, resource.DecimalSI),
+		apiext.ResourceGPUMemoryRatio: *resource.NewQuantity(60, resource.DecimalSI),
+	}
+	assert.True(t, equality.Semantic.DeepEqual(expected, usage), "expected %v, got %v", expected, usage)
+	_, ok := podMetric.ResourceList[apiext.ResourceGPUCore]
+	assert.False(t, ok, "the ResourceMap should not be modified")
+
+	// the usage reported in the ResourceList takes precedence
+	podMetric.ResourceList[apiext.ResourceGPUCore] = *resource.NewQuantity(100, resource.DecimalSI)
+	usage = resourceUsageWithDevices(podMetric)
+	gpuCore := usage[apiext.ResourceGPUCore]
+	assert.Equal(t, int64(100), gpuCore.Value())
+}
+
+func TestRelievesGPUOverutilization(t *testing.T) {
+	nodeInfo := NodeInfo{
+		NodeUsage: &NodeUsage{
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:     resource.NewMilliQuantity(1000, resource.DecimalSI),
+				apiext.ResourceGPUCore: resource.NewQuantity(150, resource.DecimalSI),
+			},
+			podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+				{Namespace: "default", Name: "gpu-pod"}: {
+					ResourceList: resourceUsageWithDevices(buildTestGPUPodMetric(500, 0, 80)),
+				},
+				{Namespace: "default", Name: "cpu-pod"}: {
+					ResourceList: corev1.ResourceList{corev1.ResourceCPU: *resource.NewMilliQuantity(500, resource.DecimalSI)},
+				},
+			},
+		},
+		thresholds: NodeThresholds{
+			highResourceThreshold: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:     resource.NewMilliQuantity(2000, resource.DecimalSI),
+				apiext.ResourceGPUCore: resource.NewQuantity(100, resource.DecimalSI),
+			},
+		},
+	}
+	gpuPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpu-pod"}}
+	cpuPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cpu-pod"}}
+	unknownPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unknown-pod"}}
+	gpuResources := overutilizedGPUResources(nodeInfo)
+	assert.Equal(t, []corev1.ResourceName{apiext.ResourceGPUCore}, gpuResources)
+	assert.True(t, relievesGPUOverutilization(nodeInfo, gpuResources, gpuPod))
+	assert.False(t, relievesGPUOverutilization(nodeInfo, gpuResources, cpuPod))
+	assert.False(t, relievesGPUOverutilization(nodeInfo, gpuResources, unknownPod))
+
+	// any pod may relieve the node overutilized on CPU
+	nodeInfo.thresholds.highResourceThreshold[corev1.ResourceCPU] = resource.NewMilliQuantity(500, resource.DecimalSI)
+	gpuResources = overutilizedGPUResources(nodeInfo)
+	assert.Nil(t, gpuResources)
+	assert.True(t, relievesGPUOverutilization(nodeInfo, gpuResources, cpuPod))
+	assert.True(t, relievesGPUOverutilization(nodeInfo, gpuResources, unknownPod))
+}
+
+func TestVictimResourceWeights(t *testing.T) {
+	resourceWeights := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:       1,
+		corev1.ResourceMemory:    1,
+		apiext.ResourceGPUMemory: 2,
+	}
+	assert.Equal(t, resourceWeights, victimResourceWeights(resourceWeights, nil))
+	expected := map[corev1.ResourceName]int64{
+		apiext.ResourceGPUCore:   1,
+		apiext.ResourceGPUMemory: 2,
+	}
+	assert.Equal(t, expected, victimResourceWeights(resourceWeights, []corev1.ResourceName{apiext.ResourceGPUCore, apiext.ResourceGPUMemory}))
+
+	// the pods using the most of the overutilized GPU resources are evicted first
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:     *resource.NewMilliQuantity(8000, resource.DecimalSI),
+		apiext.ResourceGPUCore: *resource.NewQuantity(200, resource.DecimalSI),
+	}
+	podMetrics := map[types.NamespacedName]*slov1alpha1.ResourceMap{
+		{Namespace: "default", Name: "cpu-heavy"}: {
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:     *resource.NewMilliQuantity(6000, resource.DecimalSI),
+				apiext.ResourceGPUCore: *resource.NewQuantity(10, resource.DecimalSI),
+			},
+		},
+		{Namespace: "default", Name: "gpu-heavy"}: {
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:     *resource.NewMilliQuantity(500, resource.DecimalSI),
+				apiext.ResourceGPUCore: *resource.NewQuantity(90, resource.DecimalSI),
+			},
+		},
+	}
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cpu-heavy"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpu-heavy"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+	}
+	sorter.SortPodsByUsage(pods, podMetrics, map[string]corev1.ResourceList{"node1": allocatable},
+		victimResourceWeights(resourceWeights, []corev1.ResourceName{apiext.ResourceGPUCore}))
+	assert.Equal(t, "gpu-heavy", pods[0].Name)
+}
+
+func TestIsGPUAllocatedButIdle(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				apiext.ResourceGPUCore: *resource.NewQuantity(200, resource.DecimalSI),
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		allocatedGPUCore int64
+		gpuCoreUsage     int64
+		lowThreshold     int64
+		want             bool
+	}{
+		{
+			name:             "allocated but idle",
+			allocatedGPUCore: 100,
+			gpuCoreUsage:     10,
+			lowThreshold:     60,
+			want:             true,
+		},
+		{
+			name:             "not allocated",
+			allocatedGPUCore: 0,
+			gpuCoreUsage:     0,
+			lowThreshold:     60,
+			want:             false,
+		},
+		{
+			name:             "allocated and used",
+			allocatedGPUCore: 100,
+			gpuCoreUsage:     80,
+			lowThreshold:     60,
+			want:             false,
+		},
+		{
+			name:             "low threshold not configured",
+			allocatedGPUCore: 100,
+			gpuCoreUsage:     10,
+			lowThreshold:     200,
+			want:             false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := &NodeUsage{
+				node: node,
+				usage: map[corev1.ResourceName]*resource.Quantity{
+					apiext.ResourceGPUCore: resource.NewQuantity(tt.gpuCoreUsage, resource.DecimalSI),
+				},
+				allocatedGPUCore: resource.NewQuantity(tt.allocatedGPUCore, resource.DecimalSI),
+			}
+			thresholds := NodeThresholds{
+				lowResourceThreshold: map[corev1.ResourceName]*resource.Quantity{
+					apiext.ResourceGPUCore: resource.NewQuantity(tt.lowThreshold, resource.DecimalSI),
+				},
+			}
+			assert.Equal(t, tt.want, isGPUAllocatedButIdle(usage, thresholds))
+		})
+	}
+}
+
+func TestSortPodsByIdleGPU(t *testing.T) {
+	newGPUPod := func(name string, gpuCore int64) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								apiext.ResourceGPUCore: *resource.NewQuantity(gpuCore, resource.DecimalSI),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newGPUPod("busy-pod", 100),
+		newGPUPod("idle-pod", 50),
+		newGPUPod("half-idle-pod", 100),
+	}
+	podMetrics := map[types.NamespacedName]*slov1alpha1.ResourceMap{
+		{Namespace: "default", Name: "busy-pod"}: {
+			ResourceList: resourceUsageWithDevices(buildTestGPUPodMetric(500, 0, 90)),
+		},
+		{Namespace: "default", Name: "idle-pod"}: {
+			ResourceList: resourceUsageWithDevices(buildTestGPUPodMetric(500, 1, 0)),
+		},
+		{Namespace: "default", Name: "half-idle-pod"}: {
+			ResourceList: resourceUsageWithDevices(buildTestGPUPodMetric(500, 2, 40)),
+		},
+	}
+	sortPodsByIdleGPU(pods, podMetrics)
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"half-idle-pod", "idle-pod", "busy-pod"}, names)
+}
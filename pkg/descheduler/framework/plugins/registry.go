@@ -17,14 +17,20 @@ limitations under the License.
 package plugins
 
 import (
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/cpucompaction"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/interference"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/topologyspread"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
 )
 
 func NewInTreeRegistry() runtime.Registry {
 	registry := runtime.Registry{
-		loadaware.LowNodeLoadName: loadaware.NewLowNodeLoad,
+		loadaware.LowNodeLoadName:                  loadaware.NewLowNodeLoad,
+		interference.InterferenceMigrationName:     interference.NewInterferenceMigration,
+		cpucompaction.CPUCompactionName:            cpucompaction.NewCPUCompaction,
+		topologyspread.TopologySpreadRebalanceName: topologyspread.NewTopologySpreadRebalance,
 	}
 	kubernetes.SetupK8sDeschedulerPlugins(registry)
 	return registry
@@ -0,0 +1,432 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspread
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	TopologySpreadRebalanceName = "TopologySpreadRebalance"
+)
+
+var _ framework.BalancePlugin = &TopologySpreadRebalance{}
+
+// TopologySpreadRebalance migrates the pods to restore the topology spread constraints with the koordinator
+// semantics. Unlike RemovePodsViolatingTopologySpreadConstraint, the members of a gang in the same topology
+// domain are migrated as a unit, the pods bound to reservations are never migrated, and the pods are migrated
+// by ReservationFirst PodMigrationJobs reserving the resources in the topology domains lacking pods.
+type TopologySpreadRebalance struct {
+	handle       framework.Handle
+	podFilter    framework.FilterFunc
+	nodeSelector labels.Selector
+	args         *deschedulerconfig.TopologySpreadRebalanceArgs
+}
+
+// NewTopologySpreadRebalance builds plugin from its arguments while passing a handle
+func NewTopologySpreadRebalance(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	rebalanceArgs, ok := args.(*deschedulerconfig.TopologySpreadRebalanceArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type TopologySpreadRebalanceArgs, got %T", args)
+	}
+	if err := validation.ValidateTopologySpreadRebalanceArgs(nil, rebalanceArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if rebalanceArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(rebalanceArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(rebalanceArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nodeSelector := labels.Everything()
+	if rebalanceArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(rebalanceArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TopologySpreadRebalance{
+		handle:       handle,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		args:         rebalanceArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *TopologySpreadRebalance) Name() string {
+	return TopologySpreadRebalanceName
+}
+
+// topologyConstraint is a topology spread constraint shared by the pods in a namespace.
+type topologyConstraint struct {
+	namespace   string
+	topologyKey string
+	maxSkew     int32
+	selector    labels.Selector
+}
+
+func (c *topologyConstraint) String() string {
+	return fmt.Sprintf("%s/%s(maxSkew=%d, selector=%s)", c.namespace, c.topologyKey, c.maxSkew, c.selector)
+}
+
+// podOnNode is a pod with the node it is assigned to.
+type podOnNode struct {
+	pod  *corev1.Pod
+	node *corev1.Node
+}
+
+// migrationUnit is a group of pods migrated together, it is either a single pod or
+// the members of a gang in the same topology domain.
+type migrationUnit struct {
+	gang string
+	pods []*corev1.Pod
+}
+
+// migrationPlan migrates the pods of the unit to the topology domain.
+type migrationPlan struct {
+	constraint *topologyConstraint
+	unit       *migrationUnit
+	source     string
+	target     string
+}
+
+// Balance extension point implementation for the plugin
+func (pl *TopologySpreadRebalance) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("TopologySpreadRebalance is paused and will do nothing.")
+		return nil
+	}
+
+	var selectedNodes []*corev1.Node
+	var pods []podOnNode
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		selectedNodes = append(selectedNodes, node)
+		nodePods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range nodePods {
+			if pod.DeletionTimestamp == nil {
+				pods = append(pods, podOnNode{pod: pod, node: node})
+			}
+		}
+	}
+
+	planned := sets.NewString()
+	var plans []*migrationPlan
+	for _, constraint := range pl.collectConstraints(pods) {
+		constraintPlans := pl.planConstraint(constraint, selectedNodes, pods, planned)
+		for _, plan := range constraintPlans {
+			for _, pod := range plan.unit.pods {
+				planned.Insert(string(pod.UID))
+			}
+		}
+		plans = append(plans, constraintPlans...)
+	}
+	if len(plans) == 0 {
+		klog.V(4).InfoS("None of the topology spread constraints are violated, nothing to do here")
+		return nil
+	}
+
+	for _, plan := range plans {
+		pl.migrateUnit(ctx, plan)
+	}
+	return nil
+}
+
+// migrateUnit evicts the pods of the unit in the plan. All the pods are checked by the evictor before
+// any of them is evicted, and the unit stops at the first failure, so a gang is not split by the
+// members rejected by the evictor.
+func (pl *TopologySpreadRebalance) migrateUnit(ctx context.Context, plan *migrationPlan) {
+	evictor := pl.handle.Evictor()
+	for _, pod := range plan.unit.pods {
+		if !evictor.Filter(pod) || !evictor.PreEvictionFilter(pod) {
+			klog.InfoS("Skip migrating the unit since the pod is rejected by the evictor", "pod", klog.KObj(pod), "gang", plan.unit.gang, "constraint", plan.constraint)
+			return
+		}
+	}
+	for _, pod := range plan.unit.pods {
+		reason := fmt.Sprintf("pod violates topology spread constraint %s, migrate from %s to %s", plan.constraint, plan.source, plan.target)
+		if plan.unit.gang != "" {
+			reason = fmt.Sprintf("%s together with gang %s", reason, plan.unit.gang)
+		}
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "reason", reason)
+			continue
+		}
+		evictCtx := migration.WithContext(ctx, newJobContext(pod, plan.constraint.topologyKey, plan.target))
+		if !evictor.Evict(evictCtx, pod, framework.EvictOptions{Reason: reason}) {
+			klog.InfoS("Failed to Evict Pod, stop migrating the unit", "pod", klog.KObj(pod), "reason", reason)
+			return
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "reason", reason)
+	}
+}
+
+// collectConstraints returns the distinct topology spread constraints declared by the pods.
+func (pl *TopologySpreadRebalance) collectConstraints(pods []podOnNode) []*topologyConstraint {
+	seen := sets.NewString()
+	var constraints []*topologyConstraint
+	for _, p := range pods {
+		for _, c := range p.pod.Spec.TopologySpreadConstraints {
+			if c.WhenUnsatisfiable != corev1.DoNotSchedule && !pl.args.IncludeSoftConstraints {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+			if err != nil {
+				klog.V(4).InfoS("Failed to parse label selector of topology spread constraint", "pod", klog.KObj(p.pod), "err", err)
+				continue
+			}
+			constraint := &topologyConstraint{
+				namespace:   p.pod.Namespace,
+				topologyKey: c.TopologyKey,
+				maxSkew:     c.MaxSkew,
+				selector:    selector,
+			}
+			if key := constraint.String(); !seen.Has(key) {
+				seen.Insert(key)
+				constraints = append(constraints, constraint)
+			}
+		}
+	}
+	return constraints
+}
+
+// planConstraint moves the migration units from the most populated topology domain to the least populated one,
+// until the skew is within the maxSkew, or none of the units can be moved to reduce the skew.
+// The constraints matching the pods planned by the previous constraints are not planned in this round.
+func (pl *TopologySpreadRebalance) planConstraint(constraint *topologyConstraint, nodes []*corev1.Node, pods []podOnNode, planned sets.String) []*migrationPlan {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		if domain, ok := node.Labels[constraint.topologyKey]; ok {
+			counts[domain] = 0
+		}
+	}
+	domainPods := map[string][]*corev1.Pod{}
+	for _, p := range pods {
+		domain, ok := p.node.Labels[constraint.topologyKey]
+		if !ok {
+			continue
+		}
+		if p.pod.Namespace != constraint.namespace || !constraint.selector.Matches(labels.Set(p.pod.Labels)) {
+			continue
+		}
+		// the counts are stale once the pods are planned to be migrated by the previous constraints,
+		// and the domains they will be placed in are unknown for other topology keys, so the constraint
+		// is left to the next round
+		if planned.Has(string(p.pod.UID)) {
+			klog.V(4).InfoS("Defer the topology spread constraint sharing pods with the planned migrations", "constraint", constraint, "pod", klog.KObj(p.pod))
+			return nil
+		}
+		counts[domain]++
+		domainPods[domain] = append(domainPods[domain], p.pod)
+	}
+	if len(counts) < 2 {
+		return nil
+	}
+	units := map[string][]*migrationUnit{}
+	for domain, pods := range domainPods {
+		units[domain] = pl.buildMigrationUnits(pods, planned)
+	}
+
+	var plans []*migrationPlan
+	migrating := 0
+	for {
+		source, target := mostAndLeastPopulated(counts)
+		skew := counts[source] - counts[target]
+		if skew <= int(constraint.maxSkew) {
+			break
+		}
+		// moving a unit no smaller than the skew does not make the domains more balanced
+		index := -1
+		for i, unit := range units[source] {
+			if len(unit.pods) < skew {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			klog.V(4).InfoS("No pod can be migrated to restore topology spread", "constraint", constraint, "domain", source, "skew", skew)
+			break
+		}
+		unit := units[source][index]
+		if migrating+len(unit.pods) > int(pl.args.MaxMigratingPerConstraint) {
+			break
+		}
+		units[source] = append(units[source][:index], units[source][index+1:]...)
+		counts[source] -= len(unit.pods)
+		counts[target] += len(unit.pods)
+		migrating += len(unit.pods)
+		plans = append(plans, &migrationPlan{constraint: constraint, unit: unit, source: source, target: target})
+	}
+	return plans
+}
+
+// buildMigrationUnits groups the pods of a topology domain into the migration units, the smaller units are
+// in the front. A gang is migrated only if all of its members in the domain can be migrated.
+func (pl *TopologySpreadRebalance) buildMigrationUnits(pods []*corev1.Pod, planned sets.String) []*migrationUnit {
+	var units []*migrationUnit
+	gangs := map[string]*migrationUnit{}
+	immovableGangs := sets.NewString()
+	for _, pod := range pods {
+		movable := pl.isMovable(pod, planned)
+		gangName := apiext.GetGangName(pod)
+		if gangName == "" {
+			if movable {
+				units = append(units, &migrationUnit{pods: []*corev1.Pod{pod}})
+			}
+			continue
+		}
+		if !movable {
+			immovableGangs.Insert(gangName)
+			continue
+		}
+		unit := gangs[gangName]
+		if unit == nil {
+			unit = &migrationUnit{gang: fmt.Sprintf("%s/%s", pod.Namespace, gangName)}
+			gangs[gangName] = unit
+		}
+		unit.pods = append(unit.pods, pod)
+	}
+	for gangName, unit := range gangs {
+		if !immovableGangs.Has(gangName) {
+			sort.Slice(unit.pods, func(i, j int) bool {
+				return unit.pods[i].Name < unit.pods[j].Name
+			})
+			units = append(units, unit)
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		if len(units[i].pods) != len(units[j].pods) {
+			return len(units[i].pods) < len(units[j].pods)
+		}
+		return units[i].pods[0].Name < units[j].pods[0].Name
+	})
+	return units
+}
+
+// isMovable returns true if the pod can be migrated, the pods bound to reservations
+// keep their reserved resources and are never migrated.
+func (pl *TopologySpreadRebalance) isMovable(pod *corev1.Pod, planned sets.String) bool {
+	if planned.Has(string(pod.UID)) {
+		return false
+	}
+	if reservationAllocated, err := apiext.GetReservationAllocated(pod); err != nil || reservationAllocated != nil {
+		return false
+	}
+	return pl.podFilter(pod)
+}
+
+// mostAndLeastPopulated returns the topology domains with the most and the least pods.
+func mostAndLeastPopulated(counts map[string]int) (most, least string) {
+	domains := make([]string, 0, len(counts))
+	for domain := range counts {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	most, least = domains[0], domains[0]
+	for _, domain := range domains[1:] {
+		if counts[domain] > counts[most] {
+			most = domain
+		}
+		if counts[domain] < counts[least] {
+			least = domain
+		}
+	}
+	return most, least
+}
+
+// newJobContext creates the context for the PodMigrationJob to reserve the resources in the target topology domain first.
+func newJobContext(pod *corev1.Pod, topologyKey, domain string) *migration.JobContext {
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: *pod.ObjectMeta.DeepCopy(),
+		Spec:       *pod.Spec.DeepCopy(),
+	}
+	requireTopologyDomain(&template.Spec, topologyKey, domain)
+	return &migration.JobContext{
+		Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			Template: &sev1alpha1.ReservationTemplateSpec{
+				Spec: sev1alpha1.ReservationSpec{
+					Template: template,
+				},
+			},
+		},
+	}
+}
+
+// requireTopologyDomain restricts the pod to be scheduled in the topology domain by the required node affinity.
+func requireTopologyDomain(podSpec *corev1.PodSpec, topologyKey, domain string) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      topologyKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{domain},
+	}
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, requirement)
+	}
+	if len(terms) == 0 {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}},
+		}
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspread
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	getPodsAssignedToNode framework.GetPodsAssignedToNodeFunc
+	evictor               *fakeEvictor
+}
+
+func (h *fakeFrameworkHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeFrameworkHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return h.getPodsAssignedToNode
+}
+
+// fakeEvictor records the evicted pods and the contexts of the PodMigrationJobs.
+// The rejectedPods are rejected by PreEvictionFilter and the failedPods fail to be evicted.
+type fakeEvictor struct {
+	rejectedPods sets.String
+	failedPods   sets.String
+	evictedPods  []string
+	jobContexts  []*migration.JobContext
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool { return true }
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool { return !e.rejectedPods.Has(pod.Name) }
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	if e.failedPods.Has(pod.Name) {
+		return false
+	}
+	e.evictedPods = append(e.evictedPods, pod.Name)
+	e.jobContexts = append(e.jobContexts, migration.FromContext(ctx))
+	return true
+}
+
+const testTopologyKey = "topology.kubernetes.io/zone"
+
+func buildTestZoneNode(name, zone string) *corev1.Node {
+	return test.BuildTestNode(name, 8000, 16000, 20, func(node *corev1.Node) {
+		node.Labels = map[string]string{testTopologyKey: zone}
+	})
+}
+
+func buildTestSpreadPod(name, nodeName string, whenUnsatisfiable corev1.UnsatisfiableConstraintAction, apply func(pod *corev1.Pod)) *corev1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *corev1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.UID = types.UID(name)
+		pod.Labels = map[string]string{"app": "test"}
+		pod.Annotations = map[string]string{}
+		pod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       testTopologyKey,
+				WhenUnsatisfiable: whenUnsatisfiable,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		}
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func withGang(gangName string) func(pod *corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Annotations[apiext.AnnotationGangName] = gangName
+	}
+}
+
+func withReservation(pod *corev1.Pod) {
+	data, _ := json.Marshal(&apiext.ReservationAllocated{Name: "test-reservation", UID: "test-reservation"})
+	pod.Annotations[apiext.AnnotationReservationAllocated] = string(data)
+}
+
+func TestTopologySpreadRebalance(t *testing.T) {
+	node1 := buildTestZoneNode("node-1", "zone-a")
+	node2 := buildTestZoneNode("node-2", "zone-b")
+	node3 := buildTestZoneNode("node-3", "zone-b")
+
+	tests := []struct {
+		name                   string
+		dryRun                 bool
+		includeSoftConstraints bool
+		pods                   []*corev1.Pod
+		rejectedPods           []string
+		failedPods             []string
+		wantEvicted            []string
+	}{
+		{
+			name: "migrate pods to the least populated domain",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-4", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-5", node1.Name, corev1.DoNotSchedule, nil),
+			},
+			wantEvicted: []string{"pod-1", "pod-2"},
+		},
+		{
+			name: "constraint satisfied",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-3", node2.Name, corev1.DoNotSchedule, nil),
+			},
+		},
+		{
+			name: "skip pods bound to reservations",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, withReservation),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, withReservation),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, nil),
+			},
+			wantEvicted: []string{"pod-3"},
+		},
+		{
+			name: "migrate gang members as a unit",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-4", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-5", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+			},
+			wantEvicted: []string{"pod-1", "pod-2"},
+		},
+		{
+			name: "gang with member bound to reservation is not migrated",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, func(pod *corev1.Pod) {
+					withGang("gang-a")(pod)
+					withReservation(pod)
+				}),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+			},
+		},
+		{
+			name: "gang is not migrated if a member is rejected by the evictor",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-4", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-5", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+			},
+			rejectedPods: []string{"pod-2"},
+		},
+		{
+			name: "gang stops migrating at the first member failed to be evicted",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, withGang("gang-a")),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-4", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+				buildTestSpreadPod("pod-5", node1.Name, corev1.DoNotSchedule, withGang("gang-b")),
+			},
+			failedPods: []string{"pod-1"},
+		},
+		{
+			name: "defer the constraint sharing pods with the planned migrations",
+			pods: func() []*corev1.Pod {
+				var pods []*corev1.Pod
+				for _, name := range []string{"pod-1", "pod-2", "pod-3", "pod-4", "pod-5"} {
+					pods = append(pods, buildTestSpreadPod(name, node1.Name, corev1.DoNotSchedule, func(pod *corev1.Pod) {
+						looser := pod.Spec.TopologySpreadConstraints[0]
+						looser.MaxSkew = 2
+						pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, looser)
+					}))
+				}
+				return pods
+			}(),
+			wantEvicted: []string{"pod-1", "pod-2"},
+		},
+		{
+			name: "ignore soft constraints by default",
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.ScheduleAnyway, nil),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.ScheduleAnyway, nil),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.ScheduleAnyway, nil),
+			},
+		},
+		{
+			name:                   "include soft constraints",
+			includeSoftConstraints: true,
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.ScheduleAnyway, nil),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.ScheduleAnyway, nil),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.ScheduleAnyway, nil),
+			},
+			wantEvicted: []string{"pod-1"},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			pods: []*corev1.Pod{
+				buildTestSpreadPod("pod-1", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-2", node1.Name, corev1.DoNotSchedule, nil),
+				buildTestSpreadPod("pod-3", node1.Name, corev1.DoNotSchedule, nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*corev1.Node{node1, node2, node3}
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tt.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			evictor := &fakeEvictor{
+				rejectedPods: sets.NewString(tt.rejectedPods...),
+				failedPods:   sets.NewString(tt.failedPods...),
+			}
+			pl, err := NewTopologySpreadRebalance(&deschedulerconfig.TopologySpreadRebalanceArgs{
+				DryRun:                    tt.dryRun,
+				IncludeSoftConstraints:    tt.includeSoftConstraints,
+				MaxMigratingPerConstraint: 2,
+			}, &fakeFrameworkHandle{
+				getPodsAssignedToNode: getPodsAssignedToNode,
+				evictor:               evictor,
+			})
+			assert.NoError(t, err)
+
+			status := pl.(framework.BalancePlugin).Balance(ctx, nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, evictor.evictedPods)
+			for _, jobCtx := range evictor.jobContexts {
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, jobCtx.Mode)
+				affinity := jobCtx.ReservationOptions.Template.Spec.Template.Spec.Affinity
+				expectedAffinity := &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{
+									MatchExpressions: []corev1.NodeSelectorRequirement{
+										{Key: testTopologyKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"zone-b"}},
+									},
+								},
+							},
+						},
+					},
+				}
+				assert.Equal(t, expectedAffinity, affinity)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/report"
 )
 
 type EvictionLimiter interface {
@@ -100,10 +101,12 @@ func (e *evictorProxy) Evict(ctx context.Context, pod *corev1.Pod, opts framewor
 		panic("No Evictor plugin is registered in the frameworkImpl.")
 	}
 	if !e.AllowEvict(pod) {
+		report.RecordPod(ctx, pod, report.PodSkipped, report.SkipReasonLimiter, "maximum number of evicted pods reached")
 		return false
 	}
 	if e.dryRun {
 		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.PluginName, "node", pod.Spec.NodeName)
+		report.RecordPod(ctx, pod, report.PodWouldEvict, "", opts.Reason)
 	} else {
 		succeeded := e.handle.evictPlugins[0].Evict(ctx, pod, opts)
 		if !succeeded {
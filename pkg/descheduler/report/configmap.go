@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// ConfigMapDataKey is the key of the ConfigMap data holding the latest report in JSON.
+const ConfigMapDataKey = "report.json"
+
+// maxConfigMapDataSize is the max size of the report written to the ConfigMap, which leaves
+// room for the metadata below the 1MiB limit of ConfigMap.
+const maxConfigMapDataSize = 1024*1024 - 64*1024
+
+// ConfigMapWriter writes the latest report to a ConfigMap.
+type ConfigMapWriter struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+}
+
+func NewConfigMapWriter(client clientset.Interface, namespace, name string) *ConfigMapWriter {
+	return &ConfigMapWriter{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// Write creates the ConfigMap if it does not exist, or replaces the report in it.
+func (w *ConfigMapWriter) Write(ctx context.Context, report *CycleReport) error {
+	data, err := marshalReport(report, maxConfigMapDataSize)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := w.client.CoreV1().ConfigMaps(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: w.namespace,
+					Name:      w.name,
+				},
+				Data: map[string]string{
+					ConfigMapDataKey: string(data),
+				},
+			}
+			_, err = w.client.CoreV1().ConfigMaps(w.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		configMap = configMap.DeepCopy()
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[ConfigMapDataKey] = string(data)
+		_, err = w.client.CoreV1().ConfigMaps(w.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// marshalReport marshals the report into JSON no larger than maxSize. The pod records and then
+// the node records are halved until the report fits, and the report is marked as truncated.
+func marshalReport(report *CycleReport, maxSize int) ([]byte, error) {
+	data, err := json.Marshal(report)
+	if err != nil || len(data) <= maxSize {
+		return data, err
+	}
+
+	truncated := *report
+	truncated.Truncated = true
+	for len(data) > maxSize {
+		if len(truncated.Pods) > 0 {
+			truncated.Pods = truncated.Pods[:len(truncated.Pods)/2]
+		} else if len(truncated.Nodes) > 0 {
+			truncated.Nodes = truncated.Nodes[:len(truncated.Nodes)/2]
+		} else {
+			return nil, fmt.Errorf("report of cycle %d exceeds the max size %d", report.Cycle, maxSize)
+		}
+		data, err = json.Marshal(&truncated)
+		if err != nil {
+			return nil, err
+		}
+	}
+	klog.V(4).InfoS("Truncated the descheduling report written to ConfigMap", "cycle", report.Cycle,
+		"pods", len(truncated.Pods), "totalPods", len(report.Pods), "nodes", len(truncated.Nodes), "totalNodes", len(report.Nodes))
+	return data, nil
+}
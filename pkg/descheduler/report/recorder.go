@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+type recorderContextKey struct{}
+
+// Recorder collects the records of a descheduling cycle. It is safe for concurrent use,
+// and all methods of a nil Recorder are no-op.
+type Recorder struct {
+	lock   sync.Mutex
+	report *CycleReport
+}
+
+func NewRecorder(cycle int64, dryRun bool) *Recorder {
+	return &Recorder{
+		report: &CycleReport{
+			Cycle:          cycle,
+			DryRun:         dryRun,
+			StartTimestamp: metav1.Now(),
+		},
+	}
+}
+
+// WithRecorder returns a copy of ctx carrying the recorder.
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderContextKey{}, recorder)
+}
+
+// FromContext returns the recorder carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.Value(recorderContextKey{}).(*Recorder)
+	return recorder
+}
+
+func (r *Recorder) RecordNode(record NodeRecord) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.report.Nodes = append(r.report.Nodes, record)
+}
+
+func (r *Recorder) RecordPod(record PodRecord) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.report.Pods = append(r.report.Pods, record)
+}
+
+// Complete marks the cycle as completed and returns the report.
+func (r *Recorder) Complete() *CycleReport {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.report.CompletionTimestamp = metav1.NewTime(time.Now())
+	return r.report
+}
+
+// RecordNode records the classification of the node with the recorder carried by ctx.
+// The plugin name is filled from ctx.
+func RecordNode(ctx context.Context, nodePool string, node *corev1.Node, classification NodeClassification, usagePercentages map[corev1.ResourceName]float64) {
+	recorder := FromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	recorder.RecordNode(NodeRecord{
+		Plugin:           pluginNameFromContext(ctx),
+		NodePool:         nodePool,
+		Node:             node.Name,
+		Classification:   classification,
+		UsagePercentages: usagePercentages,
+	})
+}
+
+// RecordPod records the result of the pod with the recorder carried by ctx.
+// The plugin name is filled from ctx.
+func RecordPod(ctx context.Context, pod *corev1.Pod, result PodResult, skipReason SkipReason, message string) {
+	recorder := FromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	recorder.RecordPod(PodRecord{
+		Plugin:     pluginNameFromContext(ctx),
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		Node:       pod.Spec.NodeName,
+		Result:     result,
+		SkipReason: skipReason,
+		Message:    message,
+	})
+}
+
+func pluginNameFromContext(ctx context.Context) string {
+	options := framework.EvictOptions{}
+	framework.FillEvictOptionsFromContext(ctx, &options)
+	return options.PluginName
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericmux "k8s.io/apiserver/pkg/server/mux"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+func TestRecordWithContext(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"},
+		Spec:       corev1.PodSpec{NodeName: "test-node"},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+
+	// no recorder in the context
+	RecordPod(context.TODO(), pod, PodEvicted, "", "test")
+	RecordNode(context.TODO(), "", node, NodeOverutilized, nil)
+
+	recorder := NewRecorder(1, true)
+	ctx := framework.PluginNameWithContext(WithRecorder(context.TODO(), recorder), "test-plugin")
+	RecordNode(ctx, "test-pool", node, NodeOverutilized, map[corev1.ResourceName]float64{corev1.ResourceCPU: 90})
+	RecordPod(ctx, pod, PodSkipped, SkipReasonNodeFit, "no fit node")
+
+	report := recorder.Complete()
+	assert.Equal(t, int64(1), report.Cycle)
+	assert.True(t, report.DryRun)
+	assert.False(t, report.CompletionTimestamp.IsZero())
+	assert.Equal(t, []NodeRecord{
+		{
+			Plugin:           "test-plugin",
+			NodePool:         "test-pool",
+			Node:             "test-node",
+			Classification:   NodeOverutilized,
+			UsagePercentages: map[corev1.ResourceName]float64{corev1.ResourceCPU: 90},
+		},
+	}, report.Nodes)
+	assert.Equal(t, []PodRecord{
+		{
+			Plugin:     "test-plugin",
+			Namespace:  "default",
+			Name:       "test-pod",
+			Node:       "test-node",
+			Result:     PodSkipped,
+			SkipReason: SkipReasonNodeFit,
+			Message:    "no fit node",
+		},
+	}, report.Pods)
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(2)
+	pathRecorderMux := genericmux.NewPathRecorderMux("test")
+	store.InstallHandler(pathRecorderMux)
+
+	w := httptest.NewRecorder()
+	pathRecorderMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, latestReportsPath, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	for i := 0; i < 3; i++ {
+		store.Add(store.NewRecorder(false).Complete())
+	}
+	reports := store.List()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, int64(2), reports[0].Cycle)
+	assert.Equal(t, int64(3), reports[1].Cycle)
+
+	w = httptest.NewRecorder()
+	pathRecorderMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, reportsPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var gotReports []*CycleReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &gotReports))
+	assert.Len(t, gotReports, 2)
+
+	w = httptest.NewRecorder()
+	pathRecorderMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, latestReportsPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	gotReport := &CycleReport{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), gotReport))
+	assert.Equal(t, int64(3), gotReport.Cycle)
+}
+
+func TestConfigMapWriter(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	writer := NewConfigMapWriter(client, "koordinator-system", "descheduler-report")
+
+	for cycle := int64(1); cycle <= 2; cycle++ {
+		assert.NoError(t, writer.Write(context.TODO(), NewRecorder(cycle, false).Complete()))
+
+		configMap, err := client.CoreV1().ConfigMaps("koordinator-system").Get(context.TODO(), "descheduler-report", metav1.GetOptions{})
+		assert.NoError(t, err)
+		report := &CycleReport{}
+		assert.NoError(t, json.Unmarshal([]byte(configMap.Data[ConfigMapDataKey]), report))
+		assert.Equal(t, cycle, report.Cycle)
+	}
+}
+
+func TestMarshalReport(t *testing.T) {
+	report := &CycleReport{Cycle: 1}
+	for i := 0; i < 100; i++ {
+		report.Nodes = append(report.Nodes, NodeRecord{Plugin: "test", Node: fmt.Sprintf("node-%d", i), Classification: NodeOverutilized})
+		report.Pods = append(report.Pods, PodRecord{Plugin: "test", Namespace: "default", Name: fmt.Sprintf("pod-%d", i), Result: PodSkipped})
+	}
+
+	data, err := marshalReport(report, 1<<20)
+	assert.NoError(t, err)
+	got := &CycleReport{}
+	assert.NoError(t, json.Unmarshal(data, got))
+	assert.False(t, got.Truncated)
+	assert.Len(t, got.Pods, 100)
+
+	maxSize := len(data) / 2
+	data, err = marshalReport(report, maxSize)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxSize)
+	got = &CycleReport{}
+	assert.NoError(t, json.Unmarshal(data, got))
+	assert.True(t, got.Truncated)
+	assert.Less(t, len(got.Pods), 100)
+	assert.Equal(t, report.Pods[:len(got.Pods)], got.Pods)
+	assert.Len(t, report.Pods, 100, "the original report should not be modified")
+
+	_, err = marshalReport(report, 10)
+	assert.Error(t, err)
+}
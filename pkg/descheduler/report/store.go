@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+const (
+	reportsPath       = "/apis/v1/reports"
+	latestReportsPath = reportsPath + "/latest"
+)
+
+// Store keeps the reports of the latest descheduling cycles.
+type Store struct {
+	lock       sync.RWMutex
+	maxReports int
+	lastCycle  int64
+	reports    []*CycleReport
+}
+
+func NewStore(maxReports int) *Store {
+	return &Store{
+		maxReports: maxReports,
+	}
+}
+
+// NewRecorder creates a Recorder for the next descheduling cycle.
+func (s *Store) NewRecorder(dryRun bool) *Recorder {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastCycle++
+	return NewRecorder(s.lastCycle, dryRun)
+}
+
+// Add adds the report and drops the oldest ones exceeding maxReports.
+func (s *Store) Add(report *CycleReport) {
+	if report == nil || s.maxReports <= 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reports = append(s.reports, report)
+	if len(s.reports) > s.maxReports {
+		s.reports = append([]*CycleReport(nil), s.reports[len(s.reports)-s.maxReports:]...)
+	}
+}
+
+// List returns the reports from the oldest to the latest.
+func (s *Store) List() []*CycleReport {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return append([]*CycleReport(nil), s.reports...)
+}
+
+// Latest returns the report of the latest descheduling cycle, or nil if there is none.
+func (s *Store) Latest() *CycleReport {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.reports) == 0 {
+		return nil
+	}
+	return s.reports[len(s.reports)-1]
+}
+
+type mux interface {
+	Handle(string, http.Handler)
+}
+
+// InstallHandler serves all kept reports at /apis/v1/reports and the latest one at /apis/v1/reports/latest.
+func (s *Store) InstallHandler(mux mux) {
+	mux.Handle(reportsPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.List())
+	}))
+	mux.Handle(latestReportsPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := s.Latest()
+		if report == nil {
+			http.Error(w, "no descheduling cycle is completed", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, report)
+	}))
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
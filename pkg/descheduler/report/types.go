@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeClassification is the classification of a node made by a descheduling plugin.
+type NodeClassification string
+
+const (
+	NodeOverutilized          NodeClassification = "Overutilized"
+	NodeUnderutilized         NodeClassification = "Underutilized"
+	NodeAppropriatelyUtilized NodeClassification = "AppropriatelyUtilized"
+)
+
+// PodResult is the descheduling result of a candidate pod.
+type PodResult string
+
+const (
+	// PodEvicted means the pod is evicted or the migration of the pod is started.
+	PodEvicted PodResult = "Evicted"
+	// PodWouldEvict means the pod would be evicted but the descheduler runs in dry run mode.
+	PodWouldEvict PodResult = "WouldEvict"
+	// PodSkipped means the pod is skipped and the SkipReason explains why.
+	PodSkipped PodResult = "Skipped"
+	// PodEvictFailed means the eviction of the pod is failed.
+	PodEvictFailed PodResult = "Failed"
+)
+
+// SkipReason explains why a candidate pod is skipped.
+type SkipReason string
+
+const (
+	SkipReasonFilter  SkipReason = "Filter"
+	SkipReasonLimiter SkipReason = "Limiter"
+	SkipReasonPDB     SkipReason = "PDB"
+	SkipReasonNodeFit SkipReason = "NodeFit"
+)
+
+// CycleReport explains the decisions of a descheduling cycle.
+type CycleReport struct {
+	Cycle               int64        `json:"cycle"`
+	DryRun              bool         `json:"dryRun,omitempty"`
+	StartTimestamp      metav1.Time  `json:"startTimestamp"`
+	CompletionTimestamp metav1.Time  `json:"completionTimestamp,omitempty"`
+	Nodes               []NodeRecord `json:"nodes,omitempty"`
+	Pods                []PodRecord  `json:"pods,omitempty"`
+	// Truncated means some of the records are dropped to fit the report into the ConfigMap.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// NodeRecord records how a plugin classifies a node.
+type NodeRecord struct {
+	Plugin           string                          `json:"plugin"`
+	NodePool         string                          `json:"nodePool,omitempty"`
+	Node             string                          `json:"node"`
+	Classification   NodeClassification              `json:"classification"`
+	UsagePercentages map[corev1.ResourceName]float64 `json:"usagePercentages,omitempty"`
+}
+
+// PodRecord records the result of a candidate pod of a plugin.
+type PodRecord struct {
+	Plugin     string     `json:"plugin"`
+	Namespace  string     `json:"namespace"`
+	Name       string     `json:"name"`
+	Node       string     `json:"node,omitempty"`
+	Result     PodResult  `json:"result"`
+	SkipReason SkipReason `json:"skipReason,omitempty"`
+	// Message is the eviction reason of the evicted pods or the details of the skipped pods.
+	Message string `json:"message,omitempty"`
+}
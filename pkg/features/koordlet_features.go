@@ -114,6 +114,17 @@ const (
 	//
 	// BlkIOReconcile enables block I/O QoS feature of koordlet.
 	BlkIOReconcile featuregate.Feature = "BlkIOReconcile"
+
+	// alpha: v1.3
+	//
+	// MBMCollector enables memory bandwidth monitoring collector of koordlet, which relies on the resctrl MBM.
+	MBMCollector featuregate.Feature = "MBMCollector"
+
+	// alpha: v1.3
+	//
+	// NodeContentionReport reports the contention indicators of node (e.g. PSI, CPI outliers, memory bandwidth and
+	// cpu throttled) in NodeMetric.
+	NodeContentionReport featuregate.Feature = "NodeContentionReport"
 )
 
 func init() {
@@ -139,6 +150,8 @@ var (
 		CPICollector:           {Default: false, PreRelease: featuregate.Alpha},
 		PSICollector:           {Default: false, PreRelease: featuregate.Alpha},
 		BlkIOReconcile:         {Default: false, PreRelease: featuregate.Alpha},
+		MBMCollector:           {Default: false, PreRelease: featuregate.Alpha},
+		NodeContentionReport:   {Default: false, PreRelease: featuregate.Alpha},
 	}
 )
 
@@ -51,6 +51,12 @@ const (
 	//
 	// DisablePodDisruptionBudgetInformer is used to disable PodDisruptionBudget informer
 	DisablePodDisruptionBudgetInformer featuregate.Feature = "DisablePodDisruptionBudgetInformer"
+
+	// alpha: v1.3
+	//
+	// ReservationSet enables the controller maintaining the desired number of reservations for ReservationSets.
+	// The ReservationSet CRD should be installed before enabling the FeatureGate.
+	ReservationSet featuregate.Feature = "ReservationSet"
 )
 
 var defaultSchedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -58,6 +64,7 @@ var defaultSchedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSp
 	DisableCSIStorageCapacityInformer:  {Default: false, PreRelease: featuregate.Alpha},
 	CompatiblePodDisruptionBudget:      {Default: false, PreRelease: featuregate.Alpha},
 	DisablePodDisruptionBudgetInformer: {Default: false, PreRelease: featuregate.Alpha},
+	ReservationSet:                     {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
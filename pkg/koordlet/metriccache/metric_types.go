@@ -71,6 +71,9 @@ const (
 	ContainerMetricPSICPUFullSupported MetricKind = "container_psi_cpu_full_supported"
 	PodMetricPSI                       MetricKind = "pod_psi"
 	PodMetricPSICPUFullSupported       MetricKind = "pod_psi_cpu_full_supported"
+
+	// MBM
+	NodeMetricMemoryBandwidth MetricKind = "node_memory_bandwidth"
 )
 
 // MetricProperty is the property of metric
@@ -44,18 +44,28 @@ import (
 type performanceCollector struct {
 	cpiCollectInterval        time.Duration
 	psiCollectInterval        time.Duration
+	mbmCollectInterval        time.Duration
 	collectTimeWindowDuration time.Duration
 
 	started        *atomic.Bool
 	statesInformer statesinformer.StatesInformer
 	metricCache    metriccache.MetricCache
 	cgroupReader   resourceexecutor.CgroupReader
+
+	// lastMBM is the last memory bandwidth counter of node, which is only accessed by the mbm collecting goroutine
+	lastMBM *mbmCounter
+}
+
+type mbmCounter struct {
+	totalBytes  uint64
+	collectTime time.Time
 }
 
 func New(opt *framework.Options) framework.Collector {
 	return &performanceCollector{
 		cpiCollectInterval:        opt.Config.CPICollectorInterval,
 		psiCollectInterval:        opt.Config.PSICollectorInterval,
+		mbmCollectInterval:        opt.Config.MBMCollectorInterval,
 		collectTimeWindowDuration: opt.Config.CPICollectorTimeWindow,
 
 		started:        atomic.NewBool(false),
@@ -66,7 +76,8 @@ func New(opt *framework.Options) framework.Collector {
 }
 
 func (p *performanceCollector) Enabled() bool {
-	return features.DefaultKoordletFeatureGate.Enabled(features.CPICollector) || features.DefaultKoordletFeatureGate.Enabled(features.PSICollector)
+	return features.DefaultKoordletFeatureGate.Enabled(features.CPICollector) || features.DefaultKoordletFeatureGate.Enabled(features.PSICollector) ||
+		features.DefaultKoordletFeatureGate.Enabled(features.MBMCollector)
 }
 
 func (p *performanceCollector) Setup(s *framework.Context) {}
@@ -82,6 +93,9 @@ func (p *performanceCollector) Run(stopCh <-chan struct{}) {
 	if features.DefaultKoordletFeatureGate.Enabled(features.CPICollector) {
 		go wait.Until(p.collectContainerCPI, p.cpiCollectInterval, stopCh)
 	}
+	if features.DefaultKoordletFeatureGate.Enabled(features.MBMCollector) {
+		p.collectMBM(stopCh)
+	}
 }
 
 func (p *performanceCollector) Started() bool {
@@ -379,6 +393,45 @@ func (p *performanceCollector) collectPSI(stopCh <-chan struct{}) {
 	}, p.psiCollectInterval, stopCh)
 }
 
+func (p *performanceCollector) collectMBM(stopCh <-chan struct{}) {
+	if _, err := system.ReadResctrlNodeMBMTotalBytes(); err != nil {
+		klog.V(4).Infof("Collect memory bandwidth failed, system now not support resctrl mbm, err: %v", err)
+		// skip collect mbm when system not support
+		p.started.Store(true)
+		return
+	}
+	go wait.Until(p.collectNodeMBM, p.mbmCollectInterval, stopCh)
+}
+
+// collectNodeMBM calculates the memory bandwidth of node by the increment of the resctrl mbm_total_bytes of the
+// root group and all control groups since the last collection.
+func (p *performanceCollector) collectNodeMBM() {
+	klog.V(6).Infof("start collectNodeMBM")
+	totalBytes, err := system.ReadResctrlNodeMBMTotalBytes()
+	collectTime := time.Now()
+	if err != nil {
+		klog.Warningf("failed to read node mbm_total_bytes, err: %v", err)
+		return
+	}
+	last := p.lastMBM
+	p.lastMBM = &mbmCounter{totalBytes: totalBytes, collectTime: collectTime}
+	// skip the first collection and the counter reset
+	if last == nil || totalBytes < last.totalBytes || !collectTime.After(last.collectTime) {
+		return
+	}
+
+	bandwidth := float64(totalBytes-last.totalBytes) / collectTime.Sub(last.collectTime).Seconds()
+	mbmMetric, err := metriccache.NodeMemoryBandwidthMetric.GenerateSample(nil, collectTime, bandwidth)
+	if err != nil {
+		klog.Warningf("failed to generate node memory bandwidth metric, err: %v", err)
+		return
+	}
+	p.saveMetric([]metriccache.MetricSample{mbmMetric})
+
+	p.started.Store(true)
+	klog.V(5).Infof("collectNodeMBM finished at %s, bandwidth %v bytes/s", collectTime, bandwidth)
+}
+
 func (p *performanceCollector) saveMetric(samples []metriccache.MetricSample) error {
 	if len(samples) == 0 {
 		return nil
@@ -20,6 +20,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -406,3 +407,54 @@ func getPodCgroupCPUAcctPSIPath(podParentDir string) resourceexecutor.PSIPath {
 		IO:  system.GetCgroupFilePath(podParentDir, system.CPUAcctIOPressure),
 	}
 }
+
+func Test_collectNodeMBM(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	system.Conf.SysFSRootDir = helper.TempDir
+	mbmFile := path.Join(system.ResctrlDir, system.ResctrlMonDataDir, "mon_L3_00", system.ResctrlMBMTotalBytesName)
+	helper.WriteFileContents(mbmFile, "1000\n")
+
+	m, err := metriccache.NewMetricCache(&metriccache.Config{
+		MetricGCIntervalSeconds: 60,
+		MetricExpireSeconds:     60,
+		TSDBPath:                t.TempDir(),
+		TSDBEnablePromMetrics:   false,
+	})
+	assert.NoError(t, err)
+	collector := New(&framework.Options{
+		Config:         framework.NewDefaultConfig(),
+		StatesInformer: nil,
+		MetricCache:    m,
+		CgroupReader:   resourceexecutor.NewCgroupReader(),
+	})
+	c := collector.(*performanceCollector)
+	startTime := time.Now().Add(-time.Minute)
+
+	// the first collection only records the counter
+	c.collectNodeMBM()
+	assert.NotNil(t, c.lastMBM)
+	assert.Equal(t, uint64(1000), c.lastMBM.totalBytes)
+	assert.False(t, c.Started())
+
+	c.lastMBM.collectTime = c.lastMBM.collectTime.Add(-10 * time.Second)
+	helper.WriteFileContents(mbmFile, "11000\n")
+	c.collectNodeMBM()
+	assert.True(t, c.Started())
+
+	querier, err := m.Querier(startTime, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	queryMeta, err := metriccache.NodeMemoryBandwidthMetric.BuildQueryMeta(nil)
+	assert.NoError(t, err)
+	result := metriccache.DefaultAggregateResultFactory.New(queryMeta)
+	assert.NoError(t, querier.Query(queryMeta, nil, result))
+	assert.Equal(t, 1, result.Count())
+	bandwidth, err := result.Value(metriccache.AggregationTypeLast)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1000, bandwidth, 10)
+
+	// the counter reset is skipped
+	helper.WriteFileContents(mbmFile, "100\n")
+	c.collectNodeMBM()
+	assert.Equal(t, uint64(100), c.lastMBM.totalBytes)
+}
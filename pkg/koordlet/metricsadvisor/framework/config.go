@@ -33,6 +33,7 @@ type Config struct {
 	CPICollectorInterval           time.Duration
 	PSICollectorInterval           time.Duration
 	CPICollectorTimeWindow         time.Duration
+	MBMCollectorInterval           time.Duration
 }
 
 func NewDefaultConfig() *Config {
@@ -43,6 +44,7 @@ func NewDefaultConfig() *Config {
 		CPICollectorInterval:           60 * time.Second,
 		PSICollectorInterval:           10 * time.Second,
 		CPICollectorTimeWindow:         10 * time.Second,
+		MBMCollectorInterval:           10 * time.Second,
 	}
 }
 
@@ -53,4 +55,5 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&c.CPICollectorInterval, "cpi-collector-interval", c.CPICollectorInterval, "Collect cpi interval. Non-zero values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
 	fs.DurationVar(&c.PSICollectorInterval, "psi-collector-interval", c.PSICollectorInterval, "Collect psi interval. Non-zero values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
 	fs.DurationVar(&c.CPICollectorTimeWindow, "collect-cpi-timewindow", c.CPICollectorTimeWindow, "Collect cpi time window. Non-zero values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
+	fs.DurationVar(&c.MBMCollectorInterval, "mbm-collector-interval", c.MBMCollectorInterval, "Collect memory bandwidth interval. Non-zero values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
 }
@@ -32,6 +32,7 @@ func Test_NewDefaultConfig(t *testing.T) {
 		CPICollectorInterval:           60 * time.Second,
 		PSICollectorInterval:           10 * time.Second,
 		CPICollectorTimeWindow:         10 * time.Second,
+		MBMCollectorInterval:           10 * time.Second,
 	}
 	defaultConfig := NewDefaultConfig()
 	assert.Equal(t, expectConfig, defaultConfig)
@@ -46,6 +47,7 @@ func Test_InitFlags(t *testing.T) {
 		"--cpi-collector-interval=90s",
 		"--psi-collector-interval=5s",
 		"--collect-cpi-timewindow=15s",
+		"--mbm-collector-interval=20s",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
@@ -56,6 +58,7 @@ func Test_InitFlags(t *testing.T) {
 		CPICollectorInterval           time.Duration
 		PSICollectorInterval           time.Duration
 		CPICollectorTimeWindow         time.Duration
+		MBMCollectorInterval           time.Duration
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -74,6 +77,7 @@ func Test_InitFlags(t *testing.T) {
 				CPICollectorInterval:           90 * time.Second,
 				PSICollectorInterval:           5 * time.Second,
 				CPICollectorTimeWindow:         15 * time.Second,
+				MBMCollectorInterval:           20 * time.Second,
 			},
 			args: args{fs: fs},
 		},
@@ -87,6 +91,7 @@ func Test_InitFlags(t *testing.T) {
 				CPICollectorInterval:           tt.fields.CPICollectorInterval,
 				PSICollectorInterval:           tt.fields.PSICollectorInterval,
 				CPICollectorTimeWindow:         tt.fields.CPICollectorTimeWindow,
+				MBMCollectorInterval:           tt.fields.MBMCollectorInterval,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
@@ -41,6 +41,8 @@ const (
 // PredictorFactory is an interface for creating predictors of different types.
 type PredictorFactory interface {
 	New(PredictorType) Predictor
+	// NewPeakPredictor creates a predictor for the peak usages of the pods and the node.
+	NewPeakPredictor() PeakPredictor
 }
 
 type Predictor interface {
@@ -49,6 +51,15 @@ type Predictor interface {
 	GetResult() (v1.ResourceList, error)
 }
 
+// PeakPredictor predicts the peak usages of the pods and the node in the percentiles of PeakPercentiles.
+type PeakPredictor interface {
+	GetPodPeak(pod *v1.Pod) (map[string]v1.ResourceList, error)
+	GetNodePeak() (map[string]v1.ResourceList, error)
+}
+
+// PeakPercentiles are the percentiles of the peak prediction reported to the NodeMetric.
+var PeakPercentiles = []string{"p95", "p99"}
+
 type predictorFactory struct {
 	predictServer       PredictServer
 	coldStartDuration   time.Duration
@@ -93,6 +104,14 @@ func (f *predictorFactory) New(t PredictorType) Predictor {
 	}
 }
 
+// NewPeakPredictor creates a new instance of PeakPredictor.
+func (f *predictorFactory) NewPeakPredictor() PeakPredictor {
+	return &peakPredictor{
+		predictServer:     f.predictServer,
+		coldStartDuration: f.coldStartDuration,
+	}
+}
+
 var _ Predictor = (*emptyPredictor)(nil)
 
 type emptyPredictor struct {
@@ -119,6 +138,68 @@ func (f *emptyPredictorFactory) New(t PredictorType) Predictor {
 	return &emptyPredictor{}
 }
 
+func (f *emptyPredictorFactory) NewPeakPredictor() PeakPredictor {
+	return &emptyPeakPredictor{}
+}
+
+var _ PeakPredictor = (*emptyPeakPredictor)(nil)
+
+type emptyPeakPredictor struct {
+}
+
+func (p *emptyPeakPredictor) GetPodPeak(pod *v1.Pod) (map[string]v1.ResourceList, error) {
+	return nil, fmt.Errorf("empty peak predictor")
+}
+
+func (p *emptyPeakPredictor) GetNodePeak() (map[string]v1.ResourceList, error) {
+	return nil, fmt.Errorf("empty peak predictor")
+}
+
+var _ PeakPredictor = (*peakPredictor)(nil)
+
+// peakPredictor predicts the peak usages according to the histograms of the predict server.
+type peakPredictor struct {
+	predictServer     PredictServer
+	coldStartDuration time.Duration
+}
+
+// GetPodPeak returns the predicted peak usage of the pod. Pods in cold start or terminating have no prediction.
+func (p *peakPredictor) GetPodPeak(pod *v1.Pod) (map[string]v1.ResourceList, error) {
+	if time.Since(pod.CreationTimestamp.Time) <= p.coldStartDuration {
+		return nil, fmt.Errorf("pod is in cold start")
+	}
+	if pod.DeletionTimestamp != nil || util.IsPodTerminated(pod) {
+		return nil, fmt.Errorf("pod is terminating or terminated")
+	}
+	result, err := p.predictServer.GetPrediction(MetricDesc{UID: UIDType(pod.UID)})
+	if err != nil {
+		return nil, err
+	}
+	return getPeakPercentiles(result), nil
+}
+
+// GetNodePeak returns the predicted peak usage of the node.
+func (p *peakPredictor) GetNodePeak() (map[string]v1.ResourceList, error) {
+	if !p.predictServer.HasSynced() {
+		return nil, fmt.Errorf("predict server has not synced")
+	}
+	result, err := p.predictServer.GetPrediction(MetricDesc{UID: DefaultNodeID})
+	if err != nil {
+		return nil, err
+	}
+	return getPeakPercentiles(result), nil
+}
+
+func getPeakPercentiles(result Result) map[string]v1.ResourceList {
+	peak := make(map[string]v1.ResourceList, len(PeakPercentiles))
+	for _, percentile := range PeakPercentiles {
+		if resources, ok := result.Data[percentile]; ok {
+			peak[percentile] = resources.DeepCopy()
+		}
+	}
+	return peak
+}
+
 var _ Predictor = (*podReclaimablePredictor)(nil)
 
 // podReclaimablePredictor predicts the peak according to historical metrics of the pods.
@@ -17,6 +17,7 @@ limitations under the License.
 package prediction
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -457,3 +458,73 @@ func Test_priorityReclaimablePredictor(t *testing.T) {
 	}
 	assert.Equal(t, expected, got)
 }
+
+func TestPeakPredictor(t *testing.T) {
+	peakResult := Result{
+		Data: map[string]v1.ResourceList{
+			"p60": {
+				v1.ResourceCPU:    *resource.NewMilliQuantity(200, resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(256*1024*1024, resource.BinarySI),
+			},
+			"p95": {
+				v1.ResourceCPU:    *resource.NewMilliQuantity(500, resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(512*1024*1024, resource.BinarySI),
+			},
+			"p99": {
+				v1.ResourceCPU:    *resource.NewMilliQuantity(800, resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(768*1024*1024, resource.BinarySI),
+			},
+		},
+	}
+	expectedPeak := map[string]v1.ResourceList{
+		"p95": peakResult.Data["p95"],
+		"p99": peakResult.Data["p99"],
+	}
+	coldStartPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               "pod-1-uid",
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	runningPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               "pod-2-uid",
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+		},
+	}
+	unknownPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               "pod-3-uid",
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+		},
+	}
+	predictServer := &mockPredictServer{
+		ResultMap: map[UIDType]Result{
+			DefaultNodeID:             peakResult,
+			UIDType(coldStartPod.UID): peakResult,
+			UIDType(runningPod.UID):   peakResult,
+		},
+		DefaultErr: fmt.Errorf("not found"),
+	}
+	predictor := NewPredictorFactory(predictServer, time.Hour, 10).NewPeakPredictor()
+
+	nodePeak, err := predictor.GetNodePeak()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPeak, nodePeak)
+
+	_, err = predictor.GetPodPeak(coldStartPod)
+	assert.Error(t, err)
+
+	podPeak, err := predictor.GetPodPeak(runningPod)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPeak, podPeak)
+
+	_, err = predictor.GetPodPeak(unknownPod)
+	assert.Error(t, err)
+
+	emptyPredictor := NewEmptyPredictorFactory().NewPeakPredictor()
+	_, err = emptyPredictor.GetNodePeak()
+	assert.Error(t, err)
+	_, err = emptyPredictor.GetPodPeak(runningPod)
+	assert.Error(t, err)
+}
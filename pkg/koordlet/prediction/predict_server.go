@@ -269,6 +269,10 @@ func (p *peakPredictServer) GetPrediction(metric MetricDesc) (Result, error) {
 				v1.ResourceCPU:    *resource.NewMilliQuantity(int64(model.CPU.Percentile(0.98)*1000.0), resource.DecimalSI),
 				v1.ResourceMemory: *resource.NewQuantity(int64(model.Memory.Percentile(0.98)), resource.BinarySI),
 			},
+			"p99": {
+				v1.ResourceCPU:    *resource.NewMilliQuantity(int64(model.CPU.Percentile(0.99)*1000.0), resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(int64(model.Memory.Percentile(0.99)), resource.BinarySI),
+			},
 			"max": {
 				v1.ResourceCPU:    *resource.NewMilliQuantity(int64(model.CPU.Percentile(1.0)*1000.0), resource.DecimalSI),
 				v1.ResourceMemory: *resource.NewQuantity(int64(model.Memory.Percentile(1.0)), resource.BinarySI),
@@ -69,7 +69,7 @@ func getNodeItemUID(itemID string) UIDType {
 }
 
 type Result struct {
-	// Use different quantile type as key, currently support "p60", "p90", "p95" "p98", "max".
+	// Use different quantile type as key, currently support "p60", "p90", "p95", "p98", "p99", "max".
 	Data map[string]v1.ResourceList
 }
 
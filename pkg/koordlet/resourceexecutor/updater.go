@@ -65,6 +65,7 @@ func init() {
 	)
 	DefaultCgroupUpdaterFactory.Register(NewMergeableCgroupUpdaterIfCPUSetLooser,
 		sysutil.CPUSetCPUSName,
+		sysutil.CPUSetMemsName,
 	)
 	DefaultCgroupUpdaterFactory.Register(NewBlkIOResourceUpdater,
 		sysutil.BlkioTRIopsName,
@@ -100,6 +100,12 @@ func (p *cpusetPlugin) SetContainerCPUSet(proto protocol.HooksProtocol) error {
 		return err
 	} else if cpusetVal != "" {
 		containerCtx.Response.Resources.CPUSet = pointer.String(cpusetVal)
+		// cpuset.mems from pod annotation (NUMA memory allocated by the scheduler)
+		if memsVal, err := util.GetCPUSetMemsFromPod(containerReq.PodAnnotations); err != nil {
+			return err
+		} else if memsVal != "" {
+			containerCtx.Response.Resources.CPUSetMems = pointer.String(memsVal)
+		}
 		klog.V(5).Infof("get cpuset %v for container %v/%v from pod annotation", cpusetVal,
 			containerCtx.Request.PodMeta.String(), containerCtx.Request.ContainerMeta.Name)
 		return nil
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 
 	ext "github.com/koordinator-sh/koordinator/apis/extension"
@@ -637,3 +639,32 @@ func TestUnsetContainerCPUQuota(t *testing.T) {
 		})
 	}
 }
+
+func Test_cpusetPlugin_SetContainerCPUSetMems(t *testing.T) {
+	podAlloc := &ext.ResourceStatus{
+		CPUSet: "2-4",
+		NUMANodeResources: []ext.NUMANodeResource{
+			{
+				Node: 1,
+				Resources: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+	}
+	containerCtx := &protocol.ContainerContext{
+		Request: protocol.ContainerRequest{
+			CgroupParent: "kubepods/test-pod/test-container/",
+			PodAnnotations: map[string]string{
+				ext.AnnotationResourceStatus: util.DumpJSON(podAlloc),
+			},
+		},
+	}
+	p := &cpusetPlugin{
+		executor: resourceexecutor.NewResourceUpdateExecutor(),
+	}
+	err := p.SetContainerCPUSet(containerCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.String("2-4"), containerCtx.Response.Resources.CPUSet)
+	assert.Equal(t, pointer.String("1"), containerCtx.Response.Resources.CPUSetMems)
+}
@@ -139,6 +139,9 @@ func (c *ContainerResponse) ProxyDone(resp *runtimeapi.ContainerResourceHookResp
 	if c.Resources.CPUSet != nil {
 		resp.ContainerResources.CpusetCpus = *c.Resources.CPUSet
 	}
+	if c.Resources.CPUSetMems != nil {
+		resp.ContainerResources.CpusetMems = *c.Resources.CPUSetMems
+	}
 	if c.Resources.CFSQuota != nil {
 		resp.ContainerResources.CpuQuota = *c.Resources.CFSQuota
 	}
@@ -224,6 +227,22 @@ func (c *ContainerContext) injectForOrigin() {
 				*c.Response.Resources.CPUSet, c.Request.CgroupParent)
 		}
 	}
+	// If CPUSetMems is not nil and is not an empty string, set container cpuset.mems
+	if c.Response.Resources.CPUSetMems != nil && *c.Response.Resources.CPUSetMems != "" {
+		eventHelper := audit.V(3).Container(c.Request.ContainerMeta.ID).Reason("runtime-hooks").Message("set container cpuset.mems to %v", *c.Response.Resources.CPUSetMems)
+		err := injectCPUSetMems(c.Request.CgroupParent, *c.Response.Resources.CPUSetMems, eventHelper, c.executor)
+		if err != nil && resourceexecutor.IsCgroupDirErr(err) {
+			klog.V(5).Infof("set container %v/%v/%v cpuset.mems %v on cgroup parent %v failed, error %v", c.Request.PodMeta.Namespace,
+				c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, *c.Response.Resources.CPUSetMems, c.Request.CgroupParent, err)
+		} else if err != nil {
+			klog.Infof("set container %v/%v/%v cpuset.mems %v on cgroup parent %v failed, error %v", c.Request.PodMeta.Namespace,
+				c.Request.PodMeta.Name, c.Request.ContainerMeta.Name, *c.Response.Resources.CPUSetMems, c.Request.CgroupParent, err)
+		} else {
+			klog.V(5).Infof("set container %v/%v/%v cpuset.mems %v on cgroup parent %v",
+				c.Request.PodMeta.Namespace, c.Request.PodMeta.Name, c.Request.ContainerMeta.Name,
+				*c.Response.Resources.CPUSetMems, c.Request.CgroupParent)
+		}
+	}
 	// If CFSQuota is not nil, set container cfs quota
 	if c.Response.Resources.CFSQuota != nil {
 		eventHelper := audit.V(3).Container(c.Request.ContainerMeta.ID).Reason("runtime-hooks").Message(
@@ -66,6 +66,7 @@ type Resources struct {
 	CPUShares   *int64
 	CFSQuota    *int64
 	CPUSet      *string
+	CPUSetMems  *string
 	MemoryLimit *int64
 
 	// extended resources
@@ -73,7 +74,7 @@ type Resources struct {
 }
 
 func (r *Resources) IsOriginResSet() bool {
-	return r.CPUShares != nil || r.CFSQuota != nil || r.CPUSet != nil || r.MemoryLimit != nil
+	return r.CPUShares != nil || r.CFSQuota != nil || r.CPUSet != nil || r.CPUSetMems != nil || r.MemoryLimit != nil
 }
 
 func injectCPUShares(cgroupParent string, cpuShares int64, a *audit.EventHelper, e resourceexecutor.ResourceUpdateExecutor) error {
@@ -95,6 +96,15 @@ func injectCPUSet(cgroupParent string, cpuset string, a *audit.EventHelper, e re
 	return err
 }
 
+func injectCPUSetMems(cgroupParent string, mems string, a *audit.EventHelper, e resourceexecutor.ResourceUpdateExecutor) error {
+	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.CPUSetMemsName, cgroupParent, mems, a)
+	if err != nil {
+		return err
+	}
+	_, err = e.Update(true, updater)
+	return err
+}
+
 func injectCPUQuota(cgroupParent string, cpuQuota int64, a *audit.EventHelper, e resourceexecutor.ResourceUpdateExecutor) error {
 	cpuQuotaStr := strconv.FormatInt(cpuQuota, 10)
 	updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.CPUCFSQuotaName, cgroupParent, cpuQuotaStr, a)
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -45,6 +46,7 @@ import (
 	clientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	clientsetv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1"
 	listerv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/prediction"
@@ -97,13 +99,16 @@ type nodeMetricInformer struct {
 	podsInformer     *podsInformer
 	metricCache      metriccache.MetricCache
 	predictorFactory prediction.PredictorFactory
+	hourlyProfile    *hourlyUsageProfile
 
 	rwMutex    sync.RWMutex
 	nodeMetric *slov1alpha1.NodeMetric
 }
 
 func NewNodeMetricInformer() *nodeMetricInformer {
-	return &nodeMetricInformer{}
+	return &nodeMetricInformer{
+		hourlyProfile: newHourlyUsageProfile(),
+	}
 }
 
 func (r *nodeMetricInformer) HasSynced() bool {
@@ -306,6 +311,9 @@ func (r *nodeMetricInformer) updateMetricSpec(newNodeMetric *slov1alpha1.NodeMet
 		return
 	}
 	r.nodeMetric = newNodeMetric.DeepCopy()
+	if r.hourlyProfile != nil && newNodeMetric.Status.NodeMetric != nil {
+		r.hourlyProfile.restore(newNodeMetric.Status.NodeMetric.HourlyUsageProfile)
+	}
 	data, _ := json.Marshal(newNodeMetric.Spec)
 	r.nodeMetric.Spec = *defaultNodeMetricSpec.DeepCopy()
 	_ = json.Unmarshal(data, &r.nodeMetric.Spec)
@@ -329,6 +337,18 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 		AggregatedNodeUsages: r.collectNodeAggregateMetric(endTime, spec.CollectPolicy.NodeAggregatePolicy),
 	}
 
+	if r.hourlyProfile != nil {
+		r.hourlyProfile.addSample(endTime, nodeMetricInfo.NodeUsage.ResourceList)
+		nodeMetricInfo.HourlyUsageProfile = r.hourlyProfile.getProfile()
+	}
+
+	peakPredictor := r.predictorFactory.NewPeakPredictor()
+	if nodePeak, err := peakPredictor.GetNodePeak(); err != nil {
+		klog.V(4).Infof("failed to get node peak prediction, err %v", err)
+	} else {
+		nodeMetricInfo.PeakPrediction = convertPeakPrediction(nodePeak)
+	}
+
 	var gpus koordletutil.GPUDevices
 	value, ok := r.metricCache.Get(koordletutil.GPUDeviceType)
 	if ok {
@@ -339,6 +359,11 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 	}
 
 	podsMeta := r.podsInformer.GetAllPods()
+	var podsContention map[types.UID]*slov1alpha1.PodContention
+	if features.DefaultKoordletFeatureGate.Enabled(features.NodeContentionReport) {
+		nodeMetricInfo.Contention, podsContention = r.collectContention(podsMeta, startTime, endTime)
+	}
+
 	podsMetricInfo := make([]*slov1alpha1.PodMetricInfo, 0, len(podsMeta))
 	podQueryParam := metriccache.QueryParam{
 		Aggregate: metriccache.AggregationTypeAVG,
@@ -358,6 +383,13 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 			klog.V(4).Infof("predictor add pod aborted, pod %s/%s, error %v", genPodMetaKey(podMeta), err)
 		}
 
+		if podPeak, err := peakPredictor.GetPodPeak(podMeta.Pod); err != nil {
+			klog.V(5).Infof("failed to get pod peak prediction, pod %s, err %v", genPodMetaKey(podMeta), err)
+		} else {
+			podMetric.PeakPrediction = convertPeakPrediction(podPeak)
+		}
+		podMetric.Contention = podsContention[podMeta.Pod.UID]
+
 		r.fillExtensionMap(podMetric, podMeta.Pod)
 		if len(gpus) > 0 {
 			r.fillGPUMetrics(podQueryParam, podMetric, string(podMeta.Pod.UID), gpus)
@@ -378,6 +410,18 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 	return nodeMetricInfo, podsMetricInfo, prodReclaimable
 }
 
+// convertPeakPrediction converts the percentile results of the predictor into the NodeMetric format.
+func convertPeakPrediction(peak map[string]corev1.ResourceList) map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap {
+	if len(peak) == 0 {
+		return nil
+	}
+	result := make(map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap, len(peak))
+	for percentile, resources := range peak {
+		result[slov1alpha1.AggregationType(percentile)] = slov1alpha1.ResourceMap{ResourceList: resources}
+	}
+	return result
+}
+
 func (r *nodeMetricInformer) queryNodeMetric(start time.Time, end time.Time, aggregateType metriccache.AggregationType,
 	coldStartFilter bool) slov1alpha1.ResourceMap {
 	rm := slov1alpha1.ResourceMap{}
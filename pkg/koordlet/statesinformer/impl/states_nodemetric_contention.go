@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+)
+
+const (
+	// cpiOutlierFactor indicates a container is a CPI outlier if its latest CPI exceeds the average CPI
+	// during the aggregation period by the factor.
+	cpiOutlierFactor = 1.5
+)
+
+var psiContentionResources = []metriccache.MetricPropertyValue{
+	metriccache.PSIResourceCPU,
+	metriccache.PSIResourceMem,
+	metriccache.PSIResourceIO,
+}
+
+// collectContention collects the contention indicators of each pod during the aggregation period, and aggregates
+// the contention indicators of node from them. The metrics of each pod are queried only once.
+// The node contention is nil if none of the indicators is collected, and the pods without any indicator collected
+// are absent in the pods contention.
+func (r *nodeMetricInformer) collectContention(podsMeta []*statesinformer.PodMeta, start, end time.Time) (*slov1alpha1.NodeContention, map[types.UID]*slov1alpha1.PodContention) {
+	querier, err := r.metricCache.Querier(start, end)
+	if err != nil {
+		klog.V(4).Infof("failed to get querier for contention, error %v", err)
+		return nil, nil
+	}
+
+	podsStats := make([]*podContentionStats, 0, len(podsMeta))
+	podsContention := map[types.UID]*slov1alpha1.PodContention{}
+	for _, podMeta := range podsMeta {
+		if podMeta == nil || podMeta.Pod == nil {
+			continue
+		}
+		stats := collectPodContentionStats(querier, podMeta)
+		if contention := stats.podContention(); contention != nil {
+			podsStats = append(podsStats, stats)
+			podsContention[podMeta.Pod.UID] = contention
+		}
+	}
+	return aggregateNodeContention(podsStats, collectMemoryBandwidth(querier)), podsContention
+}
+
+// podContentionStats is the raw contention indicators of a pod, from which both the pod contention and
+// the node contention are derived.
+type podContentionStats struct {
+	// pressures is the average "some avg10" pressure of resources
+	pressures map[metriccache.MetricPropertyValue]float64
+	// cpiTotal is the number of containers with CPI collected, and cpiOutliers is the number of the outliers among them
+	cpiTotal, cpiOutliers int
+	// throttledRatio is the average cpu throttled ratio, which is valid only if throttledCollected is true
+	throttledRatio     float64
+	throttledCollected bool
+}
+
+func collectPodContentionStats(querier metriccache.Querier, podMeta *statesinformer.PodMeta) *podContentionStats {
+	stats := &podContentionStats{
+		pressures: collectPodPSI(querier, podMeta),
+	}
+	stats.cpiTotal, stats.cpiOutliers = collectPodCPIOutliers(querier, podMeta)
+	stats.throttledRatio, stats.throttledCollected = collectPodCPUThrottledRatio(querier, podMeta)
+	return stats
+}
+
+// podContention returns the contention of the pod, or nil if none of the indicators is collected.
+func (s *podContentionStats) podContention() *slov1alpha1.PodContention {
+	contention := &slov1alpha1.PodContention{}
+	if len(s.pressures) > 0 {
+		contention.PSI = newPSIContention(s.pressures)
+	}
+	if s.cpiTotal > 0 {
+		contention.CPIOutlierPercent = pointer.Int64(int64(math.Round(float64(s.cpiOutliers) * 100 / float64(s.cpiTotal))))
+	}
+	if s.throttledCollected {
+		contention.CPUThrottledPercent = pointer.Int64(int64(math.Round(s.throttledRatio * 100)))
+	}
+	if contention.PSI == nil && contention.CPIOutlierPercent == nil && contention.CPUThrottledPercent == nil {
+		return nil
+	}
+	return contention
+}
+
+// aggregateNodeContention aggregates the contention of node from the pods:
+// the maximum pressure of resources among the pods, the percentage of containers whose latest CPI is an outlier,
+// and the average cpu throttled ratio of the pods. It returns nil if none of the indicators is collected.
+func aggregateNodeContention(podsStats []*podContentionStats, memoryBandwidth *resource.Quantity) *slov1alpha1.NodeContention {
+	pressures := map[metriccache.MetricPropertyValue]float64{}
+	cpiTotal, cpiOutliers := 0, 0
+	throttledCount := 0
+	var throttledSum float64
+	for _, stats := range podsStats {
+		for psiResource, pressure := range stats.pressures {
+			if old, exist := pressures[psiResource]; !exist || pressure > old {
+				pressures[psiResource] = pressure
+			}
+		}
+		cpiTotal += stats.cpiTotal
+		cpiOutliers += stats.cpiOutliers
+		if stats.throttledCollected {
+			throttledSum += stats.throttledRatio
+			throttledCount++
+		}
+	}
+
+	contention := &slov1alpha1.NodeContention{
+		MemoryBandwidth: memoryBandwidth,
+	}
+	if len(pressures) > 0 {
+		contention.PSI = newPSIContention(pressures)
+	}
+	if cpiTotal > 0 {
+		contention.CPIOutlierPercent = pointer.Int64(int64(math.Round(float64(cpiOutliers) * 100 / float64(cpiTotal))))
+	}
+	if throttledCount > 0 {
+		contention.CPUThrottledPercent = pointer.Int64(int64(math.Round(throttledSum * 100 / float64(throttledCount))))
+	}
+	if contention.PSI == nil && contention.CPIOutlierPercent == nil && contention.MemoryBandwidth == nil &&
+		contention.CPUThrottledPercent == nil {
+		return nil
+	}
+	return contention
+}
+
+// collectPodPSI returns the average "some avg10" pressure of resources of the pod.
+func collectPodPSI(querier metriccache.Querier, podMeta *statesinformer.PodMeta) map[metriccache.MetricPropertyValue]float64 {
+	pressures := map[metriccache.MetricPropertyValue]float64{}
+	podUID := string(podMeta.Pod.UID)
+	for _, psiResource := range psiContentionResources {
+		properties := metriccache.MetricPropertiesFunc.PodPSI(podUID, string(psiResource),
+			string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome))
+		pressure, ok := queryMetricValue(querier, metriccache.PodPSIMetric, properties, metriccache.AggregationTypeAVG)
+		if !ok {
+			continue
+		}
+		pressures[psiResource] = pressure
+	}
+	return pressures
+}
+
+func newPSIContention(pressures map[metriccache.MetricPropertyValue]float64) *slov1alpha1.PSIContention {
+	psi := &slov1alpha1.PSIContention{}
+	if pressure, ok := pressures[metriccache.PSIResourceCPU]; ok {
+		psi.CPU = pointer.Int64(int64(math.Round(pressure)))
+	}
+	if pressure, ok := pressures[metriccache.PSIResourceMem]; ok {
+		psi.Memory = pointer.Int64(int64(math.Round(pressure)))
+	}
+	if pressure, ok := pressures[metriccache.PSIResourceIO]; ok {
+		psi.IO = pointer.Int64(int64(math.Round(pressure)))
+	}
+	return psi
+}
+
+// collectPodCPIOutliers returns the number of the pod's containers with CPI collected, and the number of
+// the outliers among them.
+func collectPodCPIOutliers(querier metriccache.Querier, podMeta *statesinformer.PodMeta) (total, outliers int) {
+	podUID := string(podMeta.Pod.UID)
+	for _, containerStatus := range podMeta.Pod.Status.ContainerStatuses {
+		if containerStatus.ContainerID == "" {
+			continue
+		}
+		cycleResult, err := doQuery(querier, metriccache.ContainerCPI,
+			metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerStatus.ContainerID, string(metriccache.CPIResourceCycle)))
+		if err != nil || cycleResult.Count() < 2 {
+			continue
+		}
+		instructionResult, err := doQuery(querier, metriccache.ContainerCPI,
+			metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerStatus.ContainerID, string(metriccache.CPIResourceInstruction)))
+		if err != nil || instructionResult.Count() < 2 {
+			continue
+		}
+		avgCPI, ok := calculateCPI(cycleResult, instructionResult, metriccache.AggregationTypeAVG)
+		if !ok {
+			continue
+		}
+		latestCPI, ok := calculateCPI(cycleResult, instructionResult, metriccache.AggregationTypeLast)
+		if !ok {
+			continue
+		}
+		total++
+		if latestCPI > avgCPI*cpiOutlierFactor {
+			outliers++
+		}
+	}
+	return total, outliers
+}
+
+func calculateCPI(cycleResult, instructionResult metriccache.AggregateResult, aggregationType metriccache.AggregationType) (float64, bool) {
+	cycles, err := cycleResult.Value(aggregationType)
+	if err != nil {
+		return 0, false
+	}
+	instructions, err := instructionResult.Value(aggregationType)
+	if err != nil || instructions <= 0 {
+		return 0, false
+	}
+	return cycles / instructions, true
+}
+
+// collectMemoryBandwidth returns the average memory bandwidth of node in bytes per second.
+func collectMemoryBandwidth(querier metriccache.Querier) *resource.Quantity {
+	bandwidth, ok := queryMetricValue(querier, metriccache.NodeMemoryBandwidthMetric, nil, metriccache.AggregationTypeAVG)
+	if !ok {
+		return nil
+	}
+	return resource.NewQuantity(int64(bandwidth), resource.DecimalSI)
+}
+
+// collectPodCPUThrottledRatio returns the average cpu throttled ratio of the pod.
+func collectPodCPUThrottledRatio(querier metriccache.Querier, podMeta *statesinformer.PodMeta) (float64, bool) {
+	return queryMetricValue(querier, metriccache.PodCPUThrottledMetric,
+		metriccache.MetricPropertiesFunc.Pod(string(podMeta.Pod.UID)), metriccache.AggregationTypeAVG)
+}
+
+func queryMetricValue(querier metriccache.Querier, metricResource metriccache.MetricResource, properties map[metriccache.MetricProperty]string,
+	aggregationType metriccache.AggregationType) (float64, bool) {
+	result, err := doQuery(querier, metricResource, properties)
+	if err != nil || result.Count() == 0 {
+		return 0, false
+	}
+	value, err := result.Value(aggregationType)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
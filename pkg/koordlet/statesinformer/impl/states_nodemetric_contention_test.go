@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mockmetriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+)
+
+func newTestContentionPodMeta(uid string, containerIDs ...string) *statesinformer.PodMeta {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      uid,
+			UID:       types.UID(uid),
+		},
+	}
+	for _, containerID := range containerIDs {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:        containerID,
+			ContainerID: containerID,
+		})
+	}
+	return &statesinformer.PodMeta{Pod: pod}
+}
+
+func buildMockContentionResult(t *testing.T, ctrl *gomock.Controller, querier *mockmetriccache.MockQuerier, factory *mockmetriccache.MockAggregateResultFactory,
+	metricResource metriccache.MetricResource, properties map[metriccache.MetricProperty]string, avg, last float64, count int) {
+	queryMeta, err := metricResource.BuildQueryMeta(properties)
+	assert.NoError(t, err)
+	result := mockmetriccache.NewMockAggregateResult(ctrl)
+	result.EXPECT().Value(metriccache.AggregationTypeAVG).Return(avg, nil).AnyTimes()
+	result.EXPECT().Value(metriccache.AggregationTypeLast).Return(last, nil).AnyTimes()
+	result.EXPECT().Count().Return(count).AnyTimes()
+	factory.EXPECT().New(queryMeta).Return(result).AnyTimes()
+	querier.EXPECT().Query(queryMeta, gomock.Any(), result).Return(nil).AnyTimes()
+}
+
+func Test_nodeMetricInformer_collectContention(t *testing.T) {
+	endTime := time.Now()
+	startTime := endTime.Add(-5 * time.Minute)
+	podsMeta := []*statesinformer.PodMeta{
+		newTestContentionPodMeta("pod-a", "container-1", "container-2"),
+		newTestContentionPodMeta("pod-b", "container-3"),
+	}
+	psiProperties := func(podUID string, psiResource metriccache.MetricPropertyValue) map[metriccache.MetricProperty]string {
+		return metriccache.MetricPropertiesFunc.PodPSI(podUID, string(psiResource), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome))
+	}
+	cpiProperties := func(podUID, containerID string, cpiResource metriccache.MetricPropertyValue) map[metriccache.MetricProperty]string {
+		return metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerID, string(cpiResource))
+	}
+
+	tests := []struct {
+		name     string
+		prepare  func(t *testing.T, ctrl *gomock.Controller, querier *mockmetriccache.MockQuerier, factory *mockmetriccache.MockAggregateResultFactory)
+		want     *slov1alpha1.NodeContention
+		wantPods map[types.UID]*slov1alpha1.PodContention
+	}{
+		{
+			name:     "no contention metrics collected",
+			want:     nil,
+			wantPods: map[types.UID]*slov1alpha1.PodContention{},
+		},
+		{
+			name: "aggregate contention indicators",
+			prepare: func(t *testing.T, ctrl *gomock.Controller, querier *mockmetriccache.MockQuerier, factory *mockmetriccache.MockAggregateResultFactory) {
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.PodPSIMetric, psiProperties("pod-a", metriccache.PSIResourceCPU), 10.4, 12, 1)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.PodPSIMetric, psiProperties("pod-a", metriccache.PSIResourceMem), 2, 2, 1)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.PodPSIMetric, psiProperties("pod-b", metriccache.PSIResourceCPU), 20.6, 1, 1)
+
+				// container-1 is an outlier, container-2 is normal, container-3 has not enough samples
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-a", "container-1", metriccache.CPIResourceCycle), 1000, 2000, 5)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-a", "container-1", metriccache.CPIResourceInstruction), 1000, 1000, 5)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-a", "container-2", metriccache.CPIResourceCycle), 1000, 1200, 5)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-a", "container-2", metriccache.CPIResourceInstruction), 1000, 1000, 5)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-b", "container-3", metriccache.CPIResourceCycle), 1000, 3000, 1)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.ContainerCPI, cpiProperties("pod-b", "container-3", metriccache.CPIResourceInstruction), 1000, 1000, 1)
+
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.NodeMemoryBandwidthMetric, nil, 1e9, 2e9, 10)
+
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.PodCPUThrottledMetric, metriccache.MetricPropertiesFunc.Pod("pod-a"), 0.1, 0, 10)
+				buildMockContentionResult(t, ctrl, querier, factory, metriccache.PodCPUThrottledMetric, metriccache.MetricPropertiesFunc.Pod("pod-b"), 0.3, 0, 10)
+			},
+			want: &slov1alpha1.NodeContention{
+				PSI: &slov1alpha1.PSIContention{
+					CPU:    pointer.Int64(21),
+					Memory: pointer.Int64(2),
+				},
+				CPIOutlierPercent:   pointer.Int64(50),
+				MemoryBandwidth:     resource.NewQuantity(1e9, resource.DecimalSI),
+				CPUThrottledPercent: pointer.Int64(20),
+			},
+			wantPods: map[types.UID]*slov1alpha1.PodContention{
+				"pod-a": {
+					PSI: &slov1alpha1.PSIContention{
+						CPU:    pointer.Int64(10),
+						Memory: pointer.Int64(2),
+					},
+					CPIOutlierPercent:   pointer.Int64(50),
+					CPUThrottledPercent: pointer.Int64(10),
+				},
+				"pod-b": {
+					PSI: &slov1alpha1.PSIContention{
+						CPU: pointer.Int64(21),
+					},
+					CPUThrottledPercent: pointer.Int64(30),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(startTime, endTime).Return(mockQuerier, nil).AnyTimes()
+			if tt.prepare != nil {
+				tt.prepare(t, ctrl, mockQuerier, mockResultFactory)
+			}
+			// the metrics not prepared have no sample
+			emptyResult := mockmetriccache.NewMockAggregateResult(ctrl)
+			emptyResult.EXPECT().Count().Return(0).AnyTimes()
+			mockResultFactory.EXPECT().New(gomock.Any()).Return(emptyResult).AnyTimes()
+			mockQuerier.EXPECT().Query(gomock.Any(), gomock.Any(), emptyResult).Return(nil).AnyTimes()
+
+			r := &nodeMetricInformer{
+				metricCache: mockMetricCache,
+			}
+			got, gotPods := r.collectContention(podsMeta, startTime, endTime)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPods, gotPods)
+		})
+	}
+}
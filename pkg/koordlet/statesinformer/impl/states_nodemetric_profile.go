@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+const (
+	// hourlyProfileSmoothingFactor is the weight of the latest day when updating the hourly usage profile.
+	hourlyProfileSmoothingFactor = 0.3
+)
+
+// hourlyUsageProfile records the peak usage of node in each hour of the day (UTC). The peak of an hour is
+// merged into the profile when the hour completes, with the exponentially weighted moving average over days.
+type hourlyUsageProfile struct {
+	lock     sync.Mutex
+	restored bool
+	profile  map[int32]corev1.ResourceList
+	// currentHour is the start of the hour which is being sampled
+	currentHour time.Time
+	currentPeak corev1.ResourceList
+}
+
+func newHourlyUsageProfile() *hourlyUsageProfile {
+	return &hourlyUsageProfile{
+		profile: map[int32]corev1.ResourceList{},
+	}
+}
+
+// restore recovers the profile from the last reported NodeMetric, it only takes effect once before any hour is merged.
+func (p *hourlyUsageProfile) restore(usages []slov1alpha1.HourlyUsage) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.restored {
+		return
+	}
+	p.restored = true
+	if len(p.profile) > 0 {
+		return
+	}
+	for _, usage := range usages {
+		if usage.Hour < 0 || usage.Hour >= 24 || len(usage.Usage.ResourceList) == 0 {
+			continue
+		}
+		p.profile[usage.Hour] = usage.Usage.ResourceList.DeepCopy()
+	}
+}
+
+func (p *hourlyUsageProfile) addSample(sampleTime time.Time, usage corev1.ResourceList) {
+	if len(usage) == 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	hour := sampleTime.UTC().Truncate(time.Hour)
+	if !hour.Equal(p.currentHour) {
+		p.mergeCurrentHourLocked()
+		p.currentHour = hour
+		p.currentPeak = nil
+	}
+	if p.currentPeak == nil {
+		p.currentPeak = usage.DeepCopy()
+	} else {
+		p.currentPeak = quotav1.Max(p.currentPeak, usage)
+	}
+}
+
+func (p *hourlyUsageProfile) mergeCurrentHourLocked() {
+	if len(p.currentPeak) == 0 {
+		return
+	}
+	p.restored = true
+	hour := int32(p.currentHour.Hour())
+	old, ok := p.profile[hour]
+	if !ok {
+		p.profile[hour] = p.currentPeak
+		return
+	}
+	merged := corev1.ResourceList{}
+	for resourceName, quantity := range p.currentPeak {
+		oldQuantity, ok := old[resourceName]
+		if !ok {
+			merged[resourceName] = quantity.DeepCopy()
+			continue
+		}
+		merged[resourceName] = smoothQuantity(resourceName, oldQuantity, quantity)
+	}
+	p.profile[hour] = merged
+}
+
+func smoothQuantity(resourceName corev1.ResourceName, old, latest resource.Quantity) resource.Quantity {
+	if resourceName == corev1.ResourceCPU {
+		value := float64(old.MilliValue())*(1-hourlyProfileSmoothingFactor) + float64(latest.MilliValue())*hourlyProfileSmoothingFactor
+		return *resource.NewMilliQuantity(int64(math.Round(value)), resource.DecimalSI)
+	}
+	value := float64(old.Value())*(1-hourlyProfileSmoothingFactor) + float64(latest.Value())*hourlyProfileSmoothingFactor
+	return *resource.NewQuantity(int64(math.Round(value)), latest.Format)
+}
+
+// getProfile returns the hourly usage profile sorted by the hour.
+func (p *hourlyUsageProfile) getProfile() []slov1alpha1.HourlyUsage {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.profile) == 0 {
+		return nil
+	}
+	usages := make([]slov1alpha1.HourlyUsage, 0, len(p.profile))
+	for hour, usage := range p.profile {
+		usages = append(usages, slov1alpha1.HourlyUsage{
+			Hour:  hour,
+			Usage: slov1alpha1.ResourceMap{ResourceList: usage.DeepCopy()},
+		})
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Hour < usages[j].Hour
+	})
+	return usages
+}
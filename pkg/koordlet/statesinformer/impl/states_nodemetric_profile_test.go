@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func testUsage(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func Test_hourlyUsageProfile(t *testing.T) {
+	profile := newHourlyUsageProfile()
+	profile.restore([]slov1alpha1.HourlyUsage{
+		{Hour: 3, Usage: slov1alpha1.ResourceMap{ResourceList: testUsage("10", "10Gi")}},
+		{Hour: 25, Usage: slov1alpha1.ResourceMap{ResourceList: testUsage("10", "10Gi")}},
+	})
+	// restore only takes effect once
+	profile.restore([]slov1alpha1.HourlyUsage{
+		{Hour: 4, Usage: slov1alpha1.ResourceMap{ResourceList: testUsage("10", "10Gi")}},
+	})
+	assert.Equal(t, []slov1alpha1.HourlyUsage{
+		{Hour: 3, Usage: slov1alpha1.ResourceMap{ResourceList: testUsage("10", "10Gi")}},
+	}, profile.getProfile())
+
+	day := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	// the peak of hour 3 is merged when the hour completes
+	profile.addSample(day.Add(3*time.Hour), testUsage("2", "4Gi"))
+	profile.addSample(day.Add(3*time.Hour+30*time.Minute), testUsage("20", "2Gi"))
+	profile.addSample(day.Add(3*time.Hour+50*time.Minute), testUsage("4", "20Gi"))
+	assert.Len(t, profile.getProfile(), 1)
+	profile.addSample(day.Add(4*time.Hour), testUsage("8", "8Gi"))
+	profile.addSample(day.Add(5*time.Hour), testUsage("1", "1Gi"))
+
+	got := profile.getProfile()
+	assert.Len(t, got, 2)
+	assert.Equal(t, int32(3), got[0].Hour)
+	// 10 * 0.7 + 20 * 0.3, 10Gi * 0.7 + 20Gi * 0.3
+	assert.Equal(t, int64(13000), got[0].Usage.Cpu().MilliValue())
+	assert.Equal(t, int64(13<<30), got[0].Usage.Memory().Value())
+	assert.Equal(t, int32(4), got[1].Hour)
+	assert.Equal(t, int64(8000), got[1].Usage.Cpu().MilliValue())
+	assert.Equal(t, int64(8<<30), got[1].Usage.Memory().Value())
+
+	// empty samples are ignored
+	profile.addSample(day.Add(6*time.Hour), nil)
+	assert.Len(t, profile.getProfile(), 2)
+	assert.Nil(t, newHourlyUsageProfile().getProfile())
+}
@@ -619,15 +619,29 @@ func (s *nodeTopoInformer) calCPUTopology() (*metriccache.NodeCPUInfo, *extensio
 	}
 	cpus := make(map[int32]*extension.CPUInfo)
 	cpuTopology := &extension.CPUTopology{}
+	cpusInCores := map[int32]int{}
 	for _, cpu := range nodeCPUInfo.ProcessorInfos {
+		// the offline cpus (e.g. the SMT siblings when SMT is turned off) can not be bound
+		if cpu.Online == "no" {
+			continue
+		}
 		info := extension.CPUInfo{
-			ID:     cpu.CPUID,
-			Core:   cpu.CoreID,
-			Socket: cpu.SocketID,
-			Node:   cpu.NodeID,
+			ID:       cpu.CPUID,
+			Core:     cpu.CoreID,
+			Socket:   cpu.SocketID,
+			Node:     cpu.NodeID,
+			CoreType: extension.CPUCoreType(cpu.CoreType),
 		}
 		cpuTopology.Detail = append(cpuTopology.Detail, info)
 		cpus[cpu.CPUID] = &info
+		cpusInCores[cpu.SocketID<<16|cpu.CoreID]++
+	}
+	cpuTopology.SMTDisabled = len(cpusInCores) > 0
+	for _, numCPUs := range cpusInCores {
+		if numCPUs > 1 {
+			cpuTopology.SMTDisabled = false
+			break
+		}
 	}
 	sort.Slice(cpuTopology.Detail, func(i, j int) bool {
 		return cpuTopology.Detail[i].ID < cpuTopology.Detail[j].ID
@@ -858,3 +858,62 @@ func Test_removeSystemQOSCPUs(t *testing.T) {
 		})
 	}
 }
+
+func Test_calCPUTopology(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tests := []struct {
+		name            string
+		processorInfos  []koordletutil.ProcessorInfo
+		wantCPUTopology *extension.CPUTopology
+	}{
+		{
+			name: "skip offline cpus when SMT is turned off",
+			processorInfos: []koordletutil.ProcessorInfo{
+				{CPUID: 0, CoreID: 0, NodeID: 0, SocketID: 0, Online: "yes"},
+				{CPUID: 1, CoreID: 1, NodeID: 0, SocketID: 0, Online: "yes"},
+				{CPUID: 2, CoreID: 0, NodeID: 0, SocketID: 0, Online: "no"},
+				{CPUID: 3, CoreID: 1, NodeID: 0, SocketID: 0, Online: "no"},
+			},
+			wantCPUTopology: &extension.CPUTopology{
+				Detail: []extension.CPUInfo{
+					{ID: 0, Core: 0, Socket: 0, Node: 0},
+					{ID: 1, Core: 1, Socket: 0, Node: 0},
+				},
+				SMTDisabled: true,
+			},
+		},
+		{
+			name: "report core types of hybrid cpus",
+			processorInfos: []koordletutil.ProcessorInfo{
+				{CPUID: 0, CoreID: 0, NodeID: 0, SocketID: 0, Online: "yes", CoreType: "Performance"},
+				{CPUID: 1, CoreID: 0, NodeID: 0, SocketID: 0, Online: "yes", CoreType: "Performance"},
+				{CPUID: 2, CoreID: 1, NodeID: 0, SocketID: 0, Online: "yes", CoreType: "Efficiency"},
+				{CPUID: 3, CoreID: 2, NodeID: 0, SocketID: 0, Online: "yes", CoreType: "Efficiency"},
+			},
+			wantCPUTopology: &extension.CPUTopology{
+				Detail: []extension.CPUInfo{
+					{ID: 0, Core: 0, Socket: 0, Node: 0, CoreType: extension.CPUCoreTypePerformance},
+					{ID: 1, Core: 0, Socket: 0, Node: 0, CoreType: extension.CPUCoreTypePerformance},
+					{ID: 2, Core: 1, Socket: 0, Node: 0, CoreType: extension.CPUCoreTypeEfficiency},
+					{ID: 3, Core: 2, Socket: 0, Node: 0, CoreType: extension.CPUCoreTypeEfficiency},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+			mockMetricCache.EXPECT().Get(metriccache.NodeCPUInfoKey).Return(&metriccache.NodeCPUInfo{
+				ProcessorInfos: tt.processorInfos,
+			}, true).AnyTimes()
+			s := &nodeTopoInformer{
+				metricCache: mockMetricCache,
+			}
+			_, cpuTopology, _, err := s.calCPUTopology()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCPUTopology, cpuTopology)
+		})
+	}
+}
@@ -58,6 +58,8 @@ type ProcessorInfo struct {
 	L3 int32 `json:"l3"`
 	// online
 	Online string `json:"online"`
+	// core type on the heterogeneous CPUs, e.g. Performance, Efficiency
+	CoreType string `json:"coreType,omitempty"`
 }
 
 // CPUTotalInfo describes the total number infos of the local cpu, e.g. the number of cores, the number of numa nodes
@@ -201,6 +203,22 @@ func getProcessorInfos(lsCPUStr string) ([]ProcessorInfo, error) {
 	return processorInfos, nil
 }
 
+// fillProcessorCoreTypes sets the core types of the processors if the CPUs are heterogeneous
+func fillProcessorCoreTypes(processorInfos []ProcessorInfo) {
+	cpuIDs := make([]int32, 0, len(processorInfos))
+	for _, p := range processorInfos {
+		cpuIDs = append(cpuIDs, p.CPUID)
+	}
+	coreTypes, err := system.GetCPUCoreTypes(cpuIDs)
+	if err != nil {
+		klog.V(4).Infof("failed to get cpu core types, err: %v", err)
+		return
+	}
+	for i := range processorInfos {
+		processorInfos[i].CoreType = coreTypes[processorInfos[i].CPUID]
+	}
+}
+
 func calculateCPUTotalInfo(processorInfos []ProcessorInfo) *CPUTotalInfo {
 	cpuMap := map[int32]struct{}{}
 	coreMap := map[int32]struct{}{}
@@ -233,6 +251,7 @@ func GetLocalCPUInfo() (*LocalCPUInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	fillProcessorCoreTypes(processorInfos)
 	totalInfo := calculateCPUTotalInfo(processorInfos)
 	basicInfo, err := getCPUBasicInfo()
 	if err != nil {
@@ -136,6 +136,7 @@ const (
 	CPUWeightName    = "cpu.weight"
 
 	CPUSetCPUSName          = "cpuset.cpus"
+	CPUSetMemsName          = "cpuset.mems"
 	CPUSetCPUSEffectiveName = "cpuset.cpus.effective"
 
 	CPUAcctStatName           = "cpuacct.stat"
@@ -205,7 +206,8 @@ var (
 	CPUTasks     = DefaultFactory.New(CPUTasksName, CgroupCPUDir)
 	CPUProcs     = DefaultFactory.New(CPUProcsName, CgroupCPUDir)
 
-	CPUSet = DefaultFactory.New(CPUSetCPUSName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
+	CPUSet     = DefaultFactory.New(CPUSetCPUSName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
+	CPUSetMems = DefaultFactory.New(CPUSetMemsName, CgroupCPUSetDir).WithValidator(CPUSetCPUSValidator)
 
 	CPUAcctStat           = DefaultFactory.New(CPUAcctStatName, CgroupCPUAcctDir)
 	CPUAcctUsage          = DefaultFactory.New(CPUAcctUsageName, CgroupCPUAcctDir)
@@ -243,6 +245,7 @@ var (
 		CPUTasks,
 		CPUBVTWarpNs,
 		CPUSet,
+		CPUSetMems,
 		CPUAcctStat,
 		CPUAcctUsage,
 		CPUAcctCPUPressure,
@@ -283,6 +286,7 @@ var (
 	CPUAcctIOPressureV2     = DefaultFactory.NewV2(CPUAcctIOPressureName, CPUAcctIOPressureName).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
 
 	CPUSetV2                 = DefaultFactory.NewV2(CPUSetCPUSName, CPUSetCPUSName).WithValidator(CPUSetCPUSValidator)
+	CPUSetMemsV2             = DefaultFactory.NewV2(CPUSetMemsName, CPUSetMemsName).WithValidator(CPUSetCPUSValidator)
 	CPUSetEffectiveV2        = DefaultFactory.NewV2(CPUSetCPUSEffectiveName, CPUSetCPUSEffectiveName) // TODO: unify the R/W
 	CPUTasksV2               = DefaultFactory.NewV2(CPUTasksName, CPUThreadsName)
 	CPUProcsV2               = DefaultFactory.NewV2(CPUProcsName, CPUProcsName)
@@ -312,6 +316,7 @@ var (
 		CPUAcctMemoryPressureV2,
 		CPUAcctIOPressureV2,
 		CPUSetV2,
+		CPUSetMemsV2,
 		CPUSetEffectiveV2,
 		CPUTasksV2,
 		CPUProcsV2,
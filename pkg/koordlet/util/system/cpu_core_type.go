@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+const (
+	CPUCoreTypePerformance = "Performance"
+	CPUCoreTypeEfficiency  = "Efficiency"
+
+	// HybridPerformanceCPUsRelativePath lists the logical cpus of the performance cores on Intel hybrid CPUs.
+	HybridPerformanceCPUsRelativePath = "devices/cpu_core/cpus"
+	// HybridEfficiencyCPUsRelativePath lists the logical cpus of the efficiency cores on Intel hybrid CPUs.
+	HybridEfficiencyCPUsRelativePath = "devices/cpu_atom/cpus"
+	// CPUCapacityRelativePathFormat is the relative capacity of a logical cpu on the asymmetric CPUs, e.g. ARM big.LITTLE.
+	CPUCapacityRelativePathFormat = "devices/system/cpu/cpu%d/cpu_capacity"
+)
+
+// GetCPUCoreTypes returns the core types of the given logical cpus on the heterogeneous CPUs.
+// It returns nil if the cores of the node are homogeneous.
+// The Intel hybrid CPUs are recognized by the cpu lists of the cpu_core and cpu_atom pmu devices,
+// and the others (e.g. ARM big.LITTLE) are recognized by the cpu_capacity, where the cpus with the
+// max capacity are performance cores.
+func GetCPUCoreTypes(cpuIDs []int32) (map[int32]string, error) {
+	coreTypes, err := getHybridCPUCoreTypes()
+	if err != nil || coreTypes != nil {
+		return coreTypes, err
+	}
+	return getCPUCoreTypesByCapacity(cpuIDs)
+}
+
+func getHybridCPUCoreTypes() (map[int32]string, error) {
+	performanceCPUs, err := readCPUList(filepath.Join(GetSysRootDir(), HybridPerformanceCPUsRelativePath))
+	if err != nil {
+		return nil, err
+	}
+	efficiencyCPUs, err := readCPUList(filepath.Join(GetSysRootDir(), HybridEfficiencyCPUsRelativePath))
+	if err != nil {
+		return nil, err
+	}
+	if performanceCPUs.IsEmpty() || efficiencyCPUs.IsEmpty() {
+		return nil, nil
+	}
+	coreTypes := make(map[int32]string, performanceCPUs.Size()+efficiencyCPUs.Size())
+	for _, cpu := range performanceCPUs.ToSliceNoSort() {
+		coreTypes[int32(cpu)] = CPUCoreTypePerformance
+	}
+	for _, cpu := range efficiencyCPUs.ToSliceNoSort() {
+		coreTypes[int32(cpu)] = CPUCoreTypeEfficiency
+	}
+	return coreTypes, nil
+}
+
+func getCPUCoreTypesByCapacity(cpuIDs []int32) (map[int32]string, error) {
+	capacities := make(map[int32]int64, len(cpuIDs))
+	var maxCapacity int64
+	heterogeneous := false
+	for _, cpu := range cpuIDs {
+		content, err := os.ReadFile(filepath.Join(GetSysRootDir(), fmt.Sprintf(CPUCapacityRelativePathFormat, cpu)))
+		if os.IsNotExist(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		capacity, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cpu_capacity of cpu %d, err: %w", cpu, err)
+		}
+		if len(capacities) > 0 && capacity != maxCapacity {
+			heterogeneous = true
+		}
+		if capacity > maxCapacity {
+			maxCapacity = capacity
+		}
+		capacities[cpu] = capacity
+	}
+	if !heterogeneous {
+		return nil, nil
+	}
+	coreTypes := make(map[int32]string, len(capacities))
+	for cpu, capacity := range capacities {
+		if capacity == maxCapacity {
+			coreTypes[cpu] = CPUCoreTypePerformance
+		} else {
+			coreTypes[cpu] = CPUCoreTypeEfficiency
+		}
+	}
+	return coreTypes, nil
+}
+
+func readCPUList(path string) (cpuset.CPUSet, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cpuset.NewCPUSet(), nil
+	} else if err != nil {
+		return cpuset.NewCPUSet(), err
+	}
+	return cpuset.Parse(strings.TrimSpace(string(content)))
+}
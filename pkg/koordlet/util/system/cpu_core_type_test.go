@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCPUCoreTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		cpuIDs  []int32
+		files   map[string]string
+		want    map[int32]string
+		wantErr bool
+	}{
+		{
+			name:   "homogeneous cpus without sysfs files",
+			cpuIDs: []int32{0, 1},
+			want:   nil,
+		},
+		{
+			name:   "intel hybrid cpus",
+			cpuIDs: []int32{0, 1, 2, 3},
+			files: map[string]string{
+				HybridPerformanceCPUsRelativePath: "0-1\n",
+				HybridEfficiencyCPUsRelativePath:  "2-3\n",
+			},
+			want: map[int32]string{
+				0: CPUCoreTypePerformance,
+				1: CPUCoreTypePerformance,
+				2: CPUCoreTypeEfficiency,
+				3: CPUCoreTypeEfficiency,
+			},
+		},
+		{
+			name:   "big.LITTLE cpus",
+			cpuIDs: []int32{0, 1, 2},
+			files: map[string]string{
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 0): "446\n",
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 1): "1024\n",
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 2): "1024\n",
+			},
+			want: map[int32]string{
+				0: CPUCoreTypeEfficiency,
+				1: CPUCoreTypePerformance,
+				2: CPUCoreTypePerformance,
+			},
+		},
+		{
+			name:   "symmetric cpus with the same capacity",
+			cpuIDs: []int32{0, 1},
+			files: map[string]string{
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 0): "1024\n",
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 1): "1024\n",
+			},
+			want: nil,
+		},
+		{
+			name:   "invalid cpu capacity",
+			cpuIDs: []int32{0},
+			files: map[string]string{
+				fmt.Sprintf(CPUCapacityRelativePathFormat, 0): "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := NewFileTestUtil(t)
+			defer helper.Cleanup()
+			for file, content := range tt.files {
+				helper.WriteFileContents(file, content)
+			}
+			got, err := GetCPUCoreTypes(tt.cpuIDs)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
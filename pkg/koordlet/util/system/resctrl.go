@@ -43,6 +43,12 @@ const (
 	ResctrlCbmMaskName  string = "cbm_mask"
 	ResctrlTasksName    string = "tasks"
 
+	// ResctrlMonDataDir is the directory of the monitoring data of a resctrl group
+	ResctrlMonDataDir string = "mon_data"
+	// ResctrlMonL3Prefix is the prefix of the monitoring directories of L3 domains, e.g. mon_L3_00
+	ResctrlMonL3Prefix       string = "mon_L3_"
+	ResctrlMBMTotalBytesName string = "mbm_total_bytes"
+
 	// L3SchemataPrefix is the prefix of l3 cat schemata
 	L3SchemataPrefix = "L3"
 	// MbSchemataPrefix is the prefix of mba schemata
@@ -462,6 +468,72 @@ func ReadResctrlTasksMap(groupPath string) (map[int32]struct{}, error) {
 	return tasksMap, nil
 }
 
+// ReadResctrlMBMTotalBytes reads and returns the total memory bandwidth bytes of the given resctrl group counted by
+// the memory bandwidth monitoring (MBM), which sums up the counters of all L3 domains.
+// e.g. /sys/fs/resctrl/mon_data/mon_L3_00/mbm_total_bytes + /sys/fs/resctrl/mon_data/mon_L3_01/mbm_total_bytes
+func ReadResctrlMBMTotalBytes(groupPath string) (uint64, error) {
+	monDataDir := filepath.Join(GetResctrlGroupRootDirPath(groupPath), ResctrlMonDataDir)
+	entries, err := os.ReadDir(monDataDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	found := false
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), ResctrlMonL3Prefix) {
+			continue
+		}
+		mbmPath := filepath.Join(monDataDir, entry.Name(), ResctrlMBMTotalBytesName)
+		content, err := os.ReadFile(mbmPath)
+		if err != nil {
+			return 0, err
+		}
+		// the counter is "Unavailable" when the monitoring is not ready
+		value, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse mbm_total_bytes, path %s, err: %v", mbmPath, err)
+		}
+		total += value
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no mbm_total_bytes found in %s", monDataDir)
+	}
+	return total, nil
+}
+
+// ReadResctrlNodeMBMTotalBytes reads and returns the total memory bandwidth bytes of the node, which sums up the
+// MBM counters of the root group and all control groups (e.g. LSR, LS, BE), since each task is only counted by the
+// control group it belongs to.
+// e.g. /sys/fs/resctrl/mon_data/... + /sys/fs/resctrl/LSR/mon_data/... + /sys/fs/resctrl/BE/mon_data/...
+func ReadResctrlNodeMBMTotalBytes() (uint64, error) {
+	total, err := ReadResctrlMBMTotalBytes("")
+	if err != nil {
+		return 0, err
+	}
+	entries, err := os.ReadDir(GetResctrlGroupRootDirPath(""))
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		// only the control groups have the monitoring data besides the root group,
+		// the directories like info, mon_data and mon_groups are skipped
+		if !entry.IsDir() || entry.Name() == ResctrlMonDataDir {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(GetResctrlGroupRootDirPath(entry.Name()), ResctrlMonDataDir)); err != nil {
+			continue
+		}
+		groupBytes, err := ReadResctrlMBMTotalBytes(entry.Name())
+		if err != nil {
+			return 0, err
+		}
+		total += groupBytes
+	}
+	return total, nil
+}
+
 // CheckAndTryEnableResctrlCat checks if resctrl and l3_cat are enabled; if not, try to enable the features by mount
 // resctrl subsystem; See MountResctrlSubsystem() for the detail.
 // It returns whether the resctrl cat is enabled, and the error if failed to enable or to check resctrl interfaces
@@ -96,6 +96,111 @@ func Test_ReadResctrlTasksMap(t *testing.T) {
 	}
 }
 
+func Test_ReadResctrlMBMTotalBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mbm     map[string]string
+		want    uint64
+		wantErr bool
+	}{
+		{
+			name:    "no mon_data",
+			wantErr: true,
+		},
+		{
+			name: "sum up all L3 domains",
+			mbm: map[string]string{
+				"mon_L3_00": "1000\n",
+				"mon_L3_01": "2000\n",
+			},
+			want: 3000,
+		},
+		{
+			name: "monitoring unavailable",
+			mbm: map[string]string{
+				"mon_L3_00": "Unavailable\n",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sysFSRootDir := t.TempDir()
+			for domain, value := range tt.mbm {
+				domainDir := filepath.Join(sysFSRootDir, ResctrlDir, ResctrlMonDataDir, domain)
+				assert.NoError(t, os.MkdirAll(domainDir, 0700))
+				assert.NoError(t, os.WriteFile(filepath.Join(domainDir, ResctrlMBMTotalBytesName), []byte(value), 0666))
+			}
+			Conf = &Config{
+				SysFSRootDir: sysFSRootDir,
+			}
+
+			got, err := ReadResctrlMBMTotalBytes("")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ReadResctrlNodeMBMTotalBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mbm     map[string]string
+		want    uint64
+		wantErr bool
+	}{
+		{
+			name:    "no mon_data",
+			wantErr: true,
+		},
+		{
+			name: "root group only",
+			mbm: map[string]string{
+				"mon_data/mon_L3_00": "1000\n",
+			},
+			want: 1000,
+		},
+		{
+			name: "sum up root group and control groups",
+			mbm: map[string]string{
+				"mon_data/mon_L3_00":                   "1000\n",
+				"LSR/mon_data/mon_L3_00":               "100\n",
+				"LS/mon_data/mon_L3_00":                "200\n",
+				"BE/mon_data/mon_L3_00":                "300\n",
+				"BE/mon_data/mon_L3_01":                "400\n",
+				"BE/mon_groups/pod1/mon_data/mon_L3_0": "300\n",
+			},
+			want: 2000,
+		},
+		{
+			name: "monitoring of control group unavailable",
+			mbm: map[string]string{
+				"mon_data/mon_L3_00":    "1000\n",
+				"BE/mon_data/mon_L3_00": "Unavailable\n",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sysFSRootDir := t.TempDir()
+			assert.NoError(t, os.MkdirAll(filepath.Join(sysFSRootDir, ResctrlDir, "info"), 0700))
+			for domain, value := range tt.mbm {
+				domainDir := filepath.Join(sysFSRootDir, ResctrlDir, domain)
+				assert.NoError(t, os.MkdirAll(domainDir, 0700))
+				assert.NoError(t, os.WriteFile(filepath.Join(domainDir, ResctrlMBMTotalBytesName), []byte(value), 0666))
+			}
+			Conf = &Config{
+				SysFSRootDir: sysFSRootDir,
+			}
+
+			got, err := ReadResctrlNodeMBMTotalBytes()
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestResctrlSchemataRaw(t *testing.T) {
 	type fields struct {
 		l3Num     int
@@ -18,7 +18,9 @@ package config
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
@@ -55,6 +57,31 @@ type LoadAwareSchedulingArgs struct {
 	EstimatedScalingFactors map[corev1.ResourceName]int64
 	// Aggregated supports resource utilization filtering and scoring based on percentile statistics
 	Aggregated *LoadAwareSchedulingAggregatedArgs
+	// WorkloadHistory holds the arguments of the workloadHistoryEstimator
+	// It is nil unless configured or the workloadHistoryEstimator is selected.
+	WorkloadHistory *WorkloadHistoryEstimatorArgs
+	// HourlyProfile supports scoring according to the hourly usage profile of nodes during the expected lifetime of pods
+	HourlyProfile *LoadAwareSchedulingHourlyProfileArgs
+}
+
+type LoadAwareSchedulingHourlyProfileArgs struct {
+	// DefaultPodLifetime indicates the expected lifetime of pods which declare neither the annotation
+	// scheduling.koordinator.sh/expected-lifetime nor spec.activeDeadlineSeconds.
+	// The lifetime longer than a day is considered as a whole day.
+	DefaultPodLifetime metav1.Duration
+}
+
+// WorkloadHistoryEstimatorName is the name of the Estimator estimating pods by the usage history of workloads.
+const WorkloadHistoryEstimatorName = "workloadHistoryEstimator"
+
+// WorkloadHistoryEstimatorArgs holds arguments used to configure the workloadHistoryEstimator,
+// which estimates pods according to the observed usage of the pods of the same workload.
+type WorkloadHistoryEstimatorArgs struct {
+	// DecayHalfLife indicates the half-life of the observed peak usage of the workload.
+	DecayHalfLife metav1.Duration
+	// ColdStartSamples indicates the minimum number of usage samples observed for the workload
+	// before the history is used. Workloads with fewer samples are estimated according to the requests.
+	ColdStartSamples int64
 }
 
 type LoadAwareSchedulingAggregatedArgs struct {
@@ -151,6 +178,8 @@ type ReservationArgs struct {
 	metav1.TypeMeta
 
 	// EnablePreemption indicates whether to enable preemption for reservations.
+	// If enabled, the available reservations which are not allocated yet can be preempted by the pods with
+	// higher priority, and the preempted reservations are marked as Failed with the reason Preempted.
 	EnablePreemption *bool
 }
 
@@ -180,8 +209,50 @@ type ElasticQuotaArgs struct {
 
 	// EnableCheckParentQuota check parentQuotaGroups' used and runtime Quota in PreFilter
 	EnableCheckParentQuota *bool
+
+	// RuntimeQuotaCalculatePolicy decides how to distribute the runtime quota among the child quota groups
+	RuntimeQuotaCalculatePolicy RuntimeQuotaCalculatePolicy
+
+	// QuotaUsageAccountingInterval is the interval to accumulate and persist the quotaGroups' usage accounting,
+	// nil or zero means disabled, which is the default
+	QuotaUsageAccountingInterval *metav1.Duration
+
+	// RevokePolicy decides how to revoke the pods of the quota groups whose used exceeds the runtime quota,
+	// the default is Evict.
+	RevokePolicy QuotaRevokePolicy
+
+	// MaxUnavailablePerWorkload limits the number of the pods of a workload being revoked at the same time,
+	// the percentage is calculated from the number of the pods of the workload in the quota group.
+	// Nil means unlimited.
+	MaxUnavailablePerWorkload *intstr.IntOrString
 }
 
+// RuntimeQuotaCalculatePolicy is a "string" type.
+type RuntimeQuotaCalculatePolicy string
+
+const (
+	// RuntimeQuotaCalculateBySharedWeight distributes the runtime quota by the shared weight
+	// in each resource dimension independently.
+	RuntimeQuotaCalculateBySharedWeight RuntimeQuotaCalculatePolicy = "SharedWeight"
+	// RuntimeQuotaCalculateByDominantResourceFairness distributes the runtime quota by weighted
+	// Dominant Resource Fairness across all resource dimensions.
+	RuntimeQuotaCalculateByDominantResourceFairness RuntimeQuotaCalculatePolicy = "DominantResourceFairness"
+)
+
+// QuotaRevokePolicy is a "string" type.
+type QuotaRevokePolicy string
+
+const (
+	// QuotaRevokeByEviction evicts the pods by the Eviction API immediately.
+	QuotaRevokeByEviction QuotaRevokePolicy = "Evict"
+	// QuotaRevokeByMigration creates the ReservationFirst PodMigrationJobs for the pods, so that the pods
+	// are migrated gracefully after the resources are reserved.
+	QuotaRevokeByMigration QuotaRevokePolicy = "Migrate"
+	// QuotaRevokeBySoftEviction marks the pods with the soft eviction annotation,
+	// and the pods are evicted by the workload controllers.
+	QuotaRevokeBySoftEviction QuotaRevokePolicy = "SoftEvict"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // CoschedulingArgs defines the parameters for Gang Scheduling plugin.
@@ -197,6 +268,23 @@ type CoschedulingArgs struct {
 	// Skip check schedule cycle
 	// default is false
 	SkipCheckScheduleCycle bool
+	// NetworkTopologyLevels is the node label keys of the network topology hierarchy,
+	// ordered from the narrowest domain to the broadest domain, e.g. rack, spine.
+	// Gangs with the network-topology-policy annotation are placed within one domain of these levels.
+	NetworkTopologyLevels []string
+	// EnablePreemption indicates whether to preempt lower priority pods for the whole GangGroup
+	// when a member pod is unschedulable.
+	// default is false
+	EnablePreemption *bool
+	// GangReservationThreshold is the waiting time after which the scheduler creates Reservations
+	// for the unschedulable members of a gang, so that the freed resources are held for the gang
+	// instead of being taken by smaller pods.
+	// default is nil, which disables gang reservation
+	GangReservationThreshold *metav1.Duration
+	// GangReservationBackfillMaxRuntime is the max runtime that a pod declares by spec.activeDeadlineSeconds
+	// to temporarily use the resources reserved for gangs.
+	// default is nil, which disables backfilling
+	GangReservationBackfillMaxRuntime *metav1.Duration
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -208,3 +296,34 @@ type DeviceShareArgs struct {
 	// Allocator indicates the expected allocator to use
 	Allocator string
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterferenceAwareArgs holds arguments used to configure the InterferenceAware plugin.
+type InterferenceAwareArgs struct {
+	metav1.TypeMeta
+
+	// NodeMetricExpirationSeconds indicates the NodeMetric expiration in seconds.
+	// The contention indicators of the expired NodeMetrics are ignored.
+	NodeMetricExpirationSeconds *int64
+	// ContentionThresholds indicates the contention thresholds of node. The latency-sensitive pods
+	// are not scheduled to the nodes whose contention exceeds any of the thresholds.
+	ContentionThresholds *ContentionThresholds
+}
+
+// ContentionThresholds defines the thresholds of the node contention indicators reported in NodeMetric.
+// The zero value disables the threshold of the indicator.
+type ContentionThresholds struct {
+	// CPUPressurePercent is the threshold of the cpu pressure (PSI) in percentage
+	CPUPressurePercent int64
+	// MemoryPressurePercent is the threshold of the memory pressure (PSI) in percentage
+	MemoryPressurePercent int64
+	// IOPressurePercent is the threshold of the io pressure (PSI) in percentage
+	IOPressurePercent int64
+	// CPIOutlierPercent is the threshold of the percentage of containers whose CPI is an outlier
+	CPIOutlierPercent int64
+	// CPUThrottledPercent is the threshold of the average cpu throttled ratio of pods in percentage
+	CPUThrottledPercent int64
+	// MemoryBandwidth is the threshold of the memory bandwidth usage of node in bytes per second
+	MemoryBandwidth *resource.Quantity
+}
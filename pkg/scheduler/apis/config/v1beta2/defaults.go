@@ -25,6 +25,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
 var (
@@ -45,6 +47,10 @@ var (
 		corev1.ResourceMemory: 70, // 70%
 	}
 
+	defaultWorkloadHistoryDecayHalfLife          = 12 * time.Hour
+	defaultWorkloadHistoryColdStartSamples int64 = 3
+	defaultHourlyProfilePodLifetime              = 24 * time.Hour
+
 	defaultPreferredCPUBindPolicy          = CPUBindPolicyFullPCPUs
 	defaultNodeNUMAResourceScoringStrategy = &ScoringStrategy{
 		Type: MostAllocated,
@@ -77,6 +83,11 @@ var (
 
 	defaultTimeout           = 600 * time.Second
 	defaultControllerWorkers = 1
+
+	defaultContentionCPUPressurePercent    int64 = 20
+	defaultContentionMemoryPressurePercent int64 = 10
+	defaultContentionCPIOutlierPercent     int64 = 30
+	defaultContentionCPUThrottledPercent   int64 = 20
 )
 
 // SetDefaults_LoadAwareSchedulingArgs sets the default parameters for LoadAwareScheduling plugin.
@@ -102,6 +113,20 @@ func SetDefaults_LoadAwareSchedulingArgs(obj *LoadAwareSchedulingArgs) {
 			}
 		}
 	}
+	if obj.WorkloadHistory == nil && obj.Estimator == config.WorkloadHistoryEstimatorName {
+		obj.WorkloadHistory = &WorkloadHistoryEstimatorArgs{}
+	}
+	if obj.WorkloadHistory != nil {
+		if obj.WorkloadHistory.DecayHalfLife == nil {
+			obj.WorkloadHistory.DecayHalfLife = &metav1.Duration{Duration: defaultWorkloadHistoryDecayHalfLife}
+		}
+		if obj.WorkloadHistory.ColdStartSamples == nil {
+			obj.WorkloadHistory.ColdStartSamples = pointer.Int64(defaultWorkloadHistoryColdStartSamples)
+		}
+	}
+	if obj.HourlyProfile != nil && obj.HourlyProfile.DefaultPodLifetime == nil {
+		obj.HourlyProfile.DefaultPodLifetime = &metav1.Duration{Duration: defaultHourlyProfilePodLifetime}
+	}
 }
 
 // SetDefaults_NodeNUMAResourceArgs sets the default parameters for NodeNUMANodeResource plugin.
@@ -146,6 +171,12 @@ func SetDefaults_ElasticQuotaArgs(obj *ElasticQuotaArgs) {
 	if obj.EnableCheckParentQuota == nil {
 		obj.EnableCheckParentQuota = defaultEnableCheckParentQuota
 	}
+	if obj.RuntimeQuotaCalculatePolicy == "" {
+		obj.RuntimeQuotaCalculatePolicy = RuntimeQuotaCalculateBySharedWeight
+	}
+	if obj.RevokePolicy == "" {
+		obj.RevokePolicy = QuotaRevokeByEviction
+	}
 }
 
 func SetDefaults_CoschedulingArgs(obj *CoschedulingArgs) {
@@ -157,4 +188,29 @@ func SetDefaults_CoschedulingArgs(obj *CoschedulingArgs) {
 	if obj.ControllerWorkers == nil {
 		obj.ControllerWorkers = pointer.Int64(int64(defaultControllerWorkers))
 	}
+	if obj.EnablePreemption == nil {
+		obj.EnablePreemption = defaultEnablePreemption
+	}
+}
+
+// SetDefaults_InterferenceAwareArgs sets the default parameters for InterferenceAware plugin.
+func SetDefaults_InterferenceAwareArgs(obj *InterferenceAwareArgs) {
+	if obj.NodeMetricExpirationSeconds == nil {
+		obj.NodeMetricExpirationSeconds = pointer.Int64(defaultNodeMetricExpirationSeconds)
+	}
+	if obj.ContentionThresholds == nil {
+		obj.ContentionThresholds = &ContentionThresholds{}
+	}
+	if obj.ContentionThresholds.CPUPressurePercent == nil {
+		obj.ContentionThresholds.CPUPressurePercent = pointer.Int64(defaultContentionCPUPressurePercent)
+	}
+	if obj.ContentionThresholds.MemoryPressurePercent == nil {
+		obj.ContentionThresholds.MemoryPressurePercent = pointer.Int64(defaultContentionMemoryPressurePercent)
+	}
+	if obj.ContentionThresholds.CPIOutlierPercent == nil {
+		obj.ContentionThresholds.CPIOutlierPercent = pointer.Int64(defaultContentionCPIOutlierPercent)
+	}
+	if obj.ContentionThresholds.CPUThrottledPercent == nil {
+		obj.ContentionThresholds.CPUThrottledPercent = pointer.Int64(defaultContentionCPUThrottledPercent)
+	}
 }
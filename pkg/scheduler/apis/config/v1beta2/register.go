@@ -40,6 +40,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ElasticQuotaArgs{},
 		&CoschedulingArgs{},
 		&DeviceShareArgs{},
+		&InterferenceAwareArgs{},
 	)
 	return nil
 }
@@ -27,9 +27,11 @@ import (
 	v1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	config "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	apisconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 )
 
@@ -40,6 +42,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ContentionThresholds)(nil), (*config.ContentionThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ContentionThresholds_To_config_ContentionThresholds(a.(*ContentionThresholds), b.(*config.ContentionThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ContentionThresholds)(nil), (*ContentionThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ContentionThresholds_To_v1beta2_ContentionThresholds(a.(*config.ContentionThresholds), b.(*ContentionThresholds), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CoschedulingArgs)(nil), (*config.CoschedulingArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_CoschedulingArgs_To_config_CoschedulingArgs(a.(*CoschedulingArgs), b.(*config.CoschedulingArgs), scope)
 	}); err != nil {
@@ -70,6 +82,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceAwareArgs)(nil), (*config.InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(a.(*InterferenceAwareArgs), b.(*config.InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceAwareArgs)(nil), (*InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceAwareArgs_To_v1beta2_InterferenceAwareArgs(a.(*config.InterferenceAwareArgs), b.(*InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadAwareSchedulingAggregatedArgs)(nil), (*config.LoadAwareSchedulingAggregatedArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_LoadAwareSchedulingAggregatedArgs_To_config_LoadAwareSchedulingAggregatedArgs(a.(*LoadAwareSchedulingAggregatedArgs), b.(*config.LoadAwareSchedulingAggregatedArgs), scope)
 	}); err != nil {
@@ -90,6 +112,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadAwareSchedulingHourlyProfileArgs)(nil), (*config.LoadAwareSchedulingHourlyProfileArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs(a.(*LoadAwareSchedulingHourlyProfileArgs), b.(*config.LoadAwareSchedulingHourlyProfileArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LoadAwareSchedulingHourlyProfileArgs)(nil), (*LoadAwareSchedulingHourlyProfileArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs(a.(*config.LoadAwareSchedulingHourlyProfileArgs), b.(*LoadAwareSchedulingHourlyProfileArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeNUMAResourceArgs)(nil), (*config.NodeNUMAResourceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_NodeNUMAResourceArgs_To_config_NodeNUMAResourceArgs(a.(*NodeNUMAResourceArgs), b.(*config.NodeNUMAResourceArgs), scope)
 	}); err != nil {
@@ -120,15 +152,79 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadHistoryEstimatorArgs)(nil), (*config.WorkloadHistoryEstimatorArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs(a.(*WorkloadHistoryEstimatorArgs), b.(*config.WorkloadHistoryEstimatorArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.WorkloadHistoryEstimatorArgs)(nil), (*WorkloadHistoryEstimatorArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs(a.(*config.WorkloadHistoryEstimatorArgs), b.(*WorkloadHistoryEstimatorArgs), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta2_ContentionThresholds_To_config_ContentionThresholds(in *ContentionThresholds, out *config.ContentionThresholds, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_int64_To_int64(&in.CPUPressurePercent, &out.CPUPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.MemoryPressurePercent, &out.MemoryPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.IOPressurePercent, &out.IOPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.CPIOutlierPercent, &out.CPIOutlierPercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.CPUThrottledPercent, &out.CPUThrottledPercent, s); err != nil {
+		return err
+	}
+	out.MemoryBandwidth = (*resource.Quantity)(unsafe.Pointer(in.MemoryBandwidth))
 	return nil
 }
 
+// Convert_v1beta2_ContentionThresholds_To_config_ContentionThresholds is an autogenerated conversion function.
+func Convert_v1beta2_ContentionThresholds_To_config_ContentionThresholds(in *ContentionThresholds, out *config.ContentionThresholds, s conversion.Scope) error {
+	return autoConvert_v1beta2_ContentionThresholds_To_config_ContentionThresholds(in, out, s)
+}
+
+func autoConvert_config_ContentionThresholds_To_v1beta2_ContentionThresholds(in *config.ContentionThresholds, out *ContentionThresholds, s conversion.Scope) error {
+	if err := v1.Convert_int64_To_Pointer_int64(&in.CPUPressurePercent, &out.CPUPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.MemoryPressurePercent, &out.MemoryPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.IOPressurePercent, &out.IOPressurePercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.CPIOutlierPercent, &out.CPIOutlierPercent, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.CPUThrottledPercent, &out.CPUThrottledPercent, s); err != nil {
+		return err
+	}
+	out.MemoryBandwidth = (*resource.Quantity)(unsafe.Pointer(in.MemoryBandwidth))
+	return nil
+}
+
+// Convert_config_ContentionThresholds_To_v1beta2_ContentionThresholds is an autogenerated conversion function.
+func Convert_config_ContentionThresholds_To_v1beta2_ContentionThresholds(in *config.ContentionThresholds, out *ContentionThresholds, s conversion.Scope) error {
+	return autoConvert_config_ContentionThresholds_To_v1beta2_ContentionThresholds(in, out, s)
+}
+
 func autoConvert_v1beta2_CoschedulingArgs_To_config_CoschedulingArgs(in *CoschedulingArgs, out *config.CoschedulingArgs, s conversion.Scope) error {
 	out.DefaultTimeout = (*v1.Duration)(unsafe.Pointer(in.DefaultTimeout))
 	out.ControllerWorkers = (*int64)(unsafe.Pointer(in.ControllerWorkers))
 	if err := v1.Convert_Pointer_bool_To_bool(&in.SkipCheckScheduleCycle, &out.SkipCheckScheduleCycle, s); err != nil {
 		return err
 	}
+	out.NetworkTopologyLevels = *(*[]string)(unsafe.Pointer(&in.NetworkTopologyLevels))
+	out.EnablePreemption = (*bool)(unsafe.Pointer(in.EnablePreemption))
+	out.GangReservationThreshold = (*v1.Duration)(unsafe.Pointer(in.GangReservationThreshold))
+	out.GangReservationBackfillMaxRuntime = (*v1.Duration)(unsafe.Pointer(in.GangReservationBackfillMaxRuntime))
 	return nil
 }
 
@@ -143,6 +239,10 @@ func autoConvert_config_CoschedulingArgs_To_v1beta2_CoschedulingArgs(in *config.
 	if err := v1.Convert_bool_To_Pointer_bool(&in.SkipCheckScheduleCycle, &out.SkipCheckScheduleCycle, s); err != nil {
 		return err
 	}
+	out.NetworkTopologyLevels = *(*[]string)(unsafe.Pointer(&in.NetworkTopologyLevels))
+	out.EnablePreemption = (*bool)(unsafe.Pointer(in.EnablePreemption))
+	out.GangReservationThreshold = (*v1.Duration)(unsafe.Pointer(in.GangReservationThreshold))
+	out.GangReservationBackfillMaxRuntime = (*v1.Duration)(unsafe.Pointer(in.GangReservationBackfillMaxRuntime))
 	return nil
 }
 
@@ -179,6 +279,10 @@ func autoConvert_v1beta2_ElasticQuotaArgs_To_config_ElasticQuotaArgs(in *Elastic
 	out.QuotaGroupNamespace = in.QuotaGroupNamespace
 	out.MonitorAllQuotas = (*bool)(unsafe.Pointer(in.MonitorAllQuotas))
 	out.EnableCheckParentQuota = (*bool)(unsafe.Pointer(in.EnableCheckParentQuota))
+	out.RuntimeQuotaCalculatePolicy = config.RuntimeQuotaCalculatePolicy(in.RuntimeQuotaCalculatePolicy)
+	out.QuotaUsageAccountingInterval = (*v1.Duration)(unsafe.Pointer(in.QuotaUsageAccountingInterval))
+	out.RevokePolicy = config.QuotaRevokePolicy(in.RevokePolicy)
+	out.MaxUnavailablePerWorkload = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailablePerWorkload))
 	return nil
 }
 
@@ -195,6 +299,10 @@ func autoConvert_config_ElasticQuotaArgs_To_v1beta2_ElasticQuotaArgs(in *config.
 	out.QuotaGroupNamespace = in.QuotaGroupNamespace
 	out.MonitorAllQuotas = (*bool)(unsafe.Pointer(in.MonitorAllQuotas))
 	out.EnableCheckParentQuota = (*bool)(unsafe.Pointer(in.EnableCheckParentQuota))
+	out.RuntimeQuotaCalculatePolicy = RuntimeQuotaCalculatePolicy(in.RuntimeQuotaCalculatePolicy)
+	out.QuotaUsageAccountingInterval = (*v1.Duration)(unsafe.Pointer(in.QuotaUsageAccountingInterval))
+	out.RevokePolicy = QuotaRevokePolicy(in.RevokePolicy)
+	out.MaxUnavailablePerWorkload = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailablePerWorkload))
 	return nil
 }
 
@@ -203,6 +311,44 @@ func Convert_config_ElasticQuotaArgs_To_v1beta2_ElasticQuotaArgs(in *config.Elas
 	return autoConvert_config_ElasticQuotaArgs_To_v1beta2_ElasticQuotaArgs(in, out, s)
 }
 
+func autoConvert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	if in.ContentionThresholds != nil {
+		in, out := &in.ContentionThresholds, &out.ContentionThresholds
+		*out = new(config.ContentionThresholds)
+		if err := Convert_v1beta2_ContentionThresholds_To_config_ContentionThresholds(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ContentionThresholds = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in, out, s)
+}
+
+func autoConvert_config_InterferenceAwareArgs_To_v1beta2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	if in.ContentionThresholds != nil {
+		in, out := &in.ContentionThresholds, &out.ContentionThresholds
+		*out = new(ContentionThresholds)
+		if err := Convert_config_ContentionThresholds_To_v1beta2_ContentionThresholds(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ContentionThresholds = nil
+	}
+	return nil
+}
+
+// Convert_config_InterferenceAwareArgs_To_v1beta2_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_config_InterferenceAwareArgs_To_v1beta2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_config_InterferenceAwareArgs_To_v1beta2_InterferenceAwareArgs(in, out, s)
+}
+
 func autoConvert_v1beta2_LoadAwareSchedulingAggregatedArgs_To_config_LoadAwareSchedulingAggregatedArgs(in *LoadAwareSchedulingAggregatedArgs, out *config.LoadAwareSchedulingAggregatedArgs, s conversion.Scope) error {
 	out.UsageThresholds = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.UsageThresholds))
 	out.UsageAggregationType = v1alpha1.AggregationType(in.UsageAggregationType)
@@ -259,6 +405,24 @@ func autoConvert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingAr
 	} else {
 		out.Aggregated = nil
 	}
+	if in.WorkloadHistory != nil {
+		in, out := &in.WorkloadHistory, &out.WorkloadHistory
+		*out = new(config.WorkloadHistoryEstimatorArgs)
+		if err := Convert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.WorkloadHistory = nil
+	}
+	if in.HourlyProfile != nil {
+		in, out := &in.HourlyProfile, &out.HourlyProfile
+		*out = new(config.LoadAwareSchedulingHourlyProfileArgs)
+		if err := Convert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HourlyProfile = nil
+	}
 	return nil
 }
 
@@ -287,6 +451,24 @@ func autoConvert_config_LoadAwareSchedulingArgs_To_v1beta2_LoadAwareSchedulingAr
 	} else {
 		out.Aggregated = nil
 	}
+	if in.WorkloadHistory != nil {
+		in, out := &in.WorkloadHistory, &out.WorkloadHistory
+		*out = new(WorkloadHistoryEstimatorArgs)
+		if err := Convert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.WorkloadHistory = nil
+	}
+	if in.HourlyProfile != nil {
+		in, out := &in.HourlyProfile, &out.HourlyProfile
+		*out = new(LoadAwareSchedulingHourlyProfileArgs)
+		if err := Convert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HourlyProfile = nil
+	}
 	return nil
 }
 
@@ -295,6 +477,30 @@ func Convert_config_LoadAwareSchedulingArgs_To_v1beta2_LoadAwareSchedulingArgs(i
 	return autoConvert_config_LoadAwareSchedulingArgs_To_v1beta2_LoadAwareSchedulingArgs(in, out, s)
 }
 
+func autoConvert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs(in *LoadAwareSchedulingHourlyProfileArgs, out *config.LoadAwareSchedulingHourlyProfileArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.DefaultPodLifetime, &out.DefaultPodLifetime, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs is an autogenerated conversion function.
+func Convert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs(in *LoadAwareSchedulingHourlyProfileArgs, out *config.LoadAwareSchedulingHourlyProfileArgs, s conversion.Scope) error {
+	return autoConvert_v1beta2_LoadAwareSchedulingHourlyProfileArgs_To_config_LoadAwareSchedulingHourlyProfileArgs(in, out, s)
+}
+
+func autoConvert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs(in *config.LoadAwareSchedulingHourlyProfileArgs, out *LoadAwareSchedulingHourlyProfileArgs, s conversion.Scope) error {
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.DefaultPodLifetime, &out.DefaultPodLifetime, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs is an autogenerated conversion function.
+func Convert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs(in *config.LoadAwareSchedulingHourlyProfileArgs, out *LoadAwareSchedulingHourlyProfileArgs, s conversion.Scope) error {
+	return autoConvert_config_LoadAwareSchedulingHourlyProfileArgs_To_v1beta2_LoadAwareSchedulingHourlyProfileArgs(in, out, s)
+}
+
 func autoConvert_v1beta2_NodeNUMAResourceArgs_To_config_NodeNUMAResourceArgs(in *NodeNUMAResourceArgs, out *config.NodeNUMAResourceArgs, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_string_To_string(&in.DefaultCPUBindPolicy, &out.DefaultCPUBindPolicy, s); err != nil {
 		return err
@@ -362,3 +568,33 @@ func autoConvert_config_ScoringStrategy_To_v1beta2_ScoringStrategy(in *config.Sc
 func Convert_config_ScoringStrategy_To_v1beta2_ScoringStrategy(in *config.ScoringStrategy, out *ScoringStrategy, s conversion.Scope) error {
 	return autoConvert_config_ScoringStrategy_To_v1beta2_ScoringStrategy(in, out, s)
 }
+
+func autoConvert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs(in *WorkloadHistoryEstimatorArgs, out *config.WorkloadHistoryEstimatorArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.DecayHalfLife, &out.DecayHalfLife, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.ColdStartSamples, &out.ColdStartSamples, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs is an autogenerated conversion function.
+func Convert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs(in *WorkloadHistoryEstimatorArgs, out *config.WorkloadHistoryEstimatorArgs, s conversion.Scope) error {
+	return autoConvert_v1beta2_WorkloadHistoryEstimatorArgs_To_config_WorkloadHistoryEstimatorArgs(in, out, s)
+}
+
+func autoConvert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs(in *config.WorkloadHistoryEstimatorArgs, out *WorkloadHistoryEstimatorArgs, s conversion.Scope) error {
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.DecayHalfLife, &out.DecayHalfLife, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.ColdStartSamples, &out.ColdStartSamples, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs is an autogenerated conversion function.
+func Convert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs(in *config.WorkloadHistoryEstimatorArgs, out *WorkloadHistoryEstimatorArgs, s conversion.Scope) error {
+	return autoConvert_config_WorkloadHistoryEstimatorArgs_To_v1beta2_WorkloadHistoryEstimatorArgs(in, out, s)
+}
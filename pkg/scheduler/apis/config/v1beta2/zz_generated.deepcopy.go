@@ -25,9 +25,56 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	config "k8s.io/kubernetes/pkg/scheduler/apis/config"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentionThresholds) DeepCopyInto(out *ContentionThresholds) {
+	*out = *in
+	if in.CPUPressurePercent != nil {
+		in, out := &in.CPUPressurePercent, &out.CPUPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryPressurePercent != nil {
+		in, out := &in.MemoryPressurePercent, &out.MemoryPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IOPressurePercent != nil {
+		in, out := &in.IOPressurePercent, &out.IOPressurePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPIOutlierPercent != nil {
+		in, out := &in.CPIOutlierPercent, &out.CPIOutlierPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPUThrottledPercent != nil {
+		in, out := &in.CPUThrottledPercent, &out.CPUThrottledPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryBandwidth != nil {
+		in, out := &in.MemoryBandwidth, &out.MemoryBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentionThresholds.
+func (in *ContentionThresholds) DeepCopy() *ContentionThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentionThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoschedulingArgs) DeepCopyInto(out *CoschedulingArgs) {
 	*out = *in
@@ -47,6 +94,26 @@ func (in *CoschedulingArgs) DeepCopyInto(out *CoschedulingArgs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NetworkTopologyLevels != nil {
+		in, out := &in.NetworkTopologyLevels, &out.NetworkTopologyLevels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnablePreemption != nil {
+		in, out := &in.EnablePreemption, &out.EnablePreemption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GangReservationThreshold != nil {
+		in, out := &in.GangReservationThreshold, &out.GangReservationThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GangReservationBackfillMaxRuntime != nil {
+		in, out := &in.GangReservationBackfillMaxRuntime, &out.GangReservationBackfillMaxRuntime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -131,6 +198,16 @@ func (in *ElasticQuotaArgs) DeepCopyInto(out *ElasticQuotaArgs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.QuotaUsageAccountingInterval != nil {
+		in, out := &in.QuotaUsageAccountingInterval, &out.QuotaUsageAccountingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxUnavailablePerWorkload != nil {
+		in, out := &in.MaxUnavailablePerWorkload, &out.MaxUnavailablePerWorkload
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -152,6 +229,41 @@ func (in *ElasticQuotaArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceAwareArgs) DeepCopyInto(out *InterferenceAwareArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.NodeMetricExpirationSeconds != nil {
+		in, out := &in.NodeMetricExpirationSeconds, &out.NodeMetricExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ContentionThresholds != nil {
+		in, out := &in.ContentionThresholds, &out.ContentionThresholds
+		*out = new(ContentionThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceAwareArgs.
+func (in *InterferenceAwareArgs) DeepCopy() *InterferenceAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceAwareArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterferenceAwareArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAwareSchedulingAggregatedArgs) DeepCopyInto(out *LoadAwareSchedulingAggregatedArgs) {
 	*out = *in
@@ -237,6 +349,16 @@ func (in *LoadAwareSchedulingArgs) DeepCopyInto(out *LoadAwareSchedulingArgs) {
 		*out = new(LoadAwareSchedulingAggregatedArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkloadHistory != nil {
+		in, out := &in.WorkloadHistory, &out.WorkloadHistory
+		*out = new(WorkloadHistoryEstimatorArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HourlyProfile != nil {
+		in, out := &in.HourlyProfile, &out.HourlyProfile
+		*out = new(LoadAwareSchedulingHourlyProfileArgs)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -258,6 +380,27 @@ func (in *LoadAwareSchedulingArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAwareSchedulingHourlyProfileArgs) DeepCopyInto(out *LoadAwareSchedulingHourlyProfileArgs) {
+	*out = *in
+	if in.DefaultPodLifetime != nil {
+		in, out := &in.DefaultPodLifetime, &out.DefaultPodLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAwareSchedulingHourlyProfileArgs.
+func (in *LoadAwareSchedulingHourlyProfileArgs) DeepCopy() *LoadAwareSchedulingHourlyProfileArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareSchedulingHourlyProfileArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeNUMAResourceArgs) DeepCopyInto(out *NodeNUMAResourceArgs) {
 	*out = *in
@@ -343,3 +486,29 @@ func (in *ScoringStrategy) DeepCopy() *ScoringStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHistoryEstimatorArgs) DeepCopyInto(out *WorkloadHistoryEstimatorArgs) {
+	*out = *in
+	if in.DecayHalfLife != nil {
+		in, out := &in.DecayHalfLife, &out.DecayHalfLife
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ColdStartSamples != nil {
+		in, out := &in.ColdStartSamples, &out.ColdStartSamples
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHistoryEstimatorArgs.
+func (in *WorkloadHistoryEstimatorArgs) DeepCopy() *WorkloadHistoryEstimatorArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHistoryEstimatorArgs)
+	in.DeepCopyInto(out)
+	return out
+}
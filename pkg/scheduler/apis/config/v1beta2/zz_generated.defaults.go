@@ -31,6 +31,7 @@ import (
 func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&CoschedulingArgs{}, func(obj interface{}) { SetObjectDefaults_CoschedulingArgs(obj.(*CoschedulingArgs)) })
 	scheme.AddTypeDefaultingFunc(&ElasticQuotaArgs{}, func(obj interface{}) { SetObjectDefaults_ElasticQuotaArgs(obj.(*ElasticQuotaArgs)) })
+	scheme.AddTypeDefaultingFunc(&InterferenceAwareArgs{}, func(obj interface{}) { SetObjectDefaults_InterferenceAwareArgs(obj.(*InterferenceAwareArgs)) })
 	scheme.AddTypeDefaultingFunc(&LoadAwareSchedulingArgs{}, func(obj interface{}) { SetObjectDefaults_LoadAwareSchedulingArgs(obj.(*LoadAwareSchedulingArgs)) })
 	scheme.AddTypeDefaultingFunc(&NodeNUMAResourceArgs{}, func(obj interface{}) { SetObjectDefaults_NodeNUMAResourceArgs(obj.(*NodeNUMAResourceArgs)) })
 	scheme.AddTypeDefaultingFunc(&ReservationArgs{}, func(obj interface{}) { SetObjectDefaults_ReservationArgs(obj.(*ReservationArgs)) })
@@ -45,6 +46,10 @@ func SetObjectDefaults_ElasticQuotaArgs(in *ElasticQuotaArgs) {
 	SetDefaults_ElasticQuotaArgs(in)
 }
 
+func SetObjectDefaults_InterferenceAwareArgs(in *InterferenceAwareArgs) {
+	SetDefaults_InterferenceAwareArgs(in)
+}
+
 func SetObjectDefaults_LoadAwareSchedulingArgs(in *LoadAwareSchedulingArgs) {
 	SetDefaults_LoadAwareSchedulingArgs(in)
 }
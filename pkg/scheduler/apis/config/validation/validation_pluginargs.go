@@ -21,6 +21,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
@@ -51,6 +53,18 @@ func ValidateLoadAwareSchedulingArgs(args *config.LoadAwareSchedulingArgs) error
 		}
 	}
 
+	if args.WorkloadHistory != nil {
+		if args.WorkloadHistory.DecayHalfLife.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("workloadHistory", "decayHalfLife"), args.WorkloadHistory.DecayHalfLife, "decayHalfLife should be a positive value"))
+		}
+		if args.WorkloadHistory.ColdStartSamples < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("workloadHistory", "coldStartSamples"), args.WorkloadHistory.ColdStartSamples, "coldStartSamples should not be a negative value"))
+		}
+	}
+	if args.HourlyProfile != nil && args.HourlyProfile.DefaultPodLifetime.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("hourlyProfile", "defaultPodLifetime"), args.HourlyProfile.DefaultPodLifetime, "defaultPodLifetime should be a positive value"))
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -116,6 +130,29 @@ func ValidateElasticQuotaArgs(elasticArgs *config.ElasticQuotaArgs) error {
 		return fmt.Errorf("elasticQuotaArgs error, RevokePodCycle should be a positive value")
 	}
 
+	if elasticArgs.QuotaUsageAccountingInterval != nil && elasticArgs.QuotaUsageAccountingInterval.Duration < 0 {
+		return fmt.Errorf("elasticQuotaArgs error, QuotaUsageAccountingInterval should not be a negative value")
+	}
+
+	switch elasticArgs.RuntimeQuotaCalculatePolicy {
+	case "", config.RuntimeQuotaCalculateBySharedWeight, config.RuntimeQuotaCalculateByDominantResourceFairness:
+	default:
+		return fmt.Errorf("elasticQuotaArgs error, RuntimeQuotaCalculatePolicy %v is not supported", elasticArgs.RuntimeQuotaCalculatePolicy)
+	}
+
+	switch elasticArgs.RevokePolicy {
+	case "", config.QuotaRevokeByEviction, config.QuotaRevokeByMigration, config.QuotaRevokeBySoftEviction:
+	default:
+		return fmt.Errorf("elasticQuotaArgs error, RevokePolicy %v is not supported", elasticArgs.RevokePolicy)
+	}
+
+	if elasticArgs.MaxUnavailablePerWorkload != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(elasticArgs.MaxUnavailablePerWorkload, 100, true)
+		if err != nil || maxUnavailable <= 0 {
+			return fmt.Errorf("elasticQuotaArgs error, MaxUnavailablePerWorkload %v should be a positive value", elasticArgs.MaxUnavailablePerWorkload.String())
+		}
+	}
+
 	return nil
 }
 
@@ -126,5 +163,51 @@ func ValidateCoschedulingArgs(coeSchedulingArgs *config.CoschedulingArgs) error
 	if coeSchedulingArgs.ControllerWorkers != nil && *coeSchedulingArgs.ControllerWorkers < 1 {
 		return fmt.Errorf("coeSchedulingArgs ControllerWorkers invalid")
 	}
+	for _, level := range coeSchedulingArgs.NetworkTopologyLevels {
+		if errs := metav1validation.ValidateLabelName(level, field.NewPath("networkTopologyLevels")); len(errs) > 0 {
+			return fmt.Errorf("coeSchedulingArgs NetworkTopologyLevels invalid, %v", errs.ToAggregate())
+		}
+	}
+	if coeSchedulingArgs.GangReservationThreshold != nil && coeSchedulingArgs.GangReservationThreshold.Duration < 0 {
+		return fmt.Errorf("coeSchedulingArgs GangReservationThreshold invalid")
+	}
+	if coeSchedulingArgs.GangReservationBackfillMaxRuntime != nil && coeSchedulingArgs.GangReservationBackfillMaxRuntime.Duration < 0 {
+		return fmt.Errorf("coeSchedulingArgs GangReservationBackfillMaxRuntime invalid")
+	}
 	return nil
 }
+
+// ValidateInterferenceAwareArgs validates that InterferenceAwareArgs are correct.
+func ValidateInterferenceAwareArgs(args *config.InterferenceAwareArgs) error {
+	var allErrs field.ErrorList
+
+	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("nodeMetricExpirationSeconds"), *args.NodeMetricExpirationSeconds, "nodeMetricExpirationSeconds should be a positive value"))
+	}
+
+	if thresholds := args.ContentionThresholds; thresholds != nil {
+		thresholdsPath := field.NewPath("contentionThresholds")
+		for _, threshold := range []struct {
+			name    string
+			percent int64
+		}{
+			{name: "cpuPressurePercent", percent: thresholds.CPUPressurePercent},
+			{name: "memoryPressurePercent", percent: thresholds.MemoryPressurePercent},
+			{name: "ioPressurePercent", percent: thresholds.IOPressurePercent},
+			{name: "cpiOutlierPercent", percent: thresholds.CPIOutlierPercent},
+			{name: "cpuThrottledPercent", percent: thresholds.CPUThrottledPercent},
+		} {
+			if threshold.percent < 0 || threshold.percent > 100 {
+				allErrs = append(allErrs, field.Invalid(thresholdsPath.Child(threshold.name), threshold.percent, "threshold should be in the range [0, 100]"))
+			}
+		}
+		if thresholds.MemoryBandwidth != nil && thresholds.MemoryBandwidth.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("memoryBandwidth"), thresholds.MemoryBandwidth.String(), "memoryBandwidth should not be a negative value"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
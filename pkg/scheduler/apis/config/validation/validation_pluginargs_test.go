@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+)
+
+func TestValidateInterferenceAwareArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *config.InterferenceAwareArgs
+		wantErr bool
+	}{
+		{
+			name: "valid empty args",
+			args: &config.InterferenceAwareArgs{},
+		},
+		{
+			name: "valid args",
+			args: &config.InterferenceAwareArgs{
+				NodeMetricExpirationSeconds: pointer.Int64(180),
+				ContentionThresholds: &config.ContentionThresholds{
+					CPUPressurePercent:  100,
+					CPUThrottledPercent: 0,
+					MemoryBandwidth:     resource.NewQuantity(1<<30, resource.BinarySI),
+				},
+			},
+		},
+		{
+			name: "invalid nodeMetricExpirationSeconds",
+			args: &config.InterferenceAwareArgs{
+				NodeMetricExpirationSeconds: pointer.Int64(0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative threshold",
+			args: &config.InterferenceAwareArgs{
+				ContentionThresholds: &config.ContentionThresholds{
+					IOPressurePercent: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "threshold exceeds 100 percent",
+			args: &config.InterferenceAwareArgs{
+				ContentionThresholds: &config.ContentionThresholds{
+					CPIOutlierPercent: 101,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative memory bandwidth",
+			args: &config.InterferenceAwareArgs{
+				ContentionThresholds: &config.ContentionThresholds{
+					MemoryBandwidth: resource.NewQuantity(-1, resource.BinarySI),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateInterferenceAwareArgs(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInterferenceAwareArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDefaultInterferenceAwareArgs(t *testing.T) {
+	var v1beta2args v1beta2.InterferenceAwareArgs
+	v1beta2.SetDefaults_InterferenceAwareArgs(&v1beta2args)
+	var args config.InterferenceAwareArgs
+	assert.NoError(t, v1beta2.Convert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(&v1beta2args, &args, nil))
+	assert.NoError(t, ValidateInterferenceAwareArgs(&args))
+}
@@ -25,9 +25,31 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	apisconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentionThresholds) DeepCopyInto(out *ContentionThresholds) {
+	*out = *in
+	if in.MemoryBandwidth != nil {
+		in, out := &in.MemoryBandwidth, &out.MemoryBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentionThresholds.
+func (in *ContentionThresholds) DeepCopy() *ContentionThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentionThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoschedulingArgs) DeepCopyInto(out *CoschedulingArgs) {
 	*out = *in
@@ -42,6 +64,26 @@ func (in *CoschedulingArgs) DeepCopyInto(out *CoschedulingArgs) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.NetworkTopologyLevels != nil {
+		in, out := &in.NetworkTopologyLevels, &out.NetworkTopologyLevels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnablePreemption != nil {
+		in, out := &in.EnablePreemption, &out.EnablePreemption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GangReservationThreshold != nil {
+		in, out := &in.GangReservationThreshold, &out.GangReservationThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GangReservationBackfillMaxRuntime != nil {
+		in, out := &in.GangReservationBackfillMaxRuntime, &out.GangReservationBackfillMaxRuntime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -126,6 +168,16 @@ func (in *ElasticQuotaArgs) DeepCopyInto(out *ElasticQuotaArgs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.QuotaUsageAccountingInterval != nil {
+		in, out := &in.QuotaUsageAccountingInterval, &out.QuotaUsageAccountingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxUnavailablePerWorkload != nil {
+		in, out := &in.MaxUnavailablePerWorkload, &out.MaxUnavailablePerWorkload
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -147,6 +199,41 @@ func (in *ElasticQuotaArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceAwareArgs) DeepCopyInto(out *InterferenceAwareArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.NodeMetricExpirationSeconds != nil {
+		in, out := &in.NodeMetricExpirationSeconds, &out.NodeMetricExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ContentionThresholds != nil {
+		in, out := &in.ContentionThresholds, &out.ContentionThresholds
+		*out = new(ContentionThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceAwareArgs.
+func (in *InterferenceAwareArgs) DeepCopy() *InterferenceAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceAwareArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterferenceAwareArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAwareSchedulingAggregatedArgs) DeepCopyInto(out *LoadAwareSchedulingAggregatedArgs) {
 	*out = *in
@@ -219,6 +306,16 @@ func (in *LoadAwareSchedulingArgs) DeepCopyInto(out *LoadAwareSchedulingArgs) {
 		*out = new(LoadAwareSchedulingAggregatedArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkloadHistory != nil {
+		in, out := &in.WorkloadHistory, &out.WorkloadHistory
+		*out = new(WorkloadHistoryEstimatorArgs)
+		**out = **in
+	}
+	if in.HourlyProfile != nil {
+		in, out := &in.HourlyProfile, &out.HourlyProfile
+		*out = new(LoadAwareSchedulingHourlyProfileArgs)
+		**out = **in
+	}
 	return
 }
 
@@ -240,6 +337,23 @@ func (in *LoadAwareSchedulingArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAwareSchedulingHourlyProfileArgs) DeepCopyInto(out *LoadAwareSchedulingHourlyProfileArgs) {
+	*out = *in
+	out.DefaultPodLifetime = in.DefaultPodLifetime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAwareSchedulingHourlyProfileArgs.
+func (in *LoadAwareSchedulingHourlyProfileArgs) DeepCopy() *LoadAwareSchedulingHourlyProfileArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareSchedulingHourlyProfileArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeNUMAResourceArgs) DeepCopyInto(out *NodeNUMAResourceArgs) {
 	*out = *in
@@ -320,3 +434,20 @@ func (in *ScoringStrategy) DeepCopy() *ScoringStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHistoryEstimatorArgs) DeepCopyInto(out *WorkloadHistoryEstimatorArgs) {
+	*out = *in
+	out.DecayHalfLife = in.DecayHalfLife
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHistoryEstimatorArgs.
+func (in *WorkloadHistoryEstimatorArgs) DeepCopy() *WorkloadHistoryEstimatorArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHistoryEstimatorArgs)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -43,6 +43,7 @@ type frameworkExtenderImpl struct {
 
 	koordinatorClientSet             koordinatorclientset.Interface
 	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	simulation                       bool
 
 	preFilterTransformers map[string]PreFilterTransformer
 	filterTransformers    map[string]FilterTransformer
@@ -67,6 +68,7 @@ func NewFrameworkExtender(f *FrameworkExtenderFactory, fw framework.Framework) F
 		schedulerFn:                      schedulerFn,
 		koordinatorClientSet:             f.KoordinatorClientSet(),
 		koordinatorSharedInformerFactory: f.koordinatorSharedInformerFactory,
+		simulation:                       f.simulation,
 		preFilterTransformers:            map[string]PreFilterTransformer{},
 		filterTransformers:               map[string]FilterTransformer{},
 		scoreTransformers:                map[string]ScoreTransformer{},
@@ -128,6 +130,10 @@ func (ext *frameworkExtenderImpl) KoordinatorSharedInformerFactory() koordinator
 	return ext.koordinatorSharedInformerFactory
 }
 
+func (ext *frameworkExtenderImpl) IsSimulation() bool {
+	return ext.simulation
+}
+
 // Scheduler return the scheduler adapter to support operating with cache and schedulingQueue.
 // NOTE: Plugins do not acquire a dispatcher instance during plugin initialization,
 // nor are they allowed to hold the object within the plugin object.
@@ -32,10 +32,17 @@ type extendedHandleOptions struct {
 	servicesEngine                   *services.Engine
 	koordinatorClientSet             koordinatorclientset.Interface
 	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	handleWrapper                    HandleWrapper
+	simulation                       bool
 }
 
 type Option func(*extendedHandleOptions)
 
+// HandleWrapper wraps the FrameworkExtender before it is passed to the plugin factory.
+// The wrapped handle must still embed the FrameworkExtender, e.g. to provide the clientsets
+// that the plugins look up by type assertion when running without an apiserver.
+type HandleWrapper func(extender FrameworkExtender) FrameworkExtender
+
 func WithServicesEngine(engine *services.Engine) Option {
 	return func(options *extendedHandleOptions) {
 		options.servicesEngine = engine
@@ -54,11 +61,26 @@ func WithKoordinatorSharedInformerFactory(informerFactory koordinatorinformers.S
 	}
 }
 
+func WithHandleWrapper(wrapper HandleWrapper) Option {
+	return func(options *extendedHandleOptions) {
+		options.handleWrapper = wrapper
+	}
+}
+
+// WithSimulation marks the frameworks created by the factory as dedicated to the scheduling simulation API.
+func WithSimulation() Option {
+	return func(options *extendedHandleOptions) {
+		options.simulation = true
+	}
+}
+
 type FrameworkExtenderFactory struct {
 	controllerMaps                   *ControllersMap
 	servicesEngine                   *services.Engine
 	koordinatorClientSet             koordinatorclientset.Interface
 	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	handleWrapper                    HandleWrapper
+	simulation                       bool
 	profiles                         map[string]FrameworkExtender
 	scheduler                        Scheduler
 	*errorHandlerDispatcher
@@ -79,6 +101,8 @@ func NewFrameworkExtenderFactory(options ...Option) (*FrameworkExtenderFactory,
 		servicesEngine:                   handleOptions.servicesEngine,
 		koordinatorClientSet:             handleOptions.koordinatorClientSet,
 		koordinatorSharedInformerFactory: handleOptions.koordinatorSharedInformerFactory,
+		handleWrapper:                    handleOptions.handleWrapper,
+		simulation:                       handleOptions.simulation,
 		profiles:                         map[string]FrameworkExtender{},
 		errorHandlerDispatcher:           newErrorHandlerDispatcher(),
 	}, nil
@@ -143,7 +167,11 @@ func PluginFactoryProxy(extenderFactory *FrameworkExtenderFactory, factoryFn fra
 	return func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
 		fw := handle.(framework.Framework)
 		frameworkExtender := extenderFactory.NewFrameworkExtender(fw)
-		plugin, err := factoryFn(args, frameworkExtender)
+		var pluginHandle framework.Handle = frameworkExtender
+		if extenderFactory.handleWrapper != nil {
+			pluginHandle = extenderFactory.handleWrapper(frameworkExtender)
+		}
+		plugin, err := factoryFn(args, pluginHandle)
 		if err != nil {
 			return nil, err
 		}
@@ -71,4 +71,79 @@ func TestExtenderFactory(t *testing.T) {
 	assert.Len(t, impl.preFilterTransformers, 1)
 	assert.Len(t, impl.filterTransformers, 1)
 	assert.Len(t, impl.scoreTransformers, 1)
+	assert.False(t, extender.IsSimulation())
+}
+
+func TestExtenderFactoryWithSimulation(t *testing.T) {
+	koordClientSet := koordfake.NewSimpleClientset()
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	factory, err := NewFrameworkExtenderFactory(
+		WithKoordinatorClientSet(koordClientSet),
+		WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+		WithSimulation(),
+	)
+	assert.NoError(t, err)
+
+	var pluginHandle framework.Handle
+	proxyNew := PluginFactoryProxy(factory, func(args runtime.Object, f framework.Handle) (framework.Plugin, error) {
+		pluginHandle = f
+		return &TestTransformer{index: 1}, nil
+	})
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+	}
+	fh, err := schedulertesting.NewFramework(
+		registeredPlugins,
+		"koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(fakeNodeInfoLister{NodeInfoLister: frameworkfake.NodeInfoLister{}}),
+	)
+	assert.NoError(t, err)
+	_, err = proxyNew(nil, fh)
+	assert.NoError(t, err)
+
+	extendedHandle, ok := pluginHandle.(ExtendedHandle)
+	assert.True(t, ok)
+	assert.True(t, extendedHandle.IsSimulation())
+}
+
+type testWrappedHandle struct {
+	FrameworkExtender
+}
+
+func TestExtenderFactoryWithHandleWrapper(t *testing.T) {
+	koordClientSet := koordfake.NewSimpleClientset()
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	factory, err := NewFrameworkExtenderFactory(
+		WithKoordinatorClientSet(koordClientSet),
+		WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+		WithHandleWrapper(func(extender FrameworkExtender) FrameworkExtender {
+			return &testWrappedHandle{FrameworkExtender: extender}
+		}),
+	)
+	assert.NoError(t, err)
+
+	var pluginHandle framework.Handle
+	proxyNew := PluginFactoryProxy(factory, func(args runtime.Object, f framework.Handle) (framework.Plugin, error) {
+		pluginHandle = f
+		return &TestTransformer{index: 1}, nil
+	})
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+	}
+	fh, err := schedulertesting.NewFramework(
+		registeredPlugins,
+		"koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(fakeNodeInfoLister{NodeInfoLister: frameworkfake.NodeInfoLister{}}),
+	)
+	assert.NoError(t, err)
+	_, err = proxyNew(nil, fh)
+	assert.NoError(t, err)
+
+	wrapped, ok := pluginHandle.(*testWrappedHandle)
+	assert.True(t, ok)
+	assert.Equal(t, factory.GetExtender("koord-scheduler"), wrapped.FrameworkExtender)
+	impl := wrapped.FrameworkExtender.(*frameworkExtenderImpl)
+	assert.Len(t, impl.preFilterTransformers, 1)
 }
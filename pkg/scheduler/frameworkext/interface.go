@@ -43,6 +43,10 @@ type ExtendedHandle interface {
 	RegisterErrorHandler(handler ErrorHandler)
 	RegisterForgetPodHandler(handler ForgetPodHandler)
 	ForgetPod(pod *corev1.Pod) error
+	// IsSimulation returns true if the framework is dedicated to the scheduling simulation API.
+	// Such frameworks are built besides the scheduler's own frameworks, so the plugins must not
+	// write to the cluster or replace any global state during initialization.
+	IsSimulation() bool
 }
 
 // FrameworkExtender extends the K8s Scheduling Framework interface to provide more extension methods to support Koordinator.
@@ -42,6 +42,8 @@ type ReservationInfo struct {
 	AllocatablePorts framework.HostPortInfo
 	AllocatedPorts   framework.HostPortInfo
 	AssignedPods     map[types.UID]*PodRequirement
+	// BackfilledPods are the pods which are not the owners but temporarily use the reserved resources.
+	BackfilledPods map[types.UID]*PodRequirement
 }
 
 type PodRequirement struct {
@@ -235,6 +237,14 @@ func (ri *ReservationInfo) Clone() *ReservationInfo {
 		pods[k] = v.Clone()
 	}
 
+	var backfilledPods map[types.UID]*PodRequirement
+	if len(ri.BackfilledPods) > 0 {
+		backfilledPods = make(map[types.UID]*PodRequirement, len(ri.BackfilledPods))
+		for k, v := range ri.BackfilledPods {
+			backfilledPods[k] = v.Clone()
+		}
+	}
+
 	var reservation *schedulingv1alpha1.Reservation
 	if ri.Reservation != nil {
 		reservation = ri.Reservation.DeepCopy()
@@ -249,6 +259,7 @@ func (ri *ReservationInfo) Clone() *ReservationInfo {
 		AllocatablePorts: util.CloneHostPorts(ri.AllocatablePorts),
 		AllocatedPorts:   util.CloneHostPorts(ri.AllocatedPorts),
 		AssignedPods:     pods,
+		BackfilledPods:   backfilledPods,
 	}
 }
 
@@ -288,3 +299,14 @@ func (ri *ReservationInfo) RemoveAssignedPod(pod *corev1.Pod) {
 		delete(ri.AssignedPods, pod.UID)
 	}
 }
+
+func (ri *ReservationInfo) AddBackfilledPod(pod *corev1.Pod) {
+	if ri.BackfilledPods == nil {
+		ri.BackfilledPods = map[types.UID]*PodRequirement{}
+	}
+	ri.BackfilledPods[pod.UID] = NewPodRequirement(pod)
+}
+
+func (ri *ReservationInfo) RemoveBackfilledPod(pod *corev1.Pod) {
+	delete(ri.BackfilledPods, pod.UID)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package frameworkext
 
 import (
+	"errors"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -162,6 +164,86 @@ func (q *queueAdapter) MoveAllToActiveOrBackoffQueue(event framework.ClusterEven
 	q.scheduler.SchedulingQueue.MoveAllToActiveOrBackoffQueue(event, nil)
 }
 
+var errNotSupportedInSimulation = errors.New("not supported in simulation")
+
+var _ Scheduler = &simulationSchedulerAdapter{}
+
+// simulationSchedulerAdapter is the Scheduler of the frameworks dedicated to the simulation.
+// The simulation runs against a private snapshot, so there is nothing to invalidate,
+// and the scheduler cache and queue are never changed by the simulation.
+type simulationSchedulerAdapter struct{}
+
+// NewSimulationScheduler returns the Scheduler for the frameworks dedicated to the simulation.
+func NewSimulationScheduler() Scheduler {
+	return &simulationSchedulerAdapter{}
+}
+
+func (s *simulationSchedulerAdapter) GetCache() SchedulerCache {
+	return s
+}
+
+func (s *simulationSchedulerAdapter) GetSchedulingQueue() SchedulingQueue {
+	return s
+}
+
+func (s *simulationSchedulerAdapter) AddPod(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) UpdatePod(oldPod, newPod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) RemovePod(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) AssumePod(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) IsAssumedPod(pod *corev1.Pod) (bool, error) {
+	return false, nil
+}
+
+func (s *simulationSchedulerAdapter) GetPod(pod *corev1.Pod) (*corev1.Pod, error) {
+	return nil, errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) ForgetPod(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) InvalidNodeInfo(nodeName string) error {
+	return nil
+}
+
+func (s *simulationSchedulerAdapter) Add(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) Update(oldPod, newPod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) Delete(pod *corev1.Pod) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) AddUnschedulableIfNotPresent(pod *framework.QueuedPodInfo, podSchedulingCycle int64) error {
+	return errNotSupportedInSimulation
+}
+
+func (s *simulationSchedulerAdapter) SchedulingCycle() int64 {
+	return 0
+}
+
+func (s *simulationSchedulerAdapter) AssignedPodAdded(pod *corev1.Pod) {}
+
+func (s *simulationSchedulerAdapter) AssignedPodUpdated(pod *corev1.Pod) {}
+
+func (s *simulationSchedulerAdapter) MoveAllToActiveOrBackoffQueue(event framework.ClusterEvent) {}
+
 var _ Scheduler = &FakeScheduler{}
 var _ SchedulingQueue = &FakeQueue{}
 
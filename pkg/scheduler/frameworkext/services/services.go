@@ -48,6 +48,7 @@ func InstallAPIHandler(mux mux, e *Engine, sched *scheduler.Scheduler, isLeader
 		engine.Store(e)
 		baseGroup := e.Group(servicesBaseRelativePath)
 		baseGroup.GET("/nodes/:nodeName", queryNodeInfo(sched))
+		baseGroup.POST("/simulate", simulateScheduling(sched, e.simulator))
 		baseGroup.GET("/__services__", listRegisteredServices(e.Engine))
 	})
 }
@@ -71,6 +72,7 @@ func handle(isLeader func() bool) http.HandlerFunc {
 
 type Engine struct {
 	*gin.Engine
+	simulator *Simulator
 }
 
 func NewEngine(e *gin.Engine) *Engine {
@@ -79,6 +81,11 @@ func NewEngine(e *gin.Engine) *Engine {
 	}
 }
 
+// SetSimulator sets the Simulator serving the simulation API, which must be called before InstallAPIHandler.
+func (e *Engine) SetSimulator(simulator *Simulator) {
+	e.simulator = simulator
+}
+
 func (e *Engine) RegisterPluginService(plugin framework.Plugin) {
 	if serviceProvider, ok := plugin.(APIServiceProvider); ok {
 		baseGroup := e.Engine.Group(pluginServicesBaseRelativePath)
@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// Simulator runs the simulation with the frameworks dedicated to it. The frameworks are built
+// from the same profiles as the scheduler but against a private snapshot, so the extension points
+// never run on the scheduler's own frameworks and NodeInfos from the API goroutines.
+type Simulator struct {
+	lock     sync.Mutex
+	snapshot *SimulationSnapshot
+	profiles map[string]framework.Framework
+}
+
+// NewSimulator creates the Simulator with the frameworks built with the snapshot as their SnapshotSharedLister.
+func NewSimulator(snapshot *SimulationSnapshot, profiles map[string]framework.Framework) *Simulator {
+	return &Simulator{
+		snapshot: snapshot,
+		profiles: profiles,
+	}
+}
+
+func simulateScheduling(sched *scheduler.Scheduler, simulator *Simulator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if simulator == nil {
+			ResponseErrorMessage(c, http.StatusServiceUnavailable, "simulation is not enabled")
+			return
+		}
+		var request SimulationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			ResponseErrorMessage(c, http.StatusBadRequest, "invalid simulation request: %v", err)
+			return
+		}
+		if len(request.Pods) == 0 {
+			ResponseErrorMessage(c, http.StatusBadRequest, "no pods to simulate")
+			return
+		}
+
+		profiles := make([]framework.Framework, 0, len(request.Pods))
+		for _, pod := range request.Pods {
+			if pod == nil {
+				ResponseErrorMessage(c, http.StatusBadRequest, "invalid nil pod")
+				return
+			}
+			schedulerName := pod.Spec.SchedulerName
+			if schedulerName == "" {
+				schedulerName = corev1.DefaultSchedulerName
+			}
+			fwk := simulator.profiles[schedulerName]
+			if fwk == nil {
+				ResponseErrorMessage(c, http.StatusBadRequest, "cannot find profile %s for pod %s/%s", schedulerName, pod.Namespace, pod.Name)
+				return
+			}
+			profiles = append(profiles, fwk)
+		}
+
+		// The dump is a deep copy of the scheduler cache, so the simulated pods can be
+		// assumed onto it without affecting the real scheduling.
+		dump := sched.Cache.Dump()
+		nodeInfos := make([]*framework.NodeInfo, 0, len(dump.Nodes))
+		for _, nodeInfo := range dump.Nodes {
+			if nodeInfo.Node() != nil {
+				nodeInfos = append(nodeInfos, nodeInfo)
+			}
+		}
+
+		// The frameworks share the snapshot, so the simulations are serialized.
+		simulator.lock.Lock()
+		defer simulator.lock.Unlock()
+		response := simulatePods(c.Request.Context(), simulator.snapshot, profiles, nodeInfos, request.Pods)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// simulatePods runs PreFilter, Filter, PreScore, Score and Reserve of the profiles for the pods one by one
+// without Permit and Bind. Each selected placement is assumed on the given nodeInfos and reserved in the
+// plugins of the dedicated frameworks, so the later pods can see the nodes and the states of the quotas,
+// devices and cpusets allocated by the earlier ones, and the pods are reported schedulable only if all of
+// them fit. All the reserved pods are unreserved before returning.
+// Before each pod, the snapshot is refreshed with the clones of the nodeInfos in the same way as the
+// scheduler refreshes its snapshot in each cycle, since the PreFilter transformers may modify it.
+// NOTE: PostFilter is skipped because preemption nominates and evicts the victims, and Permit is skipped
+// because it waits for the other pods, so the gangs are not checked as a whole.
+func simulatePods(ctx context.Context, snapshot *SimulationSnapshot, profiles []framework.Framework, nodeInfos []*framework.NodeInfo, pods []*corev1.Pod) *SimulationResponse {
+	nodeInfoMap := make(map[string]*framework.NodeInfo, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		nodeInfoMap[nodeInfo.Node().Name] = nodeInfo
+	}
+	response := &SimulationResponse{
+		Schedulable: true,
+		Pods:        make([]*PodSimulationResult, 0, len(pods)),
+	}
+	var reservedPods []*reservedPod
+	defer func() {
+		for i := len(reservedPods) - 1; i >= 0; i-- {
+			r := reservedPods[i]
+			r.fwk.RunReservePluginsUnreserve(ctx, r.state, r.pod, r.nodeName)
+		}
+	}()
+	for i, pod := range pods {
+		pod = pod.DeepCopy()
+		if pod.Namespace == "" {
+			pod.Namespace = corev1.NamespaceDefault
+		}
+		if pod.UID == "" {
+			pod.UID = uuid.NewUUID()
+		}
+		snapshot.update(nodeInfos)
+		state := framework.NewCycleState()
+		state.SetRecordPluginMetrics(false)
+		result := simulatePod(ctx, profiles[i], state, snapshot.nodeInfoList, pod)
+		if result.NodeName != "" {
+			if status := profiles[i].RunReservePluginsReserve(ctx, state, pod, result.NodeName); !status.IsSuccess() {
+				profiles[i].RunReservePluginsUnreserve(ctx, state, pod, result.NodeName)
+				result.Message = "failed to run Reserve: " + status.Message()
+				result.NodeName = ""
+			} else {
+				reservedPods = append(reservedPods, &reservedPod{fwk: profiles[i], state: state, pod: pod, nodeName: result.NodeName})
+			}
+		}
+		if result.NodeName == "" {
+			response.Schedulable = false
+		} else {
+			assumedPod := pod.DeepCopy()
+			assumedPod.Spec.NodeName = result.NodeName
+			nodeInfoMap[result.NodeName].AddPod(assumedPod)
+		}
+		response.Pods = append(response.Pods, result)
+	}
+	return response
+}
+
+type reservedPod struct {
+	fwk      framework.Framework
+	state    *framework.CycleState
+	pod      *corev1.Pod
+	nodeName string
+}
+
+func simulatePod(ctx context.Context, fwk framework.Framework, state *framework.CycleState, nodeInfos []*framework.NodeInfo, pod *corev1.Pod) *PodSimulationResult {
+	result := &PodSimulationResult{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+	}
+
+	preFilterResult, status := fwk.RunPreFilterPlugins(ctx, state, pod)
+	if !status.IsSuccess() {
+		result.Message = "failed to run PreFilter"
+		result.PreFilterFailure = newPluginFailure(status)
+		return result
+	}
+
+	var feasibleNodes []*corev1.Node
+	for _, nodeInfo := range nodeInfos {
+		nodeName := nodeInfo.Node().Name
+		if !preFilterResult.AllNodes() && !preFilterResult.NodeNames.Has(nodeName) {
+			continue
+		}
+		status = fwk.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo)
+		if !status.IsSuccess() {
+			if result.FilterFailures == nil {
+				result.FilterFailures = map[string]*PluginFailure{}
+			}
+			result.FilterFailures[nodeName] = newPluginFailure(status)
+			continue
+		}
+		feasibleNodes = append(feasibleNodes, nodeInfo.Node())
+	}
+	if len(feasibleNodes) == 0 {
+		result.Message = "no feasible nodes"
+		return result
+	}
+
+	status = fwk.RunPreScorePlugins(ctx, state, pod, feasibleNodes)
+	if !status.IsSuccess() {
+		result.Message = "failed to run PreScore: " + status.Message()
+		return result
+	}
+	pluginToNodeScores, status := fwk.RunScorePlugins(ctx, state, pod, feasibleNodes)
+	if !status.IsSuccess() {
+		result.Message = "failed to run Score: " + status.Message()
+		return result
+	}
+
+	result.Scores = make([]*NodeScore, 0, len(feasibleNodes))
+	for i, node := range feasibleNodes {
+		nodeScore := &NodeScore{
+			NodeName:     node.Name,
+			PluginScores: make(map[string]int64, len(pluginToNodeScores)),
+		}
+		for pluginName, nodeScoreList := range pluginToNodeScores {
+			nodeScore.PluginScores[pluginName] = nodeScoreList[i].Score
+			nodeScore.Score += nodeScoreList[i].Score
+		}
+		result.Scores = append(result.Scores, nodeScore)
+	}
+	sort.SliceStable(result.Scores, func(i, j int) bool {
+		return result.Scores[i].Score > result.Scores[j].Score
+	})
+
+	result.NodeName = result.Scores[0].NodeName
+	return result
+}
+
+func newPluginFailure(status *framework.Status) *PluginFailure {
+	return &PluginFailure{
+		Plugin:  status.FailedPlugin(),
+		Reasons: status.Reasons(),
+	}
+}
+
+var _ framework.SharedLister = &SimulationSnapshot{}
+
+// SimulationSnapshot is the private snapshot of the simulation frameworks, which is refreshed
+// with the clones of the NodeInfos dumped from the scheduler cache.
+type SimulationSnapshot struct {
+	nodeInfoList                                 []*framework.NodeInfo
+	nodeInfoMap                                  map[string]*framework.NodeInfo
+	havePodsWithAffinityNodeInfoList             []*framework.NodeInfo
+	havePodsWithRequiredAntiAffinityNodeInfoList []*framework.NodeInfo
+}
+
+func NewSimulationSnapshot() *SimulationSnapshot {
+	return &SimulationSnapshot{
+		nodeInfoMap: map[string]*framework.NodeInfo{},
+	}
+}
+
+func (s *SimulationSnapshot) update(nodeInfos []*framework.NodeInfo) {
+	s.nodeInfoList = make([]*framework.NodeInfo, 0, len(nodeInfos))
+	s.nodeInfoMap = make(map[string]*framework.NodeInfo, len(nodeInfos))
+	s.havePodsWithAffinityNodeInfoList = nil
+	s.havePodsWithRequiredAntiAffinityNodeInfoList = nil
+	for _, nodeInfo := range nodeInfos {
+		nodeInfo = nodeInfo.Clone()
+		s.nodeInfoList = append(s.nodeInfoList, nodeInfo)
+		s.nodeInfoMap[nodeInfo.Node().Name] = nodeInfo
+		if len(nodeInfo.PodsWithAffinity) > 0 {
+			s.havePodsWithAffinityNodeInfoList = append(s.havePodsWithAffinityNodeInfoList, nodeInfo)
+		}
+		if len(nodeInfo.PodsWithRequiredAntiAffinity) > 0 {
+			s.havePodsWithRequiredAntiAffinityNodeInfoList = append(s.havePodsWithRequiredAntiAffinityNodeInfoList, nodeInfo)
+		}
+	}
+	sort.Slice(s.nodeInfoList, func(i, j int) bool {
+		return s.nodeInfoList[i].Node().Name < s.nodeInfoList[j].Node().Name
+	})
+}
+
+func (s *SimulationSnapshot) NodeInfos() framework.NodeInfoLister {
+	return s
+}
+
+func (s *SimulationSnapshot) List() ([]*framework.NodeInfo, error) {
+	return s.nodeInfoList, nil
+}
+
+func (s *SimulationSnapshot) HavePodsWithAffinityList() ([]*framework.NodeInfo, error) {
+	return s.havePodsWithAffinityNodeInfoList, nil
+}
+
+func (s *SimulationSnapshot) HavePodsWithRequiredAntiAffinityList() ([]*framework.NodeInfo, error) {
+	return s.havePodsWithRequiredAntiAffinityNodeInfoList, nil
+}
+
+func (s *SimulationSnapshot) Get(nodeName string) (*framework.NodeInfo, error) {
+	nodeInfo, ok := s.nodeInfoMap[nodeName]
+	if !ok || nodeInfo.Node() == nil {
+		return nil, fmt.Errorf("nodeinfo not found for node name %q", nodeName)
+	}
+	return nodeInfo, nil
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing"
+)
+
+const testSimulationPluginName = "TestSimulation"
+
+var (
+	_ framework.FilterPlugin  = &testSimulationPlugin{}
+	_ framework.ScorePlugin   = &testSimulationPlugin{}
+	_ framework.ReservePlugin = &testSimulationPlugin{}
+)
+
+// testSimulationPlugin allows only one pod per node and scores the node by the label "score".
+// If maxReserved is set, it works like a quota and allows at most maxReserved pods to be reserved.
+type testSimulationPlugin struct {
+	handle      framework.Handle
+	maxReserved int
+	reserved    map[string]string
+}
+
+func (p *testSimulationPlugin) Name() string { return testSimulationPluginName }
+
+func (p *testSimulationPlugin) Filter(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if len(nodeInfo.Pods) > 0 {
+		return framework.NewStatus(framework.Unschedulable, "node is occupied")
+	}
+	if p.maxReserved > 0 && len(p.reserved) >= p.maxReserved {
+		return framework.NewStatus(framework.Unschedulable, "quota exceeded")
+	}
+	return nil
+}
+
+func (p *testSimulationPlugin) Reserve(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeName string) *framework.Status {
+	if p.reserved == nil {
+		p.reserved = map[string]string{}
+	}
+	p.reserved[pod.Name] = nodeName
+	return nil
+}
+
+func (p *testSimulationPlugin) Unreserve(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeName string) {
+	delete(p.reserved, pod.Name)
+}
+
+func (p *testSimulationPlugin) Score(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := p.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+	score, _ := strconv.ParseInt(nodeInfo.Node().Labels["score"], 10, 64)
+	return score, nil
+}
+
+func (p *testSimulationPlugin) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+func TestSimulatePods(t *testing.T) {
+	var nodes []*corev1.Node
+	for i, score := range []string{"10", "50", "100"} {
+		nodes = append(nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-" + strconv.Itoa(i+1),
+				Labels: map[string]string{"score": score},
+			},
+		})
+	}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "running-pod",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-3",
+		},
+	}
+	var nodeInfos []*framework.NodeInfo
+	for _, node := range nodes {
+		nodeInfo := framework.NewNodeInfo()
+		nodeInfo.SetNode(node)
+		if node.Name == runningPod.Spec.NodeName {
+			nodeInfo.AddPod(runningPod)
+		}
+		nodeInfos = append(nodeInfos, nodeInfo)
+	}
+	snapshot := NewSimulationSnapshot()
+
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+		schedulertesting.RegisterPluginAsExtensions(testSimulationPluginName, func(_ runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+			return &testSimulationPlugin{handle: handle}, nil
+		}, "Filter", "Score", "Reserve"),
+	}
+	fh, err := schedulertesting.NewFramework(registeredPlugins, "koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+		frameworkruntime.WithPodNominator(&testPodNominator{}),
+	)
+	assert.NoError(t, err)
+
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-3"}},
+	}
+	// clone the nodeInfos to make sure the original ones are unchanged
+	clonedNodeInfos := make([]*framework.NodeInfo, 0, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		clonedNodeInfos = append(clonedNodeInfos, nodeInfo.Clone())
+	}
+	profiles := []framework.Framework{fh, fh, fh}
+	response := simulatePods(context.TODO(), snapshot, profiles, clonedNodeInfos, pods)
+
+	expectedResponse := &SimulationResponse{
+		Schedulable: false,
+		Pods: []*PodSimulationResult{
+			{
+				Namespace: "default",
+				Name:      "pod-1",
+				NodeName:  "node-2",
+				FilterFailures: map[string]*PluginFailure{
+					"node-3": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+				},
+				Scores: []*NodeScore{
+					{NodeName: "node-2", Score: 50, PluginScores: map[string]int64{testSimulationPluginName: 50}},
+					{NodeName: "node-1", Score: 10, PluginScores: map[string]int64{testSimulationPluginName: 10}},
+				},
+			},
+			{
+				Namespace: "default",
+				Name:      "pod-2",
+				NodeName:  "node-1",
+				FilterFailures: map[string]*PluginFailure{
+					"node-2": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+					"node-3": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+				},
+				Scores: []*NodeScore{
+					{NodeName: "node-1", Score: 10, PluginScores: map[string]int64{testSimulationPluginName: 10}},
+				},
+			},
+			{
+				Namespace: "default",
+				Name:      "pod-3",
+				Message:   "no feasible nodes",
+				FilterFailures: map[string]*PluginFailure{
+					"node-1": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+					"node-2": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+					"node-3": {Plugin: testSimulationPluginName, Reasons: []string{"node is occupied"}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedResponse, response)
+
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node().Name != "node-3" {
+			assert.Empty(t, nodeInfo.Pods, "simulation must not change the original nodeInfos")
+		}
+	}
+}
+
+func TestSimulatePodsReserve(t *testing.T) {
+	var nodeInfos []*framework.NodeInfo
+	for _, name := range []string{"node-1", "node-2"} {
+		nodeInfo := framework.NewNodeInfo()
+		nodeInfo.SetNode(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		nodeInfos = append(nodeInfos, nodeInfo)
+	}
+	snapshot := NewSimulationSnapshot()
+
+	plugin := &testSimulationPlugin{maxReserved: 1}
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+		schedulertesting.RegisterPluginAsExtensions(testSimulationPluginName, func(_ runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+			plugin.handle = handle
+			return plugin, nil
+		}, "Filter", "Reserve"),
+	}
+	fh, err := schedulertesting.NewFramework(registeredPlugins, "koord-scheduler",
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+		frameworkruntime.WithPodNominator(&testPodNominator{}),
+	)
+	assert.NoError(t, err)
+
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}},
+	}
+	response := simulatePods(context.TODO(), snapshot, []framework.Framework{fh, fh}, nodeInfos, pods)
+	assert.False(t, response.Schedulable)
+	assert.Len(t, response.Pods, 2)
+	assert.Equal(t, "node-1", response.Pods[0].NodeName)
+	assert.Equal(t, "", response.Pods[1].NodeName, "the second pod must see the quota reserved by the first one")
+	assert.Equal(t, &PluginFailure{Plugin: testSimulationPluginName, Reasons: []string{"quota exceeded"}}, response.Pods[1].FilterFailures["node-2"])
+	assert.Empty(t, plugin.reserved, "the reserved pods must be unreserved after the simulation")
+}
+
+func TestSimulationSnapshot(t *testing.T) {
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	nodeInfo.AddPod(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1", UID: "pod-1"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Affinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: corev1.LabelHostname}},
+				},
+			},
+		},
+	})
+	snapshot := NewSimulationSnapshot()
+	snapshot.update([]*framework.NodeInfo{nodeInfo})
+
+	got, err := snapshot.NodeInfos().Get("node-1")
+	assert.NoError(t, err)
+	assert.Len(t, got.Pods, 1)
+	antiAffinityNodeInfos, err := snapshot.NodeInfos().HavePodsWithRequiredAntiAffinityList()
+	assert.NoError(t, err)
+	assert.Len(t, antiAffinityNodeInfos, 1)
+	_, err = snapshot.NodeInfos().Get("node-2")
+	assert.Error(t, err)
+
+	// the changes made by the plugins, e.g. the PreFilter transformers, must not leak to the next pod
+	assert.NoError(t, got.RemovePod(got.Pods[0].Pod))
+	snapshot.update([]*framework.NodeInfo{nodeInfo})
+	got, err = snapshot.NodeInfos().Get("node-1")
+	assert.NoError(t, err)
+	assert.Len(t, got.Pods, 1)
+	assert.Len(t, nodeInfo.Pods, 1)
+}
+
+// testPodNominator has no nominated pods.
+type testPodNominator struct{}
+
+func (n *testPodNominator) AddNominatedPod(pi *framework.PodInfo, nominatingInfo *framework.NominatingInfo) {
+}
+
+func (n *testPodNominator) DeleteNominatedPodIfExists(pod *corev1.Pod) {}
+
+func (n *testPodNominator) UpdateNominatedPod(oldPod *corev1.Pod, newPodInfo *framework.PodInfo) {}
+
+func (n *testPodNominator) NominatedPodsForNode(nodeName string) []*framework.PodInfo { return nil }
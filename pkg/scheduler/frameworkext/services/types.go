@@ -38,6 +38,45 @@ func ResponseErrorMessage(c *gin.Context, statusCode int, format string, args ..
 	c.JSON(statusCode, e)
 }
 
+// SimulationRequest describes the pods to be scheduled in dry-run mode.
+// The pods are simulated in order, and each pod sees the placements of the previous ones
+// and the quotas, devices and cpusets reserved for them, so the pods bound to the same quota
+// can be checked together. The gangs are not checked as a whole since Permit is not run.
+type SimulationRequest struct {
+	Pods []*corev1.Pod `json:"pods,omitempty"`
+}
+
+type SimulationResponse struct {
+	// Schedulable is true only if all pods in the request can be scheduled.
+	Schedulable bool                   `json:"schedulable"`
+	Pods        []*PodSimulationResult `json:"pods,omitempty"`
+}
+
+type PodSimulationResult struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// NodeName is the node selected for the pod, empty if the pod is unschedulable.
+	NodeName string `json:"nodeName,omitempty"`
+	Message  string `json:"message,omitempty"`
+
+	PreFilterFailure *PluginFailure `json:"preFilterFailure,omitempty"`
+	// FilterFailures maps node name to the reason why the node is filtered out.
+	FilterFailures map[string]*PluginFailure `json:"filterFailures,omitempty"`
+	// Scores are the scores of the feasible nodes in descending order.
+	Scores []*NodeScore `json:"scores,omitempty"`
+}
+
+type PluginFailure struct {
+	Plugin  string   `json:"plugin,omitempty"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type NodeScore struct {
+	NodeName     string           `json:"nodeName,omitempty"`
+	Score        int64            `json:"score"`
+	PluginScores map[string]int64 `json:"pluginScores,omitempty"`
+}
+
 type NodeInfo struct {
 	// Overall Node information.
 	Node *corev1.Node `json:"node,omitempty"`
@@ -60,12 +60,15 @@ const (
 // Manager defines the interfaces for PodGroup management.
 type Manager interface {
 	PreFilter(context.Context, *corev1.Pod) error
+	PreFilterNetworkTopology(*corev1.Pod, []*framework.NodeInfo) (sets.String, error)
 	Permit(context.Context, *corev1.Pod) (time.Duration, Status)
 	PostBind(context.Context, *corev1.Pod, string)
 	PostFilter(context.Context, *corev1.Pod, framework.Handle, string, framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status)
 	GetCreatTime(*framework.QueuedPodInfo) time.Time
 	GetGroupId(*corev1.Pod) (string, error)
 	GetAllPodsFromGang(string) []*corev1.Pod
+	GetGangGroupRequiredPendingPods(*corev1.Pod) []*corev1.Pod
+	IsPodInGangGroup(*corev1.Pod, *corev1.Pod) bool
 	ActivateSiblings(*corev1.Pod, *framework.CycleState)
 	AllowGangGroup(*corev1.Pod, framework.Handle, string)
 	Unreserve(context.Context, *framework.CycleState, *corev1.Pod, string, framework.Handle, string)
@@ -379,6 +382,7 @@ func (pgMgr *PodGroupManager) rejectGangGroupById(handle framework.Handle, plugi
 		gangIns := pgMgr.cache.getGangFromCacheByGangId(gang, false)
 		if gangIns != nil {
 			gangIns.setScheduleCycleValid(false)
+			gangIns.setNetworkTopologyDomain(nil)
 		}
 	}
 }
@@ -517,6 +521,57 @@ func (pgMgr *PodGroupManager) GetAllPodsFromGang(gangId string) []*corev1.Pod {
 	return pods
 }
 
+// GetGangGroupRequiredPendingPods returns the pending pods of the GangGroup which are still required to satisfy
+// the MinMember of each Gang in the GangGroup, the given pod is always the first one if its Gang is not satisfied.
+func (pgMgr *PodGroupManager) GetGangGroupRequiredPendingPods(pod *corev1.Pod) []*corev1.Pod {
+	gang := pgMgr.GetGangByPod(pod)
+	if gang == nil {
+		return nil
+	}
+	var result []*corev1.Pod
+	for _, gangId := range gang.getGangGroup() {
+		groupGang := pgMgr.cache.getGangFromCacheByGangId(gangId, false)
+		if groupGang == nil {
+			continue
+		}
+		_, required := groupGang.getRequiredPendingChildren()
+		if groupGang != gang || len(required) == 0 {
+			result = append(result, required...)
+			continue
+		}
+		// the pod itself takes the place of the last required sibling if it is not selected
+		others := make([]*corev1.Pod, 0, len(required))
+		for _, p := range required {
+			if p.UID != pod.UID {
+				others = append(others, p)
+			}
+		}
+		if len(others) == len(required) {
+			others = others[:len(others)-1]
+		}
+		result = append([]*corev1.Pod{pod}, append(result, others...)...)
+	}
+	return result
+}
+
+// IsPodInGangGroup checks whether the other pod belongs to the GangGroup of the pod.
+func (pgMgr *PodGroupManager) IsPodInGangGroup(pod *corev1.Pod, other *corev1.Pod) bool {
+	if !util.IsPodNeedGang(other) {
+		return false
+	}
+	gang := pgMgr.GetGangByPod(pod)
+	if gang == nil {
+		return false
+	}
+	otherGangId := util.GetId(other.Namespace, util.GetGangNameByPod(other))
+	for _, gangId := range gang.getGangGroup() {
+		if gangId == otherGangId {
+			return true
+		}
+	}
+	return false
+}
+
 func (pgMgr *PodGroupManager) GetGangSummary(gangId string) (*GangSummary, bool) {
 	gang := pgMgr.cache.getGangFromCacheByGangId(gangId, false)
 	if gang == nil {
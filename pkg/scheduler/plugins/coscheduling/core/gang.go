@@ -17,6 +17,7 @@ limitations under the License.
 package core
 
 import (
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -67,6 +68,12 @@ type Gang struct {
 	// once-satisfied, once gang is satisfied, no need to consider any status pods
 	GangMatchPolicy string
 
+	// NetworkTopologyPolicy indicates whether the GangGroup should be placed within one network topology domain
+	NetworkTopologyPolicy string
+	// NetworkTopologyDomain is the network topology domain selected for the GangGroup in PreFilter,
+	// it will be reset when the GangGroup gets rejected.
+	NetworkTopologyDomain *NetworkTopologyDomain
+
 	// if the podGroup should be passed at PreFilter stage(Strict-Mode)
 	ScheduleCycleValid bool
 	// these fields used to count the cycle
@@ -147,6 +154,8 @@ func (gang *Gang) tryInitByPodConfig(pod *v1.Pod, args *config.CoschedulingArgs)
 	}
 	gang.GangMatchPolicy = matchPolicy
 
+	gang.NetworkTopologyPolicy = parseNetworkTopologyPolicy(gang.Name, pod.Annotations[extension.AnnotationGangNetworkTopologyPolicy])
+
 	// here we assume that Coscheduling's CreateTime equal with the pod's CreateTime
 	gang.CreateTime = pod.CreationTimestamp.Time
 
@@ -218,6 +227,8 @@ func (gang *Gang) tryInitByPodGroup(pg *v1alpha1.PodGroup, args *config.Coschedu
 	}
 	gang.GangMatchPolicy = matchPolicy
 
+	gang.NetworkTopologyPolicy = parseNetworkTopologyPolicy(gang.Name, pg.Annotations[extension.AnnotationGangNetworkTopologyPolicy])
+
 	// here we assume that Coscheduling's CreateTime equal with the podGroup CRD CreateTime
 	gang.CreateTime = pg.CreationTimestamp.Time
 
@@ -482,6 +493,57 @@ func (gang *Gang) addBoundPod(pod *v1.Pod) {
 	}
 }
 
+func (gang *Gang) getNetworkTopologyPolicy() string {
+	gang.lock.Lock()
+	defer gang.lock.Unlock()
+
+	return gang.NetworkTopologyPolicy
+}
+
+func (gang *Gang) getNetworkTopologyDomain() *NetworkTopologyDomain {
+	gang.lock.Lock()
+	defer gang.lock.Unlock()
+
+	return gang.NetworkTopologyDomain
+}
+
+func (gang *Gang) setNetworkTopologyDomain(domain *NetworkTopologyDomain) {
+	gang.lock.Lock()
+	defer gang.lock.Unlock()
+
+	gang.NetworkTopologyDomain = domain
+	klog.Infof("SetNetworkTopologyDomain, gangName: %v, domain: %v", gang.Name, domain)
+}
+
+// getRequiredPendingChildren returns the assumed children and the pending children which are still required
+// to satisfy MinRequiredNumber, the pending children are sorted by name.
+func (gang *Gang) getRequiredPendingChildren() (assumed []*v1.Pod, required []*v1.Pod) {
+	gang.lock.Lock()
+	defer gang.lock.Unlock()
+
+	var pending []*v1.Pod
+	for podId, pod := range gang.Children {
+		if waitingPod, ok := gang.WaitingForBindChildren[podId]; ok {
+			assumed = append(assumed, waitingPod)
+		} else if boundPod, ok := gang.BoundChildren[podId]; ok {
+			assumed = append(assumed, boundPod)
+		} else {
+			pending = append(pending, pod)
+		}
+	}
+	requiredNum := gang.MinRequiredNumber - len(assumed)
+	if requiredNum <= 0 {
+		return assumed, nil
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return util.GetId(pending[i].Namespace, pending[i].Name) < util.GetId(pending[j].Namespace, pending[j].Name)
+	})
+	if requiredNum > len(pending) {
+		requiredNum = len(pending)
+	}
+	return assumed, pending[:requiredNum]
+}
+
 func (gang *Gang) isGangValidForPermit() bool {
 	gang.lock.Lock()
 	defer gang.lock.Unlock()
@@ -24,6 +24,8 @@ type GangSummary struct {
 	ChildrenScheduleRoundMap map[string]int `json:"childrenScheduleRoundMap"`
 	GangFrom                 string         `json:"gangFrom"`
 	HasGangInit              bool           `json:"hasGangInit"`
+	NetworkTopologyPolicy    string         `json:"networkTopologyPolicy,omitempty"`
+	NetworkTopologyDomain    string         `json:"networkTopologyDomain,omitempty"`
 }
 
 func (gang *Gang) GetGangSummary() *GangSummary {
@@ -53,6 +55,8 @@ func (gang *Gang) GetGangSummary() *GangSummary {
 	gangSummary.ScheduleCycle = gang.ScheduleCycle
 	gangSummary.GangFrom = gang.GangFrom
 	gangSummary.HasGangInit = gang.HasGangInit
+	gangSummary.NetworkTopologyPolicy = gang.NetworkTopologyPolicy
+	gangSummary.NetworkTopologyDomain = gang.NetworkTopologyDomain.String()
 	gangSummary.GangGroup = append(gangSummary.GangGroup, gang.GangGroup...)
 
 	for podName := range gang.Children {
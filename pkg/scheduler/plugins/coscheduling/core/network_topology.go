@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+)
+
+// NetworkTopologyDomain is a network topology domain which is identified by a node label, e.g. rack=rack-1.
+type NetworkTopologyDomain struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (d *NetworkTopologyDomain) String() string {
+	if d == nil {
+		return ""
+	}
+	return d.Key + "=" + d.Value
+}
+
+func parseNetworkTopologyPolicy(gangName, policy string) string {
+	switch policy {
+	case "", extension.GangNetworkTopologyPolicyRequired, extension.GangNetworkTopologyPolicyPreferred:
+		return policy
+	default:
+		klog.Errorf("gang's annotation AnnotationGangNetworkTopologyPolicy illegal, gangName: %v, value: %v",
+			gangName, policy)
+		return ""
+	}
+}
+
+// PreFilterNetworkTopology selects a network topology domain for the GangGroup of the pod and returns the nodes
+// in the selected domain. The domain is computed once per GangGroup and reused by the member pods until the
+// GangGroup gets rejected. The levels are tried from the narrowest to the broadest, if no domain can hold the
+// pending members, the Preferred policy places the gang without restriction and the Required policy rejects the pod.
+func (pgMgr *PodGroupManager) PreFilterNetworkTopology(pod *corev1.Pod, nodeInfos []*framework.NodeInfo) (sets.String, error) {
+	if pgMgr.args == nil || len(pgMgr.args.NetworkTopologyLevels) == 0 || !util.IsPodNeedGang(pod) {
+		return nil, nil
+	}
+	gang := pgMgr.GetGangByPod(pod)
+	if gang == nil {
+		return nil, nil
+	}
+	policy := gang.getNetworkTopologyPolicy()
+	if policy == "" {
+		return nil, nil
+	}
+
+	domain := gang.getNetworkTopologyDomain()
+	if domain == nil {
+		var gangs []*Gang
+		for _, gangId := range gang.getGangGroup() {
+			if groupGang := pgMgr.cache.getGangFromCacheByGangId(gangId, false); groupGang != nil {
+				gangs = append(gangs, groupGang)
+			}
+		}
+		domain = selectNetworkTopologyDomain(pgMgr.args.NetworkTopologyLevels, gangs, nodeInfos)
+		if domain == nil {
+			if policy == extension.GangNetworkTopologyPolicyRequired {
+				return nil, fmt.Errorf("gang cannot find a network topology domain to place all members, gangName: %v, podName: %v",
+					gang.Name, util.GetId(pod.Namespace, pod.Name))
+			}
+			klog.V(4).InfoS("gang cannot find a network topology domain, fall back to all nodes", "gang", gang.Name, "pod", klog.KObj(pod))
+			return nil, nil
+		}
+		for _, groupGang := range gangs {
+			groupGang.setNetworkTopologyDomain(domain)
+		}
+	}
+
+	nodeNames := sets.NewString()
+	for _, nodeInfo := range nodeInfos {
+		if node := nodeInfo.Node(); node != nil && node.Labels[domain.Key] == domain.Value {
+			nodeNames.Insert(node.Name)
+		}
+	}
+	return nodeNames, nil
+}
+
+// selectNetworkTopologyDomain finds the narrowest domain which contains all assumed members and has enough free
+// resources for the pending members that are still required to satisfy MinMember of each gang.
+// Within one level, the domain with the least free resources is preferred to reduce fragmentation.
+func selectNetworkTopologyDomain(levels []string, gangs []*Gang, nodeInfos []*framework.NodeInfo) *NetworkTopologyDomain {
+	assumedNodes := sets.NewString()
+	var pendingPods []*corev1.Pod
+	for _, gang := range gangs {
+		assumed, required := gang.getRequiredPendingChildren()
+		for _, pod := range assumed {
+			if pod.Spec.NodeName != "" {
+				assumedNodes.Insert(pod.Spec.NodeName)
+			}
+		}
+		pendingPods = append(pendingPods, required...)
+	}
+
+	podRequests := make([]*framework.Resource, 0, len(pendingPods))
+	for _, pod := range pendingPods {
+		requests, _ := resourceapi.PodRequestsAndLimits(pod)
+		podRequests = append(podRequests, framework.NewResource(requests))
+	}
+	sort.SliceStable(podRequests, func(i, j int) bool {
+		if podRequests[i].MilliCPU != podRequests[j].MilliCPU {
+			return podRequests[i].MilliCPU > podRequests[j].MilliCPU
+		}
+		return podRequests[i].Memory > podRequests[j].Memory
+	})
+
+	for _, key := range levels {
+		domainNodes := map[string][]*framework.NodeInfo{}
+		for _, nodeInfo := range nodeInfos {
+			node := nodeInfo.Node()
+			if node == nil {
+				continue
+			}
+			if value, ok := node.Labels[key]; ok {
+				domainNodes[value] = append(domainNodes[value], nodeInfo)
+			}
+		}
+
+		var selected *NetworkTopologyDomain
+		var selectedFreeMilliCPU int64
+		for value, nodes := range domainNodes {
+			if !containsAllNodes(nodes, assumedNodes) {
+				continue
+			}
+			fits, freeMilliCPU := fitsInDomain(nodes, podRequests)
+			if !fits {
+				continue
+			}
+			if selected == nil || freeMilliCPU < selectedFreeMilliCPU ||
+				(freeMilliCPU == selectedFreeMilliCPU && value < selected.Value) {
+				selected = &NetworkTopologyDomain{Key: key, Value: value}
+				selectedFreeMilliCPU = freeMilliCPU
+			}
+		}
+		if selected != nil {
+			return selected
+		}
+	}
+	return nil
+}
+
+func containsAllNodes(nodes []*framework.NodeInfo, nodeNames sets.String) bool {
+	if nodeNames.Len() == 0 {
+		return true
+	}
+	domainNodeNames := sets.NewString()
+	for _, nodeInfo := range nodes {
+		domainNodeNames.Insert(nodeInfo.Node().Name)
+	}
+	return domainNodeNames.IsSuperset(nodeNames)
+}
+
+// fitsInDomain places the pod requests on the nodes with first-fit and returns whether all pods fit
+// and the free milli-CPU of the domain before placement.
+func fitsInDomain(nodes []*framework.NodeInfo, podRequests []*framework.Resource) (bool, int64) {
+	freeResources := make([]*framework.Resource, 0, len(nodes))
+	var freeMilliCPU int64
+	for _, nodeInfo := range nodes {
+		if nodeInfo.Node().Spec.Unschedulable {
+			continue
+		}
+		free := nodeInfo.Allocatable.Clone()
+		free.MilliCPU -= nodeInfo.Requested.MilliCPU
+		free.Memory -= nodeInfo.Requested.Memory
+		free.EphemeralStorage -= nodeInfo.Requested.EphemeralStorage
+		free.AllowedPodNumber -= len(nodeInfo.Pods)
+		for name, quantity := range nodeInfo.Requested.ScalarResources {
+			free.SetScalar(name, free.ScalarResources[name]-quantity)
+		}
+		freeResources = append(freeResources, free)
+		freeMilliCPU += free.MilliCPU
+	}
+
+	for _, request := range podRequests {
+		placed := false
+		for _, free := range freeResources {
+			if fitsResource(free, request) {
+				free.MilliCPU -= request.MilliCPU
+				free.Memory -= request.Memory
+				free.EphemeralStorage -= request.EphemeralStorage
+				free.AllowedPodNumber--
+				for name, quantity := range request.ScalarResources {
+					free.SetScalar(name, free.ScalarResources[name]-quantity)
+				}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			return false, freeMilliCPU
+		}
+	}
+	return true, freeMilliCPU
+}
+
+func fitsResource(free, request *framework.Resource) bool {
+	if free.AllowedPodNumber < 1 ||
+		free.MilliCPU < request.MilliCPU ||
+		free.Memory < request.Memory ||
+		free.EphemeralStorage < request.EphemeralStorage {
+		return false
+	}
+	for name, quantity := range request.ScalarResources {
+		if free.ScalarResources[name] < quantity {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+const (
+	testRackLabel  = "topology.kubernetes.io/rack"
+	testSpineLabel = "topology.kubernetes.io/spine"
+)
+
+func makeTopologyNodeInfo(name, rack, spine string, cpu string, pods ...*corev1.Pod) *framework.NodeInfo {
+	node := st.MakeNode().Name(name).Label(testRackLabel, rack).Label(testSpineLabel, spine).Capacity(map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    cpu,
+		corev1.ResourceMemory: "64Gi",
+		corev1.ResourcePods:   "100",
+	}).Obj()
+	nodeInfo := framework.NewNodeInfo(pods...)
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func makeGangPod(name, gangName string) *corev1.Pod {
+	return st.MakePod().Name(name).UID(name).Namespace("default").Label(v1alpha1.PodGroupLabel, gangName).
+		Req(map[corev1.ResourceName]string{corev1.ResourceCPU: "4", corev1.ResourceMemory: "8Gi"}).Obj()
+}
+
+func TestPreFilterNetworkTopology(t *testing.T) {
+	usedPod := st.MakePod().Name("used").Namespace("default").Req(map[corev1.ResourceName]string{corev1.ResourceCPU: "6"}).Obj()
+	nodeInfos := []*framework.NodeInfo{
+		makeTopologyNodeInfo("node-1", "rack-1", "spine-1", "8", usedPod),
+		makeTopologyNodeInfo("node-2", "rack-1", "spine-1", "8"),
+		makeTopologyNodeInfo("node-3", "rack-2", "spine-1", "8"),
+		makeTopologyNodeInfo("node-4", "rack-2", "spine-1", "8"),
+		makeTopologyNodeInfo("node-5", "rack-3", "spine-2", "16"),
+		makeTopologyNodeInfo("node-6", "rack-4", "spine-2", "16"),
+	}
+
+	tests := []struct {
+		name          string
+		policy        string
+		minMember     int32
+		wantNodeNames sets.String
+		wantDomain    *NetworkTopologyDomain
+		wantErr       bool
+	}{
+		{
+			name:      "policy is not specified",
+			minMember: 2,
+		},
+		{
+			name:          "select the rack with the least free resources",
+			policy:        extension.GangNetworkTopologyPolicyRequired,
+			minMember:     4,
+			wantNodeNames: sets.NewString("node-3", "node-4"),
+			wantDomain:    &NetworkTopologyDomain{Key: testRackLabel, Value: "rack-2"},
+		},
+		{
+			name:          "fall back to the spine if no rack fits",
+			policy:        extension.GangNetworkTopologyPolicyRequired,
+			minMember:     5,
+			wantNodeNames: sets.NewString("node-1", "node-2", "node-3", "node-4"),
+			wantDomain:    &NetworkTopologyDomain{Key: testSpineLabel, Value: "spine-1"},
+		},
+		{
+			name:      "required policy rejects the pod if no domain fits",
+			policy:    extension.GangNetworkTopologyPolicyRequired,
+			minMember: 9,
+			wantErr:   true,
+		},
+		{
+			name:      "preferred policy places the gang without restriction if no domain fits",
+			policy:    extension.GangNetworkTopologyPolicyPreferred,
+			minMember: 9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewManagerForTest().pgMgr
+			mgr.args.NetworkTopologyLevels = []string{testRackLabel, testSpineLabel}
+
+			pg := makePg("gang", "default", tt.minMember, nil, nil)
+			pg.Annotations = map[string]string{extension.AnnotationGangNetworkTopologyPolicy: tt.policy}
+			mgr.cache.onPodGroupAdd(pg)
+			var pods []*corev1.Pod
+			for i := 0; i < int(tt.minMember); i++ {
+				pod := makeGangPod(fmt.Sprintf("pod-%d", i), "gang")
+				mgr.cache.onPodAdd(pod)
+				pods = append(pods, pod)
+			}
+
+			nodeNames, err := mgr.PreFilterNetworkTopology(pods[0], nodeInfos)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNodeNames, nodeNames)
+			gang := mgr.GetGangByPod(pods[0])
+			assert.Equal(t, tt.wantDomain, gang.getNetworkTopologyDomain())
+
+			if tt.wantDomain != nil {
+				mgr.rejectGangGroupById(nil, "Coscheduling", gang.Name, "")
+				assert.Nil(t, gang.getNetworkTopologyDomain())
+			}
+		})
+	}
+}
+
+func TestSelectNetworkTopologyDomainWithAssumedPods(t *testing.T) {
+	nodeInfos := []*framework.NodeInfo{
+		makeTopologyNodeInfo("node-1", "rack-1", "spine-1", "8"),
+		makeTopologyNodeInfo("node-2", "rack-2", "spine-1", "8"),
+		makeTopologyNodeInfo("node-3", "rack-3", "spine-1", "8"),
+	}
+	gang := NewGang("default/gang")
+	gang.MinRequiredNumber = 2
+	assumedPod := makeGangPod("pod-a", "gang")
+	pendingPod := makeGangPod("pod-b", "gang")
+	gang.setChild(assumedPod)
+	gang.setChild(pendingPod)
+	assumedPod = assumedPod.DeepCopy()
+	assumedPod.Spec.NodeName = "node-3"
+	gang.addAssumedPod(assumedPod)
+
+	domain := selectNetworkTopologyDomain([]string{testRackLabel, testSpineLabel}, []*Gang{gang}, nodeInfos)
+	assert.Equal(t, &NetworkTopologyDomain{Key: testRackLabel, Value: "rack-3"}, domain)
+}
@@ -24,6 +24,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	policylisters "k8s.io/client-go/listers/policy/v1"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
@@ -33,6 +34,8 @@ import (
 	schedinformers "sigs.k8s.io/scheduler-plugins/pkg/generated/informers/externalversions/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
@@ -47,6 +50,8 @@ type Coscheduling struct {
 	pgClient         pgclientset.Interface
 	pgInformer       schedinformers.PodGroupInformer
 	pgMgr            core.Manager
+	rLister          schedulinglisters.ReservationLister
+	pdbLister        policylisters.PodDisruptionBudgetLister
 }
 
 var _ framework.QueueSortPlugin = &Coscheduling{}
@@ -91,6 +96,8 @@ func New(obj runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		pgClient:         pgClient,
 		pgInformer:       pgInformer,
 		pgMgr:            pgMgr,
+		rLister:          koordInformerFactory.Scheduling().V1alpha1().Reservations().Lister(),
+		pdbLister:        informerFactory.Policy().V1().PodDisruptionBudgets().Lister(),
 	}
 	return plugin, nil
 }
@@ -99,10 +106,16 @@ func (cs *Coscheduling) EventsToRegister() []framework.ClusterEvent {
 	// To register a custom event, follow the naming convention at:
 	// https://git.k8s.io/kubernetes/pkg/scheduler/eventhandlers.go#L403-L410
 	pgGVK := fmt.Sprintf("podgroups.v1alpha1.%v", scheduling.GroupName)
-	return []framework.ClusterEvent{
+	events := []framework.ClusterEvent{
 		{Resource: framework.Pod, ActionType: framework.Add},
 		{Resource: framework.GVK(pgGVK), ActionType: framework.Add | framework.Update},
 	}
+	if cs.args != nil && cs.args.GangReservationThreshold != nil && cs.args.GangReservationThreshold.Duration > 0 {
+		// the gang should be retried once its reservations get scheduled
+		reservationGVK := fmt.Sprintf("reservations.%v.%v", schedulingv1alpha1.GroupVersion.Version, schedulingv1alpha1.GroupVersion.Group)
+		events = append(events, framework.ClusterEvent{Resource: framework.GVK(reservationGVK), ActionType: framework.Add | framework.Update})
+	}
+	return events
 }
 
 // Name returns name of the plugin. It is used in logs, etc.
@@ -159,6 +172,7 @@ func (cs *Coscheduling) Less(podInfo1, podInfo2 *framework.QueuedPodInfo) bool {
 // ii.Check whether the Gang has been timeout(check the pod's annotation,later introduced at Permit section) or is inited, and reject the pod if positive.
 // iii.Check whether the Gang has met the scheduleCycleValid check, and reject the pod if negative.
 // iv.Try update scheduleCycle, scheduleCycleValid, childrenScheduleRoundMap as mentioned above.
+// v.If the Gang requires network topology aware placement, restrict the nodes to the network topology domain of the GangGroup.
 func (cs *Coscheduling) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
 	// If PreFilter fails, return framework.Error to avoid
 	// any preemption attempts.
@@ -166,13 +180,41 @@ func (cs *Coscheduling) PreFilter(ctx context.Context, state *framework.CycleSta
 		klog.ErrorS(err, "PreFilter failed", "pod", klog.KObj(pod))
 		return nil, framework.AsStatus(err)
 	}
+
+	if len(cs.args.NetworkTopologyLevels) == 0 {
+		return nil, framework.NewStatus(framework.Success, "")
+	}
+	nodeInfos, err := cs.frameworkHandler.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	nodeNames, err := cs.pgMgr.PreFilterNetworkTopology(pod, nodeInfos)
+	if err != nil {
+		klog.ErrorS(err, "PreFilter failed to select network topology domain", "pod", klog.KObj(pod))
+		return nil, framework.NewStatus(framework.UnschedulableAndUnresolvable, err.Error())
+	}
+	if nodeNames != nil {
+		return &framework.PreFilterResult{NodeNames: nodeNames}, framework.NewStatus(framework.Success, "")
+	}
 	return nil, framework.NewStatus(framework.Success, "")
 }
 
 // PostFilter
-// i. If strict-mode, we will set scheduleCycleValid to false and release all assumed pods.
-// ii. If non-strict mode, we will do nothing.
+// i. If preemption is enabled, we will try to preempt lower priority pods for all the required members of the GangGroup,
+// and the GangGroup will not be rejected if the preemption succeeds.
+// ii. If the gang has waited longer than GangReservationThreshold, we will create Reservations for the required members
+// to hold the freed resources for the gang.
+// iii. If strict-mode, we will set scheduleCycleValid to false and release all assumed pods.
+// iv. If non-strict mode, we will do nothing.
 func (cs *Coscheduling) PostFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	if cs.args.EnablePreemption != nil && *cs.args.EnablePreemption && util.IsPodNeedGang(pod) {
+		result, status := cs.preemptForGangGroup(ctx, state, pod, filteredNodeStatusMap)
+		if status.IsSuccess() {
+			return result, status
+		}
+		klog.V(4).InfoS("Gang preemption failed", "pod", klog.KObj(pod), "reason", status.Message())
+	}
+	cs.reserveForGangGroup(ctx, pod)
 	return cs.pgMgr.PostFilter(ctx, pod, cs.frameworkHandler, Name, filteredNodeStatusMap)
 }
 
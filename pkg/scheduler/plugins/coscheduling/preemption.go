@@ -0,0 +1,414 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coscheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	schedutil "k8s.io/kubernetes/pkg/scheduler/util"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+// gangPreemptionPlan records where each required member of the GangGroup will be placed
+// and which pods should be preempted to make room for them.
+type gangPreemptionPlan struct {
+	nominatedNodes map[types.UID]string
+	victims        map[types.UID]*corev1.Pod
+	victimNodes    map[types.UID]string
+}
+
+// preemptForGangGroup tries to admit all the required members of the GangGroup by preempting lower priority pods.
+// The preemption takes effect only if all the required members can be placed, which avoids the waste of preempting
+// victims for a part of the GangGroup. The members of a GangGroup are assumed to share the same scheduling constraints
+// as the preemptor, so the preemptor and its CycleState are used to evaluate the placement of every member.
+// Like the default preemption, the victims whose PodDisruptionBudgets would be violated are reprieved first and
+// the nodes with fewer PDB violations are preferred. The budgets are shared by the victims of all the members.
+func (cs *Coscheduling) preemptForGangGroup(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	if ok, msg := cs.podEligibleToPreemptOthers(pod); !ok {
+		return nil, framework.NewStatus(framework.Unschedulable, msg)
+	}
+	members := cs.pgMgr.GetGangGroupRequiredPendingPods(pod)
+	if len(members) == 0 || members[0].UID != pod.UID {
+		return nil, framework.NewStatus(framework.Unschedulable, "gang group has no pending member required")
+	}
+	nodeInfos, err := cs.frameworkHandler.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	// the members should be placed in the network topology domain of the GangGroup if there is one
+	domainNodes, err := cs.pgMgr.PreFilterNetworkTopology(pod, nodeInfos)
+	if err != nil {
+		return nil, framework.NewStatus(framework.Unschedulable, err.Error())
+	}
+	if domainNodes != nil {
+		var filtered []*framework.NodeInfo
+		for _, nodeInfo := range nodeInfos {
+			if nodeInfo.Node() != nil && domainNodes.Has(nodeInfo.Node().Name) {
+				filtered = append(filtered, nodeInfo)
+			}
+		}
+		nodeInfos = filtered
+	}
+
+	plan, status := cs.findGangPreemptionPlan(ctx, state, pod, members, nodeInfos, filteredNodeStatusMap)
+	if !status.IsSuccess() {
+		return nil, status
+	}
+	if err := cs.preparePreemption(pod, members, plan); err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	return framework.NewPostFilterResultWithNominatedNode(plan.nominatedNodes[pod.UID]), framework.NewStatus(framework.Success)
+}
+
+// podEligibleToPreemptOthers prevents the preemptor from preempting again while the victims on its
+// nominated node are still terminating.
+func (cs *Coscheduling) podEligibleToPreemptOthers(pod *corev1.Pod) (bool, string) {
+	if pod.Spec.PreemptionPolicy != nil && *pod.Spec.PreemptionPolicy == corev1.PreemptNever {
+		return false, "not eligible due to preemptionPolicy=Never."
+	}
+	nomNodeName := pod.Status.NominatedNodeName
+	if len(nomNodeName) == 0 {
+		return true, ""
+	}
+	nodeInfo, err := cs.frameworkHandler.SnapshotSharedLister().NodeInfos().Get(nomNodeName)
+	if err != nil || nodeInfo == nil {
+		return true, ""
+	}
+	podPriority := corev1helpers.PodPriority(pod)
+	for _, p := range nodeInfo.Pods {
+		if p.Pod.DeletionTimestamp != nil && corev1helpers.PodPriority(p.Pod) < podPriority {
+			return false, "not eligible due to a terminating pod on the nominated node."
+		}
+	}
+	return true, ""
+}
+
+func (cs *Coscheduling) findGangPreemptionPlan(
+	ctx context.Context,
+	state *framework.CycleState,
+	pod *corev1.Pod,
+	members []*corev1.Pod,
+	nodeInfos []*framework.NodeInfo,
+	filteredNodeStatusMap framework.NodeToStatusMap,
+) (*gangPreemptionPlan, *framework.Status) {
+	var candidateNodes []*framework.NodeInfo
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node() == nil {
+			continue
+		}
+		// preemption cannot help the nodes which are UnschedulableAndUnresolvable
+		if filteredNodeStatusMap[nodeInfo.Node().Name].Code() == framework.UnschedulableAndUnresolvable {
+			continue
+		}
+		candidateNodes = append(candidateNodes, nodeInfo.Clone())
+	}
+	sort.Slice(candidateNodes, func(i, j int) bool {
+		return candidateNodes[i].Node().Name < candidateNodes[j].Node().Name
+	})
+
+	pdbs, err := cs.listPDBs()
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+
+	plan := &gangPreemptionPlan{
+		nominatedNodes: map[types.UID]string{},
+		victims:        map[types.UID]*corev1.Pod{},
+		victimNodes:    map[types.UID]string{},
+	}
+	simState := state.Clone()
+	for _, member := range members {
+		placeholder := pod.DeepCopy()
+		placeholder.Name, placeholder.Namespace, placeholder.UID = member.Name, member.Namespace, member.UID
+
+		bestIndex, bestNumViolating := -1, 0
+		var bestVictims []*corev1.Pod
+		var bestNodeInfo *framework.NodeInfo
+		var bestState *framework.CycleState
+		for i, nodeInfo := range candidateNodes {
+			trialNodeInfo := nodeInfo.Clone()
+			trialState := simState.Clone()
+			victims, numViolating, status := cs.selectVictimsOnNode(ctx, trialState, pod, trialNodeInfo, pdbs)
+			if !status.IsSuccess() {
+				continue
+			}
+			if bestIndex < 0 || numViolating < bestNumViolating ||
+				(numViolating == bestNumViolating && isBetterVictims(victims, bestVictims)) {
+				bestIndex, bestNumViolating, bestVictims, bestNodeInfo, bestState = i, numViolating, victims, trialNodeInfo, trialState
+				if len(victims) == 0 {
+					break
+				}
+			}
+		}
+		if bestIndex < 0 {
+			return nil, framework.NewStatus(framework.Unschedulable,
+				fmt.Sprintf("preemption cannot make room for all %d required members of the gang group", len(members)))
+		}
+
+		nodeName := bestNodeInfo.Node().Name
+		placeholder.Spec.NodeName = nodeName
+		placeholderInfo := framework.NewPodInfo(placeholder)
+		bestNodeInfo.AddPodInfo(placeholderInfo)
+		if status := cs.frameworkHandler.RunPreFilterExtensionAddPod(ctx, bestState, pod, placeholderInfo, bestNodeInfo); !status.IsSuccess() {
+			return nil, status
+		}
+		candidateNodes[bestIndex] = bestNodeInfo
+		simState = bestState
+		consumePDBs(pdbs, bestVictims)
+
+		plan.nominatedNodes[member.UID] = nodeName
+		for _, victim := range bestVictims {
+			plan.victims[victim.UID] = victim
+			plan.victimNodes[victim.UID] = nodeName
+		}
+	}
+	return plan, framework.NewStatus(framework.Success)
+}
+
+// isBetterVictims prefers fewer victims, then the victims with lower highest priority.
+func isBetterVictims(victims, other []*corev1.Pod) bool {
+	if len(victims) != len(other) {
+		return len(victims) < len(other)
+	}
+	return highestPriority(victims) < highestPriority(other)
+}
+
+func highestPriority(pods []*corev1.Pod) int32 {
+	var result int32
+	for i, pod := range pods {
+		if priority := corev1helpers.PodPriority(pod); i == 0 || priority > result {
+			result = priority
+		}
+	}
+	return result
+}
+
+// selectVictimsOnNode finds the minimum set of pods on the given node that should be preempted to make room for the pod.
+// It first removes all the preemptable pods, then reprieves as many pods as possible from the highest priority,
+// the pods whose PodDisruptionBudgets would be violated are reprieved before the others. It returns the victims
+// and the number of victims violating the PodDisruptionBudgets. The state and nodeInfo are updated to reflect the result.
+func (cs *Coscheduling) selectVictimsOnNode(ctx context.Context, state *framework.CycleState, pod *corev1.Pod,
+	nodeInfo *framework.NodeInfo, pdbs []*policy.PodDisruptionBudget) ([]*corev1.Pod, int, *framework.Status) {
+	if status := cs.frameworkHandler.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo); status.IsSuccess() {
+		return nil, 0, status
+	}
+
+	removePod := func(rpi *framework.PodInfo) error {
+		if err := nodeInfo.RemovePod(rpi.Pod); err != nil {
+			return err
+		}
+		return cs.frameworkHandler.RunPreFilterExtensionRemovePod(ctx, state, pod, rpi, nodeInfo).AsError()
+	}
+	addPod := func(api *framework.PodInfo) error {
+		nodeInfo.AddPodInfo(api)
+		return cs.frameworkHandler.RunPreFilterExtensionAddPod(ctx, state, pod, api, nodeInfo).AsError()
+	}
+
+	var potentialVictims []*framework.PodInfo
+	for _, pi := range nodeInfo.Pods {
+		if cs.canPreempt(pod, pi.Pod) {
+			potentialVictims = append(potentialVictims, pi)
+		}
+	}
+	if len(potentialVictims) == 0 {
+		return nil, 0, framework.NewStatus(framework.UnschedulableAndUnresolvable,
+			fmt.Sprintf("No victims found on node %v for preemptor pod %v", nodeInfo.Node().Name, pod.Name))
+	}
+	for _, pi := range potentialVictims {
+		if err := removePod(pi); err != nil {
+			return nil, 0, framework.AsStatus(err)
+		}
+	}
+	if status := cs.frameworkHandler.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo); !status.IsSuccess() {
+		return nil, 0, status
+	}
+
+	var victims []*corev1.Pod
+	numViolatingVictim := 0
+	sort.Slice(potentialVictims, func(i, j int) bool {
+		return schedutil.MoreImportantPod(potentialVictims[i].Pod, potentialVictims[j].Pod)
+	})
+	reprievePod := func(pi *framework.PodInfo) (bool, error) {
+		if err := addPod(pi); err != nil {
+			return false, err
+		}
+		if status := cs.frameworkHandler.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo); !status.IsSuccess() {
+			if err := removePod(pi); err != nil {
+				return false, err
+			}
+			victims = append(victims, pi.Pod)
+			klog.V(5).InfoS("Pod is a potential gang preemption victim on node", "pod", klog.KObj(pi.Pod), "node", klog.KObj(nodeInfo.Node()))
+			return false, nil
+		}
+		return true, nil
+	}
+	violatingVictims, nonViolatingVictims := filterPodsWithPDBViolation(potentialVictims, pdbs)
+	for _, pi := range violatingVictims {
+		if fits, err := reprievePod(pi); err != nil {
+			return nil, 0, framework.AsStatus(err)
+		} else if !fits {
+			numViolatingVictim++
+		}
+	}
+	for _, pi := range nonViolatingVictims {
+		if _, err := reprievePod(pi); err != nil {
+			return nil, 0, framework.AsStatus(err)
+		}
+	}
+	return victims, numViolatingVictim, framework.NewStatus(framework.Success)
+}
+
+// listPDBs returns the copies of all the PodDisruptionBudgets, whose DisruptionsAllowed are consumed by the victims.
+func (cs *Coscheduling) listPDBs() ([]*policy.PodDisruptionBudget, error) {
+	if cs.pdbLister == nil {
+		return nil, nil
+	}
+	pdbs, err := cs.pdbLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*policy.PodDisruptionBudget, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		result = append(result, pdb.DeepCopy())
+	}
+	return result, nil
+}
+
+// filterPodsWithPDBViolation groups the given pods into the pods whose PodDisruptionBudgets will be violated
+// if they are preempted and the others. The order of the pods is preserved in both groups.
+func filterPodsWithPDBViolation(podInfos []*framework.PodInfo, pdbs []*policy.PodDisruptionBudget) (violatingPodInfos, nonViolatingPodInfos []*framework.PodInfo) {
+	pdbsAllowed := make([]int32, len(pdbs))
+	for i, pdb := range pdbs {
+		pdbsAllowed[i] = pdb.Status.DisruptionsAllowed
+	}
+	for _, podInfo := range podInfos {
+		violated := false
+		for _, i := range matchedPDBs(podInfo.Pod, pdbs) {
+			pdbsAllowed[i]--
+			if pdbsAllowed[i] < 0 {
+				violated = true
+			}
+		}
+		if violated {
+			violatingPodInfos = append(violatingPodInfos, podInfo)
+		} else {
+			nonViolatingPodInfos = append(nonViolatingPodInfos, podInfo)
+		}
+	}
+	return violatingPodInfos, nonViolatingPodInfos
+}
+
+// consumePDBs decreases the DisruptionsAllowed of the PodDisruptionBudgets matched by the victims,
+// so that the victims of the next members are evaluated against the remaining budgets.
+func consumePDBs(pdbs []*policy.PodDisruptionBudget, victims []*corev1.Pod) {
+	for _, victim := range victims {
+		for _, i := range matchedPDBs(victim, pdbs) {
+			pdbs[i].Status.DisruptionsAllowed--
+		}
+	}
+}
+
+// matchedPDBs returns the indexes of the PodDisruptionBudgets matching the pod. The PodDisruptionBudgets which
+// already count the pod in DisruptedPods are skipped, since the pod has been processed by the API server.
+func matchedPDBs(pod *corev1.Pod, pdbs []*policy.PodDisruptionBudget) []int {
+	// A pod with no labels will not match any PDB.
+	if len(pod.Labels) == 0 {
+		return nil
+	}
+	var indexes []int
+	for i, pdb := range pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if _, exist := pdb.Status.DisruptedPods[pod.Name]; exist {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// canPreempt checks whether the victim can be preempted by the pod. The victim should have lower priority
+// or be a BE pod while the pod is not, and the members of the same GangGroup are never preempted.
+func (cs *Coscheduling) canPreempt(pod, victim *corev1.Pod) bool {
+	if victim.DeletionTimestamp != nil || cs.pgMgr.IsPodInGangGroup(pod, victim) {
+		return false
+	}
+	if corev1helpers.PodPriority(victim) < corev1helpers.PodPriority(pod) {
+		return true
+	}
+	return extension.GetPodQoSClassWithDefault(victim) == extension.QoSBE &&
+		extension.GetPodQoSClassWithDefault(pod) != extension.QoSBE
+}
+
+// preparePreemption evicts the victims and nominates the nodes for the other members of the GangGroup.
+func (cs *Coscheduling) preparePreemption(pod *corev1.Pod, members []*corev1.Pod, plan *gangPreemptionPlan) error {
+	client := cs.frameworkHandler.ClientSet()
+	for uid, victim := range plan.victims {
+		nodeName := plan.victimNodes[uid]
+		if waitingPod := cs.frameworkHandler.GetWaitingPod(uid); waitingPod != nil {
+			waitingPod.Reject(Name, "preempted")
+			klog.V(2).InfoS("Gang preemptor rejected a waiting pod", "preemptor", klog.KObj(pod), "waitingPod", klog.KObj(victim), "node", nodeName)
+		} else {
+			if err := schedutil.DeletePod(client, victim); err != nil {
+				klog.ErrorS(err, "Failed to preempt pod for gang", "pod", klog.KObj(victim), "preemptor", klog.KObj(pod))
+				return err
+			}
+			klog.V(2).InfoS("Gang preemptor preempted victim pod", "preemptor", klog.KObj(pod), "victim", klog.KObj(victim), "node", nodeName)
+		}
+		cs.frameworkHandler.EventRecorder().Eventf(victim, pod, corev1.EventTypeNormal, "Preempted", "Preempting",
+			"Preempted by gang member %v/%v on node %v", pod.Namespace, pod.Name, nodeName)
+	}
+
+	for _, member := range members {
+		if member.UID == pod.UID {
+			continue
+		}
+		nodeName := plan.nominatedNodes[member.UID]
+		cs.frameworkHandler.AddNominatedPod(framework.NewPodInfo(member), &framework.NominatingInfo{
+			NominatingMode:    framework.ModeOverride,
+			NominatedNodeName: nodeName,
+		})
+		if member.Status.NominatedNodeName == nodeName {
+			continue
+		}
+		newStatus := member.Status.DeepCopy()
+		newStatus.NominatedNodeName = nodeName
+		if err := schedutil.PatchPodStatus(client, member, newStatus); err != nil {
+			// the nomination in scheduler cache still takes effect, so we do not return the error
+			klog.ErrorS(err, "Failed to nominate node for gang member", "pod", klog.KObj(member), "node", nodeName)
+		}
+	}
+	klog.V(2).InfoS("Gang preemption succeeded", "preemptor", klog.KObj(pod), "members", len(members), "victims", len(plan.victims))
+	return nil
+}
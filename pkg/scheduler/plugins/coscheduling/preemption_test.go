@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coscheduling
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/api/v1/resource"
+	scheduledconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	fakepgclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+var _ framework.FilterPlugin = &testCPUFitPlugin{}
+
+// testCPUFitPlugin only checks whether the requested CPU of the pod fits the node.
+type testCPUFitPlugin struct{}
+
+func (p *testCPUFitPlugin) Name() string { return "testCPUFit" }
+
+func (p *testCPUFitPlugin) Filter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	requests, _ := resource.PodRequestsAndLimits(pod)
+	if nodeInfo.Requested.MilliCPU+requests.Cpu().MilliValue() > nodeInfo.Allocatable.MilliCPU {
+		return framework.NewStatus(framework.Unschedulable, "Insufficient cpu")
+	}
+	return nil
+}
+
+type testPodNominator struct {
+	sync.RWMutex
+	nominatedPods map[string][]*framework.PodInfo
+	podToNode     map[types.UID]string
+}
+
+func newTestPodNominator() *testPodNominator {
+	return &testPodNominator{
+		nominatedPods: map[string][]*framework.PodInfo{},
+		podToNode:     map[types.UID]string{},
+	}
+}
+
+func (n *testPodNominator) AddNominatedPod(pi *framework.PodInfo, nominatingInfo *framework.NominatingInfo) {
+	n.Lock()
+	defer n.Unlock()
+	n.nominatedPods[nominatingInfo.NominatedNodeName] = append(n.nominatedPods[nominatingInfo.NominatedNodeName], pi)
+	n.podToNode[pi.Pod.UID] = nominatingInfo.NominatedNodeName
+}
+
+func (n *testPodNominator) DeleteNominatedPodIfExists(pod *corev1.Pod) {}
+
+func (n *testPodNominator) UpdateNominatedPod(oldPod *corev1.Pod, newPodInfo *framework.PodInfo) {}
+
+func (n *testPodNominator) NominatedPodsForNode(nodeName string) []*framework.PodInfo {
+	n.RLock()
+	defer n.RUnlock()
+	return n.nominatedPods[nodeName]
+}
+
+func TestPostFilterWithPreemption(t *testing.T) {
+	lowPriority, highPriority, veryHighPriority := int32(0), int32(100), int32(200)
+	makeNode := func(name string) *corev1.Node {
+		return st.MakeNode().Name(name).Capacity(map[corev1.ResourceName]string{
+			corev1.ResourceCPU:  "4",
+			corev1.ResourcePods: "100",
+		}).Obj()
+	}
+	makeRunningPod := func(name, nodeName string, priority int32) *corev1.Pod {
+		return st.MakePod().Name(name).Namespace("default").UID(name).Node(nodeName).Priority(priority).
+			Req(map[corev1.ResourceName]string{corev1.ResourceCPU: "3"}).Obj()
+	}
+	protectedPod := func(pod *corev1.Pod) *corev1.Pod {
+		pod.Labels = map[string]string{"app": "protected"}
+		return pod
+	}
+	makeMember := func(name string) *corev1.Pod {
+		return st.MakePod().Name(name).Namespace("default").UID(name).Priority(highPriority).
+			Label(v1alpha1.PodGroupLabel, "gang").Req(map[corev1.ResourceName]string{corev1.ResourceCPU: "3"}).Obj()
+	}
+
+	tests := []struct {
+		name            string
+		runningPods     []*corev1.Pod
+		nodes           []string
+		pdbs            []*policy.PodDisruptionBudget
+		wantSuccess     bool
+		wantNominated   map[string]string
+		wantDeletedPods []string
+		wantKeptPods    []string
+	}{
+		{
+			name: "preempt victims on two nodes for the whole gang",
+			runningPods: []*corev1.Pod{
+				makeRunningPod("victim-1", "node-1", lowPriority),
+				makeRunningPod("victim-2", "node-2", lowPriority),
+			},
+			wantSuccess:     true,
+			wantNominated:   map[string]string{"member-1": "node-1", "member-2": "node-2"},
+			wantDeletedPods: []string{"victim-1", "victim-2"},
+		},
+		{
+			name: "do not preempt if only a part of the gang fits",
+			runningPods: []*corev1.Pod{
+				makeRunningPod("victim-1", "node-1", lowPriority),
+				makeRunningPod("high-priority-pod", "node-2", veryHighPriority),
+			},
+			wantSuccess: false,
+		},
+		{
+			name: "prefer the victims not protected by PodDisruptionBudget",
+			runningPods: []*corev1.Pod{
+				protectedPod(makeRunningPod("protected-pod", "node-1", lowPriority)),
+				makeRunningPod("victim-2", "node-2", lowPriority),
+				makeRunningPod("victim-3", "node-3", lowPriority),
+			},
+			nodes: []string{"node-1", "node-2", "node-3"},
+			pdbs: []*policy.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pdb"},
+					Spec: policy.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "protected"}},
+					},
+					Status: policy.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+				},
+			},
+			wantSuccess:     true,
+			wantNominated:   map[string]string{"member-1": "node-2", "member-2": "node-3"},
+			wantDeletedPods: []string{"victim-2", "victim-3"},
+			wantKeptPods:    []string{"protected-pod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pgClientSet := fakepgclientset.NewSimpleClientset()
+			cs := kubefake.NewSimpleClientset()
+			pg := makePg("gang", "default", 2, nil, nil)
+			_, err := pgClientSet.SchedulingV1alpha1().PodGroups(pg.Namespace).Create(context.TODO(), pg, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			members := []*corev1.Pod{makeMember("member-1"), makeMember("member-2")}
+			for _, pod := range append(members, tt.runningPods...) {
+				_, err := cs.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			for _, pdb := range tt.pdbs {
+				_, err := cs.PolicyV1().PodDisruptionBudgets(pdb.Namespace).Create(context.TODO(), pdb, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			if tt.nodes == nil {
+				tt.nodes = []string{"node-1", "node-2"}
+			}
+			var nodes []*corev1.Node
+			for _, name := range tt.nodes {
+				nodes = append(nodes, makeNode(name))
+			}
+
+			var v1beta2args v1beta2.CoschedulingArgs
+			v1beta2.SetDefaults_CoschedulingArgs(&v1beta2args)
+			var args config.CoschedulingArgs
+			assert.NoError(t, v1beta2.Convert_v1beta2_CoschedulingArgs_To_config_CoschedulingArgs(&v1beta2args, &args, nil))
+			enablePreemption := true
+			args.EnablePreemption = &enablePreemption
+
+			koordClient := koordfake.NewSimpleClientset()
+			snapshot := newTestSharedLister(tt.runningPods, nodes)
+			gp := newTestCoschedulingWithExtender(t, &args, cs, pgClientSet, koordClient, snapshot)
+			result, status := gp.PostFilter(context.TODO(), framework.NewCycleState(), members[0], nil)
+			assert.Equal(t, tt.wantSuccess, status.IsSuccess(), status.Message())
+			if !tt.wantSuccess {
+				for _, pod := range tt.runningPods {
+					_, err := cs.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+					assert.NoError(t, err)
+				}
+				return
+			}
+			assert.Equal(t, tt.wantNominated[members[0].Name], result.NominatedNodeName)
+			member, err := cs.CoreV1().Pods("default").Get(context.TODO(), members[1].Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNominated[members[1].Name], member.Status.NominatedNodeName)
+			for _, name := range tt.wantDeletedPods {
+				_, err := cs.CoreV1().Pods("default").Get(context.TODO(), name, metav1.GetOptions{})
+				assert.True(t, errors.IsNotFound(err))
+			}
+			for _, name := range tt.wantKeptPods {
+				_, err := cs.CoreV1().Pods("default").Get(context.TODO(), name, metav1.GetOptions{})
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// newTestCoschedulingWithExtender builds the Coscheduling plugin with a framework extender
+// and a framework which runs the testCPUFit filter plugin.
+func newTestCoschedulingWithExtender(t *testing.T, args *config.CoschedulingArgs, cs *kubefake.Clientset,
+	pgClientSet *fakepgclientset.Clientset, koordClient *koordfake.Clientset, snapshot framework.SharedLister) *Coscheduling {
+	var plugin framework.Plugin
+	proxyNew := func(obj apiruntime.Object, handle framework.Handle) (framework.Plugin, error) {
+		koordInformerFactory := koordinatorinformers.NewSharedInformerFactory(koordClient, 0)
+		extenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
+			frameworkext.WithKoordinatorClientSet(koordClient),
+			frameworkext.WithKoordinatorSharedInformerFactory(koordInformerFactory))
+		if err != nil {
+			return nil, err
+		}
+		extender := extenderFactory.NewFrameworkExtender(handle.(framework.Framework))
+		extender.SetConfiguredPlugins(&scheduledconfig.Plugins{})
+		plugin, err = New(obj, &PodGroupClientSetAndHandle{
+			ExtendedHandle:       extender,
+			Interface:            pgClientSet,
+			koordInformerFactory: koordInformerFactory,
+		})
+		return plugin, err
+	}
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		func(reg *runtime.Registry, profile *scheduledconfig.KubeSchedulerProfile) {
+			profile.PluginConfig = []scheduledconfig.PluginConfig{{Name: Name, Args: args}}
+		},
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(Name, proxyNew),
+		schedulertesting.RegisterPreFilterPlugin(Name, proxyNew),
+		schedulertesting.RegisterFilterPlugin("testCPUFit", func(_ apiruntime.Object, _ framework.Handle) (framework.Plugin, error) {
+			return &testCPUFitPlugin{}, nil
+		}),
+		schedulertesting.RegisterPermitPlugin(Name, proxyNew),
+	}
+	informerFactory := informers.NewSharedInformerFactory(cs, 0)
+	_, err := schedulertesting.NewFramework(
+		registeredPlugins,
+		"koord-scheduler",
+		runtime.WithClientSet(cs),
+		runtime.WithInformerFactory(informerFactory),
+		runtime.WithSnapshotSharedLister(snapshot),
+		runtime.WithPodNominator(newTestPodNominator()),
+		runtime.WithEventRecorder(record.NewEventRecorderAdapter(record.NewFakeRecorder(1024))),
+	)
+	assert.NoError(t, err)
+	informerFactory.Start(context.TODO().Done())
+	informerFactory.WaitForCacheSync(context.TODO().Done())
+	return plugin.(*Coscheduling)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coscheduling
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	gangReservationNamePrefix = "gang-"
+	// gangReservationTTL bounds the lifetime of a gang Reservation, so that the Reservations of the gangs
+	// which are deleted or never placed are garbage-collected. The expired Reservation is recreated if the
+	// member is still pending.
+	gangReservationTTL = 30 * time.Minute
+)
+
+var timeNowFn = time.Now
+
+// reserveForGangGroup creates Reservations for the required pending members of the GangGroup
+// once the gang has waited longer than GangReservationThreshold.
+// Each Reservation is owned by one member pod, so the resources freed later are held for the member
+// until the whole GangGroup can be placed. Short-running pods can still backfill the reserved resources
+// if GangReservationBackfillMaxRuntime is set.
+func (cs *Coscheduling) reserveForGangGroup(ctx context.Context, pod *corev1.Pod) {
+	if cs.args == nil || cs.args.GangReservationThreshold == nil || cs.args.GangReservationThreshold.Duration <= 0 {
+		return
+	}
+	if !util.IsPodNeedGang(pod) || cs.pgMgr.IsGangMinSatisfied(pod) {
+		return
+	}
+	createTime := cs.pgMgr.GetCreatTime(&framework.QueuedPodInfo{PodInfo: framework.NewPodInfo(pod)})
+	if timeNowFn().Sub(createTime) < cs.args.GangReservationThreshold.Duration {
+		return
+	}
+	extendedHandle, ok := cs.frameworkHandler.(frameworkext.ExtendedHandle)
+	if !ok {
+		return
+	}
+
+	client := extendedHandle.KoordinatorClientSet().SchedulingV1alpha1().Reservations()
+	for _, member := range cs.pgMgr.GetGangGroupRequiredPendingPods(pod) {
+		name := getGangReservationName(member)
+		existing, err := cs.rLister.Get(name)
+		if err == nil {
+			if !reservationutil.IsReservationFailed(existing) {
+				continue
+			}
+			// the Reservation is expired or failed while the member is still pending
+			err = client.Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to delete failed gang reservation", "reservation", name, "pod", klog.KObj(member))
+				continue
+			}
+		} else if !errors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get gang reservation", "reservation", name, "pod", klog.KObj(member))
+			continue
+		}
+
+		reservation := newGangReservation(member, name, cs.args.GangReservationBackfillMaxRuntime)
+		_, err = client.Create(ctx, reservation, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			klog.ErrorS(err, "Failed to create gang reservation", "reservation", name, "pod", klog.KObj(member))
+			continue
+		}
+		klog.V(4).InfoS("Created gang reservation", "reservation", name, "pod", klog.KObj(member))
+		cs.frameworkHandler.EventRecorder().Eventf(member, nil, corev1.EventTypeNormal, "GangReservationCreated", "Reserve",
+			"Created reservation %s since the gang has waited longer than %v", name, cs.args.GangReservationThreshold.Duration)
+	}
+}
+
+func getGangReservationName(pod *corev1.Pod) string {
+	return gangReservationNamePrefix + string(pod.UID)
+}
+
+// newGangReservation builds a Reservation which can only be allocated by the pod.
+// The gang labels and annotations are not copied to the template,
+// otherwise the reserve pod would be regarded as a gang member.
+func newGangReservation(pod *corev1.Pod, name string, backfillMaxRuntime *metav1.Duration) *schedulingv1alpha1.Reservation {
+	spec := pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				extension.LabelGangReservation: "true",
+			},
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: pod.Namespace,
+				},
+				Spec: *spec,
+			},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{
+						Kind:      "Pod",
+						Namespace: pod.Namespace,
+						Name:      pod.Name,
+						UID:       pod.UID,
+					},
+				},
+			},
+			TTL:          &metav1.Duration{Duration: gangReservationTTL},
+			AllocateOnce: pointer.Bool(true),
+		},
+	}
+	if backfillMaxRuntime != nil && backfillMaxRuntime.Duration > 0 {
+		reservation.Annotations = map[string]string{
+			extension.AnnotationReservationBackfillMaxRuntime: backfillMaxRuntime.Duration.String(),
+		}
+	}
+	return reservation
+}
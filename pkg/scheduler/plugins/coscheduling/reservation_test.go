@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coscheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	fakepgclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+)
+
+func TestPostFilterWithGangReservation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		waitingTime          time.Duration
+		existingReservations []*schedulingv1alpha1.Reservation
+		wantReservations     []string
+	}{
+		{
+			name:        "gang has not waited long enough",
+			waitingTime: 5 * time.Minute,
+		},
+		{
+			name:             "create reservations for the required members",
+			waitingTime:      time.Hour,
+			wantReservations: []string{"gang-member-1", "gang-member-2"},
+		},
+		{
+			name:        "recreate the expired reservation",
+			waitingTime: time.Hour,
+			existingReservations: []*schedulingv1alpha1.Reservation{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "gang-member-1"},
+					Status:     schedulingv1alpha1.ReservationStatus{Phase: schedulingv1alpha1.ReservationFailed},
+				},
+			},
+			wantReservations: []string{"gang-member-1", "gang-member-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pgClientSet := fakepgclientset.NewSimpleClientset()
+			cs := kubefake.NewSimpleClientset()
+			now := time.Now()
+			pg := makePg("gang", "default", 2, &now, nil)
+			_, err := pgClientSet.SchedulingV1alpha1().PodGroups(pg.Namespace).Create(context.TODO(), pg, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			var members []*corev1.Pod
+			for _, name := range []string{"member-1", "member-2", "member-3"} {
+				pod := st.MakePod().Name(name).Namespace("default").UID(name).Label(v1alpha1.PodGroupLabel, "gang").
+					Req(map[corev1.ResourceName]string{corev1.ResourceCPU: "8"}).Obj()
+				_, err := cs.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+				assert.NoError(t, err)
+				members = append(members, pod)
+			}
+
+			var v1beta2args v1beta2.CoschedulingArgs
+			v1beta2.SetDefaults_CoschedulingArgs(&v1beta2args)
+			var args config.CoschedulingArgs
+			assert.NoError(t, v1beta2.Convert_v1beta2_CoschedulingArgs_To_config_CoschedulingArgs(&v1beta2args, &args, nil))
+			args.GangReservationThreshold = &metav1.Duration{Duration: 10 * time.Minute}
+			args.GangReservationBackfillMaxRuntime = &metav1.Duration{Duration: 30 * time.Minute}
+
+			koordClient := koordfake.NewSimpleClientset()
+			node := st.MakeNode().Name("node-1").Capacity(map[corev1.ResourceName]string{corev1.ResourceCPU: "4"}).Obj()
+			gp := newTestCoschedulingWithExtender(t, &args, cs, pgClientSet, koordClient, newTestSharedLister(nil, []*corev1.Node{node}))
+
+			reservationIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, r := range tt.existingReservations {
+				_, err := koordClient.SchedulingV1alpha1().Reservations().Create(context.TODO(), r, metav1.CreateOptions{})
+				assert.NoError(t, err)
+				assert.NoError(t, reservationIndexer.Add(r))
+			}
+			gp.rLister = schedulinglisters.NewReservationLister(reservationIndexer)
+
+			timeNowFn = func() time.Time {
+				return now.Add(tt.waitingTime)
+			}
+			defer func() {
+				timeNowFn = time.Now
+			}()
+			_, status := gp.PostFilter(context.TODO(), framework.NewCycleState(), members[0], nil)
+			assert.False(t, status.IsSuccess())
+
+			reservationList, err := koordClient.SchedulingV1alpha1().Reservations().List(context.TODO(), metav1.ListOptions{})
+			assert.NoError(t, err)
+			var names []string
+			for _, r := range reservationList.Items {
+				names = append(names, r.Name)
+				assert.Equal(t, "true", r.Labels[extension.LabelGangReservation])
+				assert.Equal(t, "30m0s", r.Annotations[extension.AnnotationReservationBackfillMaxRuntime])
+				assert.Len(t, r.Spec.Owners, 1)
+				assert.Equal(t, r.Name, getGangReservationName(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: r.Spec.Owners[0].Object.UID}}))
+				assert.Empty(t, r.Spec.Template.Labels)
+				assert.Equal(t, &metav1.Duration{Duration: gangReservationTTL}, r.Spec.TTL)
+				assert.NotEqual(t, schedulingv1alpha1.ReservationFailed, r.Status.Phase)
+			}
+			assert.Equal(t, tt.wantReservations, names)
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
@@ -50,6 +51,12 @@ type GroupQuotaManager struct {
 	// scaleMinQuotaManager is used when overRootResource
 	scaleMinQuotaManager *ScaleMinQuotaManager
 	once                 sync.Once
+	// runtimeQuotaCalculatePolicy decides how the runtimeQuotaCalculators distribute the runtimeQuota
+	runtimeQuotaCalculatePolicy config.RuntimeQuotaCalculatePolicy
+	// usageAccountingLock used for quotaUsageAccountingMap change
+	usageAccountingLock sync.Mutex
+	// quotaUsageAccountingMap stores the time-integrated usage of all the quotaGroups
+	quotaUsageAccountingMap map[string]*extension.QuotaUsageAccounting
 }
 
 func NewGroupQuotaManager(systemGroupMax, defaultGroupMax v1.ResourceList) *GroupQuotaManager {
@@ -61,6 +68,7 @@ func NewGroupQuotaManager(systemGroupMax, defaultGroupMax v1.ResourceList) *Grou
 		runtimeQuotaCalculatorMap:               make(map[string]*RuntimeQuotaCalculator),
 		quotaTopoNodeMap:                        make(map[string]*QuotaTopoNode),
 		scaleMinQuotaManager:                    NewScaleMinQuotaManager(),
+		quotaUsageAccountingMap:                 make(map[string]*extension.QuotaUsageAccounting),
 	}
 	quotaManager.quotaInfoMap[extension.SystemQuotaName] = NewQuotaInfo(false, true, extension.SystemQuotaName, extension.RootQuotaName)
 	quotaManager.quotaInfoMap[extension.SystemQuotaName].setMaxQuotaNoLock(systemGroupMax)
@@ -79,6 +87,25 @@ func (gqm *GroupQuotaManager) setScaleMinQuotaEnabled(flag bool) {
 	klog.V(5).Infof("Set ScaleMinQuotaEnabled, flag:%v", gqm.scaleMinQuotaEnabled)
 }
 
+// SetRuntimeQuotaCalculatePolicy sets the policy of all the runtimeQuotaCalculators, including the ones created later.
+func (gqm *GroupQuotaManager) SetRuntimeQuotaCalculatePolicy(policy config.RuntimeQuotaCalculatePolicy) {
+	gqm.hierarchyUpdateLock.Lock()
+	defer gqm.hierarchyUpdateLock.Unlock()
+
+	gqm.runtimeQuotaCalculatePolicy = policy
+	for _, runtimeQuotaCalculator := range gqm.runtimeQuotaCalculatorMap {
+		runtimeQuotaCalculator.setCalculatePolicy(policy)
+	}
+	klog.V(5).Infof("Set RuntimeQuotaCalculatePolicy, policy:%v", policy)
+}
+
+// newRuntimeQuotaCalculatorNoLock no need to lock gqm.hierarchyUpdateLock
+func (gqm *GroupQuotaManager) newRuntimeQuotaCalculatorNoLock(treeName string) *RuntimeQuotaCalculator {
+	runtimeQuotaCalculator := NewRuntimeQuotaCalculator(treeName)
+	runtimeQuotaCalculator.setCalculatePolicy(gqm.runtimeQuotaCalculatePolicy)
+	return runtimeQuotaCalculator
+}
+
 func (gqm *GroupQuotaManager) UpdateClusterTotalResource(deltaRes v1.ResourceList) {
 	gqm.hierarchyUpdateLock.Lock()
 	defer gqm.hierarchyUpdateLock.Unlock()
@@ -306,7 +333,11 @@ func (gqm *GroupQuotaManager) UpdateQuota(quota *v1alpha1.ElasticQuota, isDelete
 			return fmt.Errorf("get quota info failed, quotaName:%v", quotaName)
 		}
 		delete(gqm.quotaInfoMap, quotaName)
+		gqm.usageAccountingLock.Lock()
+		delete(gqm.quotaUsageAccountingMap, quotaName)
+		gqm.usageAccountingLock.Unlock()
 	} else {
+		gqm.restoreQuotaUsageAccountingNoLock(quota)
 		newQuotaInfo := NewQuotaInfoFromQuota(quota)
 		// update the local quotaInfo's crd
 		if localQuotaInfo, exist := gqm.quotaInfoMap[quotaName]; exist {
@@ -383,7 +414,7 @@ func (gqm *GroupQuotaManager) resetAllGroupQuotaNoLock() {
 	// clear old runtimeQuotaCalculator
 	gqm.runtimeQuotaCalculatorMap = make(map[string]*RuntimeQuotaCalculator)
 	// reset runtimeQuotaCalculator
-	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName] = NewRuntimeQuotaCalculator(extension.RootQuotaName)
+	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName] = gqm.newRuntimeQuotaCalculatorNoLock(extension.RootQuotaName)
 	gqm.runtimeQuotaCalculatorMap[extension.RootQuotaName].setClusterTotalResource(gqm.totalResourceExceptSystemAndDefaultUsed)
 	rootNode := gqm.quotaTopoNodeMap[extension.RootQuotaName]
 	gqm.resetAllGroupQuotaRecursiveNoLock(rootNode)
@@ -402,7 +433,7 @@ func (gqm *GroupQuotaManager) resetAllGroupQuotaNoLock() {
 func (gqm *GroupQuotaManager) resetAllGroupQuotaRecursiveNoLock(rootNode *QuotaTopoNode) {
 	childGroupQuotaInfos := rootNode.getChildGroupQuotaInfos()
 	for subName, topoNode := range childGroupQuotaInfos {
-		gqm.runtimeQuotaCalculatorMap[subName] = NewRuntimeQuotaCalculator(subName)
+		gqm.runtimeQuotaCalculatorMap[subName] = gqm.newRuntimeQuotaCalculatorNoLock(subName)
 
 		gqm.updateOneGroupMaxQuotaNoLock(topoNode.quotaInfo)
 		gqm.updateMinQuotaNoLock(topoNode.quotaInfo)
@@ -591,6 +622,7 @@ func (gqm *GroupQuotaManager) GetQuotaSummary(quotaName string) (*QuotaInfoSumma
 	quotaSummary := quotaInfo.GetQuotaSummary()
 	runtime := gqm.RefreshRuntimeNoLock(quotaName)
 	quotaSummary.Runtime = runtime.DeepCopy()
+	quotaSummary.DominantResource, quotaSummary.DominantShare = gqm.getDominantShareNoLock(quotaInfo, runtime)
 	return quotaSummary, true
 }
 
@@ -603,12 +635,26 @@ func (gqm *GroupQuotaManager) GetQuotaSummaries() map[string]*QuotaInfoSummary {
 		quotaSummary := quotaInfo.GetQuotaSummary()
 		runtime := gqm.RefreshRuntimeNoLock(quotaName)
 		quotaSummary.Runtime = runtime.DeepCopy()
+		quotaSummary.DominantResource, quotaSummary.DominantShare = gqm.getDominantShareNoLock(quotaInfo, runtime)
 		result[quotaName] = quotaSummary
 	}
 
 	return result
 }
 
+// getDominantShareNoLock returns the dominant share of the quotaGroup's runtimeQuota in its parent's runtimeQuota,
+// the SystemQuotaGroup and DefaultQuotaGroup are not involved in the runtimeQuota calculation.
+func (gqm *GroupQuotaManager) getDominantShareNoLock(quotaInfo *QuotaInfo, runtime v1.ResourceList) (v1.ResourceName, float64) {
+	if quotaInfo.Name == extension.SystemQuotaName || quotaInfo.Name == extension.DefaultQuotaName {
+		return "", 0
+	}
+	parRuntimeQuotaCalculator := gqm.getRuntimeQuotaCalculatorByNameNoLock(quotaInfo.ParentName)
+	if parRuntimeQuotaCalculator == nil {
+		return "", 0
+	}
+	return getDominantShare(runtime, parRuntimeQuotaCalculator.getTotalResource())
+}
+
 func (gqm *GroupQuotaManager) OnPodAdd(quotaName string, pod *v1.Pod) {
 	gqm.hierarchyUpdateLock.RLock()
 	defer gqm.hierarchyUpdateLock.RUnlock()
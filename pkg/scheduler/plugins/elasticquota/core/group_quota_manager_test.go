@@ -1087,6 +1087,7 @@ func NewGroupQuotaManagerForTest() *GroupQuotaManager {
 		runtimeQuotaCalculatorMap:               make(map[string]*RuntimeQuotaCalculator),
 		scaleMinQuotaManager:                    NewScaleMinQuotaManager(),
 		quotaTopoNodeMap:                        make(map[string]*QuotaTopoNode),
+		quotaUsageAccountingMap:                 make(map[string]*extension.QuotaUsageAccounting),
 	}
 	quotaManager.quotaInfoMap[extension.SystemQuotaName] = NewQuotaInfo(false, true, extension.SystemQuotaName, "")
 	quotaManager.quotaInfoMap[extension.DefaultQuotaName] = NewQuotaInfo(false, true, extension.DefaultQuotaName, "")
@@ -232,6 +232,12 @@ func (qi *QuotaInfo) getMax() v1.ResourceList {
 	return qi.CalculateInfo.Max.DeepCopy()
 }
 
+func (qi *QuotaInfo) getAutoScaleMin() v1.ResourceList {
+	qi.lock.Lock()
+	defer qi.lock.Unlock()
+	return qi.CalculateInfo.AutoScaleMin.DeepCopy()
+}
+
 func NewQuotaInfoFromQuota(quota *v1alpha1.ElasticQuota) *QuotaInfo {
 	isParent := extension.IsParentQuota(quota)
 	parentName := extension.GetParentQuotaName(quota)
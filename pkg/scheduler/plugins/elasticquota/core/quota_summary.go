@@ -40,6 +40,11 @@ type QuotaInfoSummary struct {
 	SharedWeight v1.ResourceList `json:"sharedWeight"`
 	Runtime      v1.ResourceList `json:"runtime"`
 
+	// DominantResource is the resource with the largest share of the runtime in the parent's runtime,
+	// and DominantShare is the share of the DominantResource.
+	DominantResource v1.ResourceName `json:"dominantResource,omitempty"`
+	DominantShare    float64         `json:"dominantShare"`
+
 	PodCache map[string]*SimplePodInfo `json:"podCache"`
 }
 
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+// AccountQuotaUsage accumulates the used resource-hours of all the quotaGroups since the last accounting,
+// assuming the used doesn't change in the period. The period longer than maxElapsed is dropped without
+// being charged, since the scheduler was down or the used is unknown during it.
+func (gqm *GroupQuotaManager) AccountQuotaUsage(now time.Time, maxElapsed time.Duration) {
+	gqm.hierarchyUpdateLock.RLock()
+	defer gqm.hierarchyUpdateLock.RUnlock()
+
+	gqm.usageAccountingLock.Lock()
+	defer gqm.usageAccountingLock.Unlock()
+
+	for quotaName, quotaInfo := range gqm.quotaInfoMap {
+		accounting := gqm.quotaUsageAccountingMap[quotaName]
+		if accounting == nil {
+			gqm.quotaUsageAccountingMap[quotaName] = newQuotaUsageAccounting(now)
+			continue
+		}
+		elapsed := now.Sub(accounting.LastUpdateTime.Time)
+		if elapsed <= 0 {
+			continue
+		}
+		if elapsed > maxElapsed {
+			klog.V(4).InfoS("drop the quota usage accounting period longer than expected", "quota", quotaName, "elapsed", elapsed)
+			accounting.LastUpdateTime = metav1.NewTime(now)
+			continue
+		}
+		accumulateQuotaUsage(accounting, quotaInfo.GetUsed(), quotaInfo.getAutoScaleMin(), elapsed.Hours())
+		accounting.LastUpdateTime = metav1.NewTime(now)
+	}
+}
+
+func (gqm *GroupQuotaManager) GetQuotaUsageAccounting(quotaName string) (*extension.QuotaUsageAccounting, bool) {
+	gqm.usageAccountingLock.Lock()
+	defer gqm.usageAccountingLock.Unlock()
+
+	accounting, exist := gqm.quotaUsageAccountingMap[quotaName]
+	if !exist {
+		return nil, false
+	}
+	return copyQuotaUsageAccounting(accounting), true
+}
+
+func (gqm *GroupQuotaManager) GetQuotaUsageAccountings() map[string]*extension.QuotaUsageAccounting {
+	gqm.usageAccountingLock.Lock()
+	defer gqm.usageAccountingLock.Unlock()
+
+	result := make(map[string]*extension.QuotaUsageAccounting, len(gqm.quotaUsageAccountingMap))
+	for quotaName, accounting := range gqm.quotaUsageAccountingMap {
+		result[quotaName] = copyQuotaUsageAccounting(accounting)
+	}
+	return result
+}
+
+// restoreQuotaUsageAccountingNoLock restores the accounting persisted in the quota after the scheduler restarts,
+// no need to lock gqm.hierarchyUpdateLock.
+func (gqm *GroupQuotaManager) restoreQuotaUsageAccountingNoLock(quota *v1alpha1.ElasticQuota) {
+	gqm.usageAccountingLock.Lock()
+	defer gqm.usageAccountingLock.Unlock()
+
+	if _, exist := gqm.quotaUsageAccountingMap[quota.Name]; exist {
+		return
+	}
+	accounting, err := extension.GetQuotaUsageAccounting(quota)
+	if err != nil {
+		klog.Errorf("failed to restore quota usage accounting, quota:%v, err:%v", quota.Name, err)
+		return
+	}
+	if accounting != nil {
+		gqm.quotaUsageAccountingMap[quota.Name] = accounting
+	}
+}
+
+func newQuotaUsageAccounting(now time.Time) *extension.QuotaUsageAccounting {
+	return &extension.QuotaUsageAccounting{
+		StartTime:      metav1.NewTime(now),
+		LastUpdateTime: metav1.NewTime(now),
+		Used:           map[v1.ResourceName]float64{},
+		Guaranteed:     map[v1.ResourceName]float64{},
+		Borrowed:       map[v1.ResourceName]float64{},
+	}
+}
+
+// accumulateQuotaUsage adds used*hours to the accounting, the part of used within min is guaranteed
+// and the rest is borrowed.
+func accumulateQuotaUsage(accounting *extension.QuotaUsageAccounting, used, min v1.ResourceList, hours float64) {
+	if accounting.Used == nil {
+		accounting.Used = map[v1.ResourceName]float64{}
+	}
+	if accounting.Guaranteed == nil {
+		accounting.Guaranteed = map[v1.ResourceName]float64{}
+	}
+	if accounting.Borrowed == nil {
+		accounting.Borrowed = map[v1.ResourceName]float64{}
+	}
+	guaranteed := quotav1.Mask(min, quotav1.ResourceNames(used))
+	for resourceName, quantity := range used {
+		usedValue := quantity.AsApproximateFloat64()
+		if usedValue <= 0 {
+			continue
+		}
+		guaranteedValue := 0.0
+		if minQuantity, ok := guaranteed[resourceName]; ok {
+			guaranteedValue = minQuantity.AsApproximateFloat64()
+		}
+		if guaranteedValue > usedValue {
+			guaranteedValue = usedValue
+		}
+		if guaranteedValue < 0 {
+			guaranteedValue = 0
+		}
+		accounting.Used[resourceName] += usedValue * hours
+		accounting.Guaranteed[resourceName] += guaranteedValue * hours
+		accounting.Borrowed[resourceName] += (usedValue - guaranteedValue) * hours
+	}
+}
+
+func copyQuotaUsageAccounting(accounting *extension.QuotaUsageAccounting) *extension.QuotaUsageAccounting {
+	copyMap := func(m map[v1.ResourceName]float64) map[v1.ResourceName]float64 {
+		result := make(map[v1.ResourceName]float64, len(m))
+		for k, v := range m {
+			result[k] = v
+		}
+		return result
+	}
+	return &extension.QuotaUsageAccounting{
+		StartTime:      accounting.StartTime,
+		LastUpdateTime: accounting.LastUpdateTime,
+		Used:           copyMap(accounting.Used),
+		Guaranteed:     copyMap(accounting.Guaranteed),
+		Borrowed:       copyMap(accounting.Borrowed),
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func TestGroupQuotaManager_AccountQuotaUsage(t *testing.T) {
+	gqm := NewGroupQuotaManagerForTest()
+	gqm.UpdateClusterTotalResource(createResourceList(100, 1000))
+	AddQuotaToManager(t, gqm, "test1", extension.RootQuotaName, 50, 500, 3, 30, true, false)
+	quotaInfo := gqm.GetQuotaInfoByName("test1")
+	quotaInfo.addUsedNonNegativeNoLock(createResourceList(4, 20))
+
+	now := time.Now()
+	gqm.AccountQuotaUsage(now, 2*time.Hour)
+	accounting, exist := gqm.GetQuotaUsageAccounting("test1")
+	assert.True(t, exist)
+	assert.Empty(t, accounting.Used)
+
+	// cpu: 4 cores used with 3 cores guaranteed, memory: 20 bytes used within the min 30 bytes
+	gqm.AccountQuotaUsage(now.Add(time.Hour), 2*time.Hour)
+	accounting, _ = gqm.GetQuotaUsageAccounting("test1")
+	assert.InDelta(t, 4, accounting.Used[v1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 3, accounting.Guaranteed[v1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 1, accounting.Borrowed[v1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 20, accounting.Used[v1.ResourceMemory], 1e-6)
+	assert.InDelta(t, 20, accounting.Guaranteed[v1.ResourceMemory], 1e-6)
+	assert.InDelta(t, 0, accounting.Borrowed[v1.ResourceMemory], 1e-6)
+
+	// the scheduler was down for 10 hours, which is not charged
+	gqm.AccountQuotaUsage(now.Add(11*time.Hour), 2*time.Hour)
+	accounting, _ = gqm.GetQuotaUsageAccounting("test1")
+	assert.InDelta(t, 4, accounting.Used[v1.ResourceCPU], 1e-6)
+	assert.Equal(t, now.Add(11*time.Hour).Unix(), accounting.LastUpdateTime.Unix())
+
+	// the accounting continues from the last update
+	gqm.AccountQuotaUsage(now.Add(12*time.Hour), 2*time.Hour)
+	accounting, _ = gqm.GetQuotaUsageAccounting("test1")
+	assert.InDelta(t, 8, accounting.Used[v1.ResourceCPU], 1e-6)
+	assert.Equal(t, now.Unix(), accounting.StartTime.Unix())
+
+	accountings := gqm.GetQuotaUsageAccountings()
+	assert.Contains(t, accountings, "test1")
+	assert.Contains(t, accountings, extension.DefaultQuotaName)
+
+	quota := CreateQuota("test1", extension.RootQuotaName, 50, 500, 3, 30, true, false)
+	assert.NoError(t, gqm.UpdateQuota(quota, true))
+	_, exist = gqm.GetQuotaUsageAccounting("test1")
+	assert.False(t, exist)
+}
+
+func TestGroupQuotaManager_RestoreQuotaUsageAccounting(t *testing.T) {
+	gqm := NewGroupQuotaManagerForTest()
+	now := time.Now()
+	persisted := &extension.QuotaUsageAccounting{
+		StartTime:      metav1.NewTime(now.Add(-24 * time.Hour)),
+		LastUpdateTime: metav1.NewTime(now.Add(-time.Minute)),
+		Used:           map[v1.ResourceName]float64{v1.ResourceCPU: 100},
+		Guaranteed:     map[v1.ResourceName]float64{v1.ResourceCPU: 80},
+		Borrowed:       map[v1.ResourceName]float64{v1.ResourceCPU: 20},
+	}
+	data, err := json.Marshal(persisted)
+	assert.NoError(t, err)
+	quota := CreateQuota("test1", extension.RootQuotaName, 50, 500, 3, 30, true, false)
+	quota.Annotations[extension.AnnotationUsageAccounting] = string(data)
+	assert.NoError(t, gqm.UpdateQuota(quota, false))
+
+	accounting, exist := gqm.GetQuotaUsageAccounting("test1")
+	assert.True(t, exist)
+	assert.Equal(t, persisted.StartTime.Unix(), accounting.StartTime.Unix())
+	assert.Equal(t, persisted.Used, accounting.Used)
+
+	// the local accounting is not overwritten by the stale annotation
+	gqm.AccountQuotaUsage(now, time.Hour)
+	quota.Annotations[extension.AnnotationUsageAccounting] = string(data)
+	assert.NoError(t, gqm.UpdateQuota(quota, false))
+	accounting, _ = gqm.GetQuotaUsageAccounting("test1")
+	assert.Equal(t, now.Unix(), accounting.LastUpdateTime.Unix())
+}
@@ -22,6 +22,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
 // quotaNode stores the corresponding quotaInfo's information in a specific resource dimension.
@@ -162,7 +164,8 @@ type RuntimeQuotaCalculator struct {
 	quotaTree            quotaTreeMapType             // has all resource dimension's information
 	totalResource        v1.ResourceList              // the parentQuotaInfo's runtimeQuota or the clusterResource
 	lock                 sync.Mutex
-	treeName             string // the same as the parentQuotaInfo's Name
+	treeName             string                             // the same as the parentQuotaInfo's Name
+	calculatePolicy      config.RuntimeQuotaCalculatePolicy // how to distribute the totalResource to the childGroups
 }
 
 func NewRuntimeQuotaCalculator(treeName string) *RuntimeQuotaCalculator {
@@ -176,6 +179,17 @@ func NewRuntimeQuotaCalculator(treeName string) *RuntimeQuotaCalculator {
 	}
 }
 
+// setCalculatePolicy changes the way to distribute the totalResource, then increase globalRuntimeVersion
+func (qtw *RuntimeQuotaCalculator) setCalculatePolicy(policy config.RuntimeQuotaCalculatePolicy) {
+	qtw.lock.Lock()
+	defer qtw.lock.Unlock()
+
+	if qtw.calculatePolicy != policy {
+		qtw.calculatePolicy = policy
+		qtw.globalRuntimeVersion++
+	}
+}
+
 func (qtw *RuntimeQuotaCalculator) updateResourceKeys(resourceKeys map[v1.ResourceName]struct{}) {
 	newResourceKey := make(map[v1.ResourceName]struct{})
 	for resKey := range resourceKeys {
@@ -401,8 +415,18 @@ func (qtw *RuntimeQuotaCalculator) getVersion() int64 {
 	return qtw.globalRuntimeVersion
 }
 
+func (qtw *RuntimeQuotaCalculator) getTotalResource() v1.ResourceList {
+	qtw.lock.Lock()
+	defer qtw.lock.Unlock()
+	return qtw.totalResource.DeepCopy()
+}
+
 func (qtw *RuntimeQuotaCalculator) calculateRuntimeNoLock() {
 	//lock outside
+	if qtw.calculatePolicy == config.RuntimeQuotaCalculateByDominantResourceFairness {
+		qtw.calculateRuntimeByDRFNoLock()
+		return
+	}
 	for resKey := range qtw.resourceKeys {
 		totalResourcePerKey := *qtw.totalResource.Name(resKey, resource.DecimalSI)
 		qtw.quotaTree[resKey].redistribution(getQuantityValue(totalResourcePerKey, resKey))
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// drfQuotaGroup gathers a childGroup's quotaNodes of all resource dimensions for the DRF calculation.
+type drfQuotaGroup struct {
+	nodes  map[v1.ResourceName]*quotaNode
+	demand map[v1.ResourceName]int64
+	weight float64
+}
+
+// dominantDemandShare returns the largest share of the demands relative to the totalResource.
+func (g *drfQuotaGroup) dominantDemandShare(total map[v1.ResourceName]int64) float64 {
+	share := 0.0
+	for resKey, demand := range g.demand {
+		if s := float64(demand) / float64(total[resKey]); s > share {
+			share = s
+		}
+	}
+	return share
+}
+
+// blocked checks whether the childGroup demands any resource which has been exhausted.
+func (g *drfQuotaGroup) blocked(remaining map[v1.ResourceName]int64) bool {
+	for resKey, demand := range g.demand {
+		if demand > 0 && remaining[resKey] <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// calculateRuntimeByDRFNoLock distributes the totalResource to the childGroups by weighted Dominant Resource Fairness.
+// Firstly, each childGroup gets the guaranteed quota the same as the shared weight policy.
+// Then the remaining resources are distributed by progressive filling: all the childGroups which still have demands
+// raise their weighted dominant shares at the same pace and get the resources in proportion to their demands,
+// until the demands are satisfied or one of the demanded resources is exhausted.
+// The weight of a childGroup is the dominant share of its sharedWeight, so that it is comparable across resources.
+func (qtw *RuntimeQuotaCalculator) calculateRuntimeByDRFNoLock() {
+	//lock outside
+	total := make(map[v1.ResourceName]int64, len(qtw.resourceKeys))
+	remaining := make(map[v1.ResourceName]int64, len(qtw.resourceKeys))
+	groups := make(map[string]*drfQuotaGroup)
+	for resKey := range qtw.resourceKeys {
+		totalPerKey := getQuantityValue(*qtw.totalResource.Name(resKey, resource.DecimalSI), resKey)
+		total[resKey] = totalPerKey
+		remaining[resKey] = totalPerKey
+		for quotaName, node := range qtw.quotaTree[resKey].quotaNodes {
+			if node.request > node.min || !node.allowLentResource {
+				node.runtimeQuota = node.min
+			} else {
+				node.runtimeQuota = node.request
+			}
+			remaining[resKey] -= node.runtimeQuota
+
+			group := groups[quotaName]
+			if group == nil {
+				group = &drfQuotaGroup{
+					nodes:  make(map[v1.ResourceName]*quotaNode),
+					demand: make(map[v1.ResourceName]int64),
+				}
+				groups[quotaName] = group
+			}
+			group.nodes[resKey] = node
+			if totalPerKey <= 0 {
+				continue
+			}
+			if node.request > node.runtimeQuota {
+				group.demand[resKey] = node.request - node.runtimeQuota
+			}
+			if weight := float64(node.sharedWeight) / float64(totalPerKey); weight > group.weight {
+				group.weight = weight
+			}
+		}
+	}
+
+	for {
+		var active []*drfQuotaGroup
+		for _, group := range groups {
+			if group.weight > 0 && group.dominantDemandShare(total) > 0 && !group.blocked(remaining) {
+				active = append(active, group)
+			}
+		}
+		if len(active) == 0 {
+			return
+		}
+
+		// find the highest level of the weighted dominant share that all active childGroups can reach together
+		level := math.MaxFloat64
+		consumption := make(map[v1.ResourceName]float64)
+		dominantShares := make([]float64, len(active))
+		for i, group := range active {
+			dominantShares[i] = group.dominantDemandShare(total)
+			level = math.Min(level, dominantShares[i]/group.weight)
+			for resKey, demand := range group.demand {
+				consumption[resKey] += group.weight * float64(demand) / dominantShares[i]
+			}
+		}
+		for resKey, c := range consumption {
+			if c > 0 {
+				level = math.Min(level, float64(remaining[resKey])/c)
+			}
+		}
+
+		progress := false
+		for i, group := range active {
+			fraction := math.Min(1, group.weight*level/dominantShares[i])
+			for resKey, demand := range group.demand {
+				delta := int64(fraction * float64(demand))
+				if fraction >= 1 {
+					delta = demand
+				}
+				if delta > remaining[resKey] {
+					delta = remaining[resKey]
+				}
+				if delta <= 0 {
+					continue
+				}
+				group.nodes[resKey].runtimeQuota += delta
+				group.demand[resKey] -= delta
+				remaining[resKey] -= delta
+				progress = true
+			}
+		}
+		if !progress {
+			return
+		}
+	}
+}
+
+// getDominantShare returns the resource with the largest share of the runtime quota relative to the totalResource.
+func getDominantShare(runtime, totalResource v1.ResourceList) (v1.ResourceName, float64) {
+	var dominantResource v1.ResourceName
+	dominantShare := 0.0
+	for resKey, quantity := range runtime {
+		totalQuantity, ok := totalResource[resKey]
+		if !ok {
+			continue
+		}
+		totalPerKey := getQuantityValue(totalQuantity, resKey)
+		if totalPerKey <= 0 {
+			continue
+		}
+		share := float64(getQuantityValue(quantity, resKey)) / float64(totalPerKey)
+		if share > dominantShare || (share == dominantShare && dominantResource != "" && resKey < dominantResource) {
+			dominantResource, dominantShare = resKey, share
+		}
+	}
+	return dominantResource, dominantShare
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func TestRuntimeQuotaCalculator_CalculateRuntimeByDRF(t *testing.T) {
+	qtw := createRuntimeQuotaCalculator()
+	qtw.setCalculatePolicy(config.RuntimeQuotaCalculateByDominantResourceFairness)
+	qtw.totalResource = createResourceList(9, 18)
+	cpu, memory := corev1.ResourceCPU, corev1.ResourceMemory
+	// quota-a is memory heavy and quota-b is cpu heavy, both of them demand more than the total resource.
+	qtw.quotaTree[cpu].insert("quota-a", 9000, 9000, 0, true)
+	qtw.quotaTree[memory].insert("quota-a", 18, 36, 0, true)
+	qtw.quotaTree[cpu].insert("quota-b", 9000, 27000, 0, true)
+	qtw.quotaTree[memory].insert("quota-b", 18, 9, 0, true)
+	qtw.calculateRuntimeNoLock()
+
+	// the dominant shares of both quotas are 2/3 when cpu is exhausted
+	assert.InDelta(t, 3000, qtw.quotaTree[cpu].quotaNodes["quota-a"].runtimeQuota, 2)
+	assert.InDelta(t, 12, qtw.quotaTree[memory].quotaNodes["quota-a"].runtimeQuota, 1)
+	assert.InDelta(t, 6000, qtw.quotaTree[cpu].quotaNodes["quota-b"].runtimeQuota, 2)
+	assert.InDelta(t, 2, qtw.quotaTree[memory].quotaNodes["quota-b"].runtimeQuota, 1)
+	assert.Equal(t, int64(9000), qtw.quotaTree[cpu].quotaNodes["quota-a"].runtimeQuota+qtw.quotaTree[cpu].quotaNodes["quota-b"].runtimeQuota)
+}
+
+func TestRuntimeQuotaCalculator_CalculateRuntimeByDRFWithMin(t *testing.T) {
+	qtw := createRuntimeQuotaCalculator()
+	qtw.setCalculatePolicy(config.RuntimeQuotaCalculateByDominantResourceFairness)
+	qtw.totalResource = createResourceList(10, 100)
+	cpu, memory := corev1.ResourceCPU, corev1.ResourceMemory
+	qtw.quotaTree[cpu].insert("quota-a", 10000, 4000, 6000, true)
+	qtw.quotaTree[memory].insert("quota-a", 100, 40, 60, true)
+	qtw.quotaTree[cpu].insert("quota-b", 10000, 10000, 2000, true)
+	qtw.quotaTree[memory].insert("quota-b", 100, 10, 20, true)
+	qtw.calculateRuntimeNoLock()
+
+	// quota-a lends the unused min to quota-b
+	assert.Equal(t, int64(4000), qtw.quotaTree[cpu].quotaNodes["quota-a"].runtimeQuota)
+	assert.Equal(t, int64(40), qtw.quotaTree[memory].quotaNodes["quota-a"].runtimeQuota)
+	assert.Equal(t, int64(6000), qtw.quotaTree[cpu].quotaNodes["quota-b"].runtimeQuota)
+	assert.Equal(t, int64(10), qtw.quotaTree[memory].quotaNodes["quota-b"].runtimeQuota)
+}
+
+func TestGetDominantShare(t *testing.T) {
+	resourceName, share := getDominantShare(createResourceList(3, 40), createResourceList(10, 100))
+	assert.Equal(t, corev1.ResourceMemory, resourceName)
+	assert.InDelta(t, 0.4, share, 1e-6)
+
+	resourceName, share = getDominantShare(createResourceList(5, 50), createResourceList(10, 100))
+	assert.Equal(t, corev1.ResourceCPU, resourceName)
+	assert.InDelta(t, 0.5, share, 1e-6)
+
+	resourceName, share = getDominantShare(createResourceList(5, 50), corev1.ResourceList{})
+	assert.Equal(t, corev1.ResourceName(""), resourceName)
+	assert.Equal(t, 0.0, share)
+}
@@ -38,6 +38,8 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/generated/informers/externalversions"
 	"sigs.k8s.io/scheduler-plugins/pkg/generated/listers/scheduling/v1alpha1"
 
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
@@ -119,10 +121,15 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		nodeResourceMap:   make(map[string]struct{}),
 	}
 
+	elasticQuota.groupQuotaManager.SetRuntimeQuotaCalculatePolicy(pluginArgs.RuntimeQuotaCalculatePolicy)
+
 	ctx := context.TODO()
 
-	elasticQuota.createSystemQuotaIfNotPresent()
-	elasticQuota.createDefaultQuotaIfNotPresent()
+	// The simulation frameworks only read the quotas, and the scheduler's plugin maintains them.
+	if extendedHandle, ok := handle.(frameworkext.ExtendedHandle); !ok || !extendedHandle.IsSimulation() {
+		elasticQuota.createSystemQuotaIfNotPresent()
+		elasticQuota.createDefaultQuotaIfNotPresent()
+	}
 	frameworkexthelper.ForceSyncFromInformer(ctx.Done(), scheSharedInformerFactory, elasticQuotaInformer.Informer(), cache.ResourceEventHandlerFuncs{
 		AddFunc:    elasticQuota.OnQuotaAdd,
 		UpdateFunc: elasticQuota.OnQuotaUpdate,
@@ -154,10 +161,27 @@ func (g *Plugin) Start() {
 }
 
 func (g *Plugin) NewControllers() ([]frameworkext.Controller, error) {
+	var koordClient koordclientset.Interface
+	var jobLister schedulinglisters.PodMigrationJobLister
+	if extendedHandle, ok := g.handle.(frameworkext.ExtendedHandle); ok {
+		koordClient = extendedHandle.KoordinatorClientSet()
+		if g.pluginArgs.RevokePolicy == config.QuotaRevokeByMigration {
+			jobLister = extendedHandle.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().PodMigrationJobs().Lister()
+		}
+	}
+	revoker, err := NewPodRevoker(g.pluginArgs.RevokePolicy, g.handle.ClientSet(), koordClient, jobLister)
+	if err != nil {
+		return nil, err
+	}
 	quotaOverUsedRevokeController := NewQuotaOverUsedRevokeController(g.handle.ClientSet(), g.pluginArgs.DelayEvictTime.Duration,
-		g.pluginArgs.RevokePodInterval.Duration, g.groupQuotaManager, *g.pluginArgs.MonitorAllQuotas)
+		g.pluginArgs.RevokePodInterval.Duration, g.groupQuotaManager, *g.pluginArgs.MonitorAllQuotas, revoker, g.pluginArgs.MaxUnavailablePerWorkload)
 	elasticQuotaController := NewElasticQuotaController(g.client, g.quotaLister, g.groupQuotaManager)
-	return []frameworkext.Controller{g, quotaOverUsedRevokeController, elasticQuotaController}, nil
+	controllers := []frameworkext.Controller{g, quotaOverUsedRevokeController, elasticQuotaController}
+	if g.pluginArgs.QuotaUsageAccountingInterval != nil && g.pluginArgs.QuotaUsageAccountingInterval.Duration > 0 {
+		controllers = append(controllers, NewQuotaUsageAccountingController(g.client, g.quotaLister, g.groupQuotaManager,
+			g.pluginArgs.QuotaUsageAccountingInterval.Duration))
+	}
+	return controllers, nil
 }
 
 func (g *Plugin) Name() string {
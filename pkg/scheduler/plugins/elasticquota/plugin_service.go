@@ -40,4 +40,17 @@ func (g *Plugin) RegisterEndpoints(group *gin.RouterGroup) {
 		quotaSummaries := g.GetQuotaSummaries()
 		c.JSON(http.StatusOK, quotaSummaries)
 	})
+	group.GET("/quota/:name/usage", func(c *gin.Context) {
+		quotaName := c.Param("name")
+		accounting, exist := g.GetQuotaUsageAccounting(quotaName)
+		if !exist {
+			services.ResponseErrorMessage(c, http.StatusNotFound, "cannot find usage accounting of quota %s", quotaName)
+			return
+		}
+		c.JSON(http.StatusOK, accounting)
+	})
+	group.GET("/quotas/usage", func(c *gin.Context) {
+		accountings := g.GetQuotaUsageAccountings()
+		c.JSON(http.StatusOK, accountings)
+	})
 }
@@ -83,3 +83,11 @@ func (g *Plugin) GetQuotaSummary(quotaName string) (*core.QuotaInfoSummary, bool
 func (g *Plugin) GetQuotaSummaries() map[string]*core.QuotaInfoSummary {
 	return g.groupQuotaManager.GetQuotaSummaries()
 }
+
+func (g *Plugin) GetQuotaUsageAccounting(quotaName string) (*extension.QuotaUsageAccounting, bool) {
+	return g.groupQuotaManager.GetQuotaUsageAccounting(quotaName)
+}
+
+func (g *Plugin) GetQuotaUsageAccountings() map[string]*extension.QuotaUsageAccounting {
+	return g.groupQuotaManager.GetQuotaUsageAccountings()
+}
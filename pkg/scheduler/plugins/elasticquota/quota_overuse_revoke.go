@@ -27,6 +27,8 @@ import (
 	policy "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -40,6 +42,13 @@ import (
 
 const (
 	QuotaOverUsedRevokeControllerName = "QuotaOverUsedRevokeController"
+
+	// revokingPodTimeout is the duration a revoked pod is considered as being revoked,
+	// the pod may be revoked again after it if it still occupies the quota.
+	revokingPodTimeout = 5 * time.Minute
+	// softEvictionTimeout is the duration to wait for the workload controllers to evict the pod marked with
+	// the soft eviction, the pod is evicted directly after it.
+	softEvictionTimeout = 10 * time.Minute
 )
 
 type QuotaOverUsedGroupMonitor struct {
@@ -150,10 +159,20 @@ type QuotaOverUsedRevokeController struct {
 	overUsedTriggerEvictDuration time.Duration
 	revokePodCycle               time.Duration
 	monitorAllQuotas             bool
+	revoker                      PodRevoker
+	evictionRevoker              PodRevoker
+	maxUnavailablePerWorkload    *intstr.IntOrString
+	// revokingPods records the time when the pods are revoked successfully.
+	revokingPods map[types.UID]time.Time
 }
 
+// NewQuotaOverUsedRevokeController creates the controller revoking the pods of the quota groups exceeding the runtime quota,
+// the pods are evicted directly if the revoker is nil.
 func NewQuotaOverUsedRevokeController(client clientset.Interface, overUsedTriggerEvictDuration, revokePodCycle time.Duration,
-	groupQuotaManager *core.GroupQuotaManager, monitorAllQuotas bool) *QuotaOverUsedRevokeController {
+	groupQuotaManager *core.GroupQuotaManager, monitorAllQuotas bool, revoker PodRevoker, maxUnavailablePerWorkload *intstr.IntOrString) *QuotaOverUsedRevokeController {
+	if revoker == nil {
+		revoker = &evictionRevoker{client: client}
+	}
 	controller := &QuotaOverUsedRevokeController{
 		clientSet:                    client,
 		groupQuotaManger:             groupQuotaManager,
@@ -161,6 +180,10 @@ func NewQuotaOverUsedRevokeController(client clientset.Interface, overUsedTrigge
 		revokePodCycle:               revokePodCycle,
 		monitors:                     make(map[string]*QuotaOverUsedGroupMonitor),
 		monitorAllQuotas:             monitorAllQuotas,
+		revoker:                      revoker,
+		evictionRevoker:              &evictionRevoker{client: client},
+		maxUnavailablePerWorkload:    maxUnavailablePerWorkload,
+		revokingPods:                 make(map[types.UID]time.Time),
 	}
 	return controller
 }
@@ -177,11 +200,17 @@ func (controller *QuotaOverUsedRevokeController) Start() {
 func (controller *QuotaOverUsedRevokeController) revokePodDueToQuotaOverUsed() {
 	toRevokePods := controller.monitorAll()
 	for _, pod := range toRevokePods {
-		if err := EvictPod(context.TODO(), controller.clientSet, pod, &metav1.DeleteOptions{}); err != nil {
+		revoker := controller.revoker
+		if marked, expired := softEvictionExpired(pod); marked && expired {
+			klog.V(4).Infof("pod is not evicted by the workload controller in time, evict it directly, pod:%v", klog.KObj(pod))
+			revoker = controller.evictionRevoker
+		}
+		if err := revoker.Revoke(context.TODO(), pod, "quota overused"); err != nil {
 			klog.Errorf("failed to revoke pod due to quota overused, pod:%v, error:%s",
 				pod.Name, err)
 			continue
 		}
+		controller.revokingPods[pod.UID] = time.Now()
 		klog.V(5).Infof("finish revoke pod due to quota overused, pod:%v",
 			pod.Name)
 	}
@@ -189,17 +218,93 @@ func (controller *QuotaOverUsedRevokeController) revokePodDueToQuotaOverUsed() {
 
 func (controller *QuotaOverUsedRevokeController) monitorAll() []*v1.Pod {
 	controller.syncQuota()
+	controller.cleanupRevokingPods()
 
 	monitors := controller.getToMonitorQuotas()
 
 	toRevokePods := make([]*v1.Pod, 0)
 	for quotaName, monitor := range monitors {
 		toRevokePodsTmp := monitor.getToRevokePodList(quotaName)
-		toRevokePods = append(toRevokePods, toRevokePodsTmp...)
+		toRevokePods = append(toRevokePods, controller.filterRevokingPods(quotaName, toRevokePodsTmp)...)
 	}
 	return toRevokePods
 }
 
+func (controller *QuotaOverUsedRevokeController) cleanupRevokingPods() {
+	for uid, revokeTime := range controller.revokingPods {
+		if time.Since(revokeTime) > revokingPodTimeout {
+			delete(controller.revokingPods, uid)
+		}
+	}
+}
+
+// isRevoking returns true if the pod has been revoked and is still terminating or waiting for the migration.
+func (controller *QuotaOverUsedRevokeController) isRevoking(pod *v1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return true
+	}
+	if marked, expired := softEvictionExpired(pod); marked && !expired {
+		return true
+	}
+	_, ok := controller.revokingPods[pod.UID]
+	return ok
+}
+
+// softEvictionExpired returns whether the pod is marked with the soft eviction, and whether the mark is older than
+// softEvictionTimeout. The mark without a valid timestamp is considered as expired since its age is unknown.
+func softEvictionExpired(pod *v1.Pod) (marked bool, expired bool) {
+	if _, ok := pod.Annotations[extension.AnnotationSoftEviction]; !ok {
+		return false, false
+	}
+	evictionSpec, err := extension.GetSoftEvictionSpec(pod.Annotations)
+	if err != nil || evictionSpec.Timestamp == nil {
+		return true, true
+	}
+	return true, time.Since(evictionSpec.Timestamp.Time) > softEvictionTimeout
+}
+
+// filterRevokingPods skips the pods being revoked, and limits the number of the unavailable pods of each workload
+// in the quota group by MaxUnavailablePerWorkload.
+func (controller *QuotaOverUsedRevokeController) filterRevokingPods(quotaName string, pods []*v1.Pod) []*v1.Pod {
+	total := map[types.UID]int{}
+	unavailable := map[types.UID]int{}
+	if controller.maxUnavailablePerWorkload != nil {
+		if quotaInfo := controller.groupQuotaManger.GetQuotaInfoByName(quotaName); quotaInfo != nil {
+			for _, pod := range quotaInfo.GetPodThatIsAssigned() {
+				owner := metav1.GetControllerOf(pod)
+				if owner == nil {
+					continue
+				}
+				total[owner.UID]++
+				if controller.isRevoking(pod) {
+					unavailable[owner.UID]++
+				}
+			}
+		}
+	}
+
+	result := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if controller.isRevoking(pod) {
+			continue
+		}
+		if owner := metav1.GetControllerOf(pod); owner != nil && controller.maxUnavailablePerWorkload != nil {
+			maxUnavailable, _ := intstr.GetScaledValueFromIntOrPercent(controller.maxUnavailablePerWorkload, total[owner.UID], false)
+			if maxUnavailable < 1 {
+				maxUnavailable = 1
+			}
+			if unavailable[owner.UID] >= maxUnavailable {
+				klog.V(4).Infof("skip revoking pod since the workload has too many unavailable pods, pod:%v, quotaName:%v, "+
+					"unavailable:%v, maxUnavailable:%v", klog.KObj(pod), quotaName, unavailable[owner.UID], maxUnavailable)
+				continue
+			}
+			unavailable[owner.UID]++
+		}
+		result = append(result, pod)
+	}
+	return result
+}
+
 func (controller *QuotaOverUsedRevokeController) syncQuota() {
 	controller.monitorsLock.Lock()
 	defer controller.monitorsLock.Unlock()
@@ -18,6 +18,8 @@ package elasticquota
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +27,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 )
@@ -39,7 +43,7 @@ func TestQuotaOverUsedGroupMonitor_Monitor(t *testing.T) {
 	gqm.UpdateClusterTotalResource(createResourceList(100, 1000))
 	gqm.RefreshRuntime("test1")
 	quotaOverUsedRevokeController := NewQuotaOverUsedRevokeController(pg.handle.ClientSet(), pg.pluginArgs.DelayEvictTime.Duration,
-		pg.pluginArgs.RevokePodInterval.Duration, pg.groupQuotaManager, *pg.pluginArgs.MonitorAllQuotas)
+		pg.pluginArgs.RevokePodInterval.Duration, pg.groupQuotaManager, *pg.pluginArgs.MonitorAllQuotas, nil, nil)
 	quotaOverUsedRevokeController.syncQuota()
 	monitor := quotaOverUsedRevokeController.monitors["test1"]
 	var pod *corev1.Pod
@@ -106,7 +110,7 @@ func TestQuotaOverUsedRevokeController_GetToRevokePodList(t *testing.T) {
 	qi.CalculateInfo.Runtime = createResourceList(50, 0)
 	qi.UnLock()
 	con := NewQuotaOverUsedRevokeController(plugin.handle.ClientSet(), plugin.pluginArgs.DelayEvictTime.Duration,
-		plugin.pluginArgs.RevokePodInterval.Duration, plugin.groupQuotaManager, *plugin.pluginArgs.MonitorAllQuotas)
+		plugin.pluginArgs.RevokePodInterval.Duration, plugin.groupQuotaManager, *plugin.pluginArgs.MonitorAllQuotas, nil, nil)
 	con.syncQuota()
 	quotaInfo := gqm.GetQuotaInfoByName("test1")
 	pod1 := defaultCreatePod("1", 10, 30, 0)
@@ -148,7 +152,7 @@ func TestQuotaOverUsedRevokeController_GetToMonitorQuotas(t *testing.T) {
 	gqm := plugin.groupQuotaManager
 	gqm.UpdateClusterTotalResource(createResourceList(10850060000, 0))
 	cc := NewQuotaOverUsedRevokeController(plugin.handle.ClientSet(), 0*time.Second,
-		plugin.pluginArgs.RevokePodInterval.Duration, plugin.groupQuotaManager, true)
+		plugin.pluginArgs.RevokePodInterval.Duration, plugin.groupQuotaManager, true, nil, nil)
 
 	suit.AddQuota("test1", extension.RootQuotaName, 4797411900, 0, 1085006000, 0, 4797411900, 0, true, "extended")
 	suit.AddQuota("test2", extension.RootQuotaName, 4797411900, 0, 1085006000, 0, 4797411900, 0, true, "extended")
@@ -198,3 +202,77 @@ func (controller *QuotaOverUsedRevokeController) GetMonitorsLen() int {
 func (monitor *QuotaOverUsedGroupMonitor) GetLastUnderUseTime() time.Time {
 	return monitor.lastUnderUsedTime
 }
+
+func TestQuotaOverUsedRevokeController_FilterRevokingPods(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, _ := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	pg := p.(*Plugin)
+	pg.addQuota("test1", extension.RootQuotaName, 100, 1000, 100, 1000, 100, 1000, false, "")
+	gqm := pg.groupQuotaManager
+
+	owner := metav1.NewControllerRef(&metav1.ObjectMeta{Name: "workload", UID: "workload-uid"},
+		corev1.SchemeGroupVersion.WithKind("ReplicationController"))
+	var pods []*corev1.Pod
+	for i := 0; i < 4; i++ {
+		pod := makePod2(fmt.Sprintf("pod-%d", i), createResourceList(1, 1))
+		pod.UID = types.UID(pod.Name)
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		gqm.OnPodAdd("test1", pod)
+		pods = append(pods, pod)
+	}
+	standalone := makePod2("standalone", createResourceList(1, 1))
+	standalone.UID = types.UID(standalone.Name)
+	gqm.OnPodAdd("test1", standalone)
+
+	maxUnavailable := intstr.FromString("50%")
+	controller := NewQuotaOverUsedRevokeController(pg.handle.ClientSet(), pg.pluginArgs.DelayEvictTime.Duration,
+		pg.pluginArgs.RevokePodInterval.Duration, gqm, true, nil, &maxUnavailable)
+
+	got := controller.filterRevokingPods("test1", append([]*corev1.Pod{standalone}, pods...))
+	assert.Equal(t, []*corev1.Pod{standalone, pods[0], pods[1]}, got)
+
+	controller.revokingPods[pods[0].UID] = time.Now()
+	got = controller.filterRevokingPods("test1", pods)
+	assert.Equal(t, []*corev1.Pod{pods[1]}, got)
+
+	controller.revokingPods[pods[1].UID] = time.Now().Add(-2 * revokingPodTimeout)
+	controller.cleanupRevokingPods()
+	got = controller.filterRevokingPods("test1", pods[1:])
+	assert.Equal(t, []*corev1.Pod{pods[1]}, got)
+
+	controller.maxUnavailablePerWorkload = nil
+	got = controller.filterRevokingPods("test1", pods)
+	assert.Equal(t, pods[1:], got)
+}
+
+func TestQuotaOverUsedRevokeController_SoftEvictionTimeout(t *testing.T) {
+	controller := NewQuotaOverUsedRevokeController(nil, 0, 0, nil, true, nil, nil)
+	newPod := func(timestamp *metav1.Time) *corev1.Pod {
+		pod := makePod2("pod", createResourceList(1, 1))
+		pod.UID = types.UID(pod.Name)
+		data, err := json.Marshal(extension.SoftEvictionSpec{Timestamp: timestamp, Reason: "quota overused"})
+		assert.NoError(t, err)
+		pod.Annotations = map[string]string{extension.AnnotationSoftEviction: string(data)}
+		return pod
+	}
+
+	pod := newPod(&metav1.Time{Time: time.Now()})
+	marked, expired := softEvictionExpired(pod)
+	assert.True(t, marked)
+	assert.False(t, expired)
+	assert.True(t, controller.isRevoking(pod))
+
+	// the workload controller doesn't evict the pod in time, the pod should be revoked again
+	pod = newPod(&metav1.Time{Time: time.Now().Add(-2 * softEvictionTimeout)})
+	marked, expired = softEvictionExpired(pod)
+	assert.True(t, marked)
+	assert.True(t, expired)
+	assert.False(t, controller.isRevoking(pod))
+
+	pod = newPod(nil)
+	_, expired = softEvictionExpired(pod)
+	assert.True(t, expired)
+
+	marked, _ = softEvictionExpired(makePod2("pod", createResourceList(1, 1)))
+	assert.False(t, marked)
+}
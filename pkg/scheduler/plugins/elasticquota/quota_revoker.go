@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// PodRevoker revokes the pods of the quota groups whose used exceeds the runtime quota.
+type PodRevoker interface {
+	Revoke(ctx context.Context, pod *v1.Pod, reason string) error
+}
+
+// NewPodRevoker creates the PodRevoker of the revoke policy, the koordClient and the jobLister are only required by QuotaRevokeByMigration.
+func NewPodRevoker(policy config.QuotaRevokePolicy, client clientset.Interface, koordClient koordclientset.Interface,
+	jobLister schedulinglisters.PodMigrationJobLister) (PodRevoker, error) {
+	switch policy {
+	case "", config.QuotaRevokeByEviction:
+		return &evictionRevoker{client: client}, nil
+	case config.QuotaRevokeByMigration:
+		if koordClient == nil || jobLister == nil {
+			return nil, fmt.Errorf("koordinator clientset and PodMigrationJob lister are required by revoke policy %s", policy)
+		}
+		return &migrationRevoker{koordClient: koordClient, jobLister: jobLister}, nil
+	case config.QuotaRevokeBySoftEviction:
+		return &softEvictionRevoker{client: client}, nil
+	}
+	return nil, fmt.Errorf("unsupported revoke policy %s", policy)
+}
+
+// evictionRevoker evicts the pods by the Eviction API.
+type evictionRevoker struct {
+	client clientset.Interface
+}
+
+func (r *evictionRevoker) Revoke(ctx context.Context, pod *v1.Pod, reason string) error {
+	return EvictPod(ctx, r.client, pod, &metav1.DeleteOptions{})
+}
+
+// migrationRevoker creates the ReservationFirst PodMigrationJobs, the pods are evicted
+// after the resources are reserved for them.
+type migrationRevoker struct {
+	koordClient koordclientset.Interface
+	jobLister   schedulinglisters.PodMigrationJobLister
+}
+
+func (r *migrationRevoker) Revoke(ctx context.Context, pod *v1.Pod, reason string) error {
+	activeJob, err := r.getActiveJob(pod)
+	if err != nil {
+		return err
+	}
+	if activeJob != nil {
+		klog.V(4).Infof("skip creating PodMigrationJob since the pod is being migrated, pod:%v, job:%v", klog.KObj(pod), activeJob.Name)
+		return nil
+	}
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: string(uuid.NewUUID()),
+			Annotations: map[string]string{
+				extension.AnnotationEvictReason:  reason,
+				extension.AnnotationEvictTrigger: QuotaOverUsedRevokeControllerName,
+			},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &v1.ObjectReference{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+			Mode: sev1alpha1.PodMigrationJobModeReservationFirst,
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobPending,
+		},
+	}
+	_, err = r.koordClient.SchedulingV1alpha1().PodMigrationJobs().Create(ctx, job, metav1.CreateOptions{})
+	return err
+}
+
+// getActiveJob returns the unfinished PodMigrationJob of the pod, which may be created before the scheduler restarts.
+func (r *migrationRevoker) getActiveJob(pod *v1.Pod) (*sev1alpha1.PodMigrationJob, error) {
+	jobs, err := r.jobLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.Spec.PodRef == nil || job.Spec.PodRef.UID != pod.UID {
+			continue
+		}
+		switch job.Status.Phase {
+		case sev1alpha1.PodMigrationJobSucceeded, sev1alpha1.PodMigrationJobFailed, sev1alpha1.PodMigrationJobAborted:
+			continue
+		}
+		return job, nil
+	}
+	return nil, nil
+}
+
+// softEvictionRevoker marks the pods with the soft eviction annotation, the workload controllers evict them gracefully.
+type softEvictionRevoker struct {
+	client clientset.Interface
+}
+
+func (r *softEvictionRevoker) Revoke(ctx context.Context, pod *v1.Pod, reason string) error {
+	evictionSpec := extension.SoftEvictionSpec{
+		Timestamp: &metav1.Time{Time: time.Now()},
+		Initiator: QuotaOverUsedRevokeControllerName,
+		Reason:    reason,
+	}
+	data, err := json.Marshal(evictionSpec)
+	if err != nil {
+		return err
+	}
+	newPod := pod.DeepCopy()
+	if newPod.Annotations == nil {
+		newPod.Annotations = map[string]string{}
+	}
+	newPod.Annotations[extension.AnnotationSoftEviction] = string(data)
+	return util.RetryOnConflictOrTooManyRequests(func() error {
+		_, err := util.PatchPod(ctx, r.client, pod, newPod)
+		return err
+	})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func TestNewPodRevoker(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	koordClient := koordfake.NewSimpleClientset()
+	jobLister := koordinformers.NewSharedInformerFactory(koordClient, 0).Scheduling().V1alpha1().PodMigrationJobs().Lister()
+	tests := []struct {
+		name        string
+		policy      config.QuotaRevokePolicy
+		koordClient *koordfake.Clientset
+		want        PodRevoker
+		wantErr     bool
+	}{
+		{
+			name:   "default to eviction",
+			policy: "",
+			want:   &evictionRevoker{client: client},
+		},
+		{
+			name:   "eviction",
+			policy: config.QuotaRevokeByEviction,
+			want:   &evictionRevoker{client: client},
+		},
+		{
+			name:        "migration",
+			policy:      config.QuotaRevokeByMigration,
+			koordClient: koordClient,
+			want:        &migrationRevoker{koordClient: koordClient, jobLister: jobLister},
+		},
+		{
+			name:    "migration without koordinator clientset",
+			policy:  config.QuotaRevokeByMigration,
+			wantErr: true,
+		},
+		{
+			name:   "soft eviction",
+			policy: config.QuotaRevokeBySoftEviction,
+			want:   &softEvictionRevoker{client: client},
+		},
+		{
+			name:    "unsupported policy",
+			policy:  "Unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PodRevoker
+			var err error
+			if tt.koordClient != nil {
+				got, err = NewPodRevoker(tt.policy, client, tt.koordClient, jobLister)
+			} else {
+				got, err = NewPodRevoker(tt.policy, client, nil, nil)
+			}
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrationRevoker(t *testing.T) {
+	koordClient := koordfake.NewSimpleClientset()
+	informerFactory := koordinformers.NewSharedInformerFactory(koordClient, 0)
+	jobInformer := informerFactory.Scheduling().V1alpha1().PodMigrationJobs()
+	revoker := &migrationRevoker{koordClient: koordClient, jobLister: jobInformer.Lister()}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			UID:       "test-pod-uid",
+		},
+	}
+	assert.NoError(t, revoker.Revoke(context.TODO(), pod, "quota overused"))
+
+	jobList, err := koordClient.SchedulingV1alpha1().PodMigrationJobs().List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, jobList.Items, 1)
+	job := jobList.Items[0]
+	assert.Equal(t, &corev1.ObjectReference{Namespace: "default", Name: "test-pod", UID: "test-pod-uid"}, job.Spec.PodRef)
+	assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, job.Spec.Mode)
+	assert.Equal(t, sev1alpha1.PodMigrationJobPending, job.Status.Phase)
+	assert.Equal(t, "quota overused", job.Annotations[extension.AnnotationEvictReason])
+	assert.Equal(t, QuotaOverUsedRevokeControllerName, job.Annotations[extension.AnnotationEvictTrigger])
+
+	// the pod is being migrated by the job, e.g. created before the scheduler restarts
+	assert.NoError(t, jobInformer.Informer().GetStore().Add(&job))
+	assert.NoError(t, revoker.Revoke(context.TODO(), pod, "quota overused"))
+	jobList, err = koordClient.SchedulingV1alpha1().PodMigrationJobs().List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, jobList.Items, 1)
+
+	// the job is finished, the pod can be migrated again
+	finishedJob := job.DeepCopy()
+	finishedJob.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	assert.NoError(t, jobInformer.Informer().GetStore().Update(finishedJob))
+	assert.NoError(t, revoker.Revoke(context.TODO(), pod, "quota overused"))
+	jobList, err = koordClient.SchedulingV1alpha1().PodMigrationJobs().List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, jobList.Items, 2)
+}
+
+func TestSoftEvictionRevoker(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+	}
+	client := kubefake.NewSimpleClientset(pod)
+	revoker := &softEvictionRevoker{client: client}
+	assert.NoError(t, revoker.Revoke(context.TODO(), pod, "quota overused"))
+
+	got, err := client.CoreV1().Pods("default").Get(context.TODO(), "test-pod", metav1.GetOptions{})
+	assert.NoError(t, err)
+	data, ok := got.Annotations[extension.AnnotationSoftEviction]
+	assert.True(t, ok)
+	spec := &extension.SoftEvictionSpec{}
+	assert.NoError(t, json.Unmarshal([]byte(data), spec))
+	assert.Equal(t, QuotaOverUsedRevokeControllerName, spec.Initiator)
+	assert.Equal(t, "quota overused", spec.Reason)
+	assert.NotNil(t, spec.Timestamp)
+}
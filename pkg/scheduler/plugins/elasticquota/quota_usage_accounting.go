@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	schedclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+	schedlister "sigs.k8s.io/scheduler-plugins/pkg/generated/listers/scheduling/v1alpha1"
+	"sigs.k8s.io/scheduler-plugins/pkg/util"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/elasticquota/core"
+	koordutil "github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const (
+	QuotaUsageAccountingControllerName = "QuotaUsageAccountingController"
+)
+
+var timeNowFn = time.Now
+
+// QuotaUsageAccountingController accumulates the quotaGroups' used resource-hours periodically,
+// and persists the accounting to the annotation of the elastic quota crd.
+type QuotaUsageAccountingController struct {
+	schedClient       schedclientset.Interface
+	eqLister          schedlister.ElasticQuotaLister
+	groupQuotaManager *core.GroupQuotaManager
+	interval          time.Duration
+}
+
+func NewQuotaUsageAccountingController(
+	client schedclientset.Interface,
+	eqLister schedlister.ElasticQuotaLister,
+	groupQuotaManager *core.GroupQuotaManager,
+	interval time.Duration,
+) *QuotaUsageAccountingController {
+	return &QuotaUsageAccountingController{
+		schedClient:       client,
+		eqLister:          eqLister,
+		groupQuotaManager: groupQuotaManager,
+		interval:          interval,
+	}
+}
+
+func (ctrl *QuotaUsageAccountingController) Name() string {
+	return QuotaUsageAccountingControllerName
+}
+
+func (ctrl *QuotaUsageAccountingController) Start() {
+	go wait.Until(ctrl.Run, ctrl.interval, context.TODO().Done())
+	klog.Infof("start quota usage accounting controller")
+}
+
+func (ctrl *QuotaUsageAccountingController) Run() {
+	// the period is a bit longer than the interval due to the jitter and the time to persist the accounting,
+	// but the period much longer than the interval means the scheduler was down, which is dropped without being charged
+	ctrl.groupQuotaManager.AccountQuotaUsage(timeNowFn(), 2*ctrl.interval)
+	if errs := ctrl.syncHandler(); len(errs) != 0 {
+		for _, err := range errs {
+			utilruntime.HandleError(err)
+		}
+	}
+}
+
+// syncHandler persists the usage accounting to the elastic quotas.
+func (ctrl *QuotaUsageAccountingController) syncHandler() []error {
+	eqList, err := ctrl.eqLister.List(labels.Everything())
+	if err != nil {
+		klog.V(3).ErrorS(err, "Unable to list elastic quota from store", "elasticQuota")
+		return []error{err}
+	}
+	errors := make([]error, 0)
+
+	for _, eq := range eqList {
+		accounting, exist := ctrl.groupQuotaManager.GetQuotaUsageAccounting(eq.Name)
+		if !exist {
+			continue
+		}
+		accountingBytes, err := json.Marshal(accounting)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		if eq.Annotations[extension.AnnotationUsageAccounting] == string(accountingBytes) {
+			continue
+		}
+		newEQ := eq.DeepCopy()
+		if newEQ.Annotations == nil {
+			newEQ.Annotations = make(map[string]string)
+		}
+		newEQ.Annotations[extension.AnnotationUsageAccounting] = string(accountingBytes)
+
+		patch, err := util.CreateMergePatch(eq, newEQ)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		err = koordutil.RetryOnConflictOrTooManyRequests(func() error {
+			_, patchErr := ctrl.schedClient.SchedulingV1alpha1().ElasticQuotas(eq.Namespace).
+				Patch(context.TODO(), eq.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+			return patchErr
+		})
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		klog.V(5).Infof("quota:%v, update usage accounting:%v", eq.Name, string(accountingBytes))
+	}
+	return errors
+}
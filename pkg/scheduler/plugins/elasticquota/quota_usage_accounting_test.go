@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func TestQuotaUsageAccountingController_Run(t *testing.T) {
+	ctx := context.TODO()
+	suit := newPluginTestSuitWithPod(t, nil, nil)
+	p := suit.plugin.(*Plugin)
+	eq := MakeEQ("ns1", "test1").Min(MakeResourceList().CPU(1).Mem(5).Obj()).
+		Max(MakeResourceList().CPU(10).Mem(50).Obj()).Obj()
+	_, err := suit.client.SchedulingV1alpha1().ElasticQuotas(eq.Namespace).Create(ctx, eq, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	err = wait.PollImmediate(10*time.Millisecond, 3*time.Second, func() (bool, error) {
+		_, err := p.quotaLister.ElasticQuotas(eq.Namespace).Get(eq.Name)
+		return err == nil && p.groupQuotaManager.GetQuotaInfoByName(eq.Name) != nil, nil
+	})
+	assert.NoError(t, err)
+	pod := MakePod("ns1", "pod1").Phase(corev1.PodRunning).Container(MakeResourceList().CPU(2).Mem(2).Obj()).UID("pod1").Obj()
+	pod.Labels = map[string]string{extension.LabelQuotaName: "test1"}
+	pod.Spec.NodeName = "node1"
+	p.OnPodAdd(pod)
+
+	now := time.Now()
+	defer func() {
+		timeNowFn = time.Now
+	}()
+	ctrl := NewQuotaUsageAccountingController(p.client, p.quotaLister, p.groupQuotaManager, time.Minute)
+	timeNowFn = func() time.Time { return now }
+	ctrl.Run()
+	timeNowFn = func() time.Time { return now.Add(time.Minute) }
+	ctrl.Run()
+
+	got, err := suit.client.SchedulingV1alpha1().ElasticQuotas(eq.Namespace).Get(ctx, eq.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	accounting, err := extension.GetQuotaUsageAccounting(got)
+	assert.NoError(t, err)
+	assert.NotNil(t, accounting)
+	assert.InDelta(t, 2.0/60, accounting.Used[corev1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 1.0/60, accounting.Guaranteed[corev1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 1.0/60, accounting.Borrowed[corev1.ResourceCPU], 1e-6)
+}
+
+func TestEndpointsQueryQuotaUsageAccounting(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.Nil(t, err)
+	eq := p.(*Plugin)
+	eq.OnQuotaAdd(CreateQuota2("test1", "", 100, 100, 10, 10, 20, 20, false))
+	eq.groupQuotaManager.AccountQuotaUsage(time.Now(), time.Minute)
+
+	engine := gin.Default()
+	eq.RegisterEndpoints(engine.Group("/"))
+	{
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quota/test1/usage", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		accounting := &extension.QuotaUsageAccounting{}
+		assert.NoError(t, json.NewDecoder(w.Result().Body).Decode(accounting))
+		assert.False(t, accounting.StartTime.IsZero())
+	}
+	{
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quota/not-exist/usage", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	}
+	{
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quotas/usage", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		accountings := map[string]*extension.QuotaUsageAccounting{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &accountings))
+		assert.Contains(t, accountings, "test1")
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+const (
+	Name = "InterferenceAware"
+
+	ErrReasonContentionExceedThreshold = "node(s) %s contention exceed threshold"
+)
+
+const (
+	indicatorCPUPressure     = "cpu pressure"
+	indicatorMemoryPressure  = "memory pressure"
+	indicatorIOPressure      = "io pressure"
+	indicatorCPIOutlier      = "cpi outlier"
+	indicatorCPUThrottled    = "cpu throttled"
+	indicatorMemoryBandwidth = "memory bandwidth"
+)
+
+var (
+	_ framework.EnqueueExtensions = &Plugin{}
+
+	_ framework.FilterPlugin = &Plugin{}
+	_ framework.ScorePlugin  = &Plugin{}
+)
+
+// Plugin avoids placing latency-sensitive pods on the nodes suffering from the contention of shared resources,
+// according to the contention indicators reported by koordlet in NodeMetric.
+type Plugin struct {
+	handle           framework.Handle
+	args             *config.InterferenceAwareArgs
+	nodeMetricLister slolisters.NodeMetricLister
+}
+
+func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	pluginArgs, ok := args.(*config.InterferenceAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InterferenceAwareArgs, got %T", args)
+	}
+
+	if err := validation.ValidateInterferenceAwareArgs(pluginArgs); err != nil {
+		return nil, err
+	}
+
+	frameworkExtender, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("want handle to be of type frameworkext.ExtendedHandle, got %T", handle)
+	}
+	nodeMetricLister := frameworkExtender.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics().Lister()
+
+	return &Plugin{
+		handle:           handle,
+		args:             pluginArgs,
+		nodeMetricLister: nodeMetricLister,
+	}, nil
+}
+
+func (p *Plugin) Name() string { return Name }
+
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// To register a custom event, follow the naming convention at:
+	// https://github.com/kubernetes/kubernetes/blob/e1ad9bee5bba8fbe85a6bf6201379ce8b1a611b1/pkg/scheduler/eventhandlers.go#L415-L422
+	gvk := fmt.Sprintf("nodemetrics.%v.%v", slov1alpha1.GroupVersion.Version, slov1alpha1.GroupVersion.Group)
+	return []framework.ClusterEvent{
+		{Resource: framework.GVK(gvk), ActionType: framework.Add | framework.Update | framework.Delete},
+	}
+}
+
+func (p *Plugin) Filter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	if !isLatencySensitivePod(pod) {
+		return nil
+	}
+
+	contention, err := p.getNodeContention(node.Name)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	if contention == nil {
+		return nil
+	}
+	for _, indicator := range p.contentionRatios(contention) {
+		if indicator.ratio >= 1 {
+			return framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonContentionExceedThreshold, indicator.name))
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) Score(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeName string) (int64, *framework.Status) {
+	if !isLatencySensitivePod(pod) {
+		return 0, nil
+	}
+	contention, err := p.getNodeContention(nodeName)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, err.Error())
+	}
+	if contention == nil {
+		// unknown contention is treated as no contention, consistent with Filter
+		return framework.MaxNodeScore, nil
+	}
+
+	// the node is scored by its most contended indicator relative to the threshold,
+	// a node without any thresholded indicator is treated as no contention
+	var maxRatio float64
+	for _, indicator := range p.contentionRatios(contention) {
+		if indicator.ratio > maxRatio {
+			maxRatio = indicator.ratio
+		}
+	}
+	if maxRatio >= 1 {
+		return 0, nil
+	}
+	return int64(math.Round(float64(framework.MaxNodeScore) * (1 - maxRatio))), nil
+}
+
+func (p *Plugin) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// getNodeContention returns the contention indicators of the node, it returns nil if the NodeMetric of the node
+// is not found or expired, since the interference-aware scheduling itself is an optimization.
+func (p *Plugin) getNodeContention(nodeName string) (*slov1alpha1.NodeContention, error) {
+	nodeMetric, err := p.nodeMetricLister.Get(nodeName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if p.args.NodeMetricExpirationSeconds != nil && isNodeMetricExpired(nodeMetric, *p.args.NodeMetricExpirationSeconds) {
+		return nil, nil
+	}
+	if nodeMetric.Status.NodeMetric == nil {
+		return nil, nil
+	}
+	return nodeMetric.Status.NodeMetric.Contention, nil
+}
+
+type contentionRatio struct {
+	name string
+	// ratio is the indicator value divided by the threshold
+	ratio float64
+}
+
+// contentionRatios returns the ratios of the reported indicators to their thresholds. The indicators which are
+// not reported or have no threshold are skipped.
+func (p *Plugin) contentionRatios(contention *slov1alpha1.NodeContention) []contentionRatio {
+	thresholds := p.args.ContentionThresholds
+	if thresholds == nil {
+		return nil
+	}
+	var ratios []contentionRatio
+	addRatio := func(name string, value *int64, threshold int64) {
+		if value == nil || threshold <= 0 {
+			return
+		}
+		ratios = append(ratios, contentionRatio{name: name, ratio: float64(*value) / float64(threshold)})
+	}
+	if contention.PSI != nil {
+		addRatio(indicatorCPUPressure, contention.PSI.CPU, thresholds.CPUPressurePercent)
+		addRatio(indicatorMemoryPressure, contention.PSI.Memory, thresholds.MemoryPressurePercent)
+		addRatio(indicatorIOPressure, contention.PSI.IO, thresholds.IOPressurePercent)
+	}
+	addRatio(indicatorCPIOutlier, contention.CPIOutlierPercent, thresholds.CPIOutlierPercent)
+	addRatio(indicatorCPUThrottled, contention.CPUThrottledPercent, thresholds.CPUThrottledPercent)
+	if contention.MemoryBandwidth != nil && thresholds.MemoryBandwidth != nil && thresholds.MemoryBandwidth.Sign() > 0 {
+		ratios = append(ratios, contentionRatio{
+			name:  indicatorMemoryBandwidth,
+			ratio: contention.MemoryBandwidth.AsApproximateFloat64() / thresholds.MemoryBandwidth.AsApproximateFloat64(),
+		})
+	}
+	return ratios
+}
+
+func isLatencySensitivePod(pod *corev1.Pod) bool {
+	switch extension.GetPodQoSClassWithDefault(pod) {
+	case extension.QoSLSE, extension.QoSLSR, extension.QoSLS:
+		return true
+	}
+	return false
+}
+
+func isNodeMetricExpired(nodeMetric *slov1alpha1.NodeMetric, nodeMetricExpirationSeconds int64) bool {
+	return nodeMetric.Status.UpdateTime == nil ||
+		nodeMetricExpirationSeconds > 0 &&
+			time.Since(nodeMetric.Status.UpdateTime.Time) >= time.Duration(nodeMetricExpirationSeconds)*time.Second
+}
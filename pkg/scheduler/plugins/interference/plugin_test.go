@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+func newTestArgs(t *testing.T) *config.InterferenceAwareArgs {
+	var v1beta2args v1beta2.InterferenceAwareArgs
+	v1beta2.SetDefaults_InterferenceAwareArgs(&v1beta2args)
+	var args config.InterferenceAwareArgs
+	err := v1beta2.Convert_v1beta2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(&v1beta2args, &args, nil)
+	assert.NoError(t, err)
+	return &args
+}
+
+func newTestPlugin(t *testing.T, args *config.InterferenceAwareArgs, nodeMetrics ...*slov1alpha1.NodeMetric) *Plugin {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, nodeMetric := range nodeMetrics {
+		assert.NoError(t, indexer.Add(nodeMetric))
+	}
+	return &Plugin{
+		args:             args,
+		nodeMetricLister: slolisters.NewNodeMetricLister(indexer),
+	}
+}
+
+func newTestNodeMetric(nodeName string, updateTime time.Time, contention *slov1alpha1.NodeContention) *slov1alpha1.NodeMetric {
+	return &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+		},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: updateTime},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				Contention: contention,
+			},
+		},
+	}
+}
+
+func newTestPod(qosClass extension.QoSClass) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			Labels: map[string]string{
+				extension.LabelPodQoS: string(qosClass),
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	args := newTestArgs(t)
+	assert.Equal(t, &config.InterferenceAwareArgs{
+		NodeMetricExpirationSeconds: pointer.Int64(180),
+		ContentionThresholds: &config.ContentionThresholds{
+			CPUPressurePercent:    20,
+			MemoryPressurePercent: 10,
+			CPIOutlierPercent:     30,
+			CPUThrottledPercent:   20,
+		},
+	}, args)
+
+	koordClientSet := koordfake.NewSimpleClientset()
+	koordSharedInformerFactory := koordinatorinformers.NewSharedInformerFactory(koordClientSet, 0)
+	extenderFactory, _ := frameworkext.NewFrameworkExtenderFactory(
+		frameworkext.WithKoordinatorClientSet(koordClientSet),
+		frameworkext.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+	)
+	proxyNew := frameworkext.PluginFactoryProxy(extenderFactory, New)
+
+	cs := kubefake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(cs, 0)
+	registeredPlugins := []schedulertesting.RegisterPluginFunc{
+		schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+		schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+	}
+	fh, err := schedulertesting.NewFramework(registeredPlugins, "koord-scheduler",
+		frameworkruntime.WithClientSet(cs),
+		frameworkruntime.WithInformerFactory(informerFactory),
+	)
+	assert.NoError(t, err)
+
+	p, err := proxyNew(args, fh)
+	assert.NoError(t, err)
+	assert.Equal(t, Name, p.Name())
+
+	args.ContentionThresholds.CPUPressurePercent = 101
+	_, err = proxyNew(args, fh)
+	assert.Error(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		pod        *corev1.Pod
+		nodeMetric *slov1alpha1.NodeMetric
+		wantStatus *framework.Status
+	}{
+		{
+			name:       "node metric not found",
+			pod:        newTestPod(extension.QoSLS),
+			wantStatus: nil,
+		},
+		{
+			name:       "contention not reported",
+			pod:        newTestPod(extension.QoSLS),
+			nodeMetric: newTestNodeMetric("test-node-1", now, nil),
+		},
+		{
+			name: "node metric expired",
+			pod:  newTestPod(extension.QoSLS),
+			nodeMetric: newTestNodeMetric("test-node-1", now.Add(-time.Hour), &slov1alpha1.NodeContention{
+				CPUThrottledPercent: pointer.Int64(50),
+			}),
+			wantStatus: nil,
+		},
+		{
+			name: "contention under thresholds",
+			pod:  newTestPod(extension.QoSLS),
+			nodeMetric: newTestNodeMetric("test-node-1", now, &slov1alpha1.NodeContention{
+				PSI: &slov1alpha1.PSIContention{
+					CPU:    pointer.Int64(19),
+					Memory: pointer.Int64(9),
+					IO:     pointer.Int64(90),
+				},
+				CPIOutlierPercent:   pointer.Int64(29),
+				CPUThrottledPercent: pointer.Int64(19),
+				MemoryBandwidth:     resource.NewQuantity(100<<30, resource.DecimalSI),
+			}),
+			wantStatus: nil,
+		},
+		{
+			name: "cpu pressure exceeds threshold",
+			pod:  newTestPod(extension.QoSLSR),
+			nodeMetric: newTestNodeMetric("test-node-1", now, &slov1alpha1.NodeContention{
+				PSI: &slov1alpha1.PSIContention{
+					CPU: pointer.Int64(20),
+				},
+			}),
+			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonContentionExceedThreshold, indicatorCPUPressure)),
+		},
+		{
+			name: "cpi outlier exceeds threshold",
+			pod:  newTestPod(extension.QoSLSE),
+			nodeMetric: newTestNodeMetric("test-node-1", now, &slov1alpha1.NodeContention{
+				CPIOutlierPercent: pointer.Int64(50),
+			}),
+			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonContentionExceedThreshold, indicatorCPIOutlier)),
+		},
+		{
+			name: "cpu throttled exceeds threshold",
+			pod:  newTestPod(extension.QoSLS),
+			nodeMetric: newTestNodeMetric("test-node-1", now, &slov1alpha1.NodeContention{
+				CPUThrottledPercent: pointer.Int64(30),
+			}),
+			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonContentionExceedThreshold, indicatorCPUThrottled)),
+		},
+		{
+			name: "BE pod is not filtered",
+			pod:  newTestPod(extension.QoSBE),
+			nodeMetric: newTestNodeMetric("test-node-1", now, &slov1alpha1.NodeContention{
+				CPUThrottledPercent: pointer.Int64(30),
+			}),
+			wantStatus: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nodeMetrics []*slov1alpha1.NodeMetric
+			if tt.nodeMetric != nil {
+				nodeMetrics = append(nodeMetrics, tt.nodeMetric)
+			}
+			p := newTestPlugin(t, newTestArgs(t), nodeMetrics...)
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node-1"}})
+			status := p.Filter(context.TODO(), framework.NewCycleState(), tt.pod, nodeInfo)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestFilterMemoryBandwidth(t *testing.T) {
+	args := newTestArgs(t)
+	args.ContentionThresholds.MemoryBandwidth = resource.NewQuantity(10<<30, resource.DecimalSI)
+	p := newTestPlugin(t, args, newTestNodeMetric("test-node-1", time.Now(), &slov1alpha1.NodeContention{
+		MemoryBandwidth: resource.NewQuantity(12<<30, resource.DecimalSI),
+	}))
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node-1"}})
+	status := p.Filter(context.TODO(), framework.NewCycleState(), newTestPod(extension.QoSLS), nodeInfo)
+	assert.Equal(t, framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonContentionExceedThreshold, indicatorMemoryBandwidth)), status)
+}
+
+func TestScore(t *testing.T) {
+	now := time.Now()
+	p := newTestPlugin(t, newTestArgs(t),
+		newTestNodeMetric("idle-node", now, &slov1alpha1.NodeContention{
+			CPUThrottledPercent: pointer.Int64(0),
+		}),
+		newTestNodeMetric("contended-node", now, &slov1alpha1.NodeContention{
+			PSI: &slov1alpha1.PSIContention{
+				CPU:    pointer.Int64(5),
+				Memory: pointer.Int64(5),
+			},
+			CPUThrottledPercent: pointer.Int64(4),
+		}),
+		newTestNodeMetric("overloaded-node", now, &slov1alpha1.NodeContention{
+			CPIOutlierPercent: pointer.Int64(60),
+		}),
+		newTestNodeMetric("expired-node", now.Add(-time.Hour), &slov1alpha1.NodeContention{
+			CPUThrottledPercent: pointer.Int64(0),
+		}),
+		newTestNodeMetric("no-contention-node", now, nil),
+		newTestNodeMetric("no-indicator-node", now, &slov1alpha1.NodeContention{}),
+	)
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		nodeName  string
+		wantScore int64
+	}{
+		{
+			name:      "idle node",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "idle-node",
+			wantScore: framework.MaxNodeScore,
+		},
+		{
+			name:      "scored by the most contended indicator",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "contended-node",
+			wantScore: 50, // memory pressure 5 / 10
+		},
+		{
+			name:      "contention exceeds threshold",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "overloaded-node",
+			wantScore: 0,
+		},
+		{
+			name:      "node metric expired",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "expired-node",
+			wantScore: framework.MaxNodeScore,
+		},
+		{
+			name:      "node metric not found",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "unknown-node",
+			wantScore: framework.MaxNodeScore,
+		},
+		{
+			name:      "contention not reported",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "no-contention-node",
+			wantScore: framework.MaxNodeScore,
+		},
+		{
+			name:      "no thresholded indicator reported",
+			pod:       newTestPod(extension.QoSLS),
+			nodeName:  "no-indicator-node",
+			wantScore: framework.MaxNodeScore,
+		},
+		{
+			name:      "BE pod is not scored",
+			pod:       newTestPod(extension.QoSBE),
+			nodeName:  "idle-node",
+			wantScore: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, status := p.Score(context.TODO(), framework.NewCycleState(), tt.pod, tt.nodeName)
+			assert.True(t, status.IsSuccess())
+			assert.Equal(t, tt.wantScore, score)
+		})
+	}
+}
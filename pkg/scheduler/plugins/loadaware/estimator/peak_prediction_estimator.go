@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+)
+
+const (
+	peakPredictionEstimatorName = "peakPredictionEstimator"
+
+	// peakPredictionAggregationType is the percentile of the peak prediction used to estimate pods.
+	peakPredictionAggregationType = slov1alpha1.P95
+)
+
+// PeakPredictionEstimator estimates the pod usage according to the peak predictions reported by koordlet
+// for the running pods of the same workload (the controller owner reference). Pods without owner or
+// whose workload has no prediction yet fall back to the DefaultEstimator.
+type PeakPredictionEstimator struct {
+	defaultEstimator Estimator
+	resourceWeights  map[corev1.ResourceName]int64
+	cache            *workloadPeakCache
+}
+
+func NewPeakPredictionEstimator(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error) {
+	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("want handle to be of type frameworkext.ExtendedHandle, got %T", handle)
+	}
+	defaultEstimator, err := NewDefaultEstimator(args, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	podLister := extendedHandle.SharedInformerFactory().Core().V1().Pods().Lister()
+	peakCache := newWorkloadPeakCache(podLister, nil)
+	nodeMetricInformer := extendedHandle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics().Informer()
+	frameworkexthelper.ForceSyncFromInformer(context.TODO().Done(), extendedHandle.KoordinatorSharedInformerFactory(), nodeMetricInformer, peakCache)
+
+	return &PeakPredictionEstimator{
+		defaultEstimator: defaultEstimator,
+		resourceWeights:  args.ResourceWeights,
+		cache:            peakCache,
+	}, nil
+}
+
+func (e *PeakPredictionEstimator) Name() string {
+	return peakPredictionEstimatorName
+}
+
+func (e *PeakPredictionEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName]int64, error) {
+	estimated, err := e.defaultEstimator.EstimatePod(pod)
+	if err != nil {
+		return nil, err
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return estimated, nil
+	}
+	peak := e.cache.getWorkloadPeak(owner.UID)
+	if len(peak) == 0 {
+		return estimated, nil
+	}
+	for resourceName := range e.resourceWeights {
+		quantity, ok := peak[resourceName]
+		if !ok {
+			continue
+		}
+		if resourceName == corev1.ResourceCPU {
+			estimated[resourceName] = quantity.MilliValue()
+		} else {
+			estimated[resourceName] = quantity.Value()
+		}
+	}
+	return estimated, nil
+}
+
+func (e *PeakPredictionEstimator) EstimateNode(node *corev1.Node) (corev1.ResourceList, error) {
+	return e.defaultEstimator.EstimateNode(node)
+}
+
+// workloadPeakCache indexes the peak predictions in NodeMetrics by the controller owner of pods.
+// It also feeds the observed pod usages to the workload history if it is set, so that the pods
+// reported in NodeMetrics are resolved to their workloads only once.
+type workloadPeakCache struct {
+	podLister corev1listers.PodLister
+	history   *workloadHistoryCache
+
+	lock sync.RWMutex
+	// workloadPeaks records the peak predictions of pods, workload UID -> pod namespaced name -> peak
+	workloadPeaks map[types.UID]map[string]corev1.ResourceList
+	// nodeWorkloads records the workload of the pods reported on the node, node name -> pod namespaced name -> workload UID
+	nodeWorkloads map[string]map[string]types.UID
+}
+
+func newWorkloadPeakCache(podLister corev1listers.PodLister, history *workloadHistoryCache) *workloadPeakCache {
+	return &workloadPeakCache{
+		podLister:     podLister,
+		history:       history,
+		workloadPeaks: map[types.UID]map[string]corev1.ResourceList{},
+		nodeWorkloads: map[string]map[string]types.UID{},
+	}
+}
+
+func (c *workloadPeakCache) OnAdd(obj interface{}) {
+	nodeMetric, ok := obj.(*slov1alpha1.NodeMetric)
+	if !ok {
+		return
+	}
+	c.updateNodeMetric(nodeMetric)
+}
+
+func (c *workloadPeakCache) OnUpdate(oldObj, newObj interface{}) {
+	nodeMetric, ok := newObj.(*slov1alpha1.NodeMetric)
+	if !ok {
+		return
+	}
+	c.updateNodeMetric(nodeMetric)
+}
+
+func (c *workloadPeakCache) OnDelete(obj interface{}) {
+	var nodeMetric *slov1alpha1.NodeMetric
+	switch t := obj.(type) {
+	case *slov1alpha1.NodeMetric:
+		nodeMetric = t
+	case cache.DeletedFinalStateUnknown:
+		nodeMetric, _ = t.Obj.(*slov1alpha1.NodeMetric)
+	}
+	if nodeMetric == nil {
+		return
+	}
+	if c.history != nil {
+		c.history.removeNode(nodeMetric.Name)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removeNodeLocked(nodeMetric.Name)
+}
+
+func (c *workloadPeakCache) updateNodeMetric(nodeMetric *slov1alpha1.NodeMetric) {
+	type podPeak struct {
+		workload types.UID
+		peak     corev1.ResourceList
+	}
+	peaks := map[string]podPeak{}
+	// usages records the observed usages of pods by workload key for the workload history
+	usages := map[string][]corev1.ResourceList{}
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric == nil {
+			continue
+		}
+		prediction, hasPrediction := podMetric.PeakPrediction[peakPredictionAggregationType]
+		hasPrediction = hasPrediction && len(prediction.ResourceList) > 0
+		hasUsage := c.history != nil && len(podMetric.PodUsage.ResourceList) > 0
+		if !hasPrediction && !hasUsage {
+			continue
+		}
+		pod, err := c.podLister.Pods(podMetric.Namespace).Get(podMetric.Name)
+		if err != nil {
+			continue
+		}
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil {
+			continue
+		}
+		if hasPrediction {
+			peaks[fmt.Sprintf("%s/%s", podMetric.Namespace, podMetric.Name)] = podPeak{
+				workload: owner.UID,
+				peak:     prediction.ResourceList.DeepCopy(),
+			}
+		}
+		if hasUsage {
+			workloadKey := getWorkloadKey(pod)
+			usages[workloadKey] = append(usages[workloadKey], podMetric.PodUsage.ResourceList)
+		}
+	}
+	if c.history != nil && nodeMetric.Status.UpdateTime != nil {
+		c.history.addSamples(nodeMetric.Name, nodeMetric.Status.UpdateTime.Time, usages)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removeNodeLocked(nodeMetric.Name)
+	if len(peaks) == 0 {
+		return
+	}
+	podWorkloads := make(map[string]types.UID, len(peaks))
+	for podKey, p := range peaks {
+		workloadPeaks := c.workloadPeaks[p.workload]
+		if workloadPeaks == nil {
+			workloadPeaks = map[string]corev1.ResourceList{}
+			c.workloadPeaks[p.workload] = workloadPeaks
+		}
+		workloadPeaks[podKey] = p.peak
+		podWorkloads[podKey] = p.workload
+	}
+	c.nodeWorkloads[nodeMetric.Name] = podWorkloads
+}
+
+func (c *workloadPeakCache) removeNodeLocked(nodeName string) {
+	for podKey, workload := range c.nodeWorkloads[nodeName] {
+		workloadPeaks := c.workloadPeaks[workload]
+		delete(workloadPeaks, podKey)
+		if len(workloadPeaks) == 0 {
+			delete(c.workloadPeaks, workload)
+		}
+	}
+	delete(c.nodeWorkloads, nodeName)
+}
+
+// getWorkloadPeak returns the maximum peak prediction among the pods of the workload.
+func (c *workloadPeakCache) getWorkloadPeak(workload types.UID) corev1.ResourceList {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var result corev1.ResourceList
+	for _, peak := range c.workloadPeaks[workload] {
+		if result == nil {
+			result = corev1.ResourceList{}
+		}
+		for resourceName, quantity := range peak {
+			if old, ok := result[resourceName]; !ok || quantity.Cmp(old) > 0 {
+				result[resourceName] = quantity.DeepCopy()
+			}
+		}
+	}
+	return result
+}
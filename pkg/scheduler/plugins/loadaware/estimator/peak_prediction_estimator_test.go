@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+)
+
+func newTestWorkloadPod(name string, ownerUID types.UID) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if ownerUID != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "test-rs",
+				UID:        ownerUID,
+				Controller: pointer.Bool(true),
+			},
+		}
+	}
+	return pod
+}
+
+func newTestPeakPodMetric(name string, cpu, memory string) *slov1alpha1.PodMetricInfo {
+	return &slov1alpha1.PodMetricInfo{
+		Namespace: "default",
+		Name:      name,
+		PeakPrediction: map[slov1alpha1.AggregationType]slov1alpha1.ResourceMap{
+			slov1alpha1.P95: {
+				ResourceList: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		},
+	}
+}
+
+func TestPeakPredictionEstimator(t *testing.T) {
+	var v1beta2args v1beta2.LoadAwareSchedulingArgs
+	v1beta2.SetDefaults_LoadAwareSchedulingArgs(&v1beta2args)
+	var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+	err := v1beta2.Convert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta2args, &loadAwareSchedulingArgs, nil)
+	assert.NoError(t, err)
+	defaultEstimator, err := NewDefaultEstimator(&loadAwareSchedulingArgs, nil)
+	assert.NoError(t, err)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pod := range []*corev1.Pod{
+		newTestWorkloadPod("pod-1", "workload-1"),
+		newTestWorkloadPod("pod-2", "workload-1"),
+		newTestWorkloadPod("pod-3", "workload-2"),
+		newTestWorkloadPod("pod-4", ""),
+	} {
+		assert.NoError(t, indexer.Add(pod))
+	}
+	peakCache := newWorkloadPeakCache(corev1listers.NewPodLister(indexer), nil)
+	estimator := &PeakPredictionEstimator{
+		defaultEstimator: defaultEstimator,
+		resourceWeights:  loadAwareSchedulingArgs.ResourceWeights,
+		cache:            peakCache,
+	}
+	assert.Equal(t, peakPredictionEstimatorName, estimator.Name())
+
+	peakCache.OnAdd(&slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: slov1alpha1.NodeMetricStatus{
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPeakPodMetric("pod-1", "1", "2Gi"),
+				newTestPeakPodMetric("pod-4", "3", "6Gi"),
+			},
+		},
+	})
+	peakCache.OnAdd(&slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+		Status: slov1alpha1.NodeMetricStatus{
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPeakPodMetric("pod-2", "2", "1Gi"),
+			},
+		},
+	})
+
+	// the new pod of workload-1 uses the maximum peak of its siblings
+	got, err := estimator.EstimatePod(newTestWorkloadPod("pod-5", "workload-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    2000,
+		corev1.ResourceMemory: 2 * 1024 * 1024 * 1024,
+	}, got)
+
+	// workload without prediction and pod without owner fall back to the default estimator
+	defaultEstimated, err := defaultEstimator.EstimatePod(newTestWorkloadPod("pod-6", ""))
+	assert.NoError(t, err)
+	got, err = estimator.EstimatePod(newTestWorkloadPod("pod-6", "workload-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+	got, err = estimator.EstimatePod(newTestWorkloadPod("pod-6", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+
+	// the peak of pods on the updated node is replaced
+	peakCache.OnUpdate(nil, &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+		Status: slov1alpha1.NodeMetricStatus{
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPeakPodMetric("pod-2", "500m", "1Gi"),
+			},
+		},
+	})
+	got, err = estimator.EstimatePod(newTestWorkloadPod("pod-5", "workload-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1000,
+		corev1.ResourceMemory: 2 * 1024 * 1024 * 1024,
+	}, got)
+
+	peakCache.OnDelete(&slov1alpha1.NodeMetric{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	peakCache.OnDelete(cache.DeletedFinalStateUnknown{Obj: &slov1alpha1.NodeMetric{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}})
+	assert.Empty(t, peakCache.workloadPeaks)
+	assert.Empty(t, peakCache.nodeWorkloads)
+	got, err = estimator.EstimatePod(newTestWorkloadPod("pod-5", "workload-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+}
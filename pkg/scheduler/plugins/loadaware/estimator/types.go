@@ -26,7 +26,9 @@ import (
 type FactoryFn func(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error)
 
 var Estimators = map[string]FactoryFn{
-	defaultEstimatorName: NewDefaultEstimator,
+	defaultEstimatorName:         NewDefaultEstimator,
+	peakPredictionEstimatorName:  NewPeakPredictionEstimator,
+	workloadHistoryEstimatorName: NewWorkloadHistoryEstimator,
 }
 
 type Estimator interface {
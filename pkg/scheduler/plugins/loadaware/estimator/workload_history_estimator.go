@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/clock"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+)
+
+const (
+	workloadHistoryEstimatorName = config.WorkloadHistoryEstimatorName
+
+	// workloadHistoryExpirationHalfLives indicates how many half-lives without any sample a workload history expires.
+	workloadHistoryExpirationHalfLives = 10
+	workloadHistoryGCInterval          = 10 * time.Minute
+)
+
+// WorkloadHistoryEstimator estimates the pod usage according to the observed usage of the pods of the same workload.
+// The workload is identified by the controller owner and the template hash of the pod, so that a new revision of
+// the workload starts a new history. Workloads in cold start fall back to the DefaultEstimator.
+type WorkloadHistoryEstimator struct {
+	defaultEstimator Estimator
+	resourceWeights  map[corev1.ResourceName]int64
+	coldStartSamples int64
+	cache            *workloadHistoryCache
+}
+
+func NewWorkloadHistoryEstimator(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error) {
+	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("want handle to be of type frameworkext.ExtendedHandle, got %T", handle)
+	}
+	defaultEstimator, err := NewDefaultEstimator(args, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.WorkloadHistory == nil {
+		return nil, fmt.Errorf("workloadHistory args must be set for %s", workloadHistoryEstimatorName)
+	}
+
+	historyCache := newWorkloadHistoryCache(args.WorkloadHistory.DecayHalfLife.Duration, clock.RealClock{})
+	// the pods reported in NodeMetrics are resolved to workloads by the workloadPeakCache
+	podLister := extendedHandle.SharedInformerFactory().Core().V1().Pods().Lister()
+	peakCache := newWorkloadPeakCache(podLister, historyCache)
+	nodeMetricInformer := extendedHandle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics().Informer()
+	frameworkexthelper.ForceSyncFromInformer(context.TODO().Done(), extendedHandle.KoordinatorSharedInformerFactory(), nodeMetricInformer, peakCache)
+	go wait.Until(historyCache.gc, workloadHistoryGCInterval, context.TODO().Done())
+
+	return &WorkloadHistoryEstimator{
+		defaultEstimator: defaultEstimator,
+		resourceWeights:  args.ResourceWeights,
+		coldStartSamples: args.WorkloadHistory.ColdStartSamples,
+		cache:            historyCache,
+	}, nil
+}
+
+func (e *WorkloadHistoryEstimator) Name() string {
+	return workloadHistoryEstimatorName
+}
+
+func (e *WorkloadHistoryEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName]int64, error) {
+	estimated, err := e.defaultEstimator.EstimatePod(pod)
+	if err != nil {
+		return nil, err
+	}
+	workloadKey := getWorkloadKey(pod)
+	if workloadKey == "" {
+		return estimated, nil
+	}
+	usage, samples := e.cache.getWorkloadUsage(workloadKey)
+	if samples == 0 || samples < e.coldStartSamples {
+		return estimated, nil
+	}
+	for resourceName := range e.resourceWeights {
+		if value, ok := usage[resourceName]; ok {
+			estimated[resourceName] = value
+		}
+	}
+	return estimated, nil
+}
+
+func (e *WorkloadHistoryEstimator) EstimateNode(node *corev1.Node) (corev1.ResourceList, error) {
+	return e.defaultEstimator.EstimateNode(node)
+}
+
+// getWorkloadKey returns the key of the workload the pod belongs to, which consists of the controller owner
+// and the template hash of Deployment(ReplicaSet) or StatefulSet. It returns empty if the pod has no controller.
+func getWorkloadKey(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ""
+	}
+	key := fmt.Sprintf("%s/%s/%s", pod.Namespace, owner.Kind, owner.Name)
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+		return key + "/" + hash
+	}
+	if hash := pod.Labels[appsv1.ControllerRevisionHashLabelKey]; hash != "" {
+		return key + "/" + hash
+	}
+	return key
+}
+
+// workloadHistory is the decaying peak of the observed usage of a workload.
+type workloadHistory struct {
+	// peaks records the decayed peak usage of resources, in milli-cores for cpu and in bytes for others.
+	peaks map[corev1.ResourceName]float64
+	// samples is the number of usage samples observed
+	samples        int64
+	lastSampleTime time.Time
+}
+
+// workloadHistoryCache aggregates the pod usages reported in NodeMetrics by workloads.
+type workloadHistoryCache struct {
+	decayHalfLife time.Duration
+	clock         clock.Clock
+
+	lock      sync.RWMutex
+	workloads map[string]*workloadHistory
+	// nodeUpdateTimes records the last update time of the NodeMetrics which have been sampled, to avoid duplicated samples.
+	nodeUpdateTimes map[string]time.Time
+}
+
+func newWorkloadHistoryCache(decayHalfLife time.Duration, clock clock.Clock) *workloadHistoryCache {
+	return &workloadHistoryCache{
+		decayHalfLife:   decayHalfLife,
+		clock:           clock,
+		workloads:       map[string]*workloadHistory{},
+		nodeUpdateTimes: map[string]time.Time{},
+	}
+}
+
+// addSamples adds the pod usages of a NodeMetric grouped by workload keys, the NodeMetric is
+// sampled only once for each update time.
+func (c *workloadHistoryCache) addSamples(nodeName string, updateTime time.Time, usages map[string][]corev1.ResourceList) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if lastUpdateTime, ok := c.nodeUpdateTimes[nodeName]; ok && !updateTime.After(lastUpdateTime) {
+		return
+	}
+	c.nodeUpdateTimes[nodeName] = updateTime
+	for workloadKey, workloadUsages := range usages {
+		for _, usage := range workloadUsages {
+			c.addSampleLocked(workloadKey, usage, updateTime)
+		}
+	}
+}
+
+// removeNode forgets the sampled NodeMetric of the node, the histories of workloads are kept until expired.
+func (c *workloadHistoryCache) removeNode(nodeName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.nodeUpdateTimes, nodeName)
+}
+
+// addSampleLocked updates the decaying peak of the workload: peak = max(sample, peak * 2^(-elapsed/halfLife)).
+func (c *workloadHistoryCache) addSampleLocked(workloadKey string, usage corev1.ResourceList, sampleTime time.Time) {
+	history := c.workloads[workloadKey]
+	if history == nil {
+		history = &workloadHistory{
+			peaks: map[corev1.ResourceName]float64{},
+		}
+		c.workloads[workloadKey] = history
+	}
+	decay := 1.0
+	if sampleTime.After(history.lastSampleTime) {
+		if !history.lastSampleTime.IsZero() {
+			decay = c.decayFactor(sampleTime.Sub(history.lastSampleTime))
+		}
+		history.lastSampleTime = sampleTime
+	}
+	for resourceName, peak := range history.peaks {
+		history.peaks[resourceName] = peak * decay
+	}
+	for resourceName, quantity := range usage {
+		var value float64
+		if resourceName == corev1.ResourceCPU {
+			value = float64(quantity.MilliValue())
+		} else {
+			value = float64(quantity.Value())
+		}
+		if value > history.peaks[resourceName] {
+			history.peaks[resourceName] = value
+		}
+	}
+	history.samples++
+}
+
+func (c *workloadHistoryCache) decayFactor(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(elapsed) / float64(c.decayHalfLife))
+}
+
+// getWorkloadUsage returns the decayed peak usage of the workload at now and the number of samples.
+func (c *workloadHistoryCache) getWorkloadUsage(workloadKey string) (map[corev1.ResourceName]int64, int64) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	history := c.workloads[workloadKey]
+	if history == nil {
+		return nil, 0
+	}
+	decay := c.decayFactor(c.clock.Since(history.lastSampleTime))
+	usage := make(map[corev1.ResourceName]int64, len(history.peaks))
+	for resourceName, peak := range history.peaks {
+		usage[resourceName] = int64(math.Round(peak * decay))
+	}
+	return usage, history.samples
+}
+
+// gc removes the histories of the workloads which have no sample for a long time.
+func (c *workloadHistoryCache) gc() {
+	expiration := c.decayHalfLife * workloadHistoryExpirationHalfLives
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for workloadKey, history := range c.workloads {
+		if c.clock.Since(history.lastSampleTime) > expiration {
+			delete(c.workloads, workloadKey)
+		}
+	}
+}
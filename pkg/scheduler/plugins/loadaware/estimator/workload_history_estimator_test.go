@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+)
+
+func TestGetWorkloadKey(t *testing.T) {
+	pod := newTestWorkloadPod("pod-1", "workload-1")
+	assert.Equal(t, "default/ReplicaSet/test-rs", getWorkloadKey(pod))
+
+	pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "5d8f9c7b6"}
+	assert.Equal(t, "default/ReplicaSet/test-rs/5d8f9c7b6", getWorkloadKey(pod))
+
+	pod.Labels = map[string]string{appsv1.ControllerRevisionHashLabelKey: "test-sts-7f9d8"}
+	assert.Equal(t, "default/ReplicaSet/test-rs/test-sts-7f9d8", getWorkloadKey(pod))
+
+	assert.Equal(t, "", getWorkloadKey(newTestWorkloadPod("pod-2", "")))
+}
+
+func newTestUsageNodeMetric(nodeName string, updateTime time.Time, podMetrics ...*slov1alpha1.PodMetricInfo) *slov1alpha1.NodeMetric {
+	return &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: updateTime},
+			PodsMetric: podMetrics,
+		},
+	}
+}
+
+func newTestUsagePodMetric(name string, cpu, memory string) *slov1alpha1.PodMetricInfo {
+	return &slov1alpha1.PodMetricInfo{
+		Namespace: "default",
+		Name:      name,
+		PodUsage: slov1alpha1.ResourceMap{
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func TestWorkloadHistoryEstimator(t *testing.T) {
+	var v1beta2args v1beta2.LoadAwareSchedulingArgs
+	v1beta2.SetDefaults_LoadAwareSchedulingArgs(&v1beta2args)
+	assert.Nil(t, v1beta2args.WorkloadHistory, "workloadHistory is not defaulted unless the estimator is selected")
+	v1beta2args = v1beta2.LoadAwareSchedulingArgs{Estimator: workloadHistoryEstimatorName}
+	v1beta2.SetDefaults_LoadAwareSchedulingArgs(&v1beta2args)
+	var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+	err := v1beta2.Convert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta2args, &loadAwareSchedulingArgs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &config.WorkloadHistoryEstimatorArgs{
+		DecayHalfLife:    metav1.Duration{Duration: 12 * time.Hour},
+		ColdStartSamples: 3,
+	}, loadAwareSchedulingArgs.WorkloadHistory)
+	defaultEstimator, err := NewDefaultEstimator(&loadAwareSchedulingArgs, nil)
+	assert.NoError(t, err)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pod := range []*corev1.Pod{
+		newTestWorkloadPod("pod-1", "workload-1"),
+		newTestWorkloadPod("pod-2", "workload-1"),
+		newTestWorkloadPod("pod-3", ""),
+	} {
+		assert.NoError(t, indexer.Add(pod))
+	}
+	now := time.Now()
+	fakeClock := clocktesting.NewFakeClock(now)
+	historyCache := newWorkloadHistoryCache(time.Hour, fakeClock)
+	peakCache := newWorkloadPeakCache(corev1listers.NewPodLister(indexer), historyCache)
+	estimator := &WorkloadHistoryEstimator{
+		defaultEstimator: defaultEstimator,
+		resourceWeights:  loadAwareSchedulingArgs.ResourceWeights,
+		coldStartSamples: loadAwareSchedulingArgs.WorkloadHistory.ColdStartSamples,
+		cache:            historyCache,
+	}
+	assert.Equal(t, workloadHistoryEstimatorName, estimator.Name())
+
+	newPod := newTestWorkloadPod("pod-4", "workload-1")
+	defaultEstimated, err := defaultEstimator.EstimatePod(newPod)
+	assert.NoError(t, err)
+
+	// cold start: only two samples observed
+	peakCache.OnAdd(newTestUsageNodeMetric("node-1", now,
+		newTestUsagePodMetric("pod-1", "1", "2Gi"),
+		newTestUsagePodMetric("pod-2", "2", "1Gi"),
+		newTestUsagePodMetric("pod-3", "3", "3Gi"),
+	))
+	got, err := estimator.EstimatePod(newPod)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+
+	// the same report is not sampled twice
+	peakCache.OnUpdate(nil, newTestUsageNodeMetric("node-1", now,
+		newTestUsagePodMetric("pod-1", "1", "2Gi"),
+	))
+	_, samples := historyCache.getWorkloadUsage(getWorkloadKey(newPod))
+	assert.Equal(t, int64(2), samples)
+
+	peakCache.OnUpdate(nil, newTestUsageNodeMetric("node-1", now.Add(time.Hour),
+		newTestUsagePodMetric("pod-1", "500m", "512Mi"),
+	))
+	fakeClock.SetTime(now.Add(time.Hour))
+	got, err = estimator.EstimatePod(newPod)
+	assert.NoError(t, err)
+	assert.Equal(t, map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1000,    // max(500m, 2 * 1/2)
+		corev1.ResourceMemory: 1 << 30, // max(512Mi, 2Gi * 1/2)
+	}, got)
+
+	// the peak keeps decaying without new samples
+	fakeClock.SetTime(now.Add(2 * time.Hour))
+	got, err = estimator.EstimatePod(newPod)
+	assert.NoError(t, err)
+	assert.Equal(t, map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    500,
+		corev1.ResourceMemory: 512 << 20,
+	}, got)
+
+	// pods without controller are not aggregated
+	got, err = estimator.EstimatePod(newTestWorkloadPod("pod-5", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+
+	// the history expires after the node is deleted and no new samples
+	peakCache.OnDelete(cache.DeletedFinalStateUnknown{Obj: newTestUsageNodeMetric("node-1", now)})
+	assert.Empty(t, historyCache.nodeUpdateTimes)
+	historyCache.gc()
+	assert.Len(t, historyCache.workloads, 1)
+	fakeClock.SetTime(now.Add(12 * time.Hour))
+	historyCache.gc()
+	assert.Empty(t, historyCache.workloads)
+	got, err = estimator.EstimatePod(newPod)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEstimated, got)
+}
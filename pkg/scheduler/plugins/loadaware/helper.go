@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
@@ -194,3 +195,45 @@ func isDaemonSetPod(ownerRefList []metav1.OwnerReference) bool {
 	}
 	return false
 }
+
+// getPodExpectedLifetime returns the expected lifetime of the pod, or the defaultLifetime if the pod does not declare it.
+func getPodExpectedLifetime(pod *corev1.Pod, defaultLifetime time.Duration) time.Duration {
+	lifetime, err := extension.GetPodExpectedLifetime(pod)
+	if err != nil {
+		klog.V(5).Infof("failed to get expected lifetime of pod %s/%s, err: %v", pod.Namespace, pod.Name, err)
+	}
+	if err != nil || lifetime <= 0 {
+		return defaultLifetime
+	}
+	return lifetime
+}
+
+// getExpectedHourlyUsage returns the max usage of the hourly usage profile of the node
+// during the hours of [now, now+lifetime). The lifetime longer than a day is considered as a whole day.
+func getExpectedHourlyUsage(nodeMetric *slov1alpha1.NodeMetric, now time.Time, lifetime time.Duration) corev1.ResourceList {
+	if nodeMetric.Status.NodeMetric == nil || len(nodeMetric.Status.NodeMetric.HourlyUsageProfile) == 0 {
+		return nil
+	}
+	hours := int(lifetime / time.Hour)
+	if lifetime%time.Hour != 0 {
+		hours++
+	}
+	if hours < 1 {
+		hours = 1
+	} else if hours > 24 {
+		hours = 24
+	}
+	currentHour := now.UTC().Hour()
+	var expected corev1.ResourceList
+	for _, hourlyUsage := range nodeMetric.Status.NodeMetric.HourlyUsageProfile {
+		if (int(hourlyUsage.Hour)-currentHour+24)%24 >= hours {
+			continue
+		}
+		if expected == nil {
+			expected = hourlyUsage.Usage.ResourceList.DeepCopy()
+		} else {
+			expected = quotav1.Max(expected, hourlyUsage.Usage.ResourceList)
+		}
+	}
+	return expected
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
@@ -315,7 +316,14 @@ func (p *Plugin) Score(ctx context.Context, state *framework.CycleState, pod *co
 				nodeUsage = &nodeMetric.Status.NodeMetric.NodeUsage
 			}
 			if nodeUsage != nil {
-				for resourceName, quantity := range nodeUsage.ResourceList {
+				usage := nodeUsage.ResourceList
+				if p.args.HourlyProfile != nil {
+					lifetime := getPodExpectedLifetime(pod, p.args.HourlyProfile.DefaultPodLifetime.Duration)
+					if expectedUsage := getExpectedHourlyUsage(nodeMetric, time.Now(), lifetime); len(expectedUsage) > 0 {
+						usage = quotav1.Max(usage, expectedUsage)
+					}
+				}
+				for resourceName, quantity := range usage {
 					if q := estimatedPodActualUsages[resourceName]; !q.IsZero() {
 						quantity = quantity.DeepCopy()
 						if quantity.Cmp(q) >= 0 {
@@ -919,6 +919,7 @@ func TestScore(t *testing.T) {
 		nodeMetric              *slov1alpha1.NodeMetric
 		scoreAccordingProdUsage bool
 		aggregatedArgs          *v1beta2.LoadAwareSchedulingAggregatedArgs
+		hourlyProfileArgs       *v1beta2.LoadAwareSchedulingHourlyProfileArgs
 		wantScore               int64
 		wantStatus              *framework.Status
 	}{
@@ -1068,6 +1069,41 @@ func TestScore(t *testing.T) {
 			wantScore:  72,
 			wantStatus: nil,
 		},
+		{
+			name:              "score load node with hourly usage profile during pod lifetime",
+			pod:               newTestHourlyProfilePod("2h"),
+			nodeName:          "test-node-1",
+			nodeMetric:        newTestHourlyProfileNodeMetric(1),
+			hourlyProfileArgs: &v1beta2.LoadAwareSchedulingHourlyProfileArgs{},
+			wantScore:         56,
+			wantStatus:        nil,
+		},
+		{
+			name:              "score load node with hourly usage profile out of pod lifetime",
+			pod:               newTestHourlyProfilePod("2h"),
+			nodeName:          "test-node-1",
+			nodeMetric:        newTestHourlyProfileNodeMetric(12),
+			hourlyProfileArgs: &v1beta2.LoadAwareSchedulingHourlyProfileArgs{},
+			wantScore:         72,
+			wantStatus:        nil,
+		},
+		{
+			name:              "score load node with hourly usage profile during default pod lifetime",
+			pod:               newTestHourlyProfilePod(""),
+			nodeName:          "test-node-1",
+			nodeMetric:        newTestHourlyProfileNodeMetric(12),
+			hourlyProfileArgs: &v1beta2.LoadAwareSchedulingHourlyProfileArgs{},
+			wantScore:         56,
+			wantStatus:        nil,
+		},
+		{
+			name:       "score load node ignoring hourly usage profile",
+			pod:        newTestHourlyProfilePod("2h"),
+			nodeName:   "test-node-1",
+			nodeMetric: newTestHourlyProfileNodeMetric(1),
+			wantScore:  72,
+			wantStatus: nil,
+		},
 		{
 			name: "score load node with p95",
 			aggregatedArgs: &v1beta2.LoadAwareSchedulingAggregatedArgs{
@@ -1758,6 +1794,9 @@ func TestScore(t *testing.T) {
 			if tt.aggregatedArgs != nil {
 				v1beta2args.Aggregated = tt.aggregatedArgs
 			}
+			if tt.hourlyProfileArgs != nil {
+				v1beta2args.HourlyProfile = tt.hourlyProfileArgs
+			}
 			v1beta2.SetDefaults_LoadAwareSchedulingArgs(&v1beta2args)
 			var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
 			err := v1beta2.Convert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta2args, &loadAwareSchedulingArgs, nil)
@@ -1850,3 +1889,72 @@ func TestScore(t *testing.T) {
 		})
 	}
 }
+
+func newTestHourlyProfilePod(expectedLifetime string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod-1",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "test-container",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("16"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("16"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if expectedLifetime != "" {
+		pod.Annotations = map[string]string{extension.AnnotationPodExpectedLifetime: expectedLifetime}
+	}
+	return pod
+}
+
+// newTestHourlyProfileNodeMetric returns a NodeMetric whose usage will be doubled after hoursLater hours.
+func newTestHourlyProfileNodeMetric(hoursLater int) *slov1alpha1.NodeMetric {
+	now := time.Now()
+	return &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node-1",
+		},
+		Spec: slov1alpha1.NodeMetricSpec{
+			CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
+				ReportIntervalSeconds: pointer.Int64(60),
+			},
+		},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{
+				Time: now,
+			},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				NodeUsage: slov1alpha1.ResourceMap{
+					ResourceList: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("32"),
+						corev1.ResourceMemory: resource.MustParse("10Gi"),
+					},
+				},
+				HourlyUsageProfile: []slov1alpha1.HourlyUsage{
+					{
+						Hour: int32((now.UTC().Hour() + hoursLater) % 24),
+						Usage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("64"),
+								corev1.ResourceMemory: resource.MustParse("10Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
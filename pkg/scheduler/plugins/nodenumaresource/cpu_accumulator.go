@@ -68,7 +68,7 @@ func takeCPUs(
 	}
 
 	fullPCPUs := cpuBindPolicy == schedulingconfig.CPUBindPolicyFullPCPUs
-	if fullPCPUs || acc.topology.CPUsPerCore() == 1 {
+	if fullPCPUs || acc.topology.IsSMTDisabled() {
 		// According to the NUMA allocation strategy,
 		// select the NUMA Node with the most remaining amount or the least amount remaining
 		// and the total amount of available CPUs in the NUMA Node is greater than or equal to the number of CPUs needed
@@ -126,16 +126,18 @@ func takeCPUs(
 			sort.Slice(freeCPUs, func(i, j int) bool {
 				return len(freeCPUs[i]) < len(freeCPUs[j])
 			})
-			cpusPerCore := acc.topology.CPUsPerCore()
 			for _, cpus := range freeCPUs {
-				for i := 0; i < len(cpus); i += cpusPerCore {
+				// the cores may have different number of logical cpus on the heterogeneous CPUs
+				for i := 0; i < len(cpus); {
+					cpusPerCore := acc.numCPUsInCore(acc.topology.CPUDetails[cpus[i]].CoreID)
+					if i+cpusPerCore > len(cpus) || !acc.needs(cpusPerCore) {
+						break
+					}
 					acc.take(cpus[i : i+cpusPerCore]...)
 					if acc.isSatisfied() {
 						return acc.result, nil
 					}
-					if !acc.needs(cpusPerCore) {
-						break
-					}
+					i += cpusPerCore
 				}
 			}
 		}
@@ -206,6 +208,7 @@ type cpuAccumulator struct {
 	exclusiveInNUMANodes sets.Int
 	exclusivePolicy      schedulingconfig.CPUExclusivePolicy
 	numaAllocateStrategy schedulingconfig.NUMAAllocateStrategy
+	cpusInCores          map[int]int
 	result               cpuset.CPUSet
 }
 
@@ -238,6 +241,11 @@ func newCPUAccumulator(
 		}
 	}
 
+	cpusInCores := make(map[int]int)
+	for _, v := range topology.CPUDetails {
+		cpusInCores[v.CoreID]++
+	}
+
 	return &cpuAccumulator{
 		topology:             topology,
 		maxRefCount:          maxRefCount,
@@ -248,10 +256,20 @@ func newCPUAccumulator(
 		exclusivePolicy:      exclusivePolicy,
 		numCPUsNeeded:        numCPUsNeeded,
 		numaAllocateStrategy: numaAllocateStrategy,
+		cpusInCores:          cpusInCores,
 		result:               cpuset.NewCPUSet(),
 	}
 }
 
+// numCPUsInCore returns the number of logical cpus of the core,
+// which may be different between cores on the heterogeneous CPUs.
+func (a *cpuAccumulator) numCPUsInCore(core int) int {
+	if n := a.cpusInCores[core]; n > 0 {
+		return n
+	}
+	return a.topology.CPUsPerCore()
+}
+
 func (a *cpuAccumulator) take(cpus ...int) {
 	a.result = a.result.UnionSlice(cpus...)
 	for _, cpu := range cpus {
@@ -354,7 +372,7 @@ func (a *cpuAccumulator) freeCoresInNode(filterFullFreeCore bool, filterExclusiv
 	coresInNodes := make(map[int][]int)
 	numCPUs := 0
 	for core, cpus := range cpusInCores {
-		if filterFullFreeCore && len(cpus) != a.topology.CPUsPerCore() {
+		if filterFullFreeCore && len(cpus) != a.numCPUsInCore(core) {
 			continue
 		}
 		info := allocatableCPUs[cpus[0]]
@@ -442,7 +460,7 @@ func (a *cpuAccumulator) freeCoresInSocket(filterFullFreeCore bool) [][]int {
 	coresInSockets := make(map[int][]int)
 	numCPUs := 0
 	for core, cpus := range cpusInCores {
-		if filterFullFreeCore && len(cpus) != a.topology.CPUsPerCore() {
+		if filterFullFreeCore && len(cpus) != a.numCPUsInCore(core) {
 			continue
 		}
 		info := allocatableCPUs[cpus[0]]
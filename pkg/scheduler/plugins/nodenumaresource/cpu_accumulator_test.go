@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -771,3 +772,80 @@ func TestTakePreferredCPUs(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []int{11, 13}, result.ToSlice())
 }
+
+func buildHybridCPUTopologyForTest() *CPUTopology {
+	// 4 performance cores with SMT and 8 efficiency cores without SMT
+	builder := NewCPUTopologyBuilder()
+	cpuID := 0
+	for core := 0; core < 4; core++ {
+		for i := 0; i < 2; i++ {
+			builder.AddCPUInfo(0, 0, core, cpuID)
+			builder.SetCoreType(cpuID, extension.CPUCoreTypePerformance)
+			cpuID++
+		}
+	}
+	for core := 4; core < 12; core++ {
+		builder.AddCPUInfo(0, 0, core, cpuID)
+		builder.SetCoreType(cpuID, extension.CPUCoreTypeEfficiency)
+		cpuID++
+	}
+	return builder.Result()
+}
+
+func TestCPUTopologyIsSMTAligned(t *testing.T) {
+	topology := buildCPUTopologyForTest(2, 1, 4, 2)
+	assert.True(t, topology.IsSMTAligned(4))
+	assert.False(t, topology.IsSMTAligned(3))
+
+	// 4 P-cores with 2 threads and 8 E-cores with 1 thread
+	topology = buildHybridCPUTopologyForTest()
+	assert.True(t, topology.IsSMTAligned(3))
+	assert.True(t, topology.IsSMTAligned(16))
+	assert.False(t, topology.IsSMTAligned(17))
+
+	// 4 P-cores with 2 threads and 1 E-core with 4 threads
+	builder := NewCPUTopologyBuilder()
+	cpuID := 0
+	for core := 0; core < 5; core++ {
+		threads, coreType := 2, extension.CPUCoreTypePerformance
+		if core == 4 {
+			threads, coreType = 4, extension.CPUCoreTypeEfficiency
+		}
+		for i := 0; i < threads; i++ {
+			builder.AddCPUInfo(0, 0, core, cpuID)
+			builder.SetCoreType(cpuID, coreType)
+			cpuID++
+		}
+	}
+	topology = builder.Result()
+	assert.True(t, topology.IsSMTAligned(6))
+	assert.False(t, topology.IsSMTAligned(3))
+	assert.True(t, topology.IsSMTAligned(12))
+	assert.False(t, topology.IsSMTAligned(14))
+}
+
+func TestTakeCPUsOnHybridCPUs(t *testing.T) {
+	topology := buildHybridCPUTopologyForTest()
+	assert.False(t, topology.IsSMTDisabled())
+	cpus := topology.CPUDetails.CPUs()
+
+	result, err := takeCPUs(topology, 1, cpus, nil, 4, schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUExclusivePolicyNone, schedulingconfig.NUMAMostAllocated)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, result.ToSlice())
+
+	result, err = takeCPUs(topology, 1, cpus, nil, 4, schedulingconfig.CPUBindPolicySpreadByPCPUs, schedulingconfig.CPUExclusivePolicyNone, schedulingconfig.NUMAMostAllocated)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Size())
+	assert.Equal(t, 4, topology.CPUDetails.KeepOnly(result).Cores().Size())
+
+	assert.Equal(t, cpuset.NewCPUSet(8, 9, 10, 11, 12, 13, 14, 15), topology.CPUDetails.CPUsOfCoreType(extension.CPUCoreTypeEfficiency))
+}
+
+func TestTakeCPUsWithSMTDisabled(t *testing.T) {
+	topology := buildCPUTopologyForTest(2, 1, 4, 1)
+	assert.True(t, topology.IsSMTDisabled())
+	cpus := topology.CPUDetails.CPUs()
+	result, err := takeCPUs(topology, 1, cpus, nil, 3, schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUExclusivePolicyNone, schedulingconfig.NUMAMostAllocated)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, result.ToSlice())
+}
@@ -19,6 +19,7 @@ package nodenumaresource
 import (
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
@@ -30,13 +31,16 @@ type cpuAllocation struct {
 	nodeName      string
 	allocatedPods map[types.UID]cpuset.CPUSet
 	allocatedCPUs CPUDetails
+	// allocatedNUMAMemory records the memory allocated on each NUMA Node of pods
+	allocatedNUMAMemory map[types.UID]map[int]int64
 }
 
 func newCPUAllocation(nodeName string) *cpuAllocation {
 	return &cpuAllocation{
-		nodeName:      nodeName,
-		allocatedPods: map[types.UID]cpuset.CPUSet{},
-		allocatedCPUs: NewCPUDetails(),
+		nodeName:            nodeName,
+		allocatedPods:       map[types.UID]cpuset.CPUSet{},
+		allocatedCPUs:       NewCPUDetails(),
+		allocatedNUMAMemory: map[types.UID]map[int]int64{},
 	}
 }
 
@@ -109,3 +113,44 @@ func (n *cpuAllocation) getAvailableCPUs(cpuTopology *CPUTopology, maxRefCount i
 	availableCPUs = cpuTopology.CPUDetails.CPUs().Difference(allocated).Difference(reservedCPUs)
 	return
 }
+
+func (n *cpuAllocation) updateAllocatedNUMAMemory(podUID types.UID, numaMemory map[int]int64) {
+	if len(numaMemory) == 0 {
+		delete(n.allocatedNUMAMemory, podUID)
+		return
+	}
+	n.allocatedNUMAMemory[podUID] = numaMemory
+}
+
+func (n *cpuAllocation) getNUMAMemory(podUID types.UID) (map[int]int64, bool) {
+	numaMemory, ok := n.allocatedNUMAMemory[podUID]
+	return numaMemory, ok
+}
+
+func (n *cpuAllocation) releaseNUMAMemory(podUID types.UID) {
+	delete(n.allocatedNUMAMemory, podUID)
+}
+
+// getAvailableNUMAMemory returns the free memory of each NUMA Node,
+// the preferredNUMAMemory reserved by the nominated reservation is regarded as free.
+func (n *cpuAllocation) getAvailableNUMAMemory(numaNodeResources map[int]corev1.ResourceList, preferredNUMAMemory map[int]int64) map[int]int64 {
+	available := map[int]int64{}
+	for numaNode, resources := range numaNodeResources {
+		if memory, ok := resources[corev1.ResourceMemory]; ok {
+			available[numaNode] = memory.Value()
+		}
+	}
+	for _, numaMemory := range n.allocatedNUMAMemory {
+		for numaNode, memory := range numaMemory {
+			if _, ok := available[numaNode]; ok {
+				available[numaNode] -= memory
+			}
+		}
+	}
+	for numaNode, memory := range preferredNUMAMemory {
+		if _, ok := available[numaNode]; ok {
+			available[numaNode] += memory
+		}
+	}
+	return available
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
@@ -39,6 +40,9 @@ type CPUManager interface {
 		cpuBindPolicy schedulingconfig.CPUBindPolicy,
 		cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
 		preferredCPUs cpuset.CPUSet,
+		preferredCoreType extension.CPUCoreType,
+		memoryNeeded int64,
+		preferredNUMAMemory map[int]int64,
 	) (cpuset.CPUSet, error)
 
 	UpdateAllocatedCPUSet(nodeName string, podUID types.UID, cpuset cpuset.CPUSet, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy)
@@ -56,6 +60,21 @@ type CPUManager interface {
 	) int64
 
 	GetAvailableCPUs(nodeName string) (availableCPUs cpuset.CPUSet, allocated CPUDetails, err error)
+
+	AllocateNUMAMemory(
+		node *corev1.Node,
+		cpus cpuset.CPUSet,
+		memoryNeeded int64,
+		preferredNUMAMemory map[int]int64,
+	) (map[int]int64, error)
+
+	UpdateAllocatedNUMAMemory(nodeName string, podUID types.UID, numaMemory map[int]int64)
+
+	GetAllocatedNUMAMemory(nodeName string, podUID types.UID) (map[int]int64, bool)
+
+	GetAvailableNUMAMemory(nodeName string, preferredNUMAMemory map[int]int64) map[int]int64
+
+	ScoreNUMAMemory(node *corev1.Node, numCPUsNeeded int, memoryNeeded int64, preferredNUMAMemory map[int]int64) (int64, bool)
 }
 
 type cpuManagerImpl struct {
@@ -119,6 +138,9 @@ func (c *cpuManagerImpl) Allocate(
 	cpuBindPolicy schedulingconfig.CPUBindPolicy,
 	cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy,
 	preferredCPUs cpuset.CPUSet,
+	preferredCoreType extension.CPUCoreType,
+	memoryNeeded int64,
+	preferredNUMAMemory map[int]int64,
 ) (cpuset.CPUSet, error) {
 	result := cpuset.CPUSet{}
 	// The Pod requires the CPU to be allocated according to CPUBindPolicy,
@@ -139,6 +161,10 @@ func (c *cpuManagerImpl) Allocate(
 	defer allocation.lock.Unlock()
 
 	availableCPUs, allocated := allocation.getAvailableCPUs(cpuTopologyOptions.CPUTopology, cpuTopologyOptions.MaxRefCount, reservedCPUs, preferredCPUs)
+	if memoryNeeded > 0 && len(cpuTopologyOptions.NUMANodeResources) > 0 {
+		availableNUMAMemory := allocation.getAvailableNUMAMemory(cpuTopologyOptions.NUMANodeResources, preferredNUMAMemory)
+		availableCPUs = filterCPUsByNUMAMemory(cpuTopologyOptions.CPUTopology, availableCPUs, preferredCPUs, numCPUsNeeded, memoryNeeded, availableNUMAMemory)
+	}
 	numaAllocateStrategy := c.getNUMAAllocateStrategy(node)
 	if !preferredCPUs.IsEmpty() {
 		var err error
@@ -159,16 +185,37 @@ func (c *cpuManagerImpl) Allocate(
 		availableCPUs = availableCPUs.Difference(preferredCPUs)
 	}
 	if numCPUsNeeded > 0 {
-		cpus, err := takeCPUs(
-			cpuTopologyOptions.CPUTopology,
-			cpuTopologyOptions.MaxRefCount,
-			availableCPUs,
-			allocated,
-			numCPUsNeeded,
-			cpuBindPolicy,
-			cpuExclusivePolicy,
-			numaAllocateStrategy,
-		)
+		var cpus cpuset.CPUSet
+		var err error
+		// Try to allocate CPUs from the preferred type of cores first,
+		// and fall back to all the available CPUs if the preferred cores are insufficient.
+		if preferredCoreType != "" {
+			coreTypeCPUs := availableCPUs.Intersection(cpuTopologyOptions.CPUTopology.CPUDetails.CPUsOfCoreType(preferredCoreType))
+			if coreTypeCPUs.Size() >= numCPUsNeeded {
+				cpus, err = takeCPUs(
+					cpuTopologyOptions.CPUTopology,
+					cpuTopologyOptions.MaxRefCount,
+					coreTypeCPUs,
+					allocated,
+					numCPUsNeeded,
+					cpuBindPolicy,
+					cpuExclusivePolicy,
+					numaAllocateStrategy,
+				)
+			}
+		}
+		if cpus.IsEmpty() {
+			cpus, err = takeCPUs(
+				cpuTopologyOptions.CPUTopology,
+				cpuTopologyOptions.MaxRefCount,
+				availableCPUs,
+				allocated,
+				numCPUsNeeded,
+				cpuBindPolicy,
+				cpuExclusivePolicy,
+				numaAllocateStrategy,
+			)
+		}
 		if err != nil {
 			return cpuset.CPUSet{}, err
 		}
@@ -181,6 +228,39 @@ func (c *cpuManagerImpl) Allocate(
 	return result, nil
 }
 
+// filterCPUsByNUMAMemory removes the CPUs on the NUMA Nodes which can not hold the requested memory
+// if the requested CPUs can be aligned in one NUMA Node. The CPUs are kept as is if no NUMA Node has enough
+// memory, and the following NUMA memory allocation will fail.
+func filterCPUsByNUMAMemory(
+	topology *CPUTopology,
+	availableCPUs cpuset.CPUSet,
+	preferredCPUs cpuset.CPUSet,
+	numCPUsNeeded int,
+	memoryNeeded int64,
+	availableNUMAMemory map[int]int64,
+) cpuset.CPUSet {
+	if numCPUsNeeded > topology.CPUsPerNode() {
+		return availableCPUs
+	}
+	var nodeIDs []int
+	for _, nodeID := range topology.CPUDetails.NUMANodes().ToSliceNoSort() {
+		if availableNUMAMemory[numaNodeIDOf(nodeID)] >= memoryNeeded {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	if len(nodeIDs) == 0 {
+		return availableCPUs
+	}
+	filtered := topology.CPUDetails.CPUsInNUMANodes(nodeIDs...).Union(preferredCPUs)
+	return availableCPUs.Intersection(filtered)
+}
+
+// numaNodeIDOf returns the NUMA Node ID reported by the node from the NodeID in CPUTopology,
+// which is combined with the socket ID by CPUTopologyBuilder.
+func numaNodeIDOf(nodeID int) int {
+	return nodeID & 0xffff
+}
+
 func (c *cpuManagerImpl) UpdateAllocatedCPUSet(nodeName string, podUID types.UID, cpuset cpuset.CPUSet, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy) {
 	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(nodeName)
 	if cpuTopologyOptions.CPUTopology == nil || !cpuTopologyOptions.CPUTopology.IsValid() {
@@ -207,6 +287,7 @@ func (c *cpuManagerImpl) Free(nodeName string, podUID types.UID) {
 	allocation.lock.Lock()
 	defer allocation.lock.Unlock()
 	allocation.releaseCPUs(podUID)
+	allocation.releaseNUMAMemory(podUID)
 }
 
 func (c *cpuManagerImpl) Score(node *corev1.Node, numCPUsNeeded int, cpuBindPolicy schedulingconfig.CPUBindPolicy, cpuExclusivePolicy schedulingconfig.CPUExclusivePolicy, preferredCPUs cpuset.CPUSet) int64 {
@@ -333,3 +414,123 @@ func (c *cpuManagerImpl) GetAvailableCPUs(nodeName string) (availableCPUs cpuset
 	availableCPUs, allocated = allocation.getAvailableCPUs(cpuTopologyOptions.CPUTopology, cpuTopologyOptions.MaxRefCount, cpuTopologyOptions.ReservedCPUs, emptyCPUs)
 	return availableCPUs, allocated, nil
 }
+
+// AllocateNUMAMemory allocates the memory from the NUMA Nodes where the allocated CPUs located.
+// It returns nil if the node does not report the resources of NUMA Nodes.
+func (c *cpuManagerImpl) AllocateNUMAMemory(
+	node *corev1.Node,
+	cpus cpuset.CPUSet,
+	memoryNeeded int64,
+	preferredNUMAMemory map[int]int64,
+) (map[int]int64, error) {
+	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(node.Name)
+	if len(cpuTopologyOptions.NUMANodeResources) == 0 || memoryNeeded <= 0 || cpus.IsEmpty() {
+		return nil, nil
+	}
+	if cpuTopologyOptions.CPUTopology == nil || !cpuTopologyOptions.CPUTopology.IsValid() {
+		return nil, errors.New(ErrInvalidCPUTopology)
+	}
+
+	allocation := c.getOrCreateAllocation(node.Name)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	available := allocation.getAvailableNUMAMemory(cpuTopologyOptions.NUMANodeResources, preferredNUMAMemory)
+	numaNodes := cpuTopologyOptions.CPUTopology.CPUDetails.KeepOnly(cpus).NUMANodes().ToSlice()
+	result := map[int]int64{}
+	for _, nodeID := range numaNodes {
+		numaNode := numaNodeIDOf(nodeID)
+		if memoryNeeded <= 0 {
+			break
+		}
+		free := available[numaNode]
+		if free <= 0 {
+			continue
+		}
+		if free > memoryNeeded {
+			free = memoryNeeded
+		}
+		result[numaNode] = free
+		memoryNeeded -= free
+	}
+	if memoryNeeded > 0 {
+		return nil, errors.New(ErrInsufficientNUMAMemory)
+	}
+	return result, nil
+}
+
+func (c *cpuManagerImpl) UpdateAllocatedNUMAMemory(nodeName string, podUID types.UID, numaMemory map[int]int64) {
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	allocation.updateAllocatedNUMAMemory(podUID, numaMemory)
+}
+
+func (c *cpuManagerImpl) GetAllocatedNUMAMemory(nodeName string, podUID types.UID) (map[int]int64, bool) {
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	return allocation.getNUMAMemory(podUID)
+}
+
+func (c *cpuManagerImpl) GetAvailableNUMAMemory(nodeName string, preferredNUMAMemory map[int]int64) map[int]int64 {
+	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(nodeName)
+	if len(cpuTopologyOptions.NUMANodeResources) == 0 {
+		return nil
+	}
+
+	allocation := c.getOrCreateAllocation(nodeName)
+	allocation.lock.Lock()
+	defer allocation.lock.Unlock()
+
+	return allocation.getAvailableNUMAMemory(cpuTopologyOptions.NUMANodeResources, preferredNUMAMemory)
+}
+
+// ScoreNUMAMemory scores the node with the free memory of the NUMA Nodes which may be used by the pod.
+// The second return value is false if the node does not report the resources of NUMA Nodes.
+func (c *cpuManagerImpl) ScoreNUMAMemory(node *corev1.Node, numCPUsNeeded int, memoryNeeded int64, preferredNUMAMemory map[int]int64) (int64, bool) {
+	cpuTopologyOptions := c.topologyManager.GetCPUTopologyOptions(node.Name)
+	if memoryNeeded <= 0 || cpuTopologyOptions.CPUTopology == nil || !cpuTopologyOptions.CPUTopology.IsValid() {
+		return 0, false
+	}
+	available := c.GetAvailableNUMAMemory(node.Name, preferredNUMAMemory)
+	if len(available) == 0 {
+		return 0, false
+	}
+
+	scoreFn := mostRequestedScore
+	if c.getNUMAAllocateStrategy(node) == schedulingconfig.NUMALeastAllocated {
+		scoreFn = leastRequestedScore
+	}
+	numNUMANodesNeeded := numNUMANodesNeededByCPUs(cpuTopologyOptions.CPUTopology, numCPUsNeeded)
+	return scoreFn(memoryNeeded, maxFreeNUMAMemory(available, numNUMANodesNeeded)), true
+}
+
+// numNUMANodesNeededByCPUs returns the minimum number of NUMA Nodes to hold the requested CPUs.
+func numNUMANodesNeededByCPUs(topology *CPUTopology, numCPUsNeeded int) int {
+	cpusPerNode := topology.CPUsPerNode()
+	if cpusPerNode <= 0 || numCPUsNeeded <= 0 {
+		return 1
+	}
+	return (numCPUsNeeded + cpusPerNode - 1) / cpusPerNode
+}
+
+// maxFreeNUMAMemory returns the sum of free memory of the n NUMA Nodes that have the most free memory.
+func maxFreeNUMAMemory(available map[int]int64, n int) int64 {
+	free := make([]int64, 0, len(available))
+	for _, v := range available {
+		free = append(free, v)
+	}
+	sort.Slice(free, func(i, j int) bool {
+		return free[i] > free[j]
+	})
+	var total int64
+	for i := 0; i < n && i < len(free); i++ {
+		if free[i] > 0 {
+			total += free[i]
+		}
+	}
+	return total
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package nodenumaresource
 
 import (
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingconfig "github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -28,6 +29,8 @@ type CPUTopology struct {
 	NumNodes   int        `json:"numNodes"`
 	NumSockets int        `json:"numSockets"`
 	CPUDetails CPUDetails `json:"cpuDetails"`
+	// SMTDisabled is reported by koordlet if each physical core has only one logical CPU online.
+	SMTDisabled bool `json:"smtDisabled,omitempty"`
 }
 
 type CPUTopologyBuilder struct {
@@ -70,6 +73,15 @@ func (b *CPUTopologyBuilder) AddCPUInfo(socketID, nodeID, coreID, cpuID int) *CP
 	return b
 }
 
+// SetCoreType sets the type of the physical core which the cpu belongs to.
+func (b *CPUTopologyBuilder) SetCoreType(cpuID int, coreType extension.CPUCoreType) *CPUTopologyBuilder {
+	if info, ok := b.topology.CPUDetails[cpuID]; ok {
+		info.CoreType = coreType
+		b.topology.CPUDetails[cpuID] = info
+	}
+	return b
+}
+
 func (b *CPUTopologyBuilder) Result() *CPUTopology {
 	return &b.topology
 }
@@ -79,6 +91,57 @@ func (topo *CPUTopology) IsValid() bool {
 	return topo.NumSockets != 0 && topo.NumNodes != 0 && topo.NumCores != 0 && topo.NumCPUs != 0
 }
 
+// IsSMTDisabled returns true if each physical core has only one logical CPU,
+// in which case the FullPCPUs and SpreadByPCPUs policies allocate the same CPUs.
+func (topo *CPUTopology) IsSMTDisabled() bool {
+	return topo.SMTDisabled || (topo.NumCores != 0 && topo.NumCPUs == topo.NumCores)
+}
+
+// IsSMTAligned returns true if the number of CPUs can be allocated by full physical cores.
+// The heterogeneous CPUs may have different numbers of logical CPUs in different types of cores,
+// e.g. the P-cores have 2 threads while the E-cores have 1 thread, so the number is aligned
+// if it can be composed of the full cores of all the types.
+func (topo *CPUTopology) IsSMTAligned(numCPUs int) bool {
+	coresOfTypes := map[extension.CPUCoreType]map[int]int{}
+	for _, info := range topo.CPUDetails {
+		cores := coresOfTypes[info.CoreType]
+		if cores == nil {
+			cores = map[int]int{}
+			coresOfTypes[info.CoreType] = cores
+		}
+		cores[info.CoreID]++
+	}
+	if len(coresOfTypes) <= 1 {
+		cpusPerCore := topo.CPUsPerCore()
+		return cpusPerCore > 0 && numCPUs%cpusPerCore == 0
+	}
+
+	// reachable[n] indicates whether n CPUs can be composed of the full cores of the visited types
+	reachable := make([]bool, numCPUs+1)
+	reachable[0] = true
+	for _, cores := range coresOfTypes {
+		numCPUsOfType := 0
+		for _, n := range cores {
+			numCPUsOfType += n
+		}
+		cpusPerCore := numCPUsOfType / len(cores)
+		if cpusPerCore == 0 {
+			continue
+		}
+		next := make([]bool, numCPUs+1)
+		for n := range reachable {
+			if !reachable[n] {
+				continue
+			}
+			for k := 0; k <= len(cores) && n+k*cpusPerCore <= numCPUs; k++ {
+				next[n+k*cpusPerCore] = true
+			}
+		}
+		reachable = next
+	}
+	return reachable[numCPUs]
+}
+
 // CPUsPerCore returns the number of logical CPUs are associated with each core.
 func (topo *CPUTopology) CPUsPerCore() int {
 	if topo.NumCores == 0 {
@@ -119,6 +182,7 @@ type CPUInfo struct {
 	SocketID        int                                 `json:"socketID"`
 	RefCount        int                                 `json:"refCount"`
 	ExclusivePolicy schedulingconfig.CPUExclusivePolicy `json:"exclusivePolicy"`
+	CoreType        extension.CPUCoreType               `json:"coreType,omitempty"`
 }
 
 // Clone clones the CPUDetails
@@ -267,3 +331,14 @@ func (d CPUDetails) CPUsInCores(ids ...int) cpuset.CPUSet {
 	}
 	return b.Result()
 }
+
+// CPUsOfCoreType returns the logical CPU IDs associated with the given core type in this CPUDetails.
+func (d CPUDetails) CPUsOfCoreType(coreType extension.CPUCoreType) cpuset.CPUSet {
+	b := cpuset.NewCPUSetBuilder()
+	for cpu, info := range d {
+		if info.CoreType == coreType {
+			b.Add(cpu)
+		}
+	}
+	return b.Result()
+}
@@ -24,6 +24,8 @@ import (
 
 	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
@@ -142,3 +144,47 @@ func TestCPUTopologyManager(t *testing.T) {
 	cpuTopologyOptions = topologyManager.GetCPUTopologyOptions(nodeName)
 	assert.Equal(t, CPUTopologyOptions{}, cpuTopologyOptions)
 }
+
+func TestExtractNUMANodeResources(t *testing.T) {
+	topology := &nrtv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node-1",
+		},
+		Zones: nrtv1alpha1.ZoneList{
+			{
+				Name: "node-0",
+				Type: "Node",
+				Resources: nrtv1alpha1.ResourceInfoList{
+					{
+						Name:        "memory",
+						Capacity:    resource.MustParse("32Gi"),
+						Allocatable: resource.MustParse("30Gi"),
+						Available:   resource.MustParse("30Gi"),
+					},
+				},
+			},
+			{
+				Name: "node-1",
+				Type: "Node",
+				Resources: nrtv1alpha1.ResourceInfoList{
+					{
+						Name:        "memory",
+						Capacity:    resource.MustParse("32Gi"),
+						Allocatable: resource.MustParse("32Gi"),
+						Available:   resource.MustParse("32Gi"),
+					},
+				},
+			},
+			{
+				Name: "socket-0",
+				Type: "Socket",
+			},
+		},
+	}
+	expected := map[int]corev1.ResourceList{
+		0: {corev1.ResourceMemory: resource.MustParse("30Gi")},
+		1: {corev1.ResourceMemory: resource.MustParse("32Gi")},
+	}
+	assert.Equal(t, expected, extractNUMANodeResources(topology))
+	assert.Nil(t, extractNUMANodeResources(&nrtv1alpha1.NodeResourceTopology{}))
+}
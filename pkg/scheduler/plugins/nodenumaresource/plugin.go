@@ -21,9 +21,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
@@ -54,6 +56,7 @@ const (
 	ErrInvalidCPUTopology      = "node(s) invalid CPU Topology"
 	ErrSMTAlignmentError       = "node(s) requested cpus not multiple cpus per core"
 	ErrRequiredFullPCPUsPolicy = "node(s) required FullPCPUs policy"
+	ErrInsufficientNUMAMemory  = "node(s) insufficient NUMA memory"
 )
 
 var (
@@ -156,7 +159,9 @@ type preFilterState struct {
 	preferredCPUBindPolicy      schedulingconfig.CPUBindPolicy
 	preferredCPUExclusivePolicy schedulingconfig.CPUExclusivePolicy
 	numCPUsNeeded               int
+	memoryNeeded                int64
 	allocatedCPUs               cpuset.CPUSet
+	allocatedNUMAMemory         map[int]int64
 }
 
 func (s *preFilterState) Clone() framework.StateData {
@@ -166,8 +171,15 @@ func (s *preFilterState) Clone() framework.StateData {
 		preferredCPUBindPolicy:      s.preferredCPUBindPolicy,
 		preferredCPUExclusivePolicy: s.preferredCPUExclusivePolicy,
 		numCPUsNeeded:               s.numCPUsNeeded,
+		memoryNeeded:                s.memoryNeeded,
 		allocatedCPUs:               s.allocatedCPUs.Clone(),
 	}
+	if s.allocatedNUMAMemory != nil {
+		ns.allocatedNUMAMemory = make(map[int]int64, len(s.allocatedNUMAMemory))
+		for numaNode, memory := range s.allocatedNUMAMemory {
+			ns.allocatedNUMAMemory[numaNode] = memory
+		}
+	}
 	return ns
 }
 
@@ -198,7 +210,7 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 	state := &preFilterState{
 		skip: true,
 	}
-	if AllowUseCPUSet(pod) {
+	if extension.AllowUseCPUSet(pod) {
 		preferredCPUBindPolicy := schedulingconfig.CPUBindPolicy(resourceSpec.PreferredCPUBindPolicy)
 		if preferredCPUBindPolicy == "" || preferredCPUBindPolicy == schedulingconfig.CPUBindPolicyDefault {
 			preferredCPUBindPolicy = p.pluginArgs.DefaultCPUBindPolicy
@@ -217,6 +229,7 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 				state.preferredCPUBindPolicy = preferredCPUBindPolicy
 				state.preferredCPUExclusivePolicy = resourceSpec.PreferredCPUExclusivePolicy
 				state.numCPUsNeeded = int(requestedCPU / 1000)
+				state.memoryNeeded = requests.Memory().Value()
 			}
 		}
 	}
@@ -225,15 +238,6 @@ func (p *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 	return nil, nil
 }
 
-func AllowUseCPUSet(pod *corev1.Pod) bool {
-	if pod == nil {
-		return false
-	}
-	qosClass := extension.GetPodQoSClassRaw(pod)
-	priorityClass := extension.GetPodPriorityClassWithDefault(pod)
-	return (qosClass == extension.QoSLSE || qosClass == extension.QoSLSR) && priorityClass == extension.PriorityProd
-}
-
 func (p *Plugin) PreFilterExtensions() framework.PreFilterExtensions {
 	return nil
 }
@@ -266,14 +270,35 @@ func (p *Plugin) Filter(ctx context.Context, cycleState *framework.CycleState, p
 
 	kubeletCPUPolicy := cpuTopologyOptions.Policy
 	if extension.GetNodeCPUBindPolicy(node.Labels, kubeletCPUPolicy) == extension.NodeCPUBindPolicyFullPCPUsOnly {
-		if state.numCPUsNeeded%cpuTopologyOptions.CPUTopology.CPUsPerCore() != 0 {
+		if !cpuTopologyOptions.CPUTopology.IsSMTAligned(state.numCPUsNeeded) {
 			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrSMTAlignmentError)
 		}
-		if state.preferredCPUBindPolicy != schedulingconfig.CPUBindPolicyFullPCPUs {
+		// The FullPCPUs and SpreadByPCPUs policies are the same if SMT is disabled,
+		// and the CPU bind policy will be overwritten as FullPCPUs in Reserve.
+		if state.preferredCPUBindPolicy != schedulingconfig.CPUBindPolicyFullPCPUs &&
+			!cpuTopologyOptions.CPUTopology.IsSMTDisabled() {
 			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrRequiredFullPCPUsPolicy)
 		}
 	}
 
+	if state.memoryNeeded > 0 && len(cpuTopologyOptions.NUMANodeResources) > 0 {
+		// The nominated reservation is unknown in Filter, so all the memory reserved by
+		// the matched reservations on the node is regarded as available.
+		reservationRestoreState := getReservationRestoreState(cycleState)
+		nodeReservationRestoreState := reservationRestoreState.getNodeState(node.Name)
+		reservedNUMAMemory := map[int]int64{}
+		for _, numaMemory := range nodeReservationRestoreState.reservedNUMAMemory {
+			for numaNode, memory := range numaMemory {
+				reservedNUMAMemory[numaNode] += memory
+			}
+		}
+		available := p.cpuManager.GetAvailableNUMAMemory(node.Name, reservedNUMAMemory)
+		numNUMANodesNeeded := numNUMANodesNeededByCPUs(cpuTopologyOptions.CPUTopology, state.numCPUsNeeded)
+		if maxFreeNUMAMemory(available, numNUMANodesNeeded) < state.memoryNeeded {
+			return framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAMemory)
+		}
+	}
+
 	return nil
 }
 
@@ -305,6 +330,10 @@ func (p *Plugin) Score(ctx context.Context, cycleState *framework.CycleState, po
 		return 0, framework.AsStatus(err)
 	}
 	score := p.cpuManager.Score(node, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy, reservationReservedCPUs)
+	reservationReservedNUMAMemory := p.getReservationReservedNUMAMemory(cycleState, pod, node)
+	if memoryScore, ok := p.cpuManager.ScoreNUMAMemory(node, state.numCPUsNeeded, state.memoryNeeded, reservationReservedNUMAMemory); ok {
+		score = (score + memoryScore) / 2
+	}
 	return score, nil
 }
 
@@ -339,12 +368,23 @@ func (p *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 	if err != nil {
 		return framework.AsStatus(err)
 	}
-	result, err := p.cpuManager.Allocate(node, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy, reservationReservedCPUs)
+	reservationReservedNUMAMemory := p.getReservationReservedNUMAMemory(cycleState, pod, node)
+	var preferredCPUCoreType extension.CPUCoreType
+	if state.resourceSpec != nil {
+		preferredCPUCoreType = state.resourceSpec.PreferredCPUCoreType
+	}
+	result, err := p.cpuManager.Allocate(node, state.numCPUsNeeded, preferredCPUBindPolicy, state.preferredCPUExclusivePolicy, reservationReservedCPUs, preferredCPUCoreType, state.memoryNeeded, reservationReservedNUMAMemory)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	numaMemory, err := p.cpuManager.AllocateNUMAMemory(node, result, state.memoryNeeded, reservationReservedNUMAMemory)
 	if err != nil {
 		return framework.AsStatus(err)
 	}
 	p.cpuManager.UpdateAllocatedCPUSet(nodeName, pod.UID, result, state.preferredCPUExclusivePolicy)
+	p.cpuManager.UpdateAllocatedNUMAMemory(nodeName, pod.UID, numaMemory)
 	state.allocatedCPUs = result
+	state.allocatedNUMAMemory = numaMemory
 	state.preferredCPUBindPolicy = preferredCPUBindPolicy
 	return nil
 }
@@ -372,6 +412,19 @@ func (p *Plugin) getReservationReservedCPUs(cycleState *framework.CycleState, po
 	return reservedCPUs, nil
 }
 
+func (p *Plugin) getReservationReservedNUMAMemory(cycleState *framework.CycleState, pod *corev1.Pod, node *corev1.Node) map[int]int64 {
+	if reservationutil.IsReservePod(pod) {
+		return nil
+	}
+	nominatedReservation := frameworkext.GetNominatedReservation(cycleState, node.Name)
+	if nominatedReservation == nil {
+		return nil
+	}
+	reservationRestoreState := getReservationRestoreState(cycleState)
+	nodeReservationRestoreState := reservationRestoreState.getNodeState(node.Name)
+	return nodeReservationRestoreState.reservedNUMAMemory[nominatedReservation.UID()]
+}
+
 func (p *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) {
 	state, status := getPreFilterState(cycleState)
 	if !status.IsSuccess() {
@@ -425,6 +478,21 @@ func (p *Plugin) preBindObject(ctx context.Context, cycleState *framework.CycleS
 	}
 
 	resourceStatus := &extension.ResourceStatus{CPUSet: state.allocatedCPUs.String()}
+	if len(state.allocatedNUMAMemory) > 0 {
+		numaNodes := make([]int, 0, len(state.allocatedNUMAMemory))
+		for numaNode := range state.allocatedNUMAMemory {
+			numaNodes = append(numaNodes, numaNode)
+		}
+		sort.Ints(numaNodes)
+		for _, numaNode := range numaNodes {
+			resourceStatus.NUMANodeResources = append(resourceStatus.NUMANodeResources, extension.NUMANodeResource{
+				Node: int32(numaNode),
+				Resources: corev1.ResourceList{
+					corev1.ResourceMemory: *resource.NewQuantity(state.allocatedNUMAMemory[numaNode], resource.BinarySI),
+				},
+			})
+		}
+	}
 	if err := extension.SetResourceStatus(object, resourceStatus); err != nil {
 		return framework.AsStatus(err)
 	}
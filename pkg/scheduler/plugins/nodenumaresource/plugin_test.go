@@ -535,6 +535,44 @@ func TestPlugin_Filter(t *testing.T) {
 			pod:  &corev1.Pod{},
 			want: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrRequiredFullPCPUsPolicy),
 		},
+		{
+			name: "succeed with Kubelet FullPCPUsOnly and SpreadByPCPUs when SMT is disabled",
+			state: &preFilterState{
+				skip:                   false,
+				resourceSpec:           &extension.ResourceSpec{},
+				preferredCPUBindPolicy: schedulingconfig.CPUBindPolicySpreadByPCPUs,
+				numCPUsNeeded:          3,
+			},
+			cpuTopology:     buildCPUTopologyForTest(2, 1, 4, 1),
+			allocationState: newCPUAllocation("test-node-1"),
+			kubeletPolicy: &extension.KubeletCPUManagerPolicy{
+				Policy: extension.KubeletCPUManagerPolicyStatic,
+				Options: map[string]string{
+					extension.KubeletCPUManagerPolicyFullPCPUsOnlyOption: "true",
+				},
+			},
+			pod:  &corev1.Pod{},
+			want: nil,
+		},
+		{
+			name: "succeed with Kubelet FullPCPUsOnly on the heterogeneous CPUs aligned by the E-cores",
+			state: &preFilterState{
+				skip:                   false,
+				resourceSpec:           &extension.ResourceSpec{},
+				preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+				numCPUsNeeded:          3,
+			},
+			cpuTopology:     buildHybridCPUTopologyForTest(),
+			allocationState: newCPUAllocation("test-node-1"),
+			kubeletPolicy: &extension.KubeletCPUManagerPolicy{
+				Policy: extension.KubeletCPUManagerPolicyStatic,
+				Options: map[string]string{
+					extension.KubeletCPUManagerPolicyFullPCPUsOnlyOption: "true",
+				},
+			},
+			pod:  &corev1.Pod{},
+			want: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1099,6 +1137,131 @@ func TestPlugin_Unreserve(t *testing.T) {
 	assert.Equal(t, cpuTopology.CPUDetails.CPUs().ToSlice(), availableCPUs.ToSlice())
 }
 
+func TestCPUManager_AllocateWithPreferredCoreType(t *testing.T) {
+	topologyManager := NewCPUTopologyManager()
+	topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+		options.CPUTopology = buildHybridCPUTopologyForTest()
+	})
+	cpuManager := &cpuManagerImpl{
+		numaAllocateStrategy: schedulingconfig.NUMAMostAllocated,
+		topologyManager:      topologyManager,
+		allocationStates:     map[string]*cpuAllocation{},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node-1"}}
+
+	result, err := cpuManager.Allocate(node, 4, schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUExclusivePolicyNone, cpuset.NewCPUSet(), extension.CPUCoreTypeEfficiency, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{8, 9, 10, 11}, result.ToSlice())
+
+	// fall back to all cores if the preferred cores are insufficient
+	cpuManager.UpdateAllocatedCPUSet("test-node-1", uuid.NewUUID(), cpuset.NewCPUSet(0, 1, 2, 3, 4, 5), schedulingconfig.CPUExclusivePolicyNone)
+	result, err = cpuManager.Allocate(node, 4, schedulingconfig.CPUBindPolicyFullPCPUs, schedulingconfig.CPUExclusivePolicyNone, cpuset.NewCPUSet(), extension.CPUCoreTypePerformance, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Size())
+	assert.False(t, result.Intersection(cpuset.NewCPUSet(0, 1, 2, 3, 4, 5)).Size() > 0)
+}
+
+func memoryQuantity(s string) int64 {
+	q := resource.MustParse(s)
+	return q.Value()
+}
+
+func TestPlugin_NUMAMemory(t *testing.T) {
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "test-node-1",
+				Labels: map[string]string{},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("16"),
+					corev1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+			},
+		},
+	}
+	suit := newPluginTestSuit(t, nodes)
+	p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
+	assert.NotNil(t, p)
+	assert.Nil(t, err)
+	plg := p.(*Plugin)
+
+	cpuTopology := buildCPUTopologyForTest(2, 1, 4, 2)
+	plg.topologyManager.UpdateCPUTopologyOptions("test-node-1", func(options *CPUTopologyOptions) {
+		options.CPUTopology = cpuTopology
+		options.NUMANodeResources = map[int]corev1.ResourceList{
+			0: {corev1.ResourceMemory: resource.MustParse("32Gi")},
+			1: {corev1.ResourceMemory: resource.MustParse("32Gi")},
+		}
+	})
+	// the NUMA Node 0 has only 8Gi free memory
+	plg.cpuManager.UpdateAllocatedNUMAMemory("test-node-1", uuid.NewUUID(), map[int]int64{0: 24 * 1024 * 1024 * 1024})
+
+	suit.start()
+
+	nodeInfo, err := suit.Handle.SnapshotSharedLister().NodeInfos().Get("test-node-1")
+	assert.NoError(t, err)
+
+	newState := func(memory string) *preFilterState {
+		return &preFilterState{
+			skip:                   false,
+			resourceSpec:           &extension.ResourceSpec{PreferredCPUBindPolicy: extension.CPUBindPolicyFullPCPUs},
+			preferredCPUBindPolicy: schedulingconfig.CPUBindPolicyFullPCPUs,
+			numCPUsNeeded:          4,
+			memoryNeeded:           memoryQuantity(memory),
+		}
+	}
+
+	cycleState := framework.NewCycleState()
+	cycleState.Write(stateKey, newState("40Gi"))
+	status := plg.Filter(context.TODO(), cycleState, &corev1.Pod{}, nodeInfo)
+	assert.Equal(t, framework.NewStatus(framework.Unschedulable, ErrInsufficientNUMAMemory), status)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Namespace: "default",
+			Name:      "test-pod-1",
+		},
+	}
+	state := newState("16Gi")
+	cycleState = framework.NewCycleState()
+	cycleState.Write(stateKey, state)
+	status = plg.Filter(context.TODO(), cycleState, pod, nodeInfo)
+	assert.True(t, status.IsSuccess())
+
+	status = plg.Reserve(context.TODO(), cycleState, pod, "test-node-1")
+	assert.True(t, status.IsSuccess(), status.Message())
+	assert.Equal(t, cpuset.NewCPUSet(8, 9, 10, 11), state.allocatedCPUs)
+	expectNUMAMemory := map[int]int64{1: 16 * 1024 * 1024 * 1024}
+	assert.Equal(t, expectNUMAMemory, state.allocatedNUMAMemory)
+	numaMemory, ok := plg.cpuManager.GetAllocatedNUMAMemory("test-node-1", pod.UID)
+	assert.True(t, ok)
+	assert.Equal(t, expectNUMAMemory, numaMemory)
+
+	status = plg.PreBind(context.TODO(), cycleState, pod, "test-node-1")
+	assert.True(t, status.IsSuccess())
+	resourceStatus, err := extension.GetResourceStatus(pod.Annotations)
+	assert.NoError(t, err)
+	expectResourceStatus := &extension.ResourceStatus{
+		CPUSet: "8-11",
+		NUMANodeResources: []extension.NUMANodeResource{
+			{
+				Node: 1,
+				Resources: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectResourceStatus, resourceStatus)
+
+	plg.Unreserve(context.TODO(), cycleState, pod, "test-node-1")
+	_, ok = plg.cpuManager.GetAllocatedNUMAMemory("test-node-1", pod.UID)
+	assert.False(t, ok)
+}
+
 func TestPlugin_PreBind(t *testing.T) {
 	suit := newPluginTestSuit(t, nil)
 	p, err := suit.proxyNew(suit.nodeNUMAResourceArgs, suit.Handle)
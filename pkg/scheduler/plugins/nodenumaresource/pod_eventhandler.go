@@ -115,6 +115,16 @@ func (c *podEventHandler) updatePod(oldPod, pod *corev1.Pod) {
 	}
 
 	c.cpuManager.UpdateAllocatedCPUSet(pod.Spec.NodeName, pod.UID, cpus, resourceSpec.PreferredCPUExclusivePolicy)
+
+	if len(resourceStatus.NUMANodeResources) > 0 {
+		numaMemory := map[int]int64{}
+		for _, numaNodeResource := range resourceStatus.NUMANodeResources {
+			if memory, ok := numaNodeResource.Resources[corev1.ResourceMemory]; ok {
+				numaMemory[int(numaNodeResource.Node)] = memory.Value()
+			}
+		}
+		c.cpuManager.UpdateAllocatedNUMAMemory(pod.Spec.NodeName, pod.UID, numaMemory)
+	}
 }
 
 func (c *podEventHandler) deletePod(pod *corev1.Pod) {
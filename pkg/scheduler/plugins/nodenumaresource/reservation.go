@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
@@ -35,7 +36,8 @@ type reservationRestoreStateData struct {
 }
 
 type nodeReservationRestoreStateData struct {
-	reservedCPUs map[types.UID]cpuset.CPUSet
+	reservedCPUs       map[types.UID]cpuset.CPUSet
+	reservedNUMAMemory map[types.UID]map[int]int64
 }
 
 func getReservationRestoreState(cycleState *framework.CycleState) *reservationRestoreStateData {
@@ -67,7 +69,7 @@ func (s *reservationRestoreStateData) getNodeState(nodeName string) *nodeReserva
 
 func (p *Plugin) PreRestoreReservation(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod) *framework.Status {
 	state := &reservationRestoreStateData{
-		skip: !AllowUseCPUSet(pod),
+		skip: !extension.AllowUseCPUSet(pod),
 	}
 	cycleState.Write(reservationRestoreStateKey, state)
 	return nil
@@ -103,12 +105,45 @@ func (p *Plugin) RestoreReservation(ctx context.Context, cycleState *framework.C
 		}
 	}
 
-	if len(reservedCPUs) == 0 {
+	reservedNUMAMemory := map[types.UID]map[int]int64{}
+	for _, rInfo := range matched {
+		allocatedNUMAMemory, ok := p.cpuManager.GetAllocatedNUMAMemory(nodeName, rInfo.UID())
+		if !ok || len(allocatedNUMAMemory) == 0 {
+			continue
+		}
+
+		remaining := make(map[int]int64, len(allocatedNUMAMemory))
+		for numaNode, memory := range allocatedNUMAMemory {
+			remaining[numaNode] = memory
+		}
+		for _, pod := range rInfo.AssignedPods {
+			podNUMAMemory, ok := p.cpuManager.GetAllocatedNUMAMemory(nodeName, pod.UID)
+			if !ok {
+				continue
+			}
+			for numaNode, memory := range podNUMAMemory {
+				if _, ok := remaining[numaNode]; ok {
+					remaining[numaNode] -= memory
+				}
+			}
+		}
+		for numaNode, memory := range remaining {
+			if memory <= 0 {
+				delete(remaining, numaNode)
+			}
+		}
+		if len(remaining) > 0 {
+			reservedNUMAMemory[rInfo.UID()] = remaining
+		}
+	}
+
+	if len(reservedCPUs) == 0 && len(reservedNUMAMemory) == 0 {
 		return nil, nil
 	}
 
 	return &nodeReservationRestoreStateData{
-		reservedCPUs: reservedCPUs,
+		reservedCPUs:       reservedCPUs,
+		reservedNUMAMemory: reservedNUMAMemory,
 	}, nil
 }
 
@@ -18,10 +18,12 @@ package nodenumaresource
 
 import (
 	"context"
+	"fmt"
 
 	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
 	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
 	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -32,6 +34,11 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
 
+const (
+	numaNodeZoneType       = "Node"
+	numaNodeZoneNameFormat = "node-%d"
+)
+
 type nodeResourceTopologyEventHandler struct {
 	topologyManager CPUTopologyManager
 }
@@ -146,13 +153,16 @@ func (m *nodeResourceTopologyEventHandler) updateNodeResourceTopology(oldNodeRes
 		}
 	}
 
+	numaNodeResources := extractNUMANodeResources(newNodeResTopology)
+
 	nodeName := newNodeResTopology.Name
 	m.topologyManager.UpdateCPUTopologyOptions(nodeName, func(options *CPUTopologyOptions) {
 		*options = CPUTopologyOptions{
-			CPUTopology:  cpuTopology,
-			ReservedCPUs: reservedCPUs,
-			Policy:       kubeletPolicy,
-			MaxRefCount:  options.MaxRefCount,
+			CPUTopology:       cpuTopology,
+			ReservedCPUs:      reservedCPUs,
+			Policy:            kubeletPolicy,
+			MaxRefCount:       options.MaxRefCount,
+			NUMANodeResources: numaNodeResources,
 		}
 	})
 }
@@ -179,6 +189,36 @@ func convertCPUTopology(reportedCPUTopology *extension.CPUTopology) *CPUTopology
 	builder := NewCPUTopologyBuilder()
 	for _, info := range reportedCPUTopology.Detail {
 		builder.AddCPUInfo(int(info.Socket), int(info.Node), int(info.Core), int(info.ID))
+		if info.CoreType != "" {
+			builder.SetCoreType(int(info.ID), info.CoreType)
+		}
 	}
-	return builder.Result()
+	topology := builder.Result()
+	topology.SMTDisabled = reportedCPUTopology.SMTDisabled
+	return topology
+}
+
+// extractNUMANodeResources parses the allocatable resources of each NUMA Node from the zones of NodeResourceTopology,
+// the NUMA Node zone is named as "node-<NUMA Node ID>" and its type is "Node".
+func extractNUMANodeResources(nodeResTopology *nrtv1alpha1.NodeResourceTopology) map[int]corev1.ResourceList {
+	var numaNodeResources map[int]corev1.ResourceList
+	for _, zone := range nodeResTopology.Zones {
+		if zone.Type != numaNodeZoneType {
+			continue
+		}
+		var numaNodeID int
+		if _, err := fmt.Sscanf(zone.Name, numaNodeZoneNameFormat, &numaNodeID); err != nil {
+			klog.V(5).Infof("Failed to parse NUMA Node zone %s of NodeResourceTopology %s, err: %v", zone.Name, nodeResTopology.Name, err)
+			continue
+		}
+		resources := corev1.ResourceList{}
+		for _, info := range zone.Resources {
+			resources[corev1.ResourceName(info.Name)] = info.Allocatable.DeepCopy()
+		}
+		if numaNodeResources == nil {
+			numaNodeResources = map[int]corev1.ResourceList{}
+		}
+		numaNodeResources[numaNodeID] = resources
+	}
+	return numaNodeResources
 }
@@ -214,6 +214,40 @@ func (cache *reservationCache) deletePod(reservationUID types.UID, pod *corev1.P
 	}
 }
 
+func (cache *reservationCache) assumeBackfilledPod(reservationUID types.UID, pod *corev1.Pod) error {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	rInfo := cache.reservationInfos[reservationUID]
+	if rInfo == nil {
+		return fmt.Errorf("cannot find target reservation")
+	}
+	rInfo.AddBackfilledPod(pod)
+	return nil
+}
+
+func (cache *reservationCache) updateBackfilledPod(reservationUIDs []types.UID, pod *corev1.Pod) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	for _, uid := range reservationUIDs {
+		if rInfo := cache.reservationInfos[uid]; rInfo != nil {
+			rInfo.AddBackfilledPod(pod)
+		}
+	}
+}
+
+func (cache *reservationCache) deleteBackfilledPod(reservationUIDs []types.UID, pod *corev1.Pod) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	for _, uid := range reservationUIDs {
+		if rInfo := cache.reservationInfos[uid]; rInfo != nil {
+			rInfo.RemoveBackfilledPod(pod)
+		}
+	}
+}
+
 func (cache *reservationCache) getReservationInfo(name string) *frameworkext.ReservationInfo {
 	reservation, err := cache.reservationLister.Get(name)
 	if err != nil {
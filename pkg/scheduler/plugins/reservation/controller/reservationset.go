@@ -0,0 +1,404 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	ReservationSetControllerName = "reservationSetController"
+)
+
+var reservationSetKind = schedulingv1alpha1.SchemeGroupVersion.WithKind("ReservationSet")
+
+var _ frameworkext.Controller = &ReservationSetController{}
+
+// ReservationSetController maintains the desired number of reservations for each ReservationSet. The reservations
+// consumed by owners, expired or failed are replenished by new ones created from the template.
+type ReservationSetController struct {
+	koordSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	reservationSetLister       schedulinglister.ReservationSetLister
+	reservationLister          schedulinglister.ReservationLister
+	koordClientSet             koordclientset.Interface
+	queue                      workqueue.RateLimitingInterface
+	// expectations tracks the reservations created or deleted by the controller but not observed by the informer,
+	// to avoid creating or deleting reservations repeatedly with a stale cache.
+	expectations kubecontroller.ControllerExpectationsInterface
+	numWorker    int
+}
+
+func NewReservationSetController(
+	koordSharedInformerFactory koordinatorinformers.SharedInformerFactory,
+	koordClientSet koordclientset.Interface,
+	numWorker int,
+) *ReservationSetController {
+	reservationSetLister := koordSharedInformerFactory.Scheduling().V1alpha1().ReservationSets().Lister()
+	reservationLister := koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Lister()
+
+	rateLimiter := workqueue.DefaultControllerRateLimiter()
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ReservationSetControllerName)
+
+	if numWorker <= 0 {
+		numWorker = 1
+	}
+	return &ReservationSetController{
+		koordSharedInformerFactory: koordSharedInformerFactory,
+		reservationSetLister:       reservationSetLister,
+		reservationLister:          reservationLister,
+		koordClientSet:             koordClientSet,
+		queue:                      queue,
+		expectations:               kubecontroller.NewControllerExpectations(),
+		numWorker:                  numWorker,
+	}
+}
+
+func (c *ReservationSetController) Name() string { return ReservationSetControllerName }
+
+func (c *ReservationSetController) Start() {
+	reservationSetInformer := c.koordSharedInformerFactory.Scheduling().V1alpha1().ReservationSets().Informer()
+	reservationSetInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onReservationSetAdd,
+		UpdateFunc: c.onReservationSetUpdate,
+		DeleteFunc: c.onReservationSetDelete,
+	})
+
+	reservationInformer := c.koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Informer()
+	reservationInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onOwnedReservationAdd,
+		UpdateFunc: c.onOwnedReservationUpdate,
+		DeleteFunc: c.onOwnedReservationDelete,
+	})
+
+	done := context.Background().Done()
+	c.koordSharedInformerFactory.Start(done)
+	c.koordSharedInformerFactory.WaitForCacheSync(done)
+
+	for i := 0; i < c.numWorker; i++ {
+		go c.worker()
+	}
+}
+
+func (c *ReservationSetController) worker() {
+	for c.processNextWorkItem() {
+
+	}
+}
+
+func (c *ReservationSetController) processNextWorkItem() bool {
+	req, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(req)
+
+	if err := c.sync(req.(string)); err != nil {
+		c.queue.AddRateLimited(req)
+		klog.ErrorS(err, "failed to sync ReservationSet", "reservationSet", req)
+		return true
+	}
+	c.queue.Forget(req)
+	return true
+}
+
+func (c *ReservationSetController) sync(name string) error {
+	reservationSet, err := c.reservationSetLister.Get(name)
+	if errors.IsNotFound(err) {
+		c.expectations.DeleteExpectations(name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	reservations, err := c.getOwnedReservations(reservationSet)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if c.expectations.SatisfiedExpectations(name) && reservationSet.DeletionTimestamp == nil {
+		if err := c.manageReservations(reservationSet, reservations); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.syncStatus(reservationSet, reservations); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *ReservationSetController) getOwnedReservations(reservationSet *schedulingv1alpha1.ReservationSet) ([]*schedulingv1alpha1.Reservation, error) {
+	reservations, err := c.reservationLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var owned []*schedulingv1alpha1.Reservation
+	for _, reservation := range reservations {
+		controllerRef := metav1.GetControllerOf(reservation)
+		if controllerRef != nil && controllerRef.UID == reservationSet.UID {
+			owned = append(owned, reservation)
+		}
+	}
+	return owned, nil
+}
+
+// manageReservations creates or deletes reservations to make the number of the unconsumed reservations equal to
+// the desired replicas.
+func (c *ReservationSetController) manageReservations(reservationSet *schedulingv1alpha1.ReservationSet, reservations []*schedulingv1alpha1.Reservation) error {
+	var active []*schedulingv1alpha1.Reservation
+	for _, reservation := range reservations {
+		if isReservationSetActiveReservation(reservation) {
+			active = append(active, reservation)
+		}
+	}
+
+	diff := int(reservationSet.Spec.Replicas) - len(active)
+	if diff > 0 {
+		if reservationSet.Spec.Template == nil {
+			return fmt.Errorf("missing template of ReservationSet %s", reservationSet.Name)
+		}
+		c.expectations.ExpectCreations(reservationSet.Name, diff)
+		var errs []error
+		for i := 0; i < diff; i++ {
+			reservation := newReservationFromTemplate(reservationSet)
+			_, err := c.koordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), reservation, metav1.CreateOptions{})
+			if err != nil {
+				c.expectations.CreationObserved(reservationSet.Name)
+				errs = append(errs, err)
+				continue
+			}
+			klog.V(4).InfoS("ReservationSet created reservation", "reservationSet", klog.KObj(reservationSet), "reservation", klog.KObj(reservation))
+		}
+		return utilerrors.NewAggregate(errs)
+	}
+
+	if diff < 0 {
+		candidates := getReservationsToDelete(active, -diff)
+		c.expectations.ExpectDeletions(reservationSet.Name, len(candidates))
+		var errs []error
+		for _, reservation := range candidates {
+			err := c.koordClientSet.SchedulingV1alpha1().Reservations().Delete(context.TODO(), reservation.Name, metav1.DeleteOptions{})
+			if err != nil {
+				c.expectations.DeletionObserved(reservationSet.Name)
+				if !errors.IsNotFound(err) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			klog.V(4).InfoS("ReservationSet deleted reservation", "reservationSet", klog.KObj(reservationSet), "reservation", klog.KObj(reservation))
+		}
+		return utilerrors.NewAggregate(errs)
+	}
+	return nil
+}
+
+func (c *ReservationSetController) syncStatus(reservationSet *schedulingv1alpha1.ReservationSet, reservations []*schedulingv1alpha1.Reservation) error {
+	newStatus := calculateReservationSetStatus(reservationSet, reservations)
+	if reflect.DeepEqual(reservationSet.Status, newStatus) {
+		return nil
+	}
+	reservationSet = reservationSet.DeepCopy()
+	reservationSet.Status = newStatus
+	_, err := c.koordClientSet.SchedulingV1alpha1().ReservationSets().UpdateStatus(context.TODO(), reservationSet, metav1.UpdateOptions{})
+	if err == nil {
+		klog.V(4).InfoS("Successfully sync ReservationSet status", "reservationSet", klog.KObj(reservationSet))
+	}
+	return err
+}
+
+func calculateReservationSetStatus(reservationSet *schedulingv1alpha1.ReservationSet, reservations []*schedulingv1alpha1.Reservation) schedulingv1alpha1.ReservationSetStatus {
+	status := schedulingv1alpha1.ReservationSetStatus{
+		ObservedGeneration: reservationSet.Generation,
+	}
+	for _, reservation := range reservations {
+		if len(reservation.Status.CurrentOwners) > 0 {
+			status.AllocatedReplicas++
+		}
+		if !isReservationSetActiveReservation(reservation) {
+			continue
+		}
+		status.Replicas++
+		if reservationutil.IsReservationAvailable(reservation) {
+			status.AvailableReplicas++
+			status.Allocatable = quotav1.Add(status.Allocatable, reservation.Status.Allocatable)
+			status.Allocated = quotav1.Add(status.Allocated, reservation.Status.Allocated)
+		}
+	}
+	return status
+}
+
+// isReservationSetActiveReservation checks if the reservation is counted as a replica of the ReservationSet, i.e.
+// it is neither consumed, expired, failed nor being deleted.
+func isReservationSetActiveReservation(r *schedulingv1alpha1.Reservation) bool {
+	return r.DeletionTimestamp == nil && !reservationutil.IsReservationFailed(r) && !reservationutil.IsReservationSucceeded(r)
+}
+
+// getReservationsToDelete picks the reservations to scale down. The reservations allocated by owners are kept, and
+// the unscheduled and newer ones are preferred to delete.
+func getReservationsToDelete(reservations []*schedulingv1alpha1.Reservation, count int) []*schedulingv1alpha1.Reservation {
+	var candidates []*schedulingv1alpha1.Reservation
+	for _, reservation := range reservations {
+		if len(reservation.Status.CurrentOwners) == 0 {
+			candidates = append(candidates, reservation)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iRank, jRank := reservationPhaseRank(candidates[i]), reservationPhaseRank(candidates[j])
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+	return candidates
+}
+
+func reservationPhaseRank(r *schedulingv1alpha1.Reservation) int {
+	switch r.Status.Phase {
+	case "", schedulingv1alpha1.ReservationPending:
+		return 0
+	case schedulingv1alpha1.ReservationWaiting:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func newReservationFromTemplate(reservationSet *schedulingv1alpha1.ReservationSet) *schedulingv1alpha1.Reservation {
+	template := reservationSet.Spec.Template
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s", reservationSet.Name, utilrand.String(5)),
+			Labels:          map[string]string{},
+			Annotations:     map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(reservationSet, reservationSetKind)},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for k, v := range template.Labels {
+		reservation.Labels[k] = v
+	}
+	for k, v := range template.Annotations {
+		reservation.Annotations[k] = v
+	}
+	return reservation
+}
+
+func (c *ReservationSetController) onReservationSetAdd(obj interface{}) {
+	reservationSet, _ := obj.(*schedulingv1alpha1.ReservationSet)
+	if reservationSet != nil {
+		c.queue.Add(reservationSet.Name)
+	}
+}
+
+func (c *ReservationSetController) onReservationSetUpdate(oldObj, newObj interface{}) {
+	oldReservationSet, _ := oldObj.(*schedulingv1alpha1.ReservationSet)
+	newReservationSet, _ := newObj.(*schedulingv1alpha1.ReservationSet)
+	if oldReservationSet != nil && newReservationSet != nil {
+		if oldReservationSet.Generation != newReservationSet.Generation {
+			c.queue.Add(newReservationSet.Name)
+		}
+	}
+}
+
+func (c *ReservationSetController) onReservationSetDelete(obj interface{}) {
+	var reservationSet *schedulingv1alpha1.ReservationSet
+	switch t := obj.(type) {
+	case *schedulingv1alpha1.ReservationSet:
+		reservationSet = t
+	case cache.DeletedFinalStateUnknown:
+		reservationSet, _ = t.Obj.(*schedulingv1alpha1.ReservationSet)
+	}
+	if reservationSet != nil {
+		c.expectations.DeleteExpectations(reservationSet.Name)
+	}
+}
+
+func (c *ReservationSetController) onOwnedReservationAdd(obj interface{}) {
+	reservation, _ := obj.(*schedulingv1alpha1.Reservation)
+	if reservation == nil {
+		return
+	}
+	if name := getReservationSetName(reservation); name != "" {
+		c.expectations.CreationObserved(name)
+		c.queue.Add(name)
+	}
+}
+
+func (c *ReservationSetController) onOwnedReservationUpdate(oldObj, newObj interface{}) {
+	oldReservation, _ := oldObj.(*schedulingv1alpha1.Reservation)
+	newReservation, _ := newObj.(*schedulingv1alpha1.Reservation)
+	if oldReservation == nil || newReservation == nil {
+		return
+	}
+	if oldReservation.ResourceVersion == newReservation.ResourceVersion {
+		return
+	}
+	if name := getReservationSetName(newReservation); name != "" {
+		c.queue.Add(name)
+	}
+}
+
+func (c *ReservationSetController) onOwnedReservationDelete(obj interface{}) {
+	var reservation *schedulingv1alpha1.Reservation
+	switch t := obj.(type) {
+	case *schedulingv1alpha1.Reservation:
+		reservation = t
+	case cache.DeletedFinalStateUnknown:
+		reservation, _ = t.Obj.(*schedulingv1alpha1.Reservation)
+	}
+	if reservation == nil {
+		return
+	}
+	if name := getReservationSetName(reservation); name != "" {
+		c.expectations.DeletionObserved(name)
+		c.queue.Add(name)
+	}
+}
+
+func getReservationSetName(reservation *schedulingv1alpha1.Reservation) string {
+	controllerRef := metav1.GetControllerOf(reservation)
+	if controllerRef == nil || controllerRef.Kind != reservationSetKind.Kind ||
+		controllerRef.APIVersion != reservationSetKind.GroupVersion().String() {
+		return ""
+	}
+	return controllerRef.Name
+}
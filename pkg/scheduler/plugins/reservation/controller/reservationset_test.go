@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+)
+
+func newTestReservationSet(replicas int32) *schedulingv1alpha1.ReservationSet {
+	return &schedulingv1alpha1.ReservationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:        uuid.NewUUID(),
+			Name:       "test-set",
+			Generation: 2,
+		},
+		Spec: schedulingv1alpha1.ReservationSetSpec{
+			Replicas: replicas,
+			Template: &schedulingv1alpha1.ReservationTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "test"},
+				},
+				Spec: schedulingv1alpha1.ReservationSpec{
+					Template: &corev1.PodTemplateSpec{},
+					Owners: []schedulingv1alpha1.ReservationOwner{
+						{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestOwnedReservation(reservationSet *schedulingv1alpha1.ReservationSet, name string, phase schedulingv1alpha1.ReservationPhase, owners ...corev1.ObjectReference) *schedulingv1alpha1.Reservation {
+	var nodeName string
+	if phase != "" && phase != schedulingv1alpha1.ReservationPending {
+		nodeName = "test-node"
+	}
+	return &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               uuid.NewUUID(),
+			Name:              name,
+			CreationTimestamp: metav1.Now(),
+			OwnerReferences:   []metav1.OwnerReference{*metav1.NewControllerRef(reservationSet, reservationSetKind)},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:         phase,
+			NodeName:      nodeName,
+			CurrentOwners: owners,
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			},
+		},
+	}
+}
+
+func newTestReservationSetController(t *testing.T, reservationSet *schedulingv1alpha1.ReservationSet, reservations ...*schedulingv1alpha1.Reservation) (*ReservationSetController, *koordfake.Clientset) {
+	fakeKoordClientSet := koordfake.NewSimpleClientset()
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(fakeKoordClientSet, 0)
+	_, err := fakeKoordClientSet.SchedulingV1alpha1().ReservationSets().Create(context.TODO(), reservationSet, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	for _, reservation := range reservations {
+		_, err = fakeKoordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), reservation, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	controller := NewReservationSetController(koordSharedInformerFactory, fakeKoordClientSet, 0)
+	koordSharedInformerFactory.Start(nil)
+	koordSharedInformerFactory.WaitForCacheSync(nil)
+	return controller, fakeKoordClientSet
+}
+
+func listTestReservationNames(t *testing.T, client *koordfake.Clientset) []string {
+	reservationList, err := client.SchedulingV1alpha1().Reservations().List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, reservation := range reservationList.Items {
+		names = append(names, reservation.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReservationSetReplenishReservations(t *testing.T) {
+	reservationSet := newTestReservationSet(3)
+	owner := corev1.ObjectReference{Namespace: "default", Name: "pod-1", UID: types.UID("pod-1")}
+	available := newTestOwnedReservation(reservationSet, "available", schedulingv1alpha1.ReservationAvailable)
+	succeeded := newTestOwnedReservation(reservationSet, "succeeded", schedulingv1alpha1.ReservationSucceeded, owner)
+	failed := newTestOwnedReservation(reservationSet, "failed", schedulingv1alpha1.ReservationFailed)
+	notOwned := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "not-owned",
+		},
+	}
+	controller, client := newTestReservationSetController(t, reservationSet, available, succeeded, failed, notOwned)
+
+	assert.NoError(t, controller.sync(reservationSet.Name))
+	reservationList, err := client.SchedulingV1alpha1().Reservations().List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, reservationList.Items, 6)
+	created := 0
+	for _, reservation := range reservationList.Items {
+		if reservation.Status.Phase != "" || reservation.Name == notOwned.Name {
+			continue
+		}
+		created++
+		assert.Equal(t, reservationSet.Name, getReservationSetName(&reservation))
+		assert.Equal(t, map[string]string{"app": "test"}, reservation.Labels)
+		assert.Equal(t, reservationSet.Spec.Template.Spec, reservation.Spec)
+	}
+	assert.Equal(t, 2, created)
+
+	got, err := client.SchedulingV1alpha1().ReservationSets().Get(context.TODO(), reservationSet.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, schedulingv1alpha1.ReservationSetStatus{
+		ObservedGeneration: 2,
+		Replicas:           1,
+		AvailableReplicas:  1,
+		AllocatedReplicas:  1,
+		Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("4"),
+		},
+		Allocated: corev1.ResourceList{},
+	}, got.Status)
+
+	// the created reservations are not observed yet, so no more reservations are created
+	assert.NoError(t, controller.sync(reservationSet.Name))
+	assert.Len(t, listTestReservationNames(t, client), 6)
+}
+
+func TestReservationSetScaleDownReservations(t *testing.T) {
+	reservationSet := newTestReservationSet(2)
+	owner := corev1.ObjectReference{Namespace: "default", Name: "pod-1", UID: types.UID("pod-1")}
+	oldPending := newTestOwnedReservation(reservationSet, "old-pending", schedulingv1alpha1.ReservationPending)
+	oldPending.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	newPending := newTestOwnedReservation(reservationSet, "new-pending", schedulingv1alpha1.ReservationPending)
+	available := newTestOwnedReservation(reservationSet, "available", schedulingv1alpha1.ReservationAvailable)
+	allocated := newTestOwnedReservation(reservationSet, "allocated", schedulingv1alpha1.ReservationAvailable, owner)
+	controller, client := newTestReservationSetController(t, reservationSet, oldPending, newPending, available, allocated)
+
+	assert.NoError(t, controller.sync(reservationSet.Name))
+	// the allocated reservation is kept, and the pending ones are deleted before the available one
+	assert.Equal(t, []string{"allocated", "available"}, listTestReservationNames(t, client))
+}
+
+func Test_getReservationsToDelete(t *testing.T) {
+	reservationSet := newTestReservationSet(0)
+	waiting := newTestOwnedReservation(reservationSet, "waiting", schedulingv1alpha1.ReservationWaiting)
+	available := newTestOwnedReservation(reservationSet, "available", schedulingv1alpha1.ReservationAvailable)
+	pending := newTestOwnedReservation(reservationSet, "pending", "")
+	got := getReservationsToDelete([]*schedulingv1alpha1.Reservation{available, waiting, pending}, 2)
+	assert.Equal(t, []*schedulingv1alpha1.Reservation{pending, waiting}, got)
+}
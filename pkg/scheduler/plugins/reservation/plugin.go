@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	k8sfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
@@ -35,6 +36,7 @@ import (
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	clientschedulingv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
 	listerschedulingv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/reservation/controller"
@@ -106,7 +108,10 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	// TODO(joseph): Considering the amount of changed code,
 	// temporarily use global variable to store ReservationCache instance,
 	// and then refactor to separate ReservationCache later.
-	SetReservationCache(cache)
+	// The cache of the simulation frameworks must not replace the scheduler's one.
+	if !extendedHandle.IsSimulation() {
+		SetReservationCache(cache)
+	}
 
 	p := &Plugin{
 		handle:           extendedHandle,
@@ -127,7 +132,15 @@ func (pl *Plugin) NewControllers() ([]frameworkext.Controller, error) {
 		pl.handle.KoordinatorSharedInformerFactory(),
 		pl.handle.KoordinatorClientSet(),
 		1)
-	return []frameworkext.Controller{reservationController}, nil
+	controllers := []frameworkext.Controller{reservationController}
+	if k8sfeature.DefaultFeatureGate.Enabled(features.ReservationSet) {
+		reservationSetController := controller.NewReservationSetController(
+			pl.handle.KoordinatorSharedInformerFactory(),
+			pl.handle.KoordinatorClientSet(),
+			1)
+		controllers = append(controllers, reservationSetController)
+	}
+	return controllers, nil
 }
 
 func (pl *Plugin) EventsToRegister() []framework.ClusterEvent {
@@ -151,11 +164,14 @@ type stateData struct {
 	nodeReservationStates map[string]nodeReservationState
 	preferredNode         string
 	assumed               *frameworkext.ReservationInfo
+	assumedBackfilled     []*frameworkext.ReservationInfo
 }
 
 type nodeReservationState struct {
 	nodeName string
 	matched  []*frameworkext.ReservationInfo
+	// backfilled represents the reservations whose reserved resources are temporarily used by the pod
+	backfilled []*frameworkext.ReservationInfo
 	// podRequested represents all Pods(including matched reservation) requested resources
 	// but excluding the already allocated from unmatched reservations
 	podRequested *framework.Resource
@@ -175,6 +191,7 @@ func (s *stateData) Clone() framework.StateData {
 		nodeReservationStates: s.nodeReservationStates,
 		preferredNode:         s.preferredNode,
 		assumed:               s.assumed,
+		assumedBackfilled:     s.assumedBackfilled,
 	}
 	preemptible := map[string]corev1.ResourceList{}
 	for nodeName, returned := range s.preemptible {
@@ -490,11 +507,20 @@ func fitsNode(podRequest *framework.Resource, nodeInfo *framework.NodeInfo, node
 	return true
 }
 
-func (pl *Plugin) PostFilter(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, _ framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+// PostFilter preempts the lower priority reservations which are not allocated yet for the pod if the preemption is
+// enabled. If no reservations can be preempted, the following PostFilter plugins like DefaultPreemption still work.
+func (pl *Plugin) PostFilter(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
 	if reservationutil.IsReservePod(pod) {
 		// return err to stop default preemption
 		return nil, framework.NewStatus(framework.Error)
 	}
+	if pl.args != nil && pl.args.EnablePreemption != nil && *pl.args.EnablePreemption {
+		result, status := pl.preemptReservations(ctx, cycleState, pod, filteredNodeStatusMap)
+		if status.IsSuccess() {
+			return result, status
+		}
+		klog.V(4).InfoS("Failed to preempt reservations for pod", "pod", klog.KObj(pod), "status", status.Message())
+	}
 	return nil, framework.NewStatus(framework.Unschedulable)
 }
 
@@ -554,7 +580,7 @@ func (pl *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 		}
 		if nominatedReservation == nil {
 			klog.V(5).Infof("Skip reserve with reservation since there are no matched reservations, pod %v, node: %v", klog.KObj(pod), nodeName)
-			return nil
+			return pl.reserveBackfilledReservations(cycleState, pod, nodeName)
 		}
 		frameworkext.SetNominatedReservation(cycleState, map[string]*frameworkext.ReservationInfo{nodeName: nominatedReservation})
 	}
@@ -571,6 +597,21 @@ func (pl *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 	return nil
 }
 
+// reserveBackfilledReservations records the pod in the reservations it backfilled on the node, so that the
+// owners cannot allocate the reservations until the pod finishes.
+func (pl *Plugin) reserveBackfilledReservations(cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) *framework.Status {
+	state := getStateData(cycleState)
+	nodeRState := state.nodeReservationStates[nodeName]
+	for _, rInfo := range nodeRState.backfilled {
+		if err := pl.reservationCache.assumeBackfilledPod(rInfo.UID(), pod); err != nil {
+			klog.ErrorS(err, "Failed to assume backfilled pod in reservationCache", "pod", klog.KObj(pod), "reservation", klog.KObj(rInfo))
+			return framework.AsStatus(err)
+		}
+		state.assumedBackfilled = append(state.assumedBackfilled, rInfo)
+	}
+	return nil
+}
+
 func (pl *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) {
 	if reservationutil.IsReservePod(pod) {
 		rName := reservationutil.GetReservationNameFromReservePod(pod)
@@ -586,6 +627,13 @@ func (pl *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleStat
 	}
 
 	state := getStateData(cycleState)
+	if len(state.assumedBackfilled) > 0 {
+		var uids []types.UID
+		for _, rInfo := range state.assumedBackfilled {
+			uids = append(uids, rInfo.UID())
+		}
+		pl.reservationCache.deleteBackfilledPod(uids, pod)
+	}
 	if state.assumed != nil {
 		klog.V(4).InfoS("Attempting to unreserve pod to node with reservations", "pod", klog.KObj(pod), "node", nodeName, "assumed", klog.KObj(state.assumed))
 		pl.reservationCache.forgetPod(state.assumed.UID(), pod)
@@ -601,6 +649,13 @@ func (pl *Plugin) PreBind(ctx context.Context, cycleState *framework.CycleState,
 	}
 
 	state := getStateData(cycleState)
+	if len(state.assumedBackfilled) > 0 {
+		reservations := make([]metav1.Object, 0, len(state.assumedBackfilled))
+		for _, rInfo := range state.assumedBackfilled {
+			reservations = append(reservations, rInfo.GetObject())
+		}
+		apiext.SetReservationBackfilled(pod, reservations)
+	}
 	if state.assumed == nil {
 		klog.V(5).Infof("Skip the Reservation PreBind since no reservation allocated for the pod %d o node %s", klog.KObj(pod), nodeName)
 		return nil
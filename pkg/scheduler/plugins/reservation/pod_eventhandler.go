@@ -101,6 +101,12 @@ func (h *podEventHandler) updatePod(oldPod, newPod *corev1.Pod) {
 		h.cache.updatePod(reservationUID, oldPod, newPod)
 	}
 
+	if newPod != nil {
+		if backfilled := getBackfilledReservationUIDs(newPod); len(backfilled) > 0 {
+			h.cache.updateBackfilledPod(backfilled, newPod)
+		}
+	}
+
 	if newPod != nil && apiext.IsReservationOperatingMode(newPod) {
 		if newPod.Spec.NodeName == "" {
 			return
@@ -119,7 +125,26 @@ func (h *podEventHandler) deletePod(pod *corev1.Pod) {
 		h.cache.deletePod(reservationAllocated.UID, pod)
 	}
 
+	if backfilled := getBackfilledReservationUIDs(pod); len(backfilled) > 0 {
+		h.cache.deleteBackfilledPod(backfilled, pod)
+	}
+
 	if apiext.IsReservationOperatingMode(pod) {
 		h.cache.deleteReservationOperatingPod(pod)
 	}
 }
+
+func getBackfilledReservationUIDs(pod *corev1.Pod) []types.UID {
+	backfilled, err := apiext.GetReservationBackfilled(pod)
+	if err != nil {
+		klog.ErrorS(err, "Invalid backfilled reservations in Pod", "pod", klog.KObj(pod))
+		return nil
+	}
+	var uids []types.UID
+	for _, v := range backfilled {
+		if v.UID != "" {
+			uids = append(uids, v.UID)
+		}
+	}
+	return uids
+}
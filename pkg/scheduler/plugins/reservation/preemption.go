@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const (
+	// ErrReasonNoPreemptibleReservations is the reason for no reservations can be preempted to make room for the pod.
+	ErrReasonNoPreemptibleReservations = "no lower priority reservations can be preempted to make room for the pod"
+)
+
+// reservationPreemptionCandidate records the node and the reservations which should be preempted on it.
+type reservationPreemptionCandidate struct {
+	nodeName string
+	victims  []*frameworkext.ReservationInfo
+}
+
+// preemptReservations tries to make room for the pod by failing the lower priority reservations which are available
+// but not allocated by any owner yet. The reservations are preempted only when the pod can be placed on the node
+// after removing them, and the node with the fewest victims is preferred.
+func (pl *Plugin) preemptReservations(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	if pod.Spec.PreemptionPolicy != nil && *pod.Spec.PreemptionPolicy == corev1.PreemptNever {
+		return nil, framework.NewStatus(framework.Unschedulable, "not eligible due to preemptionPolicy=Never.")
+	}
+	nodeInfos, err := pl.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+
+	var best *reservationPreemptionCandidate
+	for _, nodeInfo := range nodeInfos {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+		// preemption cannot help the nodes which are UnschedulableAndUnresolvable
+		if filteredNodeStatusMap[node.Name].Code() == framework.UnschedulableAndUnresolvable {
+			continue
+		}
+		victims, status := pl.selectReservationVictimsOnNode(ctx, cycleState.Clone(), pod, nodeInfo.Clone())
+		if !status.IsSuccess() {
+			klog.V(5).InfoS("Cannot preempt reservations on node", "pod", klog.KObj(pod), "node", node.Name, "status", status.Message())
+			continue
+		}
+		candidate := &reservationPreemptionCandidate{nodeName: node.Name, victims: victims}
+		if best == nil || isBetterReservationCandidate(candidate, best) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return nil, framework.NewStatus(framework.Unschedulable, ErrReasonNoPreemptibleReservations)
+	}
+
+	if err := pl.prepareReservationPreemption(pod, best); err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	return framework.NewPostFilterResultWithNominatedNode(best.nodeName), framework.NewStatus(framework.Success)
+}
+
+// isBetterReservationCandidate prefers fewer victims, then the victims with lower highest priority, then the node name.
+func isBetterReservationCandidate(candidate, other *reservationPreemptionCandidate) bool {
+	if len(candidate.victims) != len(other.victims) {
+		return len(candidate.victims) < len(other.victims)
+	}
+	if p, q := highestReservationPriority(candidate.victims), highestReservationPriority(other.victims); p != q {
+		return p < q
+	}
+	return candidate.nodeName < other.nodeName
+}
+
+func highestReservationPriority(rInfos []*frameworkext.ReservationInfo) int32 {
+	var result int32
+	for i, rInfo := range rInfos {
+		if priority := rInfo.GetPriority(); i == 0 || priority > result {
+			result = priority
+		}
+	}
+	return result
+}
+
+// selectReservationVictimsOnNode finds the minimum set of reservations on the given node that should be preempted
+// to make room for the pod. It first removes the reserve pods of all the preemptible reservations, then reprieves
+// as many reservations as possible from the highest priority.
+func (pl *Plugin) selectReservationVictimsOnNode(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) ([]*frameworkext.ReservationInfo, *framework.Status) {
+	reservePods := make(map[types.UID]*framework.PodInfo, len(nodeInfo.Pods))
+	for _, pi := range nodeInfo.Pods {
+		if reservationutil.IsReservePod(pi.Pod) {
+			reservePods[pi.Pod.UID] = pi
+		}
+	}
+
+	var potentialVictims []*frameworkext.ReservationInfo
+	for _, rInfo := range pl.reservationCache.listAvailableReservationInfosOnNode(nodeInfo.Node().Name) {
+		// the matched reservations have been restored for the pod, and their reserve pods are not on the node
+		if _, ok := reservePods[rInfo.UID()]; ok && canPreemptReservation(pod, rInfo) {
+			potentialVictims = append(potentialVictims, rInfo)
+		}
+	}
+	if len(potentialVictims) == 0 {
+		return nil, framework.NewStatus(framework.UnschedulableAndUnresolvable,
+			fmt.Sprintf("No preemptible reservations found on node %v for pod %v", nodeInfo.Node().Name, pod.Name))
+	}
+
+	removeReservePod := func(rInfo *frameworkext.ReservationInfo) error {
+		pi := reservePods[rInfo.UID()]
+		if err := nodeInfo.RemovePod(pi.Pod); err != nil {
+			return err
+		}
+		return pl.handle.RunPreFilterExtensionRemovePod(ctx, cycleState, pod, pi, nodeInfo).AsError()
+	}
+	addReservePod := func(rInfo *frameworkext.ReservationInfo) error {
+		pi := reservePods[rInfo.UID()]
+		nodeInfo.AddPodInfo(pi)
+		return pl.handle.RunPreFilterExtensionAddPod(ctx, cycleState, pod, pi, nodeInfo).AsError()
+	}
+
+	for _, rInfo := range potentialVictims {
+		if err := removeReservePod(rInfo); err != nil {
+			return nil, framework.AsStatus(err)
+		}
+	}
+	if status := pl.handle.RunFilterPluginsWithNominatedPods(ctx, cycleState, pod, nodeInfo); !status.IsSuccess() {
+		return nil, status
+	}
+
+	sort.SliceStable(potentialVictims, func(i, j int) bool {
+		if p, q := potentialVictims[i].GetPriority(), potentialVictims[j].GetPriority(); p != q {
+			return p > q
+		}
+		return potentialVictims[i].Reservation.CreationTimestamp.Before(&potentialVictims[j].Reservation.CreationTimestamp)
+	})
+	var victims []*frameworkext.ReservationInfo
+	for _, rInfo := range potentialVictims {
+		if err := addReservePod(rInfo); err != nil {
+			return nil, framework.AsStatus(err)
+		}
+		if status := pl.handle.RunFilterPluginsWithNominatedPods(ctx, cycleState, pod, nodeInfo); !status.IsSuccess() {
+			if err := removeReservePod(rInfo); err != nil {
+				return nil, framework.AsStatus(err)
+			}
+			victims = append(victims, rInfo)
+			klog.V(5).InfoS("Reservation is a potential preemption victim on node", "reservation", klog.KObj(rInfo), "node", nodeInfo.Node().Name)
+		}
+	}
+	return victims, framework.NewStatus(framework.Success)
+}
+
+// canPreemptReservation checks whether the reservation can be preempted by the pod. Only the available reservations
+// with lower priority which are not allocated by any owner can be preempted.
+func canPreemptReservation(pod *corev1.Pod, rInfo *frameworkext.ReservationInfo) bool {
+	if rInfo.Reservation == nil || !rInfo.IsAvailable() || rInfo.IsTerminating() {
+		return false
+	}
+	if len(rInfo.AssignedPods) > 0 || len(rInfo.Reservation.Status.CurrentOwners) > 0 {
+		return false
+	}
+	return rInfo.GetPriority() < corev1helpers.PodPriority(pod)
+}
+
+// prepareReservationPreemption marks the victims as failed and records events on them,
+// so that the creators of the reservations can be notified to react, e.g. recreate the reservations on other nodes.
+func (pl *Plugin) prepareReservationPreemption(pod *corev1.Pod, candidate *reservationPreemptionCandidate) error {
+	for _, victim := range candidate.victims {
+		rName := victim.GetName()
+		message := fmt.Sprintf("Preempted by pod %v/%v with higher priority on node %v", pod.Namespace, pod.Name, candidate.nodeName)
+		err := util.RetryOnConflictOrTooManyRequests(func() error {
+			reservation, err := pl.rLister.Get(rName)
+			if err != nil {
+				return err
+			}
+			if reservation.UID != victim.UID() || !reservationutil.IsReservationAvailable(reservation) {
+				return fmt.Errorf("reservation %v is not available to preempt", rName)
+			}
+			reservation = reservation.DeepCopy()
+			reservationutil.SetReservationPreempted(reservation, message)
+			_, err = pl.client.Reservations().UpdateStatus(context.TODO(), reservation, metav1.UpdateOptions{})
+			if err != nil {
+				klog.V(4).ErrorS(err, "failed to update reservation", "reservation", klog.KObj(reservation))
+			}
+			return err
+		})
+		if err != nil {
+			klog.ErrorS(err, "Failed to preempt reservation", "reservation", rName, "preemptor", klog.KObj(pod))
+			return err
+		}
+		pl.handle.EventRecorder().Eventf(victim.Reservation, pod, corev1.EventTypeWarning, "Preempted", "Preempting", message)
+		klog.V(2).InfoS("Preemptor preempted reservation", "preemptor", klog.KObj(pod), "reservation", rName, "node", candidate.nodeName)
+	}
+	return nil
+}
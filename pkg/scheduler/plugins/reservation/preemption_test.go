@@ -0,0 +1,272 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedulertesting "k8s.io/kubernetes/pkg/scheduler/testing"
+	"k8s.io/utils/pointer"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+var _ framework.FilterPlugin = &testCPUFitPlugin{}
+
+// testCPUFitPlugin only checks whether the requested CPU of the pod fits the node.
+type testCPUFitPlugin struct{}
+
+func (p *testCPUFitPlugin) Name() string { return "testCPUFit" }
+
+func (p *testCPUFitPlugin) Filter(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	requests, _ := resourceapi.PodRequestsAndLimits(pod)
+	if nodeInfo.Requested.MilliCPU+requests.Cpu().MilliValue() > nodeInfo.Allocatable.MilliCPU {
+		return framework.NewStatus(framework.Unschedulable, "Insufficient cpu")
+	}
+	return nil
+}
+
+// testPodNominator has no nominated pods.
+type testPodNominator struct{}
+
+func (n *testPodNominator) AddNominatedPod(pi *framework.PodInfo, nominatingInfo *framework.NominatingInfo) {
+}
+
+func (n *testPodNominator) DeleteNominatedPodIfExists(pod *corev1.Pod) {}
+
+func (n *testPodNominator) UpdateNominatedPod(oldPod *corev1.Pod, newPodInfo *framework.PodInfo) {}
+
+func (n *testPodNominator) NominatedPodsForNode(nodeName string) []*framework.PodInfo { return nil }
+
+func newTestPreemptibleReservation(name, nodeName string, priority int32, cpu string) *schedulingv1alpha1.Reservation {
+	r := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: name,
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32(priority),
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse(cpu),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	reservationutil.SetReservationAvailable(r, nodeName)
+	return r
+}
+
+func TestPostFilterWithReservationPreemption(t *testing.T) {
+	lowReservation := newTestPreemptibleReservation("low", "node-1", 1, "2")
+	midReservation := newTestPreemptibleReservation("mid", "node-1", 2, "2")
+	allocatedReservation := newTestPreemptibleReservation("allocated", "node-2", 1, "4")
+	allocatedReservation.Status.CurrentOwners = []corev1.ObjectReference{{Namespace: "default", Name: "owner", UID: "owner"}}
+	highReservation := newTestPreemptibleReservation("high", "node-3", 100, "4")
+	reservations := []*schedulingv1alpha1.Reservation{lowReservation, midReservation, allocatedReservation, highReservation}
+
+	var nodes []*corev1.Node
+	for _, nodeName := range []string{"node-1", "node-2", "node-3"} {
+		nodes = append(nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:  resource.MustParse("4"),
+					corev1.ResourcePods: resource.MustParse("100"),
+				},
+			},
+		})
+	}
+	var reservePods []*corev1.Pod
+	for _, r := range reservations {
+		reservePods = append(reservePods, reservationutil.NewReservePod(r))
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		wantNode      string
+		wantPreempted []string
+	}{
+		{
+			name: "preempt the lowest priority reservation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", UID: uuid.NewUUID()},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32(10),
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+							},
+						},
+					},
+				},
+			},
+			wantNode:      "node-1",
+			wantPreempted: []string{"low"},
+		},
+		{
+			name: "preempt all the lower priority reservations on the node",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", UID: uuid.NewUUID()},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32(10),
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+							},
+						},
+					},
+				},
+			},
+			wantNode:      "node-1",
+			wantPreempted: []string{"low", "mid"},
+		},
+		{
+			name: "no lower priority reservations",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", UID: uuid.NewUUID()},
+				Spec: corev1.PodSpec{
+					Priority: pointer.Int32(1),
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not eligible due to preemptionPolicy=Never",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", UID: uuid.NewUUID()},
+				Spec: corev1.PodSpec{
+					Priority:         pointer.Int32(10),
+					PreemptionPolicy: func() *corev1.PreemptionPolicy { p := corev1.PreemptNever; return &p }(),
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			koordClientSet := koordfake.NewSimpleClientset()
+			koordSharedInformerFactory := koordinatorinformers.NewSharedInformerFactory(koordClientSet, 0)
+			for _, r := range reservations {
+				_, err := koordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), r, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			extenderFactory, _ := frameworkext.NewFrameworkExtenderFactory(
+				frameworkext.WithKoordinatorClientSet(koordClientSet),
+				frameworkext.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+			)
+			extenderFactory.InitScheduler(frameworkext.NewFakeScheduler())
+			proxyNew := frameworkext.PluginFactoryProxy(extenderFactory, New)
+
+			cs := kubefake.NewSimpleClientset()
+			fakeRecorder := record.NewFakeRecorder(1024)
+			fw, err := schedulertesting.NewFramework(
+				[]schedulertesting.RegisterPluginFunc{
+					schedulertesting.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+					schedulertesting.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+					schedulertesting.RegisterFilterPlugin("testCPUFit", func(_ apiruntime.Object, _ framework.Handle) (framework.Plugin, error) {
+						return &testCPUFitPlugin{}, nil
+					}),
+				},
+				"koord-scheduler",
+				frameworkruntime.WithClientSet(cs),
+				frameworkruntime.WithInformerFactory(informers.NewSharedInformerFactory(cs, 0)),
+				frameworkruntime.WithSnapshotSharedLister(newFakeSharedLister(reservePods, nodes, false)),
+				frameworkruntime.WithEventRecorder(record.NewEventRecorderAdapter(fakeRecorder)),
+				frameworkruntime.WithPodNominator(&testPodNominator{}),
+			)
+			assert.NoError(t, err)
+
+			p, err := proxyNew(&config.ReservationArgs{EnablePreemption: pointer.Bool(true)}, fw)
+			assert.NoError(t, err)
+			pl := p.(*Plugin)
+			pl.handle.(frameworkext.FrameworkExtender).SetConfiguredPlugins(&schedconfig.Plugins{})
+			koordSharedInformerFactory.Start(nil)
+			koordSharedInformerFactory.WaitForCacheSync(nil)
+			for _, r := range reservations {
+				pl.reservationCache.updateReservation(r)
+			}
+
+			cycleState := framework.NewCycleState()
+			_, status := fw.RunPreFilterPlugins(context.TODO(), cycleState, tt.pod)
+			assert.True(t, status.IsSuccess())
+			result, status := pl.PostFilter(context.TODO(), cycleState, tt.pod, framework.NodeToStatusMap{})
+			if tt.wantNode == "" {
+				assert.Nil(t, result)
+				assert.Equal(t, framework.NewStatus(framework.Unschedulable), status)
+			} else {
+				assert.True(t, status.IsSuccess())
+				assert.Equal(t, framework.NewPostFilterResultWithNominatedNode(tt.wantNode), result)
+			}
+
+			var preempted []string
+			for _, r := range reservations {
+				got, err := koordClientSet.SchedulingV1alpha1().Reservations().Get(context.TODO(), r.Name, metav1.GetOptions{})
+				assert.NoError(t, err)
+				if reservationutil.IsReservationPreempted(got) {
+					preempted = append(preempted, got.Name)
+				}
+			}
+			assert.Equal(t, tt.wantPreempted, preempted)
+			assert.Len(t, fakeRecorder.Events, len(tt.wantPreempted))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package reservation
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler/framework/parallelize"
 
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/util"
@@ -86,7 +88,7 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 			return
 		}
 
-		var unmatched, matched []*frameworkext.ReservationInfo
+		var unmatched, matched, backfilled []*frameworkext.ReservationInfo
 		for _, rInfo := range rOnNode {
 			if !rInfo.IsAvailable() {
 				continue
@@ -99,6 +101,12 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 			}
 
 			if !isReservedPod && !rInfo.IsUnschedulable() && matchReservation(pod, node, rInfo, reservationAffinity) {
+				// The reserved resources are still used by the backfilled pods, so the owners wait until the
+				// backfilled pods finish. Otherwise, the node would be overcommitted.
+				if len(rInfo.BackfilledPods) > 0 {
+					klog.V(5).InfoS("reservation is still backfilled by other pods", "reservation", klog.KObj(rInfo), "pod", klog.KObj(pod))
+					continue
+				}
 				matched = append(matched, rInfo)
 
 			} else if len(rInfo.AssignedPods) > 0 {
@@ -106,9 +114,16 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 				if !isReservedPod {
 					klog.V(6).InfoS("got reservation on node does not match the pod", "reservation", klog.KObj(rInfo), "pod", klog.KObj(pod))
 				}
+
+			} else if !isReservedPod && reservationAffinity == nil && canBackfillReservation(pod, rInfo) {
+				backfilled = append(backfilled, rInfo)
 			}
 		}
-		if len(matched) == 0 && len(unmatched) == 0 {
+		// The pod should allocate the matched reservations instead of borrowing the others.
+		if len(matched) > 0 {
+			backfilled = nil
+		}
+		if len(matched) == 0 && len(unmatched) == 0 && len(backfilled) == 0 {
 			return
 		}
 
@@ -124,6 +139,12 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 				return
 			}
 		}
+		for _, rInfo := range backfilled {
+			if err = nodeInfo.RemovePod(rInfo.GetReservePod()); err != nil {
+				errCh.SendErrorWithCancel(err, cancel)
+				return
+			}
+		}
 		// Save requested state after trimmed by unmatched to support reservation allocate policy.
 		podRequested := nodeInfo.Requested.Clone()
 
@@ -161,11 +182,12 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 			}
 		}
 
-		if len(matched) > 0 || len(unmatched) > 0 {
+		if len(matched) > 0 || len(unmatched) > 0 || len(backfilled) > 0 {
 			index := atomic.AddInt32(&stateIndex, 1)
 			allNodeReservationStates[index-1] = &nodeReservationState{
 				nodeName:        node.Name,
 				matched:         matched,
+				backfilled:      backfilled,
 				podRequested:    podRequested,
 				rAllocated:      framework.NewResource(rAllocated),
 				totalAligned:    totalAligned,
@@ -173,7 +195,8 @@ func (pl *Plugin) prepareMatchReservationState(ctx context.Context, cycleState *
 			}
 			allPluginToRestoreState[index-1] = pluginToRestoreState
 		}
-		klog.V(4).Infof("Pod %v has reservations on node %v, %d matched, %d unmatched", klog.KObj(pod), node.Name, len(matched), len(unmatched))
+		klog.V(4).Infof("Pod %v has reservations on node %v, %d matched, %d unmatched, %d backfilled",
+			klog.KObj(pod), node.Name, len(matched), len(unmatched), len(backfilled))
 	}
 	pl.handle.Parallelizer().Until(parallelCtx, len(allNodes), processNode)
 	err = errCh.ReceiveError()
@@ -276,6 +299,37 @@ func occupyUnallocatedResources(rInfo *frameworkext.ReservationInfo, reservePod
 	}
 }
 
+// canBackfillReservation checks if the pod can temporarily use the resources of the unallocated Reservation.
+// The pod must declare a max runtime by spec.activeDeadlineSeconds which is not longer than the backfill max runtime
+// of the Reservation, so that the reserved resources are returned before long.
+// NOTE: Only the resources accounted in NodeInfo are returned for backfilling, the CPUs and devices held by the
+// Reservation are not restored, so the pods requiring cpuset or devices cannot backfill.
+// The backfilled pods are recorded in the Reservation, which cannot be allocated by the owners until they finish.
+func canBackfillReservation(pod *corev1.Pod, rInfo *frameworkext.ReservationInfo) bool {
+	if pod.Spec.ActiveDeadlineSeconds == nil || len(rInfo.AssignedPods) > 0 {
+		return false
+	}
+	if apiext.AllowUseCPUSet(pod) || requestsDevices(pod) {
+		return false
+	}
+	maxRuntime, err := apiext.GetReservationBackfillMaxRuntime(rInfo.GetObject().GetAnnotations())
+	if err != nil {
+		klog.V(5).InfoS("Failed to parse reservation backfill max runtime", "reservation", klog.KObj(rInfo), "err", err)
+		return false
+	}
+	return maxRuntime > 0 && time.Duration(*pod.Spec.ActiveDeadlineSeconds)*time.Second <= maxRuntime
+}
+
+func requestsDevices(pod *corev1.Pod) bool {
+	requests, _ := resourceapi.PodRequestsAndLimits(pod)
+	for resourceName := range requests {
+		if apiext.IsDeviceResource(resourceName) {
+			return true
+		}
+	}
+	return false
+}
+
 func matchReservation(pod *corev1.Pod, node *corev1.Node, reservation *frameworkext.ReservationInfo, reservationAffinity *reservationutil.RequiredReservationAffinity) bool {
 	if !reservationutil.MatchReservationOwners(pod, reservation.GetPodOwners()) {
 		return false
@@ -334,6 +334,251 @@ func TestRestoreReservation(t *testing.T) {
 	assert.True(t, status.IsSuccess())
 }
 
+func TestRestoreReservationForBackfill(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("32"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			},
+		},
+	}
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "reservation-for-gang",
+			Annotations: map[string]string{
+				apiext.AnnotationReservationBackfillMaxRuntime: "30m",
+			},
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{
+						Namespace: "default",
+						Name:      "gang-member",
+					},
+				},
+			},
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("16"),
+									corev1.ResourceMemory: resource.MustParse("32Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: node.Name,
+		},
+	}
+	reservePod := reservationutil.NewReservePod(reservation)
+
+	tests := []struct {
+		name                  string
+		activeDeadlineSeconds *int64
+		labels                map[string]string
+		requests              corev1.ResourceList
+		wantRestored          bool
+		wantRequested         *framework.Resource
+	}{
+		{
+			name:          "pod without max runtime cannot backfill",
+			wantRequested: &framework.Resource{MilliCPU: 16000, Memory: 32 * 1024 * 1024 * 1024},
+		},
+		{
+			name:                  "pod requiring cpuset cannot backfill",
+			activeDeadlineSeconds: pointer.Int64(600),
+			labels: map[string]string{
+				apiext.LabelPodQoS:           string(apiext.QoSLSR),
+				apiext.LabelPodPriorityClass: string(apiext.PriorityProd),
+			},
+			wantRequested: &framework.Resource{MilliCPU: 16000, Memory: 32 * 1024 * 1024 * 1024},
+		},
+		{
+			name:                  "pod requesting devices cannot backfill",
+			activeDeadlineSeconds: pointer.Int64(600),
+			requests: corev1.ResourceList{
+				apiext.ResourceGPU: resource.MustParse("100"),
+			},
+			wantRequested: &framework.Resource{MilliCPU: 16000, Memory: 32 * 1024 * 1024 * 1024},
+		},
+		{
+			name:                  "pod with too long max runtime cannot backfill",
+			activeDeadlineSeconds: pointer.Int64(3600),
+			wantRequested:         &framework.Resource{MilliCPU: 16000, Memory: 32 * 1024 * 1024 * 1024},
+		},
+		{
+			name:                  "short-running pod backfills the reserved resources",
+			activeDeadlineSeconds: pointer.Int64(600),
+			wantRestored:          true,
+			wantRequested:         &framework.Resource{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuitWith(t, []*corev1.Pod{reservePod}, []*corev1.Node{node})
+			p, err := suit.pluginFactory()
+			assert.NoError(t, err)
+			pl := p.(*Plugin)
+			pl.reservationCache.updateReservation(reservation)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "short-running-pod",
+					Labels:    tt.labels,
+				},
+				Spec: corev1.PodSpec{
+					ActiveDeadlineSeconds: tt.activeDeadlineSeconds,
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: tt.requests,
+							},
+						},
+					},
+				},
+			}
+			cycleState := framework.NewCycleState()
+			_, restored, status := pl.BeforePreFilter(context.TODO(), cycleState, pod)
+			assert.True(t, status.IsSuccess())
+			assert.Equal(t, tt.wantRestored, restored)
+			assert.Empty(t, getStateData(cycleState).nodeReservationStates[node.Name].matched)
+
+			nodeInfo, err := suit.fw.SnapshotSharedLister().NodeInfos().Get(node.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRequested.MilliCPU, nodeInfo.Requested.MilliCPU)
+			assert.Equal(t, tt.wantRequested.Memory, nodeInfo.Requested.Memory)
+		})
+	}
+}
+
+func TestBackfilledReservationBlocksOwners(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("32"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			},
+		},
+	}
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:  uuid.NewUUID(),
+			Name: "reservation-for-gang",
+			Annotations: map[string]string{
+				apiext.AnnotationReservationBackfillMaxRuntime: "30m",
+			},
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{
+						Namespace: "default",
+						Name:      "gang-member",
+					},
+				},
+			},
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("16"),
+									corev1.ResourceMemory: resource.MustParse("32Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: node.Name,
+		},
+	}
+	reservePod := reservationutil.NewReservePod(reservation)
+	backfilledPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "short-running-pod",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: corev1.PodSpec{
+			ActiveDeadlineSeconds: pointer.Int64(600),
+		},
+	}
+
+	// the backfilled pod is recorded in the Reservation during Reserve and PreBind
+	suit := newPluginTestSuitWith(t, []*corev1.Pod{reservePod}, []*corev1.Node{node})
+	p, err := suit.pluginFactory()
+	assert.NoError(t, err)
+	pl := p.(*Plugin)
+	pl.reservationCache.updateReservation(reservation)
+	cycleState := framework.NewCycleState()
+	_, restored, status := pl.BeforePreFilter(context.TODO(), cycleState, backfilledPod)
+	assert.True(t, status.IsSuccess())
+	assert.True(t, restored)
+	assert.True(t, pl.Reserve(context.TODO(), cycleState, backfilledPod, node.Name).IsSuccess())
+	assert.Len(t, pl.reservationCache.getReservationInfoByUID(reservation.UID).BackfilledPods, 1)
+	boundPod := backfilledPod.DeepCopy()
+	assert.True(t, pl.PreBind(context.TODO(), cycleState, boundPod, node.Name).IsSuccess())
+	backfilled, err := apiext.GetReservationBackfilled(boundPod)
+	assert.NoError(t, err)
+	assert.Equal(t, []apiext.ReservationAllocated{{Name: reservation.Name, UID: reservation.UID}}, backfilled)
+	pl.Unreserve(context.TODO(), cycleState, backfilledPod, node.Name)
+	assert.Empty(t, pl.reservationCache.getReservationInfoByUID(reservation.UID).BackfilledPods)
+
+	// the owner cannot allocate the Reservation until the backfilled pod finishes
+	suit = newPluginTestSuitWith(t, []*corev1.Pod{reservePod}, []*corev1.Node{node})
+	p, err = suit.pluginFactory()
+	assert.NoError(t, err)
+	pl = p.(*Plugin)
+	pl.reservationCache.updateReservation(reservation)
+	boundPod.Spec.NodeName = node.Name
+	eventHandler := &podEventHandler{cache: pl.reservationCache}
+	eventHandler.OnAdd(boundPod)
+
+	owner := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gang-member",
+			UID:       uuid.NewUUID(),
+		},
+	}
+	cycleState = framework.NewCycleState()
+	_, restored, status = pl.BeforePreFilter(context.TODO(), cycleState, owner)
+	assert.True(t, status.IsSuccess())
+	assert.False(t, restored)
+	nodeInfo, err := suit.fw.SnapshotSharedLister().NodeInfos().Get(node.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16000), nodeInfo.Requested.MilliCPU)
+
+	eventHandler.OnDelete(boundPod)
+	cycleState = framework.NewCycleState()
+	_, restored, status = pl.BeforePreFilter(context.TODO(), cycleState, owner)
+	assert.True(t, status.IsSuccess())
+	assert.True(t, restored)
+	assert.Len(t, getStateData(cycleState).nodeReservationStates[node.Name].matched, 1)
+}
+
 func Test_matchReservation(t *testing.T) {
 	tests := []struct {
 		name                string
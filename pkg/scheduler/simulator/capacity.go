@@ -0,0 +1,254 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	defaultPodNamePrefix = "simulated-pod-"
+
+	assumedPodTimeout = 10 * time.Second
+	cacheSyncTimeout  = 30 * time.Second
+)
+
+// CapacityReport is the result of scheduling the replicas of a template pod until it is unschedulable.
+type CapacityReport struct {
+	// Count is the number of replicas scheduled.
+	Count int `json:"count"`
+	// NodeCounts is the number of replicas scheduled on each node.
+	NodeCounts map[string]int `json:"nodeCounts,omitempty"`
+	// LimitingReason is the most common reason why the nodes reject the next replica,
+	// empty if the maximum count is reached.
+	LimitingReason string `json:"limitingReason,omitempty"`
+	// Reasons maps the reason to the number of nodes rejecting the next replica for it.
+	Reasons map[string]int `json:"reasons,omitempty"`
+	// Message is the scheduling failure message of the next replica.
+	Message string `json:"message,omitempty"`
+}
+
+// CapacityEstimator schedules the replicas of a template pod one by one through the scheduling cycle
+// and the binding cycle of the scheduler, except that the binding is done by updating the pod directly.
+// The scheduler must not be running, and the informers must have been synced.
+type CapacityEstimator struct {
+	sched  *scheduler.Scheduler
+	client kubernetes.Interface
+}
+
+func NewCapacityEstimator(sched *scheduler.Scheduler, client kubernetes.Interface) *CapacityEstimator {
+	return &CapacityEstimator{
+		sched:  sched,
+		client: client,
+	}
+}
+
+// Estimate schedules at most maxCount replicas of the template, stops at the first unschedulable one.
+func (e *CapacityEstimator) Estimate(ctx context.Context, template *corev1.Pod, maxCount int) (*CapacityReport, error) {
+	template = template.DeepCopy()
+	if template.Spec.SchedulerName == "" {
+		template.Spec.SchedulerName = corev1.DefaultSchedulerName
+	}
+	fwk, ok := e.sched.Profiles[template.Spec.SchedulerName]
+	if !ok {
+		return nil, fmt.Errorf("cannot find profile %s", template.Spec.SchedulerName)
+	}
+
+	if err := e.waitForSchedulerCache(ctx); err != nil {
+		return nil, err
+	}
+
+	report := &CapacityReport{
+		NodeCounts: map[string]int{},
+	}
+	for i := 0; i < maxCount; i++ {
+		pod := newReplica(template, i)
+		nodeName, err := e.schedulePod(ctx, fwk, pod)
+		if err != nil {
+			var fitErr *framework.FitError
+			if errors.As(err, &fitErr) || errors.Is(err, scheduler.ErrNoNodesAvailable) {
+				report.setFailure(err)
+				break
+			}
+			return nil, err
+		}
+		klog.V(4).InfoS("Simulated pod scheduled", "pod", klog.KObj(pod), "node", nodeName)
+		report.Count++
+		report.NodeCounts[nodeName]++
+	}
+	return report, nil
+}
+
+// waitForSchedulerCache waits for the event handlers adding the nodes and the assigned pods to the scheduler cache,
+// which may lag behind the synced informers.
+func (e *CapacityEstimator) waitForSchedulerCache(ctx context.Context) error {
+	nodeList, err := e.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	podList, err := e.client.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	assignedPods := 0
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			assignedPods++
+		}
+	}
+	return wait.PollImmediate(10*time.Millisecond, cacheSyncTimeout, func() (bool, error) {
+		podCount, err := e.sched.Cache.PodCount()
+		if err != nil {
+			return false, err
+		}
+		return e.sched.Cache.NodeCount() >= len(nodeList.Items) && podCount >= assignedPods, nil
+	})
+}
+
+func (r *CapacityReport) setFailure(err error) {
+	r.Message = err.Error()
+	var fitErr *framework.FitError
+	if !errors.As(err, &fitErr) {
+		r.LimitingReason = err.Error()
+		return
+	}
+	r.Reasons = map[string]int{}
+	for _, status := range fitErr.Diagnosis.NodeToStatusMap {
+		for _, reason := range status.Reasons() {
+			r.Reasons[reason]++
+		}
+	}
+	reasons := make([]string, 0, len(r.Reasons))
+	for reason := range r.Reasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if r.Reasons[reasons[i]] != r.Reasons[reasons[j]] {
+			return r.Reasons[reasons[i]] > r.Reasons[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	if len(reasons) > 0 {
+		r.LimitingReason = reasons[0]
+	}
+}
+
+func newReplica(template *corev1.Pod, index int) *corev1.Pod {
+	pod := template.DeepCopy()
+	prefix := pod.GenerateName
+	if prefix == "" && pod.Name != "" {
+		prefix = pod.Name + "-"
+	}
+	if prefix == "" {
+		prefix = defaultPodNamePrefix
+	}
+	pod.Name = fmt.Sprintf("%s%d", prefix, index)
+	pod.GenerateName = ""
+	if pod.Namespace == "" {
+		pod.Namespace = corev1.NamespaceDefault
+	}
+	pod.UID = uuid.NewUUID()
+	pod.ResourceVersion = ""
+	pod.Spec.NodeName = ""
+	pod.Status = corev1.PodStatus{Phase: corev1.PodPending}
+	return pod
+}
+
+// schedulePod runs the scheduling cycle and the binding cycle for the pod in the same way as
+// the scheduler, and waits for the bound pod to be confirmed in the scheduler cache.
+func (e *CapacityEstimator) schedulePod(ctx context.Context, fwk framework.Framework, pod *corev1.Pod) (string, error) {
+	pod, err := e.client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	state := framework.NewCycleState()
+	state.SetRecordPluginMetrics(false)
+	result, err := e.sched.SchedulePod(ctx, fwk, state, pod)
+	if err != nil {
+		return "", err
+	}
+	nodeName := result.SuggestedHost
+
+	assumedPod := pod.DeepCopy()
+	assumedPod.Spec.NodeName = nodeName
+	if err := e.sched.Cache.AssumePod(assumedPod); err != nil {
+		return "", err
+	}
+	if status := fwk.RunReservePluginsReserve(ctx, state, assumedPod, nodeName); !status.IsSuccess() {
+		e.forget(ctx, fwk, state, assumedPod, nodeName)
+		return "", status.AsError()
+	}
+	if status := fwk.RunPermitPlugins(ctx, state, assumedPod, nodeName); !status.IsSuccess() {
+		e.forget(ctx, fwk, state, assumedPod, nodeName)
+		if status.Code() == framework.Wait {
+			return "", fmt.Errorf("waiting pod %s is not supported in simulation", klog.KObj(pod))
+		}
+		return "", status.AsError()
+	}
+	if status := fwk.RunPreBindPlugins(ctx, state, assumedPod, nodeName); !status.IsSuccess() {
+		e.forget(ctx, fwk, state, assumedPod, nodeName)
+		return "", status.AsError()
+	}
+
+	// PreBind plugins may have patched the pod, so bind the latest one.
+	boundPod, err := e.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		e.forget(ctx, fwk, state, assumedPod, nodeName)
+		return "", err
+	}
+	boundPod.Spec.NodeName = nodeName
+	if _, err := e.client.CoreV1().Pods(pod.Namespace).Update(ctx, boundPod, metav1.UpdateOptions{}); err != nil {
+		e.forget(ctx, fwk, state, assumedPod, nodeName)
+		return "", err
+	}
+	if err := e.sched.Cache.FinishBinding(assumedPod); err != nil {
+		return "", err
+	}
+
+	// The next replica must see this one in the snapshot, which is only guaranteed
+	// after the informer adds the bound pod to the cache.
+	err = wait.PollImmediate(10*time.Millisecond, assumedPodTimeout, func() (bool, error) {
+		assumed, err := e.sched.Cache.IsAssumedPod(assumedPod)
+		return !assumed, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for pod %s added to cache: %w", klog.KObj(pod), err)
+	}
+	return nodeName, nil
+}
+
+func (e *CapacityEstimator) forget(ctx context.Context, fwk framework.Framework, state *framework.CycleState, assumedPod *corev1.Pod, nodeName string) {
+	fwk.RunReservePluginsUnreserve(ctx, state, assumedPod, nodeName)
+	if err := e.sched.Cache.ForgetPod(assumedPod); err != nil {
+		klog.ErrorS(err, "Failed to forget simulated pod", "pod", klog.KObj(assumedPod))
+	}
+}
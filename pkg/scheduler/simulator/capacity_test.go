@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestCapacityReportSetFailure(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-3"}}
+	fitErr := &framework.FitError{
+		Pod:         pod,
+		NumAllNodes: 3,
+		Diagnosis: framework.Diagnosis{
+			NodeToStatusMap: framework.NodeToStatusMap{
+				"node-1": framework.NewStatus(framework.Unschedulable, "Insufficient cpu"),
+				"node-2": framework.NewStatus(framework.Unschedulable, "Insufficient cpu", "Insufficient memory"),
+				"node-3": framework.NewStatus(framework.Unschedulable, "Insufficient memory", "node(s) had taint"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		err                error
+		wantLimitingReason string
+		wantReasons        map[string]int
+	}{
+		{
+			name:               "fit error",
+			err:                fitErr,
+			wantLimitingReason: "Insufficient cpu",
+			wantReasons: map[string]int{
+				"Insufficient cpu":    2,
+				"Insufficient memory": 2,
+				"node(s) had taint":   1,
+			},
+		},
+		{
+			name:               "wrapped fit error",
+			err:                fmt.Errorf("failed to schedule: %w", fitErr),
+			wantLimitingReason: "Insufficient cpu",
+			wantReasons: map[string]int{
+				"Insufficient cpu":    2,
+				"Insufficient memory": 2,
+				"node(s) had taint":   1,
+			},
+		},
+		{
+			name:               "no nodes",
+			err:                scheduler.ErrNoNodesAvailable,
+			wantLimitingReason: scheduler.ErrNoNodesAvailable.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &CapacityReport{}
+			report.setFailure(tt.err)
+			assert.Equal(t, tt.wantLimitingReason, report.LimitingReason)
+			assert.Equal(t, tt.wantReasons, report.Reasons)
+			assert.Equal(t, tt.err.Error(), report.Message)
+		})
+	}
+}
+
+func TestNewReplica(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *corev1.Pod
+		wantName string
+	}{
+		{
+			name:     "generate name",
+			template: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "web-", Name: "ignored"}},
+			wantName: "web-1",
+		},
+		{
+			name:     "name",
+			template: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+			wantName: "web-1",
+		},
+		{
+			name:     "no name",
+			template: &corev1.Pod{},
+			wantName: "simulated-pod-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := tt.template.DeepCopy()
+			template.Spec.NodeName = "node-1"
+			template.Status.Phase = corev1.PodRunning
+			pod := newReplica(template, 1)
+			assert.Equal(t, tt.wantName, pod.Name)
+			assert.Empty(t, pod.GenerateName)
+			assert.Equal(t, corev1.NamespaceDefault, pod.Namespace)
+			assert.NotEmpty(t, pod.UID)
+			assert.Empty(t, pod.Spec.NodeName)
+			assert.Equal(t, corev1.PodPending, pod.Status.Phase)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/typed/topology/v1alpha1"
+	topologyv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/typed/topology/v1alpha2"
+	"k8s.io/client-go/discovery"
+	schedclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+	schedulingv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/typed/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+var (
+	_ schedclientset.Interface = &extendedHandle{}
+	_ nrtclientset.Interface   = &extendedHandle{}
+)
+
+// extendedHandle provides the clientsets which the plugins look up by type assertion on the handle
+// instead of creating them from the kubeconfig.
+type extendedHandle struct {
+	frameworkext.FrameworkExtender
+	schedulingClient schedclientset.Interface
+	topologyClient   nrtclientset.Interface
+}
+
+// HandleWrapper returns the wrapper to provide the fake clientsets to the plugins.
+func (c *ClientSets) HandleWrapper() frameworkext.HandleWrapper {
+	return func(extender frameworkext.FrameworkExtender) frameworkext.FrameworkExtender {
+		return &extendedHandle{
+			FrameworkExtender: extender,
+			schedulingClient:  c.SchedulingClient,
+			topologyClient:    c.TopologyClient,
+		}
+	}
+}
+
+func (h *extendedHandle) Discovery() discovery.DiscoveryInterface {
+	return h.schedulingClient.Discovery()
+}
+
+func (h *extendedHandle) SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface {
+	return h.schedulingClient.SchedulingV1alpha1()
+}
+
+func (h *extendedHandle) TopologyV1alpha1() topologyv1alpha1.TopologyV1alpha1Interface {
+	return h.topologyClient.TopologyV1alpha1()
+}
+
+func (h *extendedHandle) TopologyV1alpha2() topologyv1alpha2.TopologyV1alpha2Interface {
+	return h.topologyClient.TopologyV1alpha2()
+}
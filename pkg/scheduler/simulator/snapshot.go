@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtfake "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/fake"
+	nrtscheme "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	schedclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+	schedfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+	schedscheme "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/scheme"
+
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordscheme "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/scheme"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(scheme))
+	utilruntime.Must(koordscheme.AddToScheme(scheme))
+	utilruntime.Must(schedscheme.AddToScheme(scheme))
+	utilruntime.Must(nrtscheme.AddToScheme(scheme))
+}
+
+// Snapshot holds the objects of a cluster, grouped by the clientset serving them.
+type Snapshot struct {
+	// KubeObjects are the Kubernetes builtin objects, e.g. Nodes and Pods.
+	KubeObjects []runtime.Object
+	// KoordObjects are the Koordinator objects, e.g. NodeMetrics, Devices and Reservations.
+	KoordObjects []runtime.Object
+	// SchedulingObjects are the scheduler-plugins objects, e.g. ElasticQuotas and PodGroups.
+	SchedulingObjects []runtime.Object
+	// TopologyObjects are the NodeResourceTopologies.
+	TopologyObjects []runtime.Object
+}
+
+// LoadSnapshot loads the objects from the YAML or JSON files. If a path is a directory,
+// all the files with the extension .yaml, .yml or .json in it are loaded.
+func LoadSnapshot(paths ...string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	for _, path := range paths {
+		files, err := listFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			objects, err := LoadObjectsFromFile(file)
+			if err != nil {
+				return nil, err
+			}
+			for _, obj := range objects {
+				if err := snapshot.Add(obj); err != nil {
+					return nil, fmt.Errorf("failed to add object from %s: %w", file, err)
+				}
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// Add adds the object to the group of the clientset serving it.
+// The UID is generated if missing since the scheduler cache indexes pods by UID.
+func (s *Snapshot) Add(obj runtime.Object) error {
+	if metaObj, ok := obj.(metav1.Object); ok && metaObj.GetUID() == "" {
+		metaObj.SetUID(uuid.NewUUID())
+	}
+	switch {
+	case isRecognized(kubescheme.Scheme, obj):
+		s.KubeObjects = append(s.KubeObjects, obj)
+	case isRecognized(koordscheme.Scheme, obj):
+		s.KoordObjects = append(s.KoordObjects, obj)
+	case isRecognized(schedscheme.Scheme, obj):
+		s.SchedulingObjects = append(s.SchedulingObjects, obj)
+	case isRecognized(nrtscheme.Scheme, obj):
+		s.TopologyObjects = append(s.TopologyObjects, obj)
+	default:
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+	return nil
+}
+
+func isRecognized(s *runtime.Scheme, obj runtime.Object) bool {
+	_, _, err := s.ObjectKinds(obj)
+	return err == nil
+}
+
+// ClientSets are the fake clientsets serving the objects of a Snapshot.
+type ClientSets struct {
+	KubeClient       kubernetes.Interface
+	KoordClient      koordclientset.Interface
+	SchedulingClient schedclientset.Interface
+	TopologyClient   nrtclientset.Interface
+}
+
+// NewFakeClientSets creates the fake clientsets tracking the objects of the snapshot,
+// so the scheduler can run without an apiserver.
+func (s *Snapshot) NewFakeClientSets() *ClientSets {
+	return &ClientSets{
+		KubeClient:       kubefake.NewSimpleClientset(s.KubeObjects...),
+		KoordClient:      koordfake.NewSimpleClientset(s.KoordObjects...),
+		SchedulingClient: schedfake.NewSimpleClientset(s.SchedulingObjects...),
+		TopologyClient:   nrtfake.NewSimpleClientset(s.TopologyObjects...),
+	}
+}
+
+// LoadPodFromFile loads the only Pod in the file.
+func LoadPodFromFile(file string) (*corev1.Pod, error) {
+	objects, err := LoadObjectsFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("expect exactly one Pod in %s, got %d objects", file, len(objects))
+	}
+	pod, ok := objects[0].(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("expect a Pod in %s, got %T", file, objects[0])
+	}
+	return pod, nil
+}
+
+func LoadObjectsFromFile(file string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := LoadObjects(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load objects from %s: %w", file, err)
+	}
+	return objects, nil
+}
+
+// LoadObjects decodes the objects from the YAML documents or JSON objects in the reader.
+// The items of a List are flattened.
+func LoadObjects(reader io.Reader) ([]runtime.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	deserializer := codecs.UniversalDeserializer()
+	var objects []runtime.Object
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+		obj, _, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if list, ok := obj.(*corev1.List); ok {
+			for _, item := range list.Items {
+				itemObj, _, err := deserializer.Decode(item.Raw, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, itemObj)
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func listFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	return files, nil
+}
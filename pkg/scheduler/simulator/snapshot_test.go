@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+const testSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node-1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-1
+    namespace: default
+  spec:
+    nodeName: node-1
+---
+apiVersion: slo.koordinator.sh/v1alpha1
+kind: NodeMetric
+metadata:
+  name: node-1
+---
+apiVersion: scheduling.koordinator.sh/v1alpha1
+kind: Reservation
+metadata:
+  name: reservation-1
+---
+{"apiVersion": "scheduling.sigs.k8s.io/v1alpha1", "kind": "ElasticQuota", "metadata": {"name": "quota-1", "namespace": "default"}}
+`
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "snapshot.yaml"), []byte(testSnapshot), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a snapshot"), 0644))
+
+	snapshot, err := LoadSnapshot(dir)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.KubeObjects, 2)
+	assert.IsType(t, &corev1.Node{}, snapshot.KubeObjects[0])
+	assert.IsType(t, &corev1.Pod{}, snapshot.KubeObjects[1])
+	assert.Len(t, snapshot.KoordObjects, 2)
+	assert.IsType(t, &slov1alpha1.NodeMetric{}, snapshot.KoordObjects[0])
+	assert.IsType(t, &schedulingv1alpha1.Reservation{}, snapshot.KoordObjects[1])
+	assert.Len(t, snapshot.SchedulingObjects, 1)
+	assert.IsType(t, &schedv1alpha1.ElasticQuota{}, snapshot.SchedulingObjects[0])
+	assert.Empty(t, snapshot.TopologyObjects)
+	for _, obj := range snapshot.KubeObjects {
+		assert.NotEmpty(t, obj.(metav1.Object).GetUID())
+	}
+
+	clients := snapshot.NewFakeClientSets()
+	pod, err := clients.KubeClient.CoreV1().Pods("default").Get(context.TODO(), "pod-1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "node-1", pod.Spec.NodeName)
+	_, err = clients.KoordClient.SloV1alpha1().NodeMetrics().Get(context.TODO(), "node-1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = clients.SchedulingClient.SchedulingV1alpha1().ElasticQuotas("default").Get(context.TODO(), "quota-1", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestSnapshotAddUnsupportedObject(t *testing.T) {
+	snapshot := &Snapshot{}
+	err := snapshot.Add(&unstructured.Unstructured{})
+	assert.Error(t, err)
+}
+
+func TestLoadPodFromFile(t *testing.T) {
+	dir := t.TempDir()
+	podFile := filepath.Join(dir, "pod.yaml")
+	assert.NoError(t, os.WriteFile(podFile, []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  schedulerName: koord-scheduler
+`), 0644))
+	pod, err := LoadPodFromFile(podFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "web", pod.Name)
+	assert.Equal(t, "koord-scheduler", pod.Spec.SchedulerName)
+
+	nodeFile := filepath.Join(dir, "node.yaml")
+	assert.NoError(t, os.WriteFile(nodeFile, []byte(`
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-1
+`), 0644))
+	_, err = LoadPodFromFile(nodeFile)
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ import (
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
 )
 
 func GetEmptyPodExtendedResources() *apiext.ExtendedResourceSpec {
@@ -83,3 +84,24 @@ func GetCPUSetFromPod(podAnnotations map[string]string) (string, error) {
 	}
 	return podAlloc.CPUSet, nil
 }
+
+// GetCPUSetMemsFromPod returns the NUMA Nodes of the memory allocated to the pod in cpuset list format, e.g. "0-1".
+func GetCPUSetMemsFromPod(podAnnotations map[string]string) (string, error) {
+	if podAnnotations == nil {
+		return "", nil
+	}
+	podAlloc, err := apiext.GetResourceStatus(podAnnotations)
+	if err != nil {
+		return "", err
+	}
+	var numaNodes []int
+	for _, numaNodeResource := range podAlloc.NUMANodeResources {
+		if memory, ok := numaNodeResource.Resources[corev1.ResourceMemory]; ok && !memory.IsZero() {
+			numaNodes = append(numaNodes, int(numaNodeResource.Node))
+		}
+	}
+	if len(numaNodes) == 0 {
+		return "", nil
+	}
+	return cpuset.NewCPUSet(numaNodes...).String(), nil
+}
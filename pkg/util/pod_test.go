@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 )
@@ -59,3 +61,49 @@ func Test_GetCPUSetFromPod(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCPUSetMemsFromPod(t *testing.T) {
+	tests := []struct {
+		name     string
+		podAlloc *apiext.ResourceStatus
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "no NUMA memory allocated",
+			podAlloc: &apiext.ResourceStatus{CPUSet: "2-4"},
+			want:     "",
+		},
+		{
+			name: "get NUMA Nodes of allocated memory",
+			podAlloc: &apiext.ResourceStatus{
+				CPUSet: "2-4",
+				NUMANodeResources: []apiext.NUMANodeResource{
+					{
+						Node:      0,
+						Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+					{
+						Node:      1,
+						Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+					{
+						Node:      3,
+						Resources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("0")},
+					},
+				},
+			},
+			want: "0-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAnnotations := map[string]string{
+				apiext.AnnotationResourceStatus: DumpJSON(tt.podAlloc),
+			}
+			got, err := GetCPUSetMemsFromPod(podAnnotations)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -191,8 +191,30 @@ func GetReservationNodeName(r *schedulingv1alpha1.Reservation) string {
 }
 
 func SetReservationExpired(r *schedulingv1alpha1.Reservation) {
+	setReservationFailed(r, schedulingv1alpha1.ReasonReservationExpired, "")
+}
+
+// SetReservationPreempted marks the reservation as failed since its reserved resources are preempted by other pods.
+func SetReservationPreempted(r *schedulingv1alpha1.Reservation, message string) {
+	setReservationFailed(r, schedulingv1alpha1.ReasonReservationPreempted, message)
+}
+
+func IsReservationPreempted(r *schedulingv1alpha1.Reservation) bool {
+	if r == nil || r.Status.Phase != schedulingv1alpha1.ReservationFailed {
+		return false
+	}
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == schedulingv1alpha1.ReservationConditionReady {
+			return condition.Status == schedulingv1alpha1.ConditionStatusFalse &&
+				condition.Reason == schedulingv1alpha1.ReasonReservationPreempted
+		}
+	}
+	return false
+}
+
+func setReservationFailed(r *schedulingv1alpha1.Reservation, reason, message string) {
 	r.Status.Phase = schedulingv1alpha1.ReservationFailed
-	// not duplicate expired info
+	// not duplicate failed info
 	idx := -1
 	isReady := false
 	for i, condition := range r.Status.Conditions {
@@ -205,7 +227,8 @@ func SetReservationExpired(r *schedulingv1alpha1.Reservation) {
 		condition := schedulingv1alpha1.ReservationCondition{
 			Type:               schedulingv1alpha1.ReservationConditionReady,
 			Status:             schedulingv1alpha1.ConditionStatusFalse,
-			Reason:             schedulingv1alpha1.ReasonReservationExpired,
+			Reason:             reason,
+			Message:            message,
 			LastProbeTime:      metav1.Now(),
 			LastTransitionTime: metav1.Now(),
 		}
@@ -214,13 +237,17 @@ func SetReservationExpired(r *schedulingv1alpha1.Reservation) {
 		condition := schedulingv1alpha1.ReservationCondition{
 			Type:               schedulingv1alpha1.ReservationConditionReady,
 			Status:             schedulingv1alpha1.ConditionStatusFalse,
-			Reason:             schedulingv1alpha1.ReasonReservationExpired,
+			Reason:             reason,
+			Message:            message,
 			LastProbeTime:      metav1.Now(),
 			LastTransitionTime: metav1.Now(),
 		}
 		r.Status.Conditions[idx] = condition
 	} else { // if already not ready
-		r.Status.Conditions[idx].Reason = schedulingv1alpha1.ReasonReservationExpired
+		r.Status.Conditions[idx].Reason = reason
+		if message != "" {
+			r.Status.Conditions[idx].Message = message
+		}
 		r.Status.Conditions[idx].LastProbeTime = metav1.Now()
 	}
 }
@@ -235,6 +235,32 @@ func TestIsReservationExpired(t *testing.T) {
 	}
 }
 
+func TestSetReservationPreempted(t *testing.T) {
+	r := &schedulingv1alpha1.Reservation{
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: "test-node-0",
+		},
+	}
+	SetReservationAvailable(r, "test-node-0")
+	assert.False(t, IsReservationPreempted(r))
+
+	SetReservationPreempted(r, "preempted by pod default/test-pod")
+	assert.True(t, IsReservationFailed(r))
+	assert.True(t, IsReservationPreempted(r))
+	assert.False(t, IsReservationExpired(r))
+	var readyCondition *schedulingv1alpha1.ReservationCondition
+	for i := range r.Status.Conditions {
+		if r.Status.Conditions[i].Type == schedulingv1alpha1.ReservationConditionReady {
+			readyCondition = &r.Status.Conditions[i]
+		}
+	}
+	assert.NotNil(t, readyCondition)
+	assert.Equal(t, schedulingv1alpha1.ConditionStatusFalse, readyCondition.Status)
+	assert.Equal(t, schedulingv1alpha1.ReasonReservationPreempted, readyCondition.Reason)
+	assert.Equal(t, "preempted by pod default/test-pod", readyCondition.Message)
+}
+
 func TestGetReservationSchedulerName(t *testing.T) {
 	tests := []struct {
 		name string